@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"thoreinstein.com/rig/pkg/ai"
+	rigerrors "thoreinstein.com/rig/pkg/errors"
+)
+
+// askCmd sends a single prompt to the configured AI provider
+var askCmd = &cobra.Command{
+	Use:   "ask <prompt>",
+	Short: "Ask the AI provider a one-off question",
+	Long: `Send a single prompt to the configured AI provider and print the answer.
+
+When input is piped in, it is appended to the prompt as additional context.
+Unlike 'rig chat', no conversation is kept between invocations, which makes
+this suitable for scripts.
+
+Examples:
+  rig ask "What does EADDRINUSE mean?"
+  go test ./... 2>&1 | rig ask "summarize these errors"
+  rig ask --provider ollama --model llama3.2 "explain git rebase --onto"
+  rig ask --system "Answer in one sentence" "what is a monad"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAskCommand(args[0])
+	},
+}
+
+var (
+	askProvider string
+	askModel    string
+	askSystem   string
+)
+
+func init() {
+	rootCmd.AddCommand(askCmd)
+
+	askCmd.Flags().StringVar(&askProvider, "provider", "", "Override the configured AI provider")
+	askCmd.Flags().StringVar(&askModel, "model", "", "Override the configured model")
+	askCmd.Flags().StringVar(&askSystem, "system", "", "System prompt")
+}
+
+func runAskCommand(prompt string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return rigerrors.NewConfigErrorWithCause("", "failed to load configuration", err)
+	}
+
+	aiCfg := cfg.AI
+	if askProvider != "" {
+		aiCfg.Provider = askProvider
+	}
+	if askModel != "" {
+		aiCfg.Model = askModel
+	}
+
+	provider, err := ai.NewProvider(&aiCfg, verbose)
+	if err != nil {
+		return err
+	}
+
+	stdinContext, err := readPipedStdin(os.Stdin)
+	if err != nil {
+		return rigerrors.Wrap(err, "failed to read stdin")
+	}
+
+	return runAsk(context.Background(), provider, buildAskMessages(askSystem, prompt, stdinContext), os.Stdout, os.Stderr)
+}
+
+// runAsk sends messages to provider, writes the answer to out, and reports
+// token usage to errOut in verbose mode
+func runAsk(ctx context.Context, provider ai.Provider, messages []ai.Message, out, errOut io.Writer) error {
+	resp, err := provider.Chat(ctx, messages)
+	if err != nil {
+		return rigerrors.Wrap(err, "AI request failed")
+	}
+
+	fmt.Fprintln(out, strings.TrimRight(resp.Content, "\n"))
+
+	if verbose {
+		fmt.Fprintf(errOut, "Tokens: %d input, %d output (%s)\n", resp.InputTokens, resp.OutputTokens, provider.Name())
+	}
+
+	return nil
+}
+
+// buildAskMessages assembles the message list for a one-shot request
+func buildAskMessages(system, prompt, stdinContext string) []ai.Message {
+	var messages []ai.Message
+
+	if system != "" {
+		messages = append(messages, ai.Message{Role: "system", Content: system})
+	}
+
+	content := prompt
+	if strings.TrimSpace(stdinContext) != "" {
+		content = prompt + "\n\n" + stdinContext
+	}
+	messages = append(messages, ai.Message{Role: "user", Content: content})
+
+	return messages
+}
+
+// readPipedStdin returns stdin's contents when it is a pipe or file, and an
+// empty string when it is an interactive terminal
+func readPipedStdin(f *os.File) (string, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return "", nil
+	}
+	if info.Mode()&os.ModeCharDevice != 0 {
+		return "", nil
+	}
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
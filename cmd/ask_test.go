@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildAskMessages(t *testing.T) {
+	tests := []struct {
+		name         string
+		system       string
+		prompt       string
+		stdinContext string
+		wantRoles    []string
+		wantUser     string
+	}{
+		{
+			name:      "prompt only",
+			prompt:    "hello",
+			wantRoles: []string{"user"},
+			wantUser:  "hello",
+		},
+		{
+			name:      "with system prompt",
+			system:    "be brief",
+			prompt:    "hello",
+			wantRoles: []string{"system", "user"},
+			wantUser:  "hello",
+		},
+		{
+			name:         "with stdin context",
+			prompt:       "summarize these errors",
+			stdinContext: "FAIL: TestFoo\n",
+			wantRoles:    []string{"user"},
+			wantUser:     "summarize these errors\n\nFAIL: TestFoo\n",
+		},
+		{
+			name:         "whitespace stdin ignored",
+			prompt:       "hello",
+			stdinContext: "  \n",
+			wantRoles:    []string{"user"},
+			wantUser:     "hello",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := buildAskMessages(tt.system, tt.prompt, tt.stdinContext)
+			if len(messages) != len(tt.wantRoles) {
+				t.Fatalf("got %d messages, want %d", len(messages), len(tt.wantRoles))
+			}
+			for i, role := range tt.wantRoles {
+				if messages[i].Role != role {
+					t.Errorf("message %d role = %q, want %q", i, messages[i].Role, role)
+				}
+			}
+			if last := messages[len(messages)-1].Content; last != tt.wantUser {
+				t.Errorf("user content = %q, want %q", last, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestRunAsk(t *testing.T) {
+	oldVerbose := verbose
+	verbose = true
+	defer func() { verbose = oldVerbose }()
+
+	provider := &fakeAIProvider{replies: []string{"It means the port is taken.\n"}}
+	var out, errOut bytes.Buffer
+
+	err := runAsk(context.Background(), provider, buildAskMessages("", "what is EADDRINUSE", ""), &out, &errOut)
+	if err != nil {
+		t.Fatalf("runAsk() error = %v", err)
+	}
+
+	if out.String() != "It means the port is taken.\n" {
+		t.Errorf("stdout = %q", out.String())
+	}
+	if !strings.Contains(errOut.String(), "10 input, 5 output") {
+		t.Errorf("stderr should report token usage, got %q", errOut.String())
+	}
+}
+
+func TestRunAsk_Error(t *testing.T) {
+	provider := &fakeAIProvider{err: errors.New("boom")}
+	var out, errOut bytes.Buffer
+
+	if err := runAsk(context.Background(), provider, buildAskMessages("", "hi", ""), &out, &errOut); err == nil {
+		t.Error("runAsk() should return provider errors")
+	}
+	if out.Len() != 0 {
+		t.Errorf("stdout should be empty on error, got %q", out.String())
+	}
+}
+
+func TestReadPipedStdin(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("piped context"), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	defer f.Close()
+
+	got, err := readPipedStdin(f)
+	if err != nil {
+		t.Fatalf("readPipedStdin() error = %v", err)
+	}
+	if got != "piped context" {
+		t.Errorf("readPipedStdin() = %q, want %q", got, "piped context")
+	}
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"thoreinstein.com/rig/pkg/ai"
+	rigerrors "thoreinstein.com/rig/pkg/errors"
+)
+
+// chatCmd starts an interactive chat with the configured AI provider
+var chatCmd = &cobra.Command{
+	Use:   "chat",
+	Short: "Chat with the configured AI provider",
+	Long: `Start an interactive chat session with the configured AI provider.
+
+Each line you enter is sent as a new turn and the response is streamed back
+as it arrives. The conversation history is kept for the whole session.
+
+Commands:
+  /clear   Forget the conversation so far
+  /exit    Leave the chat (Ctrl-D also works)
+
+Press Ctrl-C while a response is streaming to cancel it without leaving
+the chat.
+
+Examples:
+  rig chat
+  rig chat --system "You are a terse Go reviewer"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runChatCommand()
+	},
+}
+
+var chatSystemPrompt string
+
+func init() {
+	rootCmd.AddCommand(chatCmd)
+
+	chatCmd.Flags().StringVar(&chatSystemPrompt, "system", "", "System prompt for the conversation")
+}
+
+func runChatCommand() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return rigerrors.NewConfigErrorWithCause("", "failed to load configuration", err)
+	}
+
+	provider, err := ai.NewProvider(&cfg.AI, verbose)
+	if err != nil {
+		return err
+	}
+
+	if !provider.IsAvailable() {
+		return rigerrors.NewConfigError("ai.provider", fmt.Sprintf("AI provider %s is not available", provider.Name()))
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+
+	fmt.Printf("Chatting with %s. Type /exit or press Ctrl-D to quit.\n", provider.Name())
+
+	return runChatLoop(context.Background(), provider, chatSystemPrompt, os.Stdin, os.Stdout, sigCh)
+}
+
+// chatInterrupter routes Ctrl-C to the in-flight response, if any
+type chatInterrupter struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	out    io.Writer
+}
+
+// watch cancels the active stream for every interrupt received. Interrupts
+// at the prompt only print a hint so the REPL keeps running.
+func (ci *chatInterrupter) watch(interrupts <-chan os.Signal) {
+	for range interrupts {
+		ci.mu.Lock()
+		if ci.cancel != nil {
+			ci.cancel()
+		} else {
+			fmt.Fprint(ci.out, "\n(Use /exit or Ctrl-D to quit)\n> ")
+		}
+		ci.mu.Unlock()
+	}
+}
+
+func (ci *chatInterrupter) set(cancel context.CancelFunc) {
+	ci.mu.Lock()
+	ci.cancel = cancel
+	ci.mu.Unlock()
+}
+
+// runChatLoop reads one turn per line from in and streams each response to
+// out. interrupts may be nil when cancellation isn't needed (e.g., in tests).
+func runChatLoop(ctx context.Context, provider ai.Provider, systemPrompt string, in io.Reader, out io.Writer, interrupts <-chan os.Signal) error {
+	conversation := ai.NewConversation(provider, systemPrompt)
+
+	interrupter := &chatInterrupter{out: out}
+	if interrupts != nil {
+		go interrupter.watch(interrupts)
+	}
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for {
+		fmt.Fprint(out, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+
+		input := strings.TrimSpace(scanner.Text())
+		switch input {
+		case "":
+			continue
+		case "/exit", "/quit":
+			return nil
+		case "/clear":
+			conversation.Clear()
+			fmt.Fprintln(out, "Conversation cleared.")
+			continue
+		}
+
+		conversation.AddUserMessage(input)
+
+		turnCtx, cancel := context.WithCancel(ctx)
+		interrupter.set(cancel)
+		err := streamChatTurn(turnCtx, conversation, out)
+		interrupter.set(nil)
+		cancelled := turnCtx.Err() != nil
+		cancel()
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if cancelled {
+				fmt.Fprintln(out, "\n(response cancelled)")
+			} else {
+				fmt.Fprintf(out, "\nError: %v\n", err)
+			}
+		}
+	}
+}
+
+// streamChatTurn sends the conversation and prints chunks as they arrive.
+// A turn that fails keeps any partial reply in the history so user and
+// assistant messages stay paired; a turn with no reply at all is rolled back.
+func streamChatTurn(ctx context.Context, conversation *ai.Conversation, out io.Writer) error {
+	chunks, err := conversation.Stream(ctx)
+	if err != nil {
+		conversation.RemoveLastMessage()
+		return err
+	}
+
+	var partial strings.Builder
+	var streamErr error
+	completed := false
+
+	for chunk := range chunks {
+		if chunk.Content != "" {
+			fmt.Fprint(out, chunk.Content)
+			partial.WriteString(chunk.Content)
+		}
+		if chunk.Error != nil {
+			streamErr = chunk.Error
+		}
+		if chunk.Done {
+			completed = streamErr == nil
+		}
+	}
+	fmt.Fprintln(out)
+
+	if completed {
+		if partial.Len() == 0 {
+			// Nothing was added to the history for an empty reply
+			conversation.RemoveLastMessage()
+		}
+		return nil
+	}
+
+	if partial.Len() > 0 {
+		conversation.AddAssistantMessage(partial.String())
+	} else {
+		conversation.RemoveLastMessage()
+	}
+
+	if streamErr == nil {
+		streamErr = rigerrors.New("stream ended unexpectedly")
+	}
+	return streamErr
+}
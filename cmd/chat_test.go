@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"thoreinstein.com/rig/pkg/ai"
+)
+
+// fakeAIProvider is a scripted ai.Provider that records the messages it receives
+type fakeAIProvider struct {
+	replies  []string
+	err      error
+	received [][]ai.Message
+}
+
+func (p *fakeAIProvider) IsAvailable() bool { return true }
+
+func (p *fakeAIProvider) Name() string { return "fake" }
+
+func (p *fakeAIProvider) nextReply(messages []ai.Message) string {
+	p.received = append(p.received, append([]ai.Message(nil), messages...))
+	if len(p.replies) == 0 {
+		return ""
+	}
+	reply := p.replies[0]
+	p.replies = p.replies[1:]
+	return reply
+}
+
+func (p *fakeAIProvider) Chat(ctx context.Context, messages []ai.Message) (*ai.Response, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &ai.Response{Content: p.nextReply(messages), InputTokens: 10, OutputTokens: 5}, nil
+}
+
+func (p *fakeAIProvider) StreamChat(ctx context.Context, messages []ai.Message) (<-chan ai.StreamChunk, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	reply := p.nextReply(messages)
+	ch := make(chan ai.StreamChunk, len(reply)+1)
+	for _, word := range strings.SplitAfter(reply, " ") {
+		ch <- ai.StreamChunk{Content: word}
+	}
+	ch <- ai.StreamChunk{Done: true}
+	close(ch)
+	return ch, nil
+}
+
+func TestRunChatLoop_MaintainsHistory(t *testing.T) {
+	provider := &fakeAIProvider{replies: []string{"Hello there", "Still here"}}
+	in := strings.NewReader("hi\n\nare you there?\n/exit\nignored\n")
+	var out bytes.Buffer
+
+	if err := runChatLoop(context.Background(), provider, "be brief", in, &out, nil); err != nil {
+		t.Fatalf("runChatLoop() error = %v", err)
+	}
+
+	if !strings.Contains(out.String(), "Hello there") || !strings.Contains(out.String(), "Still here") {
+		t.Errorf("output missing streamed replies:\n%s", out.String())
+	}
+
+	if len(provider.received) != 2 {
+		t.Fatalf("provider called %d times, want 2", len(provider.received))
+	}
+
+	second := provider.received[1]
+	wantRoles := []string{"system", "user", "assistant", "user"}
+	if len(second) != len(wantRoles) {
+		t.Fatalf("second turn sent %d messages, want %d", len(second), len(wantRoles))
+	}
+	for i, role := range wantRoles {
+		if second[i].Role != role {
+			t.Errorf("message %d role = %q, want %q", i, second[i].Role, role)
+		}
+	}
+	if second[2].Content != "Hello there" {
+		t.Errorf("assistant history = %q, want %q", second[2].Content, "Hello there")
+	}
+}
+
+func TestRunChatLoop_Clear(t *testing.T) {
+	provider := &fakeAIProvider{replies: []string{"one", "two"}}
+	in := strings.NewReader("first\n/clear\nsecond\n")
+	var out bytes.Buffer
+
+	if err := runChatLoop(context.Background(), provider, "", in, &out, nil); err != nil {
+		t.Fatalf("runChatLoop() error = %v", err)
+	}
+
+	if len(provider.received) != 2 {
+		t.Fatalf("provider called %d times, want 2", len(provider.received))
+	}
+	if len(provider.received[1]) != 1 {
+		t.Errorf("after /clear, sent %d messages, want 1", len(provider.received[1]))
+	}
+}
+
+func TestRunChatLoop_ErrorRollsBackTurn(t *testing.T) {
+	provider := &fakeAIProvider{err: context.DeadlineExceeded}
+	in := strings.NewReader("hello\n")
+	var out bytes.Buffer
+
+	if err := runChatLoop(context.Background(), provider, "", in, &out, nil); err != nil {
+		t.Fatalf("runChatLoop() error = %v, want errors reported inline", err)
+	}
+	if !strings.Contains(out.String(), "Error:") {
+		t.Errorf("output should report the error:\n%s", out.String())
+	}
+}
+
+func TestStreamChatTurn_CancelledKeepsPartialReply(t *testing.T) {
+	conversation := ai.NewConversation(&cancelledStreamProvider{}, "")
+	conversation.AddUserMessage("tell me a story")
+
+	var out bytes.Buffer
+	if err := streamChatTurn(context.Background(), conversation, &out); err == nil {
+		t.Fatal("streamChatTurn() should return an error when cancelled")
+	}
+
+	history := conversation.History()
+	if len(history) != 2 || history[1].Role != "assistant" || history[1].Content != "Once upon" {
+		t.Errorf("history = %+v, want user turn followed by partial assistant reply", history)
+	}
+}
+
+// cancelledStreamProvider emits one chunk and then reports cancellation
+type cancelledStreamProvider struct {
+	fakeAIProvider
+}
+
+func (p *cancelledStreamProvider) StreamChat(ctx context.Context, messages []ai.Message) (<-chan ai.StreamChunk, error) {
+	ch := make(chan ai.StreamChunk, 2)
+	ch <- ai.StreamChunk{Content: "Once upon"}
+	ch <- ai.StreamChunk{Error: context.Canceled, Done: true}
+	close(ch)
+	return ch, nil
+}
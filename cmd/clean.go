@@ -3,21 +3,27 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/cockroachdb/errors"
 	"github.com/spf13/cobra"
 
 	"thoreinstein.com/rig/pkg/config"
+	"thoreinstein.com/rig/pkg/events"
 	"thoreinstein.com/rig/pkg/git"
+	"thoreinstein.com/rig/pkg/hooks"
 	"thoreinstein.com/rig/pkg/tmux"
 )
 
 var cleanDryRun bool
 var cleanForce bool
+var cleanJobs int
 
 // cleanCmd represents the clean command
 var cleanCmd = &cobra.Command{
@@ -31,9 +37,10 @@ to clean them up. By default, it prompts for confirmation before removing.
 Examples:
   rig clean              # Interactive cleanup with confirmation
   rig clean --dry-run    # Show what would be removed without removing
-  rig clean --force      # Remove without confirmation`,
+  rig clean --force      # Remove without confirmation
+  rig clean -j 4         # Limit merge checks to 4 concurrent git processes`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runCleanCommand()
+		return runCleanCommand(cmd.InOrStdin(), cmd.OutOrStdout())
 	},
 }
 
@@ -42,6 +49,7 @@ func init() {
 
 	cleanCmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "Show what would be removed without removing")
 	cleanCmd.Flags().BoolVar(&cleanForce, "force", false, "Remove without confirmation prompts")
+	cleanCmd.Flags().IntVarP(&cleanJobs, "jobs", "j", 0, "Number of concurrent merge checks (default GOMAXPROCS)")
 }
 
 // CleanupCandidate represents a worktree that can be cleaned up
@@ -54,7 +62,9 @@ type CleanupCandidate struct {
 	HasSession bool
 }
 
-func runCleanCommand() error {
+// runCleanCommand lists cleanup candidates on out and, unless --force is set,
+// reads the confirmation from in
+func runCleanCommand(in io.Reader, out io.Writer) error {
 	// Load configuration
 	cfg, err := loadConfig()
 	if err != nil {
@@ -68,13 +78,13 @@ func runCleanCommand() error {
 	}
 
 	if len(candidates) == 0 {
-		fmt.Println("No worktrees found to clean up.")
+		fmt.Fprintln(out, "No worktrees found to clean up.")
 		return nil
 	}
 
 	// Display candidates
-	fmt.Println("=== Cleanup Candidates ===")
-	fmt.Println()
+	fmt.Fprintln(out, "=== Cleanup Candidates ===")
+	fmt.Fprintln(out)
 
 	for i, candidate := range candidates {
 		status := ""
@@ -86,23 +96,23 @@ func runCleanCommand() error {
 		}
 
 		relPath := strings.TrimPrefix(candidate.Path, candidate.RepoPath+"/")
-		fmt.Printf("  %d. [%s] %s%s\n", i+1, candidate.RepoName, relPath, status)
+		fmt.Fprintf(out, "  %d. [%s] %s%s\n", i+1, candidate.RepoName, relPath, status)
 		if verbose {
-			fmt.Printf("      Branch: %s\n", candidate.Branch)
-			fmt.Printf("      Path: %s\n", candidate.Path)
+			fmt.Fprintf(out, "      Branch: %s\n", candidate.Branch)
+			fmt.Fprintf(out, "      Path: %s\n", candidate.Path)
 		}
 	}
-	fmt.Println()
+	fmt.Fprintln(out)
 
 	if cleanDryRun {
-		fmt.Printf("Would remove %d worktree(s) (dry-run mode)\n", len(candidates))
+		fmt.Fprintf(out, "Would remove %d worktree(s) (dry-run mode)\n", len(candidates))
 		return nil
 	}
 
 	// Confirm unless --force
 	if !cleanForce {
-		fmt.Printf("Remove %d worktree(s)? [y/N]: ", len(candidates))
-		reader := bufio.NewReader(os.Stdin)
+		fmt.Fprintf(out, "Remove %d worktree(s)? [y/N]: ", len(candidates))
+		reader := bufio.NewReader(in)
 		response, err := reader.ReadString('\n')
 		if err != nil {
 			return errors.Wrap(err, "failed to read input")
@@ -110,7 +120,7 @@ func runCleanCommand() error {
 
 		response = strings.TrimSpace(strings.ToLower(response))
 		if response != "y" && response != "yes" {
-			fmt.Println("Aborted.")
+			fmt.Fprintln(out, "Aborted.")
 			return nil
 		}
 	}
@@ -118,16 +128,33 @@ func runCleanCommand() error {
 	// Remove worktrees
 	removed := 0
 	for _, candidate := range candidates {
+		hookEnv := hooks.Env{
+			Ticket:   filepath.Base(candidate.Path),
+			Worktree: candidate.Path,
+			GitRoot:  candidate.RepoPath,
+		}
+		if err := runHook(cfg, "pre_clean", cfg.Hooks.PreClean, hookEnv); err != nil {
+			fmt.Fprintf(out, "  Skipped %s: %v\n", candidate.Path, err)
+			continue
+		}
+
 		err := removeWorktree(cfg, candidate)
 		if err != nil {
-			fmt.Printf("  Failed to remove %s: %v\n", candidate.Path, err)
+			fmt.Fprintf(out, "  Failed to remove %s: %v\n", candidate.Path, err)
 		} else {
-			fmt.Printf("  Removed %s\n", candidate.Path)
+			fmt.Fprintf(out, "  Removed %s\n", candidate.Path)
+			lifecycleEvents().Emit(events.WorktreeRemoved, filepath.Base(candidate.Path), map[string]string{
+				"path":   candidate.Path,
+				"branch": candidate.Branch,
+			})
+			if err := runHook(cfg, "post_clean", cfg.Hooks.PostClean, hookEnv); err != nil {
+				fmt.Fprintf(out, "  %v\n", err)
+			}
 			removed++
 		}
 	}
 
-	fmt.Printf("\nRemoved %d worktree(s)\n", removed)
+	fmt.Fprintf(out, "\nRemoved %d worktree(s)\n", removed)
 	return nil
 }
 
@@ -150,7 +177,7 @@ func findCleanupCandidates(cfg *config.Config) ([]CleanupCandidate, error) {
 	}
 	sessionSet := make(map[string]bool)
 	for _, s := range sessions {
-		sessionSet[s] = true
+		sessionSet[s.Name] = true
 	}
 
 	worktrees, err := gitManager.ListWorktrees()
@@ -187,24 +214,52 @@ func findCleanupCandidates(cfg *config.Config) ([]CleanupCandidate, error) {
 			sessionName = cfg.Tmux.SessionPrefix + sessionName
 		}
 
-		// Check if branch is merged
-		isMerged := isBranchMerged(repoRoot, branch, baseBranch)
-
 		candidate := CleanupCandidate{
 			Path:       wt,
 			Branch:     branch,
 			RepoName:   repoName,
 			RepoPath:   repoRoot,
-			IsMerged:   isMerged,
 			HasSession: sessionSet[sessionName],
 		}
 
 		candidates = append(candidates, candidate)
 	}
 
+	// Merge checks each spawn git, so run them concurrently
+	markMergedCandidates(candidates, baseBranch, cleanJobs)
+
 	return candidates, nil
 }
 
+// markMergedCandidates sets IsMerged on each candidate using at most jobs
+// concurrent git processes (GOMAXPROCS when jobs <= 0). Results are written
+// back by index, so candidate order is unchanged.
+func markMergedCandidates(candidates []CleanupCandidate, baseBranch string, jobs int) {
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+	jobs = min(jobs, len(candidates))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				c := &candidates[i]
+				c.IsMerged = isBranchMerged(c.RepoPath, c.Branch, baseBranch)
+			}
+		}()
+	}
+
+	for i := range candidates {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
 func getWorktreeDetailsForClean(repoPath string) map[string]WorktreeInfo {
 	result := make(map[string]WorktreeInfo)
 
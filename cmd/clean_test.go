@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/viper"
@@ -372,6 +375,15 @@ func TestCleanCommandFlags(t *testing.T) {
 	if forceFlag != nil && forceFlag.DefValue != "false" {
 		t.Errorf("--force default should be false, got %s", forceFlag.DefValue)
 	}
+
+	// Check --jobs flag exists
+	jobsFlag := cmd.Flags().Lookup("jobs")
+	if jobsFlag == nil {
+		t.Error("clean command should have --jobs flag")
+	}
+	if jobsFlag != nil && jobsFlag.DefValue != "0" {
+		t.Errorf("--jobs default should be 0, got %s", jobsFlag.DefValue)
+	}
 }
 
 func TestCleanCommandDescription(t *testing.T) {
@@ -756,11 +768,16 @@ func TestRunCleanCommand_DryRun(t *testing.T) {
 	cleanDryRun = true
 	cleanForce = false
 
-	// Capture output (command runs synchronously)
-	err := runCleanCommand()
+	var out bytes.Buffer
+	err := runCleanCommand(strings.NewReader(""), &out)
 	if err != nil {
 		t.Fatalf("runCleanCommand() with --dry-run error: %v", err)
 	}
+	for _, want := range []string{"=== Cleanup Candidates ===", "Would remove 2 worktree(s) (dry-run mode)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
 
 	// Verify worktrees still exist (dry-run should NOT remove them)
 	for _, path := range worktreePaths {
@@ -792,10 +809,14 @@ func TestRunCleanCommand_Force(t *testing.T) {
 	cleanDryRun = false
 	cleanForce = true
 
-	err := runCleanCommand()
+	var out bytes.Buffer
+	err := runCleanCommand(strings.NewReader(""), &out)
 	if err != nil {
 		t.Fatalf("runCleanCommand() with --force error: %v", err)
 	}
+	if !strings.Contains(out.String(), "Removed 2 worktree(s)") {
+		t.Errorf("output should report removed worktrees:\n%s", out.String())
+	}
 
 	// Verify worktrees were removed
 	for _, path := range worktreePaths {
@@ -805,6 +826,44 @@ func TestRunCleanCommand_Force(t *testing.T) {
 	}
 }
 
+func TestRunCleanCommand_Declined(t *testing.T) {
+	// Skip if git is not available
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH, skipping test")
+	}
+
+	repoDir, worktreePaths := setupCleanTestGitRepo(t)
+
+	notesDir := t.TempDir()
+	setupCleanTestConfig(t, notesDir)
+	defer func() {
+		cleanDryRun = false
+		cleanForce = false
+		viper.Reset()
+	}()
+
+	t.Chdir(repoDir)
+
+	cleanDryRun = false
+	cleanForce = false
+
+	var out bytes.Buffer
+	if err := runCleanCommand(strings.NewReader("n\n"), &out); err != nil {
+		t.Fatalf("runCleanCommand() error: %v", err)
+	}
+	for _, want := range []string{"Remove 2 worktree(s)? [y/N]: ", "Aborted."} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	for _, path := range worktreePaths {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			t.Errorf("Worktree %q should still exist after declining", path)
+		}
+	}
+}
+
 func TestRunCleanCommand_NoWorktrees(t *testing.T) {
 	// Skip if git is not available
 	if _, err := exec.LookPath("git"); err != nil {
@@ -863,10 +922,14 @@ func TestRunCleanCommand_NoWorktrees(t *testing.T) {
 	cleanForce = true
 
 	// Should not error when no worktrees to clean
-	err := runCleanCommand()
+	var out bytes.Buffer
+	err := runCleanCommand(strings.NewReader(""), &out)
 	if err != nil {
 		t.Errorf("runCleanCommand() should not error with no worktrees: %v", err)
 	}
+	if !strings.Contains(out.String(), "No worktrees found to clean up.") {
+		t.Errorf("output = %q, want no-worktrees message", out.String())
+	}
 }
 
 func TestRunCleanCommand_MergedBranchDetection(t *testing.T) {
@@ -992,3 +1055,77 @@ func setupCleanTestConfig(t *testing.T, notesPath string) {
 func loadTestConfig() (*config.Config, error) {
 	return config.Load()
 }
+
+func TestMarkMergedCandidates_MatchesSerial(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH, skipping test")
+	}
+
+	repoDir := filepath.Join(t.TempDir(), "repo")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	if err := os.MkdirAll(repoDir, 0755); err != nil {
+		t.Fatalf("Failed to create repo dir: %v", err)
+	}
+	run(repoDir, "init", "-b", "main")
+	run(repoDir, "config", "user.email", "test@example.com")
+	run(repoDir, "config", "user.name", "Test User")
+	run(repoDir, "config", "commit.gpgsign", "false")
+	run(repoDir, "commit", "--allow-empty", "-m", "Initial commit")
+
+	// Even-numbered branches stay at main's commit and count as merged;
+	// odd-numbered ones get an extra commit.
+	var candidates []CleanupCandidate
+	for i := range 6 {
+		branch := fmt.Sprintf("feature-%d", i)
+		wtPath := filepath.Join(repoDir, "feature", branch)
+		run(repoDir, "worktree", "add", "-b", branch, wtPath)
+		if i%2 == 1 {
+			run(wtPath, "commit", "--allow-empty", "-m", "Unmerged work")
+		}
+		candidates = append(candidates, CleanupCandidate{Path: wtPath, Branch: branch, RepoPath: repoDir})
+	}
+	// A detached worktree has no branch and is never merged
+	candidates = append(candidates, CleanupCandidate{Path: filepath.Join(repoDir, "detached"), RepoPath: repoDir})
+
+	want := make([]bool, len(candidates))
+	for i, c := range candidates {
+		want[i] = isBranchMerged(c.RepoPath, c.Branch, "main")
+	}
+
+	for _, jobs := range []int{0, 1, 3, 100} {
+		t.Run(fmt.Sprintf("jobs=%d", jobs), func(t *testing.T) {
+			got := make([]CleanupCandidate, len(candidates))
+			copy(got, candidates)
+
+			markMergedCandidates(got, "main", jobs)
+
+			for i, c := range got {
+				if c.Path != candidates[i].Path {
+					t.Errorf("candidate %d path = %q, want %q", i, c.Path, candidates[i].Path)
+				}
+				if c.IsMerged != want[i] {
+					t.Errorf("candidate %d (%s) IsMerged = %v, want %v", i, c.Branch, c.IsMerged, want[i])
+				}
+			}
+		})
+	}
+
+	for i, merged := range want {
+		if wantMerged := i < 6 && i%2 == 0; merged != wantMerged {
+			t.Errorf("serial isBranchMerged for candidate %d = %v, want %v", i, merged, wantMerged)
+		}
+	}
+}
+
+func TestMarkMergedCandidates_Empty(t *testing.T) {
+	// Must not block or panic without candidates
+	markMergedCandidates(nil, "main", 4)
+}
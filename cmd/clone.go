@@ -1,7 +1,12 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/spf13/cobra"
@@ -11,7 +16,7 @@ import (
 
 // cloneCmd represents the clone command
 var cloneCmd = &cobra.Command{
-	Use:   "clone <url>",
+	Use:   "clone <url>...",
 	Short: "Clone a GitHub repository to ~/src/<owner>/<repo>",
 	Long: `Clone a GitHub repository using a structured directory layout.
 
@@ -29,22 +34,80 @@ HTTPS URLs (https://github.com/...):
 Shorthand URLs (github.com/owner/repo or owner/repo):
   - Interpreted as SSH by default
 
+Several repositories, given as arguments or one per line in a file (--file),
+are cloned concurrently, at most --jobs at a time. A failed clone does not
+stop the others; a summary is printed at the end.
+
 Examples:
   rig clone git@github.com:thoreinstein/rig.git
   rig clone https://github.com/thoreinstein/rig
   rig clone github.com/owner/repo
-  rig clone owner/repo`,
-	Args: cobra.ExactArgs(1),
+  rig clone owner/repo
+  rig clone owner/repo1 owner/repo2 owner/repo3
+  rig clone --file repos.txt --jobs 8`,
+	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runCloneCommand(args[0])
+		urls := args
+		if cloneFile != "" {
+			fromFile, err := readCloneList(cloneFile)
+			if err != nil {
+				return err
+			}
+			urls = append(urls, fromFile...)
+		}
+		return runCloneCommand(cmd.OutOrStdout(), urls)
 	},
 }
 
+var (
+	cloneFile string
+	cloneJobs int
+)
+
 func init() {
 	rootCmd.AddCommand(cloneCmd)
+
+	cloneCmd.Flags().StringVarP(&cloneFile, "file", "f", "", "Read repositories to clone from a file, one per line")
+	cloneCmd.Flags().IntVarP(&cloneJobs, "jobs", "j", 4, "Maximum number of concurrent clones")
 }
 
-func runCloneCommand(urlInput string) error {
+// readCloneList reads repository URLs from path, one per line, skipping blank
+// lines and # comments
+func readCloneList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open clone list %s", path)
+	}
+	defer f.Close()
+
+	var urls []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		urls = append(urls, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read clone list %s", path)
+	}
+	return urls, nil
+}
+
+func runCloneCommand(w io.Writer, urlInputs []string) error {
+	switch len(urlInputs) {
+	case 0:
+		return errors.New("no repositories to clone: pass URLs as arguments or use --file")
+	case 1:
+		return cloneOne(w, urlInputs[0])
+	default:
+		return cloneMany(w, urlInputs)
+	}
+}
+
+// cloneOne clones a single repository, reporting errors directly
+func cloneOne(w io.Writer, urlInput string) error {
 	// Parse the URL first
 	repoURL, err := git.ParseGitHubURL(urlInput)
 	if err != nil {
@@ -52,12 +115,12 @@ func runCloneCommand(urlInput string) error {
 	}
 
 	if verbose {
-		fmt.Printf("Parsed URL:\n")
-		fmt.Printf("  Original: %s\n", repoURL.Original)
-		fmt.Printf("  Canonical: %s\n", repoURL.Canonical)
-		fmt.Printf("  Protocol: %s\n", repoURL.Protocol)
-		fmt.Printf("  Owner: %s\n", repoURL.Owner)
-		fmt.Printf("  Repo: %s\n", repoURL.Repo)
+		fmt.Fprintf(w, "Parsed URL:\n")
+		fmt.Fprintf(w, "  Original: %s\n", repoURL.Original)
+		fmt.Fprintf(w, "  Canonical: %s\n", repoURL.Canonical)
+		fmt.Fprintf(w, "  Protocol: %s\n", repoURL.Protocol)
+		fmt.Fprintf(w, "  Owner: %s\n", repoURL.Owner)
+		fmt.Fprintf(w, "  Repo: %s\n", repoURL.Repo)
 	}
 
 	// Load configuration to get base path (if configured)
@@ -71,17 +134,85 @@ func runCloneCommand(urlInput string) error {
 
 	// Create clone manager and perform clone
 	cloneManager := git.NewCloneManager(basePath, verbose)
+	cloneManager.BaseBranch = cfg.Git.BaseBranch
+
+	ctx, cancel := networkContext(cfg.Network.Timeout)
+	defer cancel()
 
-	repoPath, err := cloneManager.Clone(repoURL)
+	repoPath, err := cloneManager.CloneContext(ctx, repoURL)
 	if err != nil {
 		return errors.Wrap(err, "clone failed")
 	}
 
-	fmt.Printf("Repository cloned to: %s\n", repoPath)
+	fmt.Fprintf(w, "Repository cloned to: %s\n", repoPath)
 
 	if repoURL.Protocol == "ssh" {
-		fmt.Printf("\nWorktree workflow enabled. Use 'rig hack <name>' from within the repo to create feature worktrees.\n")
+		fmt.Fprintf(w, "\nWorktree workflow enabled. Use 'rig hack <name>' from within the repo to create feature worktrees.\n")
 	}
 
 	return nil
 }
+
+// cloneMany clones several repositories concurrently and prints a progress
+// line per finished clone followed by a summary. Inputs that fail to parse
+// are reported alongside failed clones.
+func cloneMany(w io.Writer, urlInputs []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	style := outputStyle(w)
+	var failures []string
+
+	repoURLs := make([]*git.RepoURL, 0, len(urlInputs))
+	for _, input := range urlInputs {
+		repoURL, err := git.ParseGitHubURL(input)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", input, err))
+			continue
+		}
+		repoURLs = append(repoURLs, repoURL)
+	}
+
+	cloneManager := git.NewCloneManager(cfg.Clone.BasePath, verbose)
+	cloneManager.BaseBranch = cfg.Git.BaseBranch
+	cloneManager.NetworkTimeout = cfg.Network.Timeout
+
+	total := len(repoURLs)
+	if total > 0 {
+		jobs := cloneJobs
+		if jobs <= 0 || jobs > total {
+			jobs = total
+		}
+		fmt.Fprintf(w, "Cloning %d repositories (%d at a time)...\n", total, jobs)
+	}
+	results := cloneManager.CloneAll(context.Background(), repoURLs, cloneJobs, func(done int, result git.CloneResult) {
+		name := result.URL.Owner + "/" + result.URL.Repo
+		if result.Err != nil {
+			fmt.Fprintf(w, "  [%d/%d] %s %s: %v\n", done, total, style.CrossMark(), name, result.Err)
+			return
+		}
+		fmt.Fprintf(w, "  [%d/%d] %s %s\n", done, total, style.CheckMark(), name)
+	})
+
+	cloned := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failures = append(failures, fmt.Sprintf("%s/%s: %v", result.URL.Owner, result.URL.Repo, result.Err))
+			continue
+		}
+		cloned++
+	}
+
+	fmt.Fprintf(w, "\nCloned %d of %d repositories\n", cloned, len(urlInputs))
+	if len(failures) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(w, "Failed:")
+	for _, failure := range failures {
+		fmt.Fprintf(w, "  %s %s\n", style.CrossMark(), failure)
+	}
+	return errors.Newf("%d of %d repositories failed to clone", len(failures), len(urlInputs))
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -13,16 +15,15 @@ import (
 )
 
 func TestCloneCommandArgs(t *testing.T) {
-	// Test that clone command requires exactly 1 argument
 	cmd := cloneCmd
 
 	if cmd.Args == nil {
 		t.Error("clone command should have Args validation")
 	}
 
-	// The command should have Use showing <url> argument
-	if cmd.Use != "clone <url>" {
-		t.Errorf("clone command Use = %q, want %q", cmd.Use, "clone <url>")
+	// The command should have Use showing one or more <url> arguments
+	if cmd.Use != "clone <url>..." {
+		t.Errorf("clone command Use = %q, want %q", cmd.Use, "clone <url>...")
 	}
 }
 
@@ -92,7 +93,7 @@ func TestRunCloneCommand_InvalidURL(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := runCloneCommand(tt.url)
+			err := runCloneCommand(io.Discard, []string{tt.url})
 			if err == nil {
 				t.Errorf("runCloneCommand(%q) should have returned an error", tt.url)
 				return
@@ -104,6 +105,74 @@ func TestRunCloneCommand_InvalidURL(t *testing.T) {
 	}
 }
 
+func TestRunCloneCommand_NoURLs(t *testing.T) {
+	err := runCloneCommand(io.Discard, nil)
+	if err == nil || !strings.Contains(err.Error(), "no repositories") {
+		t.Errorf("runCloneCommand(nil) error = %v, want no repositories error", err)
+	}
+}
+
+func TestRunCloneCommand_MultipleInvalidURLs(t *testing.T) {
+	viper.Reset()
+	resetConfig()
+	viper.Set("clone.base_path", t.TempDir())
+	defer viper.Reset()
+
+	var out bytes.Buffer
+	err := runCloneCommand(&out, []string{"not-a-valid-url", "git@gitlab.com:owner/repo.git"})
+	if err == nil {
+		t.Fatal("runCloneCommand() should fail when every URL is invalid")
+	}
+	if !strings.Contains(err.Error(), "2 of 2 repositories failed") {
+		t.Errorf("error = %q, want failure count", err.Error())
+	}
+
+	output := out.String()
+	for _, want := range []string{"Cloned 0 of 2 repositories", "not-a-valid-url", "git@gitlab.com:owner/repo.git"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Cloning") {
+		t.Errorf("output should not announce clones when none are valid, got:\n%s", output)
+	}
+}
+
+func TestReadCloneList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repos.txt")
+	content := "# work repos\nowner/repo1\n\n  owner/repo2  \n# owner/skipped\ngit@github.com:owner/repo3.git\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write clone list: %v", err)
+	}
+
+	urls, err := readCloneList(path)
+	if err != nil {
+		t.Fatalf("readCloneList() error = %v", err)
+	}
+
+	want := []string{"owner/repo1", "owner/repo2", "git@github.com:owner/repo3.git"}
+	if strings.Join(urls, ",") != strings.Join(want, ",") {
+		t.Errorf("readCloneList() = %v, want %v", urls, want)
+	}
+
+	if _, err := readCloneList(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("readCloneList() should fail for a missing file")
+	}
+}
+
+func TestCloneCommandFlags(t *testing.T) {
+	if f := cloneCmd.Flags().Lookup("file"); f == nil || f.Shorthand != "f" {
+		t.Error("clone command should have --file/-f flag")
+	}
+	jobs := cloneCmd.Flags().Lookup("jobs")
+	if jobs == nil {
+		t.Fatal("clone command should have --jobs flag")
+	}
+	if jobs.DefValue != "4" {
+		t.Errorf("--jobs default = %s, want 4", jobs.DefValue)
+	}
+}
+
 func TestRunCloneCommand_ValidURL_Integration(t *testing.T) {
 	// Skip if git is not available
 	if _, err := exec.LookPath("git"); err != nil {
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"thoreinstein.com/rig/pkg/ai"
+	rigerrors "thoreinstein.com/rig/pkg/errors"
+	"thoreinstein.com/rig/pkg/git"
+)
+
+// commitSystemPrompt instructs the provider to write a conventional commit
+const commitSystemPrompt = `You write git commit messages following the Conventional Commits specification.
+
+Rules:
+- First line: <type>(<optional scope>): <summary>, at most 72 characters, imperative mood, no trailing period
+- Types: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert
+- If the change needs explanation, add a blank line and a short body wrapped at 72 characters describing what and why
+- Output only the commit message, with no code fences or commentary`
+
+// commitCmd proposes a commit message for the staged changes
+var commitCmd = &cobra.Command{
+	Use:   "commit",
+	Short: "Commit staged changes with an AI-generated message",
+	Long: `Generate a Conventional Commits message for the staged changes and commit.
+
+The staged diff (git diff --cached) is sent to the configured AI provider,
+truncated to ai.diff_token_budget tokens if needed. You can then accept the
+proposed message, edit it in $EDITOR, or cancel.
+
+Examples:
+  rig commit                  # Propose a message and ask before committing
+  rig commit --yes            # Commit with the proposed message
+  rig commit --max-tokens 2000`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCommitCommand()
+	},
+}
+
+var (
+	commitYes       bool
+	commitMaxTokens int
+)
+
+func init() {
+	rootCmd.AddCommand(commitCmd)
+
+	commitCmd.Flags().BoolVarP(&commitYes, "yes", "y", false, "Commit with the proposed message without prompting")
+	commitCmd.Flags().IntVar(&commitMaxTokens, "max-tokens", 0, "Token budget for the diff (default: ai.diff_token_budget)")
+}
+
+func runCommitCommand() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return rigerrors.NewConfigErrorWithCause("", "failed to load configuration", err)
+	}
+
+	provider, err := ai.NewProvider(&cfg.AI, verbose)
+	if err != nil {
+		return err
+	}
+
+	budget := cfg.AI.DiffTokenBudget
+	if commitMaxTokens > 0 {
+		budget = commitMaxTokens
+	}
+
+	commitManager := git.NewCommitManager(".", verbose)
+
+	return runCommit(context.Background(), commitManager, provider, budget, os.Stdin, os.Stdout, editCommitMessage)
+}
+
+// runCommit generates a message for the staged diff and commits once the user
+// accepts it. editFn is called when the user chooses to edit the proposal.
+func runCommit(ctx context.Context, cm *git.CommitManager, provider ai.Provider, budget int, in io.Reader, out io.Writer, editFn func(string) (string, error)) error {
+	diff, err := cm.StagedDiff()
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(diff) == "" {
+		return rigerrors.New("no staged changes to commit (use git add first)")
+	}
+
+	diff, truncated := ai.TruncateToTokenBudget(diff, budget)
+	if truncated {
+		fmt.Fprintf(out, "Note: staged diff truncated to ~%d tokens\n", budget)
+	}
+
+	fmt.Fprintf(out, "Generating commit message with %s...\n", provider.Name())
+
+	resp, err := provider.Chat(ctx, []ai.Message{
+		{Role: "system", Content: commitSystemPrompt},
+		{Role: "user", Content: "Write a commit message for this staged diff:\n\n" + diff},
+	})
+	if err != nil {
+		return rigerrors.Wrap(err, "failed to generate commit message")
+	}
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "Tokens: %d input, %d output\n", resp.InputTokens, resp.OutputTokens)
+	}
+
+	message := cleanCommitMessage(resp.Content)
+	if message == "" {
+		return rigerrors.New("AI provider returned an empty commit message")
+	}
+
+	fmt.Fprintf(out, "\nProposed commit message:\n\n%s\n\n", indentBlock(message, "    "))
+
+	if !commitYes {
+		fmt.Fprint(out, "[a]ccept, [e]dit, [c]ancel: ")
+		reader := bufio.NewReader(in)
+		response, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return rigerrors.Wrap(err, "failed to read input")
+		}
+
+		switch strings.TrimSpace(strings.ToLower(response)) {
+		case "a", "accept", "y", "yes":
+		case "e", "edit":
+			message, err = editFn(message)
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(message) == "" {
+				fmt.Fprintln(out, "Empty commit message, aborted.")
+				return nil
+			}
+		default:
+			fmt.Fprintln(out, "Aborted.")
+			return nil
+		}
+	}
+
+	if err := cm.Commit(message); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "Committed.")
+	return nil
+}
+
+// cleanCommitMessage strips code fences and surrounding whitespace that
+// models sometimes add despite instructions
+func cleanCommitMessage(content string) string {
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+
+	var kept []string
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			continue
+		}
+		kept = append(kept, strings.TrimRight(line, " \t\r"))
+	}
+
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// indentBlock prefixes every non-empty line of text with indent
+func indentBlock(text, indent string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// editCommitMessage opens message in the user's editor and returns the
+// result with git-style '#' comment lines removed
+func editCommitMessage(message string) (string, error) {
+	f, err := os.CreateTemp("", "rig-commit-*.txt")
+	if err != nil {
+		return "", rigerrors.Wrap(err, "failed to create temp file")
+	}
+	path := f.Name()
+	defer os.Remove(path)
+
+	content := message + "\n\n# Edit the commit message above. Lines starting with '#' are ignored.\n"
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return "", rigerrors.Wrap(err, "failed to write temp file")
+	}
+	if err := f.Close(); err != nil {
+		return "", rigerrors.Wrap(err, "failed to write temp file")
+	}
+
+	if err := openInEditor(path); err != nil {
+		return "", rigerrors.Wrap(err, "editor failed")
+	}
+
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return "", rigerrors.Wrap(err, "failed to read edited message")
+	}
+
+	return stripCommentLines(string(edited)), nil
+}
+
+// stripCommentLines removes lines starting with '#' and trims the result
+func stripCommentLines(text string) string {
+	var kept []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"thoreinstein.com/rig/pkg/git"
+)
+
+// fakeGitRunner implements git.CommandRunner with canned output keyed by the
+// space-joined git arguments, recording every Run invocation
+type fakeGitRunner struct {
+	outputs map[string]string
+	runs    [][]string
+	runErr  error // Returned from every Run call when set
+}
+
+func (r *fakeGitRunner) Run(dir string, name string, args ...string) error {
+	r.runs = append(r.runs, args)
+	return r.runErr
+}
+
+func (r *fakeGitRunner) Output(dir string, name string, args ...string) ([]byte, error) {
+	return []byte(r.outputs[strings.Join(args, " ")]), nil
+}
+
+const testStagedDiff = "diff --git a/main.go b/main.go\n+fmt.Println(\"hi\")\n"
+
+func TestRunCommit(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		yes        bool
+		reply      string
+		edited     string
+		wantCommit string
+	}{
+		{
+			name:       "accept",
+			input:      "a\n",
+			reply:      "feat: print greeting",
+			wantCommit: "feat: print greeting",
+		},
+		{
+			name:       "yes flag skips prompt",
+			yes:        true,
+			reply:      "```\nfix: handle nil\n```",
+			wantCommit: "fix: handle nil",
+		},
+		{
+			name:       "edit",
+			input:      "e\n",
+			reply:      "feat: print greeting",
+			edited:     "feat(cli): print a greeting",
+			wantCommit: "feat(cli): print a greeting",
+		},
+		{
+			name:  "cancel",
+			input: "c\n",
+			reply: "feat: print greeting",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldCommitYes := commitYes
+			commitYes = tt.yes
+			defer func() { commitYes = oldCommitYes }()
+
+			runner := &fakeGitRunner{outputs: map[string]string{"diff --cached --no-color": testStagedDiff}}
+			cm := git.NewCommitManagerWithRunner(".", false, runner)
+			provider := &fakeAIProvider{replies: []string{tt.reply}}
+
+			editFn := func(message string) (string, error) {
+				return tt.edited, nil
+			}
+
+			var out bytes.Buffer
+			err := runCommit(context.Background(), cm, provider, 8000, strings.NewReader(tt.input), &out, editFn)
+			if err != nil {
+				t.Fatalf("runCommit() error = %v", err)
+			}
+
+			if tt.wantCommit == "" {
+				if len(runner.runs) != 0 {
+					t.Errorf("expected no commit, got %v", runner.runs)
+				}
+				return
+			}
+
+			if len(runner.runs) != 1 {
+				t.Fatalf("expected one git commit, got %v", runner.runs)
+			}
+			want := []string{"commit", "-m", tt.wantCommit}
+			if strings.Join(runner.runs[0], "\x00") != strings.Join(want, "\x00") {
+				t.Errorf("git args = %q, want %q", runner.runs[0], want)
+			}
+
+			user := provider.received[0][1].Content
+			if !strings.Contains(user, testStagedDiff) {
+				t.Errorf("prompt should include the staged diff, got %q", user)
+			}
+		})
+	}
+}
+
+func TestRunCommit_NoStagedChanges(t *testing.T) {
+	runner := &fakeGitRunner{outputs: map[string]string{}}
+	cm := git.NewCommitManagerWithRunner(".", false, runner)
+
+	var out bytes.Buffer
+	err := runCommit(context.Background(), cm, &fakeAIProvider{}, 8000, strings.NewReader(""), &out, nil)
+	if err == nil || !strings.Contains(err.Error(), "no staged changes") {
+		t.Errorf("runCommit() error = %v, want no staged changes", err)
+	}
+}
+
+func TestRunCommit_TruncatesLargeDiff(t *testing.T) {
+	oldCommitYes := commitYes
+	commitYes = true
+	defer func() { commitYes = oldCommitYes }()
+
+	bigDiff := strings.Repeat("+added line of code\n", 500)
+	runner := &fakeGitRunner{outputs: map[string]string{"diff --cached --no-color": bigDiff}}
+	cm := git.NewCommitManagerWithRunner(".", false, runner)
+	provider := &fakeAIProvider{replies: []string{"chore: bulk update"}}
+
+	var out bytes.Buffer
+	if err := runCommit(context.Background(), cm, provider, 100, strings.NewReader(""), &out, nil); err != nil {
+		t.Fatalf("runCommit() error = %v", err)
+	}
+
+	if !strings.Contains(out.String(), "truncated") {
+		t.Errorf("output should mention truncation:\n%s", out.String())
+	}
+	if sent := provider.received[0][1].Content; len(sent) > 100*4+200 {
+		t.Errorf("prompt has %d chars, expected it to be truncated", len(sent))
+	}
+}
+
+func TestStripCommentLines(t *testing.T) {
+	got := stripCommentLines("feat: thing\n\nbody\n# comment\n")
+	if got != "feat: thing\n\nbody" {
+		t.Errorf("stripCommentLines() = %q", got)
+	}
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"thoreinstein.com/rig/pkg/config"
+	"thoreinstein.com/rig/pkg/git"
+	"thoreinstein.com/rig/pkg/notes"
+)
+
+// completionCmd generates shell completion scripts
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate shell completion scripts",
+	Long: `Generate a completion script for rig and write it to stdout.
+
+Bash (requires the bash-completion package):
+  source <(rig completion bash)
+  # or persist it:
+  rig completion bash > ~/.local/share/bash-completion/completions/rig
+
+Zsh (compinit must be enabled):
+  rig completion zsh > "${fpath[1]}/_rig"
+
+Fish:
+  rig completion fish > ~/.config/fish/completions/rig.fish
+
+PowerShell:
+  rig completion powershell | Out-String | Invoke-Expression
+
+Start a new shell for the completions to take effect.`,
+	DisableFlagsInUseLine: true,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writeCompletion(cmd.Root(), args[0], cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
+
+// writeCompletion writes the completion script for shell to out
+func writeCompletion(root *cobra.Command, shell string, out io.Writer) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletionV2(out, true)
+	case "zsh":
+		return root.GenZshCompletion(out)
+	case "fish":
+		return root.GenFishCompletion(out, true)
+	case "powershell":
+		return root.GenPowerShellCompletionWithDesc(out)
+	default:
+		return errors.Newf("unsupported shell %q: must be one of bash, zsh, fish, powershell", shell)
+	}
+}
+
+// completeTickets is a ValidArgsFunction for commands whose first argument is
+// a ticket. It offers tickets that have a worktree in the current repository
+// or a note, reading only local state, and offers nothing when that fails.
+func completeTickets(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var candidates []string
+	if worktrees, err := git.NewWorktreeManager(cfg.Git.BaseBranch, false).ListWorktrees(); err == nil {
+		for _, path := range worktrees {
+			if ticket, ok := ticketFromWorktreePath(path); ok {
+				candidates = append(candidates, ticket)
+			}
+		}
+	}
+	candidates = append(candidates, ticketsFromNotes(cfg)...)
+
+	return filterCompletions(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// ticketsFromNotes returns the ticket of every note in a ticket note
+// directory. Without notes.subdirs notes are laid out as
+// {notes.path}/{type}/{ticket}.md, skipping the daily and template
+// directories; with a mapping only the mapped subdirectories and the default
+// subdirectory are searched.
+func ticketsFromNotes(cfg *config.Config) []string {
+	if cfg.Notes.Path == "" {
+		return nil
+	}
+
+	if len(cfg.Notes.Subdirs) > 0 {
+		var tickets []string
+		for _, subdir := range mappedNoteSubdirs(cfg) {
+			tickets = append(tickets, ticketsInNoteDir(filepath.Join(cfg.Notes.Path, subdir), "")...)
+		}
+		return tickets
+	}
+
+	dirs, err := os.ReadDir(cfg.Notes.Path)
+	if err != nil {
+		return nil
+	}
+
+	var tickets []string
+	for _, dir := range dirs {
+		if !dir.IsDir() || dir.Name() == cfg.Notes.DailyDir || dir.Name() == cfg.Notes.TemplateDir {
+			continue
+		}
+		tickets = append(tickets, ticketsInNoteDir(filepath.Join(cfg.Notes.Path, dir.Name()), dir.Name())...)
+	}
+	return tickets
+}
+
+// mappedNoteSubdirs returns the distinct subdirectories named by
+// notes.subdirs plus the default subdirectory for unmapped types
+func mappedNoteSubdirs(cfg *config.Config) []string {
+	defaultSubdir := cfg.Notes.DefaultSubdir
+	if defaultSubdir == "" {
+		defaultSubdir = notes.DefaultSubdir
+	}
+
+	seen := make(map[string]bool)
+	var subdirs []string
+	for _, subdir := range append(slices.Sorted(maps.Values(cfg.Notes.Subdirs)), defaultSubdir) {
+		if subdir == "" || seen[subdir] {
+			continue
+		}
+		seen[subdir] = true
+		subdirs = append(subdirs, subdir)
+	}
+	return subdirs
+}
+
+// ticketsInNoteDir returns the ticket of every note in dir. When ticketType
+// is set, notes for other ticket types are ignored.
+func ticketsInNoteDir(dir, ticketType string) []string {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var tickets []string
+	for _, file := range files {
+		name, ok := strings.CutSuffix(file.Name(), ".md")
+		if !ok || file.IsDir() {
+			continue
+		}
+		ticketInfo, err := parseTicket(name)
+		if err != nil || (ticketType != "" && ticketInfo.Type != ticketType) {
+			continue
+		}
+		tickets = append(tickets, name)
+	}
+	return tickets
+}
+
+// filterCompletions returns the sorted, de-duplicated candidates starting
+// with prefix, ignoring case as ticket input does
+func filterCompletions(candidates []string, prefix string) []string {
+	prefix = strings.ToLower(prefix)
+	seen := make(map[string]bool)
+
+	var matches []string
+	for _, c := range candidates {
+		if seen[c] || !strings.HasPrefix(strings.ToLower(c), prefix) {
+			continue
+		}
+		seen[c] = true
+		matches = append(matches, c)
+	}
+	sort.Strings(matches)
+	return matches
+}
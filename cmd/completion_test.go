@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"thoreinstein.com/rig/pkg/config"
+)
+
+func TestWriteCompletion(t *testing.T) {
+	// Not parallel - generators walk the global rootCmd
+	tests := []struct {
+		shell string
+		want  string
+	}{
+		{"bash", "__start_rig"},
+		{"zsh", "#compdef rig"},
+		{"fish", "complete -c rig"},
+		{"powershell", "Register-ArgumentCompleter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeCompletion(rootCmd, tt.shell, &buf); err != nil {
+				t.Fatalf("writeCompletion(%q) error = %v", tt.shell, err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("writeCompletion(%q) output missing %q", tt.shell, tt.want)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := writeCompletion(rootCmd, "tcsh", &buf); err == nil {
+		t.Error("writeCompletion() should reject an unsupported shell")
+	}
+}
+
+func TestCompletionCommandArgs(t *testing.T) {
+	// Not parallel - accesses global completionCmd
+	for _, args := range [][]string{{"bash"}, {"zsh"}, {"fish"}, {"powershell"}} {
+		if err := completionCmd.ValidateArgs(args); err != nil {
+			t.Errorf("ValidateArgs(%v) error = %v", args, err)
+		}
+	}
+	for _, args := range [][]string{{}, {"tcsh"}, {"bash", "zsh"}} {
+		if err := completionCmd.ValidateArgs(args); err == nil {
+			t.Errorf("ValidateArgs(%v) should fail", args)
+		}
+	}
+
+	// The explicit command replaces cobra's default one
+	count := 0
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == "completion" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("rootCmd has %d completion commands, want 1", count)
+	}
+}
+
+func TestTicketsFromNotes(t *testing.T) {
+	notesDir := t.TempDir()
+	files := []string{
+		"proj/proj-123.md",
+		"proj/proj-456.md",
+		"proj/readme.md", // not a ticket
+		"proj/ops-1.md",  // wrong type directory
+		"ops/ops-7.md",
+		"ops/ops-8.txt",         // not a note
+		"daily/2025-01-01.md",   // daily notes are skipped
+		"templates/proj-999.md", // templates are skipped
+		"top-1.md",              // not in a type directory
+	}
+	for _, f := range files {
+		path := filepath.Join(notesDir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := &config.Config{}
+	cfg.Notes.Path = notesDir
+	cfg.Notes.DailyDir = "daily"
+	cfg.Notes.TemplateDir = "templates"
+
+	got := ticketsFromNotes(cfg)
+	slices.Sort(got)
+	want := []string{"ops-7", "proj-123", "proj-456"}
+	if !slices.Equal(got, want) {
+		t.Errorf("ticketsFromNotes() = %v, want %v", got, want)
+	}
+
+	cfg.Notes.Path = filepath.Join(notesDir, "missing")
+	if got := ticketsFromNotes(cfg); got != nil {
+		t.Errorf("ticketsFromNotes() with missing dir = %v, want nil", got)
+	}
+}
+
+func TestTicketsFromNotes_Subdirs(t *testing.T) {
+	notesDir := t.TempDir()
+	files := []string{
+		"Incidents/inc-1.md",
+		"Tickets/proj-123.md",
+		"Tickets/ops-7.md",
+		"Tickets/readme.md", // not a ticket
+		"proj/proj-999.md",  // unmapped directory
+		"daily/2025-01-01.md",
+	}
+	for _, f := range files {
+		path := filepath.Join(notesDir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := &config.Config{}
+	cfg.Notes.Path = notesDir
+	cfg.Notes.DailyDir = "daily"
+	cfg.Notes.Subdirs = map[string]string{"inc": "Incidents"}
+
+	got := ticketsFromNotes(cfg)
+	slices.Sort(got)
+	want := []string{"inc-1", "ops-7", "proj-123"}
+	if !slices.Equal(got, want) {
+		t.Errorf("ticketsFromNotes() = %v, want %v", got, want)
+	}
+}
+
+func TestFilterCompletions(t *testing.T) {
+	candidates := []string{"proj-456", "proj-123", "ops-7", "proj-123"}
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"", []string{"ops-7", "proj-123", "proj-456"}},
+		{"proj-1", []string{"proj-123"}},
+		{"PROJ", []string{"proj-123", "proj-456"}},
+		{"x", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			if got := filterCompletions(candidates, tt.prefix); !slices.Equal(got, tt.want) {
+				t.Errorf("filterCompletions(%q) = %v, want %v", tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompleteTickets(t *testing.T) {
+	viper.Reset()
+	resetConfig()
+	defer viper.Reset()
+
+	notesDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(notesDir, "proj"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(notesDir, "proj", "proj-42.md"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("notes.path", notesDir)
+
+	got, directive := completeTickets(sessionAttachCmd, nil, "proj")
+	if !slices.Contains(got, "proj-42") {
+		t.Errorf("completeTickets() = %v, want it to contain proj-42", got)
+	}
+	if directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("directive = %v, want NoFileComp", directive)
+	}
+
+	if got, _ := completeTickets(sessionRenameCmd, []string{"proj-42"}, ""); got != nil {
+		t.Errorf("completeTickets() after first argument = %v, want nil", got)
+	}
+
+	for _, c := range []*cobra.Command{sessionAttachCmd, sessionKillCmd, sessionRenameCmd, sessionSendCmd, syncCmd} {
+		if c.ValidArgsFunction == nil {
+			t.Errorf("%s should complete ticket arguments", c.CommandPath())
+		}
+	}
+}
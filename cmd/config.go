@@ -3,11 +3,12 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 
 	"github.com/cockroachdb/errors"
 	"github.com/spf13/cobra"
+
+	"thoreinstein.com/rig/pkg/config"
 )
 
 // configCmd represents the config command
@@ -17,7 +18,9 @@ var configCmd = &cobra.Command{
 	Long: `Display and manage the Rig configuration.
 
 This command shows the current configuration values and can help with
-initial setup by creating a default configuration file.`,
+initial setup by creating a default configuration file. Use
+'rig config show' to print the effective merged configuration and
+'rig config validate' to check configuration files for problems.`,
 	RunE: runConfigCommand,
 }
 
@@ -33,17 +36,46 @@ Uses $EDITOR environment variable, falls back to $VISUAL, then common editors (v
 	},
 }
 
+// configInitCmd represents the config init subcommand
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a commented starter configuration file",
+	Long: `Write a starter configuration file documenting every recognized section
+(notes, git, history, jira, github, ai, tmux) with its default values.
+
+By default the file is written to $XDG_CONFIG_HOME/rig/config.toml
+(~/.config/rig/config.toml when XDG_CONFIG_HOME is unset). Use --path to
+choose another location, or --repo to write a .rig.toml of per-repository
+overrides at the root of the current git repository.
+
+An existing file is never overwritten unless --force is passed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigInit(configInitPath, configInitRepo, configInitForce)
+	},
+}
+
 var (
 	configInit bool
 	configShow bool
+
+	configInitPath  string
+	configInitRepo  bool
+	configInitForce bool
 )
 
 func init() {
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configInitCmd)
 
 	configCmd.Flags().BoolVar(&configInit, "init", false, "create default configuration file")
 	configCmd.Flags().BoolVar(&configShow, "show", false, "show current configuration")
+
+	configInitCmd.Flags().StringVar(&configInitPath, "path", "", "write the configuration to this file")
+	configInitCmd.Flags().BoolVar(&configInitRepo, "repo", false, "write a .rig.toml at the git root instead")
+	configInitCmd.Flags().BoolVar(&configInitForce, "force", false, "overwrite an existing file")
+	configInitCmd.MarkFlagsMutuallyExclusive("path", "repo")
 }
 
 func runConfigCommand(cmd *cobra.Command, args []string) error {
@@ -58,49 +90,77 @@ func runConfigCommand(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func createDefaultConfig() error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return errors.Wrap(err, "failed to get home directory")
-	}
-
-	configDir := filepath.Join(homeDir, ".config", "rig")
-	configFile := filepath.Join(configDir, "config.toml")
+// starterConfig is the commented user configuration written by 'rig config init'
+const starterConfig = `# Rig Configuration
+#
+# Values shown are the defaults. Settings can be overridden per repository
+# with a .rig.toml at the git root, and by RIG_* environment variables
+# (e.g. RIG_NOTES_PATH). Run 'rig config validate' after editing.
 
-	// Check if config file already exists
-	if _, err := os.Stat(configFile); err == nil {
-		fmt.Printf("Configuration file already exists at: %s\n", configFile)
-		return nil
-	}
-
-	// Create config directory
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return errors.Wrap(err, "failed to create config directory")
-	}
-
-	// Default configuration content
-	defaultConfig := `# Rig Configuration
+# Optional file holding credentials (jira.token, github.token, ai.api_key),
+# kept out of this file so it can be synced with your dotfiles.
+# secrets_file = "~/.config/rig/secrets.toml"
 
 [notes]
+# Base directory of your markdown notes vault
 path = "~/Documents/Notes"
+# Subdirectory for daily notes
 daily_dir = "daily"
+# Subdirectory for weekly notes ('rig sync --weekly')
+weekly_dir = "weekly"
+# Directory containing custom note templates
 template_dir = "~/.config/rig/templates"
+# Start new ticket notes with YAML front matter (ticket, type, status, created)
+# frontmatter = false
+# Directory under path that 'rig done' moves finished ticket notes into
+# archive_dir = "archive"
+# Editor for --edit (defaults to $EDITOR); open the note after every 'rig work'
+# editor = "nvim"
+# open_after_create = false
 
 [git]
-# Optional: override auto-detected default branch
+# Override the auto-detected default branch
 # base_branch = "main"
+# Branch name for 'rig work'; tokens: {ticket}, {type}, {slug}, {user}
+# branch_template = "feature/{ticket}-{slug}"
 
 [history]
+# Shell history source: zsh-histdb/atuin SQLite database, fish_history, or .bash_history
 database_path = "~/.histdb/zsh-history.db"
+# Commands excluded from history queries
 ignore_patterns = ["ls", "cd", "pwd", "clear"]
 
 [jira]
+# Set to true and configure below to fetch ticket details
+enabled = false
+# "api" talks to Jira directly; "acli" shells out to the Atlassian CLI
+mode = "api"
+# base_url = "https://your-domain.atlassian.net"
+# email = "you@example.com"
+# Prefer the JIRA_TOKEN environment variable over storing the token here
+# token = ""
+# cli_command = "acli"
+
+[github]
+# Merge method used by 'rig pr merge': "merge", "squash", or "rebase"
+default_merge_method = "squash"
+delete_branch_on_merge = true
+# default_reviewers = ["teammate"]
+
+[ai]
 enabled = true
-cli_command = "acli"
+# One of "anthropic", "groq", "ollama", "gemini"
+provider = "anthropic"
+# Leave empty to use the provider's default model
+model = ""
+# API keys are read from ANTHROPIC_API_KEY, GROQ_API_KEY, or GOOGLE_GENAI_API_KEY
 
 [tmux]
+# Prefix added to session names
 session_prefix = ""
 
+# Windows created for each work session. {note_path} and {worktree_path}
+# are replaced with the ticket note and worktree paths.
 [[tmux.windows]]
 name = "note"
 command = "nvim {note_path}"
@@ -115,10 +175,52 @@ name = "term"
 working_dir = "{worktree_path}"
 `
 
-	// Write the default configuration with restricted permissions (owner read/write only)
-	// Config files may contain sensitive paths and can execute arbitrary commands via tmux
-	if err := os.WriteFile(configFile, []byte(defaultConfig), 0600); err != nil {
-		return errors.Wrap(err, "failed to write config file")
+// repoStarterConfig is the commented repository configuration written by
+// 'rig config init --repo'
+const repoStarterConfig = `# Rig repository configuration
+#
+# Values here override your user config (~/.config/rig/config.toml) while
+# working in this repository. Uncomment only what this repository needs.
+
+# [git]
+# base_branch = "main"
+# branch_template = "feature/{ticket}-{slug}"
+
+# [github]
+# default_merge_method = "squash"
+# default_reviewers = ["teammate"]
+
+# [jira]
+# enabled = true
+# base_url = "https://your-domain.atlassian.net"
+
+# [ai]
+# provider = "anthropic"
+`
+
+// userConfigPath returns the default user config file location
+func userConfigPath() (string, error) {
+	dir, err := config.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.toml"), nil
+}
+
+func createDefaultConfig() error {
+	configFile, err := userConfigPath()
+	if err != nil {
+		return err
+	}
+
+	// Check if config file already exists
+	if _, err := os.Stat(configFile); err == nil {
+		fmt.Printf("Configuration file already exists at: %s\n", configFile)
+		return nil
+	}
+
+	if err := writeStarterConfig(configFile, starterConfig, false); err != nil {
+		return err
 	}
 
 	fmt.Printf("Default configuration created at: %s\n", configFile)
@@ -127,6 +229,58 @@ working_dir = "{worktree_path}"
 	return nil
 }
 
+func runConfigInit(path string, repo, force bool) error {
+	content := starterConfig
+
+	switch {
+	case repo:
+		gitRoot, err := findGitRoot()
+		if err != nil {
+			return errors.Wrap(err, "failed to find git root")
+		}
+		if gitRoot == "" {
+			return errors.New("not in a git repository: --repo writes .rig.toml at the git root")
+		}
+		path = filepath.Join(gitRoot, ".rig.toml")
+		content = repoStarterConfig
+	case path == "":
+		var err error
+		path, err = userConfigPath()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := writeStarterConfig(path, content, force); err != nil {
+		return err
+	}
+
+	fmt.Printf("Configuration written to: %s\n", path)
+	fmt.Println("Edit this file to customize your Rig settings, then run 'rig config validate'.")
+
+	return nil
+}
+
+// writeStarterConfig writes content to path, creating parent directories.
+// An existing file is only replaced when force is set.
+func writeStarterConfig(path, content string, force bool) error {
+	if _, err := os.Stat(path); err == nil && !force {
+		return errors.Newf("configuration file already exists at %s (use --force to overwrite)", path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "failed to create config directory")
+	}
+
+	// Write with restricted permissions (owner read/write only)
+	// Config files may contain sensitive paths and can execute arbitrary commands via tmux
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		return errors.Wrap(err, "failed to write config file")
+	}
+
+	return nil
+}
+
 func showConfig() error {
 	cfg, err := loadConfig()
 	if err != nil {
@@ -166,13 +320,11 @@ func showConfig() error {
 }
 
 func editConfig() error {
-	homeDir, err := os.UserHomeDir()
+	configFile, err := userConfigPath()
 	if err != nil {
-		return errors.Wrap(err, "failed to get home directory")
+		return err
 	}
 
-	configFile := filepath.Join(homeDir, ".config", "rig", "config.toml")
-
 	// Create default config if it doesn't exist
 	if _, err := os.Stat(configFile); os.IsNotExist(err) {
 		fmt.Println("Config file does not exist, creating default...")
@@ -181,29 +333,5 @@ func editConfig() error {
 		}
 	}
 
-	// Get editor from environment, with fallbacks
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = os.Getenv("VISUAL")
-	}
-	if editor == "" {
-		// Check for common editors
-		for _, e := range []string{"vim", "vi", "nano"} {
-			if _, err := exec.LookPath(e); err == nil {
-				editor = e
-				break
-			}
-		}
-	}
-	if editor == "" {
-		return errors.New("no editor found: set $EDITOR environment variable")
-	}
-
-	// Execute editor
-	cmd := exec.Command(editor, configFile)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	return cmd.Run()
+	return openInEditor(configFile)
 }
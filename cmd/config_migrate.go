@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"thoreinstein.com/rig/pkg/config"
+)
+
+// configMigrateCmd represents the config migrate subcommand
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Rewrite deprecated configuration keys to their current names",
+	Long: `Rewrite deprecated keys (for example vault.path -> notes.path) in the user
+config and any repository .rig.toml files. YAML and JSON config files are
+skipped; rename their keys by hand.
+
+A diff of each change is printed and the original file is backed up next to
+it with a timestamped .bak suffix. Comments are not preserved in rewritten
+files; they remain in the backup. Use --dry-run to preview without writing.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigMigrate(cmd.OutOrStdout(), configFilesInOrder(), configMigrateDryRun, time.Now())
+	},
+}
+
+var configMigrateDryRun bool
+
+func init() {
+	configCmd.AddCommand(configMigrateCmd)
+
+	configMigrateCmd.Flags().BoolVar(&configMigrateDryRun, "dry-run", false, "print changes without writing files")
+}
+
+func runConfigMigrate(out io.Writer, files []string, dryRun bool, now time.Time) error {
+	migrated := 0
+
+	for _, path := range files {
+		if config.FileFormat(path) != "toml" {
+			fmt.Fprintf(out, "Skipping %s: only TOML files can be migrated\n", path)
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", path)
+		}
+
+		updated, renames, err := config.MigrateTOML(data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to migrate %s", path)
+		}
+		if len(renames) == 0 {
+			continue
+		}
+		migrated++
+
+		fmt.Fprintf(out, "%s:\n", path)
+		for _, r := range renames {
+			if r.Conflict {
+				fmt.Fprintf(out, "  %s -> %s (already set; keeping %s)\n", r.Old, r.New, r.New)
+			} else {
+				fmt.Fprintf(out, "  %s -> %s\n", r.Old, r.New)
+			}
+		}
+		fmt.Fprintf(out, "--- %s\n+++ %s (migrated)\n", path, path)
+		writeLineDiff(out, string(data), string(updated))
+
+		if dryRun {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to stat %s", path)
+		}
+
+		backup := path + ".bak-" + now.Format("20060102150405")
+		if err := os.WriteFile(backup, data, info.Mode().Perm()); err != nil {
+			return errors.Wrapf(err, "failed to back up %s", path)
+		}
+		if err := os.WriteFile(path, updated, info.Mode().Perm()); err != nil {
+			return errors.Wrapf(err, "failed to write %s", path)
+		}
+		fmt.Fprintf(out, "Backed up original to %s\n\n", backup)
+	}
+
+	switch {
+	case migrated == 0:
+		fmt.Fprintln(out, "No deprecated configuration keys found.")
+	case dryRun:
+		fmt.Fprintf(out, "Dry run: %d file(s) would be migrated.\n", migrated)
+	default:
+		fmt.Fprintf(out, "Migrated %d file(s).\n", migrated)
+	}
+
+	return nil
+}
+
+// writeLineDiff writes the lines removed from and added to a document,
+// prefixed with "-" and "+", keeping unchanged lines as context
+func writeLineDiff(out io.Writer, before, after string) {
+	a := strings.Split(strings.TrimRight(before, "\n"), "\n")
+	b := strings.Split(strings.TrimRight(after, "\n"), "\n")
+
+	// Longest common subsequence table
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			fmt.Fprintf(out, " %s\n", a[i])
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			fmt.Fprintf(out, "+%s\n", b[j])
+			j++
+		default:
+			fmt.Fprintf(out, "-%s\n", a[i])
+			i++
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"thoreinstein.com/rig/pkg/config"
+)
+
+// redactedValue replaces secret values in displayed configuration
+const redactedValue = "********"
+
+// configShowCmd represents the config show subcommand
+var configShowCmd = &cobra.Command{
+	Use:     "show",
+	Aliases: []string{"dump"},
+	Short:   "Print the effective merged configuration as TOML",
+	Long: `Print the fully merged configuration as TOML.
+
+Values are resolved with the same precedence as every other command:
+environment variables > repository .rig.toml or .rig.yaml > user config >
+defaults.
+
+Use --origin to annotate each key with where its value came from.
+Secrets such as jira.token are redacted unless --show-secrets is passed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigShow(cmd.OutOrStdout(), configSources(), configShowOrigin, configShowSecrets)
+	},
+}
+
+var (
+	configShowOrigin  bool
+	configShowSecrets bool
+)
+
+func init() {
+	configCmd.AddCommand(configShowCmd)
+
+	configShowCmd.Flags().BoolVar(&configShowOrigin, "origin", false, "annotate each key with its source (default/user/repo/env)")
+	configShowCmd.Flags().BoolVar(&configShowSecrets, "show-secrets", false, "print secret values instead of redacting them")
+}
+
+// configSource is a config file that contributes to the merged configuration
+type configSource struct {
+	Kind string // "user" or "repo"
+	Path string
+	keys map[string]bool
+}
+
+// configSources returns the existing config files in merge order, lowest
+// precedence first
+func configSources() []configSource {
+	var sources []configSource
+	if userConfig := userConfigFile(); userConfig != "" {
+		sources = append(sources, configSource{Kind: "user", Path: userConfig})
+	}
+	for _, p := range repoLocalConfigPaths() {
+		sources = append(sources, configSource{Kind: "repo", Path: p})
+	}
+
+	var existing []configSource
+	for _, s := range sources {
+		if _, err := os.Stat(s.Path); err == nil {
+			existing = append(existing, s)
+		}
+	}
+	return existing
+}
+
+// runConfigShow renders the merged viper configuration as TOML
+func runConfigShow(out io.Writer, sources []configSource, withOrigin, showSecrets bool) error {
+	// Loading applies defaults to viper so they appear in the output
+	if _, err := config.LoadUnvalidated(); err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	if withOrigin {
+		for i := range sources {
+			keys, err := readConfigKeys(sources[i].Path)
+			if err != nil {
+				return err
+			}
+			sources[i].keys = keys
+		}
+	}
+
+	// Group leaf keys by their enclosing table
+	tables := make(map[string][]string)
+	for _, key := range viper.AllKeys() {
+		table := ""
+		if i := strings.LastIndex(key, "."); i >= 0 {
+			table = key[:i]
+		}
+		tables[table] = append(tables[table], key)
+	}
+
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	first := true
+	for _, name := range names {
+		keys := tables[name]
+		sort.Strings(keys)
+
+		var arrays []string
+		if name != "" {
+			if !first {
+				fmt.Fprintln(out)
+			}
+			fmt.Fprintf(out, "[%s]\n", name)
+		}
+		first = false
+
+		for _, key := range keys {
+			value := viper.Get(key)
+			if tableArray(value) != nil {
+				arrays = append(arrays, key)
+				continue
+			}
+			line, err := renderConfigLine(key, value, showSecrets)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(out, line)
+			if withOrigin {
+				fmt.Fprintf(out, "  # %s", configKeyOrigin(key, sources))
+			}
+			fmt.Fprintln(out)
+		}
+
+		for _, key := range arrays {
+			for _, entry := range tableArray(viper.Get(key)) {
+				fmt.Fprintf(out, "\n[[%s]]", key)
+				if withOrigin {
+					fmt.Fprintf(out, "  # %s", configKeyOrigin(key, sources))
+				}
+				fmt.Fprintln(out)
+
+				fields := make([]string, 0, len(entry))
+				for field := range entry {
+					fields = append(fields, field)
+				}
+				sort.Strings(fields)
+				for _, field := range fields {
+					line, err := renderConfigLine(key+"."+field, entry[field], showSecrets)
+					if err != nil {
+						return err
+					}
+					fmt.Fprintln(out, line)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// renderConfigLine renders a single "name = value" TOML line for key
+func renderConfigLine(key string, value interface{}, showSecrets bool) (string, error) {
+	if d, ok := value.(time.Duration); ok {
+		value = d.String()
+	}
+	if !showSecrets && config.IsSecretKey(key) {
+		if s, ok := value.(string); ok && s != "" {
+			value = redactedValue
+		}
+	}
+
+	var buf strings.Builder
+	enc := toml.NewEncoder(&buf).SetTablesInline(true)
+	name := key[strings.LastIndex(key, ".")+1:]
+	if err := enc.Encode(map[string]interface{}{name: value}); err != nil {
+		return "", errors.Wrapf(err, "failed to render %s", key)
+	}
+	return strings.TrimRight(buf.String(), "\n"), nil
+}
+
+// tableArray converts a slice of structs or maps into a TOML array of tables.
+// It returns nil for any other value.
+func tableArray(value interface{}) []map[string]interface{} {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice || v.Len() == 0 {
+		return nil
+	}
+
+	var entries []map[string]interface{}
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		for elem.Kind() == reflect.Interface || elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+
+		entry := make(map[string]interface{})
+		switch elem.Kind() {
+		case reflect.Map:
+			for _, k := range elem.MapKeys() {
+				entry[fmt.Sprint(k.Interface())] = elem.MapIndex(k).Interface()
+			}
+		case reflect.Struct:
+			t := elem.Type()
+			for j := 0; j < t.NumField(); j++ {
+				name := t.Field(j).Tag.Get("mapstructure")
+				if name == "" || elem.Field(j).IsZero() {
+					continue
+				}
+				entry[name] = elem.Field(j).Interface()
+			}
+		default:
+			return nil
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// configKeyOrigin describes where the effective value of key comes from
+func configKeyOrigin(key string, sources []configSource) string {
+	envVar := "RIG_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+	if _, ok := os.LookupEnv(envVar); ok {
+		return "env (" + envVar + ")"
+	}
+
+	for i := len(sources) - 1; i >= 0; i-- {
+		if sources[i].keys[key] {
+			return sources[i].Kind + " (" + sources[i].Path + ")"
+		}
+	}
+
+	return "default"
+}
+
+// readConfigKeys returns the set of dotted keys defined in a TOML file,
+// lowercased to match viper's key normalization
+func readConfigKeys(path string) (map[string]bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+
+	var settings map[string]interface{}
+	if err := toml.Unmarshal(data, &settings); err != nil {
+		// Malformed files are skipped during loading, so they contribute nothing
+		return map[string]bool{}, nil
+	}
+
+	keys := make(map[string]bool)
+	var walk func(prefix string, m map[string]interface{})
+	walk = func(prefix string, m map[string]interface{}) {
+		for k, v := range m {
+			key := strings.ToLower(k)
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			keys[key] = true
+			if nested, ok := v.(map[string]interface{}); ok {
+				walk(key, nested)
+			}
+		}
+	}
+	walk("", settings)
+
+	return keys, nil
+}
@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"thoreinstein.com/rig/pkg/config"
 )
 
 func TestConfigCommandFlags(t *testing.T) {
@@ -283,3 +289,367 @@ working_dir = "{worktree_path}"
 		}
 	}
 }
+
+func TestRunConfigValidate(t *testing.T) {
+	t.Setenv("JIRA_TOKEN", "")
+
+	tests := []struct {
+		name       string
+		content    string
+		wantErr    bool
+		wantOutput []string
+	}{
+		{
+			name: "valid config",
+			content: `[jira]
+enabled = false
+
+[ai]
+provider = "groq"
+`,
+			wantOutput: []string{"Configuration is valid"},
+		},
+		{
+			name: "invalid enum and missing jira fields",
+			content: `[github]
+default_merge_method = "fast-forward"
+
+[jira]
+enabled = true
+mode = "api"
+email = "me@example.com"
+`,
+			wantErr: true,
+			wantOutput: []string{
+				"config.toml:2: github.default_merge_method:",
+				"config.toml:4: jira.base_url: required",
+				"config.toml:4: jira.token: required",
+			},
+		},
+		{
+			name:       "malformed TOML",
+			content:    "[jira]\nenabled = \n",
+			wantErr:    true,
+			wantOutput: []string{"config.toml:2: malformed TOML"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.toml")
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			viper.Reset()
+			resetConfig()
+			viper.SetConfigFile(path)
+			_ = viper.ReadInConfig()
+			defer viper.Reset()
+
+			var out bytes.Buffer
+			err := runConfigValidate(&out, []string{path})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runConfigValidate() error = %v, wantErr %v\noutput:\n%s", err, tt.wantErr, out.String())
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestLocateConfigKey(t *testing.T) {
+	files := []string{"user.toml", "repo.toml"}
+	keyLines := []map[string]int{
+		{"jira": 1, "jira.enabled": 2, "tmux.windows": 8},
+		{"jira": 3, "jira.email": 4},
+	}
+
+	tests := []struct {
+		key      string
+		wantFile string
+		wantLine int
+	}{
+		{"jira.email", "repo.toml", 4},
+		{"jira.enabled", "user.toml", 2},
+		{"jira.base_url", "repo.toml", 3},
+		{"tmux.windows[2]", "user.toml", 8},
+		{"ai.provider", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			file, line := locateConfigKey(tt.key, files, keyLines)
+			if file != tt.wantFile || line != tt.wantLine {
+				t.Errorf("locateConfigKey(%q) = %s:%d, want %s:%d", tt.key, file, line, tt.wantFile, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestRunConfigShow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := `[jira]
+token = "super-secret"
+
+[github]
+default_merge_method = "rebase"
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		origin      bool
+		showSecrets bool
+		want        []string
+		notWant     []string
+	}{
+		{
+			name:    "redacts secrets",
+			want:    []string{"[jira]", "token = '********'", "default_merge_method = 'rebase'", "[[tmux.windows]]"},
+			notWant: []string{"super-secret", "# default"},
+		},
+		{
+			name:        "show secrets",
+			showSecrets: true,
+			want:        []string{"token = 'super-secret'"},
+		},
+		{
+			name:   "origin annotations",
+			origin: true,
+			want: []string{
+				"default_merge_method = 'rebase'  # user (" + path + ")",
+				"provider = 'groq'  # env (RIG_AI_PROVIDER)",
+				"daily_dir = 'daily'  # default",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("RIG_AI_PROVIDER", "groq")
+
+			viper.Reset()
+			resetConfig()
+			viper.SetConfigFile(path)
+			viper.SetEnvPrefix("RIG")
+			viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+			viper.AutomaticEnv()
+			if err := viper.ReadInConfig(); err != nil {
+				t.Fatal(err)
+			}
+			defer viper.Reset()
+
+			var out bytes.Buffer
+			sources := []configSource{{Kind: "user", Path: path}}
+			if err := runConfigShow(&out, sources, tt.origin, tt.showSecrets); err != nil {
+				t.Fatalf("runConfigShow() error = %v", err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(out.String(), notWant) {
+					t.Errorf("output should not contain %q:\n%s", notWant, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRunConfigInit(t *testing.T) {
+	tests := []struct {
+		name        string
+		usePath     bool
+		repo        bool
+		existing    bool
+		force       bool
+		wantErr     bool
+		wantContent string
+	}{
+		{name: "default user path", wantContent: "[notes]"},
+		{name: "custom path", usePath: true, wantContent: "[ai]"},
+		{name: "refuses to overwrite", usePath: true, existing: true, wantErr: true, wantContent: "existing"},
+		{name: "force overwrites", usePath: true, existing: true, force: true, wantContent: "[tmux]"},
+		{name: "repo config at git root", repo: true, wantContent: "Rig repository configuration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			t.Setenv("HOME", tmpDir)
+
+			repoDir := filepath.Join(tmpDir, "repo")
+			if err := os.MkdirAll(filepath.Join(repoDir, ".git"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			subDir := filepath.Join(repoDir, "sub")
+			if err := os.MkdirAll(subDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			t.Chdir(subDir)
+
+			var flagPath, target string
+			switch {
+			case tt.repo:
+				target = filepath.Join(repoDir, ".rig.toml")
+			case tt.usePath:
+				flagPath = filepath.Join(tmpDir, "custom", "rig.toml")
+				target = flagPath
+			default:
+				target = filepath.Join(tmpDir, ".config", "rig", "config.toml")
+			}
+
+			if tt.existing {
+				if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(target, []byte("# existing\n"), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			captureOutput(func() {
+				err := runConfigInit(flagPath, tt.repo, tt.force)
+				if (err != nil) != tt.wantErr {
+					t.Errorf("runConfigInit() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			})
+
+			content, err := os.ReadFile(target)
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", target, err)
+			}
+			if !strings.Contains(string(content), tt.wantContent) {
+				t.Errorf("content missing %q:\n%s", tt.wantContent, content)
+			}
+		})
+	}
+}
+
+func TestStarterConfig_IsValid(t *testing.T) {
+	t.Setenv("JIRA_TOKEN", "")
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(starterConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	resetConfig()
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("starter config does not parse: %v", err)
+	}
+	defer viper.Reset()
+
+	var out bytes.Buffer
+	if err := runConfigValidate(&out, []string{path}); err != nil {
+		t.Errorf("starter config has problems: %v\n%s", err, out.String())
+	}
+
+	// The repository starter is entirely commented out but must still parse
+	repoPath := filepath.Join(t.TempDir(), ".rig.toml")
+	if err := os.WriteFile(repoPath, []byte(repoStarterConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if problem, err := config.CheckFile(repoPath); err != nil || problem != nil {
+		t.Errorf("repo starter config does not parse: %v %v", problem, err)
+	}
+}
+
+func TestRunConfigMigrate(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	original := "[vault]\npath = \"/vault\"\n"
+
+	tests := []struct {
+		name       string
+		file       string // Defaults to config.toml
+		content    string
+		dryRun     bool
+		wantOutput []string
+		wantBackup bool
+	}{
+		{
+			name:       "migrates and backs up",
+			content:    original,
+			wantOutput: []string{"vault.path -> notes.path", "-[vault]", "+[notes]", "Migrated 1 file(s)"},
+			wantBackup: true,
+		},
+		{
+			name:       "dry run leaves file untouched",
+			content:    original,
+			dryRun:     true,
+			wantOutput: []string{"vault.path -> notes.path", "Dry run: 1 file(s) would be migrated"},
+		},
+		{
+			name:       "nothing to migrate",
+			content:    "[notes]\npath = \"/notes\"\n",
+			wantOutput: []string{"No deprecated configuration keys found"},
+		},
+		{
+			name:       "skips yaml",
+			file:       "config.yaml",
+			content:    "vault:\n  path: /vault\n",
+			wantOutput: []string{"only TOML files can be migrated", "No deprecated configuration keys found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := tt.file
+			if file == "" {
+				file = "config.toml"
+			}
+			path := filepath.Join(t.TempDir(), file)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			if err := runConfigMigrate(&out, []string{path}, tt.dryRun, now); err != nil {
+				t.Fatalf("runConfigMigrate() error = %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			backup := path + ".bak-20260102030405"
+			_, statErr := os.Stat(backup)
+
+			if tt.wantBackup {
+				if statErr != nil {
+					t.Fatalf("backup not written: %v", statErr)
+				}
+				saved, _ := os.ReadFile(backup)
+				if string(saved) != tt.content {
+					t.Errorf("backup = %q, want original %q", saved, tt.content)
+				}
+				if strings.Contains(string(content), "[vault]") {
+					t.Errorf("config still contains deprecated key:\n%s", content)
+				}
+			} else {
+				if statErr == nil {
+					t.Error("backup should not be written")
+				}
+				if string(content) != tt.content {
+					t.Errorf("config changed to %q, want unchanged", content)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"thoreinstein.com/rig/pkg/config"
+)
+
+// configValidateCmd represents the config validate subcommand
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check configuration files for problems",
+	Long: `Validate the merged Rig configuration.
+
+Loads the user config and any repository .rig.toml or .rig.yaml files using
+the same precedence as every other command, then reports:
+  - malformed TOML, YAML or JSON (with the offending line for TOML)
+  - required fields missing for enabled features (e.g. jira.base_url)
+  - unsupported values (e.g. github.default_merge_method, ai.provider)
+
+Each problem is printed as file:line when it can be traced to a file.
+Exits non-zero when any problem is found.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigValidate(cmd.OutOrStdout(), configFilesInOrder())
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+}
+
+// userConfigFile returns the path of the user config file: the --config flag,
+// the file viper loaded, or the default location.
+func userConfigFile() string {
+	if cfgFile != "" {
+		return cfgFile
+	}
+	if used := viper.ConfigFileUsed(); used != "" {
+		return used
+	}
+	dir, err := config.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "config.toml")
+}
+
+// configFilesInOrder returns the existing config files in merge order, lowest
+// precedence first: the user config, then repository config files.
+func configFilesInOrder() []string {
+	var files []string
+	if userConfig := userConfigFile(); userConfig != "" {
+		files = append(files, userConfig)
+	}
+	files = append(files, repoLocalConfigPaths()...)
+
+	var existing []string
+	for _, f := range files {
+		if _, err := os.Stat(f); err == nil {
+			existing = append(existing, f)
+		}
+	}
+	return existing
+}
+
+// runConfigValidate checks each config file for syntax errors, then checks
+// the merged configuration and attributes each problem to the file that set
+// the key last.
+func runConfigValidate(out io.Writer, files []string) error {
+	var problems []config.Problem
+	keyLines := make([]map[string]int, len(files))
+
+	for i, f := range files {
+		problem, err := config.CheckFile(f)
+		if err != nil {
+			return err
+		}
+		if problem != nil {
+			problems = append(problems, *problem)
+			continue
+		}
+
+		// Line numbers are only traced for TOML files
+		if config.FileFormat(f) != "toml" {
+			continue
+		}
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", f)
+		}
+		keyLines[i] = config.KeyLines(data)
+	}
+
+	cfg, err := config.LoadUnvalidated()
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	for _, p := range cfg.Problems() {
+		p.File, p.Line = locateConfigKey(p.Key, files, keyLines)
+		problems = append(problems, p)
+	}
+
+	if len(problems) == 0 {
+		fmt.Fprintf(out, "Configuration is valid (%d file(s) checked)\n", len(files))
+		return nil
+	}
+
+	for _, p := range problems {
+		fmt.Fprintln(out, p.String())
+	}
+	return errors.Newf("configuration has %d problem(s)", len(problems))
+}
+
+// locateConfigKey finds the highest-precedence file that defines key. When no
+// file sets the key itself, the nearest enclosing table is used instead so
+// missing required fields still point at their section.
+func locateConfigKey(key string, files []string, keyLines []map[string]int) (string, int) {
+	if i := strings.Index(key, "["); i >= 0 {
+		key = key[:i]
+	}
+
+	for k := key; k != ""; {
+		for i := len(files) - 1; i >= 0; i-- {
+			if line, ok := keyLines[i][k]; ok {
+				return files[i], line
+			}
+		}
+
+		dot := strings.LastIndex(k, ".")
+		if dot < 0 {
+			break
+		}
+		k = k[:dot]
+	}
+
+	return "", 0
+}
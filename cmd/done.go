@@ -0,0 +1,372 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"thoreinstein.com/rig/pkg/config"
+	"thoreinstein.com/rig/pkg/events"
+	"thoreinstein.com/rig/pkg/git"
+	"thoreinstein.com/rig/pkg/github"
+	"thoreinstein.com/rig/pkg/hooks"
+	"thoreinstein.com/rig/pkg/jira"
+	"thoreinstein.com/rig/pkg/workflow"
+)
+
+var (
+	doneNoMerge     bool
+	doneKeepBranch  bool
+	doneNoJira      bool
+	doneNoCleanup   bool
+	doneKeepNote    bool
+	doneDryRun      bool
+	doneMergeMethod string
+)
+
+// doneCmd represents the done command
+var doneCmd = &cobra.Command{
+	Use:   "done <ticket>",
+	Short: "Merge, close and clean up a finished ticket",
+	Long: `Finish a ticket in one step.
+
+This command performs the following actions, in order:
+- Merges the ticket's pull request with github.default_merge_method
+  (use --no-merge to skip; already merged PRs are left as is)
+- Deletes the remote branch when github.delete_branch_on_merge is set
+  (use --keep-branch to skip)
+- Transitions the Jira ticket to Done (use --no-jira to skip)
+- Removes the worktree and its tmux session (use --no-cleanup to skip)
+- Moves the ticket note into notes.archive_dir (use --keep-note to skip)
+
+The pull request is the one whose head is the worktree's branch. If a step
+fails, rig stops and reports which steps completed.
+
+Examples:
+  rig done proj-123
+  rig done proj-123 --dry-run
+  rig done proj-123 --merge-method rebase
+  rig done proj-123 --no-merge --no-jira`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDoneCommand(cmd.OutOrStdout(), args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doneCmd)
+
+	doneCmd.Flags().BoolVar(&doneNoMerge, "no-merge", false, "Don't merge the pull request")
+	doneCmd.Flags().BoolVar(&doneKeepBranch, "keep-branch", false, "Don't delete the remote branch")
+	doneCmd.Flags().BoolVar(&doneNoJira, "no-jira", false, "Don't transition the Jira ticket")
+	doneCmd.Flags().BoolVar(&doneNoCleanup, "no-cleanup", false, "Keep the worktree and tmux session")
+	doneCmd.Flags().BoolVar(&doneKeepNote, "keep-note", false, "Don't move the ticket note to the archive")
+	doneCmd.Flags().BoolVar(&doneDryRun, "dry-run", false, "Print the steps that would run without changing anything")
+	doneCmd.Flags().StringVar(&doneMergeMethod, "merge-method", "", "Merge method: merge, squash, rebase")
+	doneCmd.Flags().StringVarP(&projectFlag, "project", "p", "", "Override project directory")
+}
+
+// doneTarget is what rig done operates on for a ticket
+type doneTarget struct {
+	Ticket       *TicketInfo
+	Branch       string
+	PR           *github.PRInfo // Nil when no PR was looked up
+	RepoPath     string
+	RepoName     string
+	WorktreePath string
+	HasWorktree  bool
+}
+
+// doneStep is one stage of rig done. Skipped holds why the step won't run
+// and is empty when it will.
+type doneStep struct {
+	Name    string
+	Skipped string
+	Run     func(ctx context.Context) error
+}
+
+func runDoneCommand(out io.Writer, ticket string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	ticketInfo, err := parseTicket(ticket)
+	if err != nil {
+		return err
+	}
+
+	repoPath, err := resolveProjectContext(cfg, projectFlag, ticketInfo.Project)
+	if err != nil {
+		return err
+	}
+	// Work from the repository so removing the worktree doesn't pull the
+	// directory out from under us
+	if err := os.Chdir(repoPath); err != nil {
+		return errors.Wrapf(err, "failed to chdir to %s", repoPath)
+	}
+
+	target, err := resolveDoneTarget(cfg, repoPath, ticketInfo)
+	if err != nil {
+		return err
+	}
+
+	mergeMethod, err := resolveMergeMethod(cfg, doneMergeMethod)
+	if err != nil {
+		return err
+	}
+
+	var ghClient github.Client
+	if !doneNoMerge || (cfg.GitHub.DeleteBranchOnMerge && !doneKeepBranch) {
+		ghClient, err = github.NewClient(&cfg.GitHub, verbose)
+		if err != nil {
+			return err
+		}
+	}
+	if !doneNoMerge {
+		ctx, cancel := networkContext(cfg.Network.Timeout)
+		target.PR, err = findPRForBranch(ctx, ghClient, target.Branch)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+
+	var jiraClient jira.JiraClient
+	if cfg.Jira.Enabled && !doneNoJira {
+		jiraClient, err = jira.NewJiraClientForTicket(&cfg.Jira, ticketInfo.ID, verbose)
+		if err != nil {
+			if verbose {
+				fmt.Fprintf(out, "Warning: Could not initialize JIRA client: %v\n", err)
+			}
+			jiraClient = nil
+		}
+	}
+
+	steps := planDoneSteps(cfg, target, mergeMethod, ghClient, jiraClient)
+	if err := runDoneSteps(out, target, steps, cfg.Network.Timeout, doneDryRun); err != nil || doneDryRun {
+		return err
+	}
+
+	// The worktree may be gone by now, so the hook falls back to the git root
+	return runHook(cfg, "post_done", cfg.Hooks.PostDone, hooks.Env{
+		Ticket:   ticketInfo.ID,
+		Worktree: target.WorktreePath,
+		GitRoot:  target.RepoPath,
+	})
+}
+
+// resolveDoneTarget locates the ticket's worktree and the branch checked out
+// in it. Without a worktree the branch is assumed to be named after the ticket.
+func resolveDoneTarget(cfg *config.Config, repoPath string, ticketInfo *TicketInfo) (*doneTarget, error) {
+	gitManager := git.NewWorktreeManagerAtPath(repoPath, cfg.Git.BaseBranch, verbose)
+	repoRoot, err := gitManager.GetRepoRoot()
+	if err != nil {
+		return nil, err
+	}
+	repoName, err := gitManager.GetRepoName()
+	if err != nil {
+		return nil, err
+	}
+	worktreePath, err := gitManager.GetWorktreePath(ticketInfo.Type, ticketInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &doneTarget{
+		Ticket:       ticketInfo,
+		Branch:       ticketInfo.ID,
+		RepoPath:     repoRoot,
+		RepoName:     repoName,
+		WorktreePath: worktreePath,
+		HasWorktree:  pathExists(worktreePath),
+	}
+	if info, ok := getWorktreeDetailsForClean(repoRoot)[worktreePath]; ok && info.Branch != "" {
+		target.Branch = info.Branch
+	}
+	return target, nil
+}
+
+// findPRForBranch returns the open or merged PR whose head is branch, with
+// full details such as its mergeable state
+func findPRForBranch(ctx context.Context, ghClient github.Client, branch string) (*github.PRInfo, error) {
+	prs, err := ghClient.ListPRs(ctx, github.ListPRsOptions{State: "all"})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pull requests")
+	}
+
+	for _, pr := range prs {
+		if pr.HeadBranch != branch || (!strings.EqualFold(pr.State, "open") && !pr.IsMerged()) {
+			continue
+		}
+		full, err := ghClient.GetPR(ctx, pr.Number)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get PR #%d", pr.Number)
+		}
+		return full, nil
+	}
+
+	return nil, errors.Newf("no open or merged PR found for branch %q (use --no-merge to skip merging)", branch)
+}
+
+// planDoneSteps builds the merge, branch deletion, Jira, cleanup and note
+// archive steps for target, recording why any of them will be skipped
+func planDoneSteps(cfg *config.Config, target *doneTarget, mergeMethod string, ghClient github.Client, jiraClient jira.JiraClient) []doneStep {
+	ticketInfo := target.Ticket
+
+	merge := doneStep{Name: "Merge pull request"}
+	switch {
+	case doneNoMerge:
+		merge.Skipped = "--no-merge"
+	case target.PR == nil:
+		merge.Skipped = "no pull request"
+	case target.PR.IsMerged():
+		merge.Name = fmt.Sprintf("Merge PR #%d", target.PR.Number)
+		merge.Skipped = "already merged"
+	default:
+		pr := target.PR
+		merge.Name = fmt.Sprintf("Merge PR #%d (%s)", pr.Number, mergeMethod)
+		merge.Run = func(ctx context.Context) error {
+			if !pr.IsMergeable() {
+				return errors.Newf("PR #%d is not mergeable (mergeable: %s, state: %s)", pr.Number, pr.Mergeable, pr.MergeableState)
+			}
+			return ghClient.MergePR(ctx, pr.Number, github.MergeOptions{Method: mergeMethod})
+		}
+	}
+
+	deleteBranch := doneStep{Name: fmt.Sprintf("Delete remote branch %s", target.Branch)}
+	switch {
+	case doneKeepBranch:
+		deleteBranch.Skipped = "--keep-branch"
+	case !cfg.GitHub.DeleteBranchOnMerge:
+		deleteBranch.Skipped = "github.delete_branch_on_merge is off"
+	case ghClient == nil:
+		deleteBranch.Skipped = "GitHub not configured"
+	default:
+		deleteBranch.Run = func(ctx context.Context) error {
+			err := ghClient.DeleteBranch(ctx, target.Branch)
+			// GitHub may already have deleted it if the repository auto-deletes head branches
+			if err != nil && !strings.Contains(err.Error(), "Reference does not exist") && !strings.Contains(err.Error(), "404") {
+				return err
+			}
+			return nil
+		}
+	}
+
+	transition := doneStep{Name: fmt.Sprintf("Transition %s to Done", ticketInfo.ID)}
+	switch {
+	case doneNoJira:
+		transition.Skipped = "--no-jira"
+	case jiraClient == nil:
+		transition.Skipped = "Jira not configured"
+	case ticketInfo.Type == "incident":
+		transition.Skipped = "incident ticket"
+	case workflow.NewTicketRouter(cfg, target.WorktreePath, verbose).RouteTicket(ticketInfo.ID) == workflow.TicketSourceBeads:
+		transition.Skipped = "beads ticket"
+	default:
+		transition.Run = func(ctx context.Context) error {
+			var err error
+			for _, status := range workflow.DoneStatuses {
+				if err = jira.TransitionTicketByNameContext(ctx, jiraClient, ticketInfo.ID, status); err == nil {
+					lifecycleEvents().Emit(events.JiraTransitioned, ticketInfo.ID, map[string]string{"status": status})
+					return nil
+				}
+			}
+			return errors.Wrapf(err, "could not transition to any of %s", strings.Join(workflow.DoneStatuses, ", "))
+		}
+	}
+
+	cleanup := doneStep{Name: fmt.Sprintf("Remove worktree %s", target.WorktreePath)}
+	switch {
+	case doneNoCleanup:
+		cleanup.Skipped = "--no-cleanup"
+	case !target.HasWorktree:
+		cleanup.Skipped = "no worktree"
+	default:
+		cleanup.Run = func(ctx context.Context) error {
+			err := removeWorktree(cfg, CleanupCandidate{
+				Path:       target.WorktreePath,
+				Branch:     target.Branch,
+				RepoName:   target.RepoName,
+				RepoPath:   target.RepoPath,
+				HasSession: true,
+			})
+			if err != nil {
+				return err
+			}
+			lifecycleEvents().Emit(events.WorktreeRemoved, ticketInfo.ID, map[string]string{
+				"path":   target.WorktreePath,
+				"branch": target.Branch,
+			})
+			return nil
+		}
+	}
+
+	noteManager := newNoteManager(cfg)
+	// An invalid ticket is left to ArchiveNote, which reports it when the step runs
+	notePath, noteErr := noteManager.GetNotePath(ticketInfo.Type, ticketInfo.ID)
+	archive := doneStep{Name: "Archive note"}
+	switch {
+	case doneKeepNote:
+		archive.Skipped = "--keep-note"
+	case noteErr == nil && !pathExists(notePath):
+		archive.Skipped = "no note"
+	default:
+		archive.Run = func(ctx context.Context) error {
+			path, err := noteManager.ArchiveNote(ticketInfo.ID, ticketInfo.Type)
+			if err != nil {
+				return err
+			}
+			lifecycleEvents().Emit(events.NoteUpdated, ticketInfo.ID, map[string]string{"path": path, "archived": "true"})
+			return nil
+		}
+	}
+
+	return []doneStep{merge, deleteBranch, transition, cleanup, archive}
+}
+
+// runDoneSteps runs steps in order, each bound to timeout, or only lists them
+// when dryRun is set. The first failure stops the sequence and the error names
+// the steps that had already completed.
+func runDoneSteps(out io.Writer, target *doneTarget, steps []doneStep, timeout time.Duration, dryRun bool) error {
+	if dryRun {
+		fmt.Fprintf(out, "Dry run for %s (no changes made)\n\n", target.Ticket.Full)
+	} else {
+		fmt.Fprintf(out, "Finishing %s...\n", target.Ticket.Full)
+	}
+
+	var completed []string
+	for _, step := range steps {
+		switch {
+		case step.Skipped != "":
+			fmt.Fprintf(out, "  - %s: skipped (%s)\n", step.Name, step.Skipped)
+		case dryRun:
+			fmt.Fprintf(out, "  - %s: would run\n", step.Name)
+		default:
+			ctx, cancel := networkContext(timeout)
+			err := step.Run(ctx)
+			cancel()
+			if err != nil {
+				fmt.Fprintf(out, "  %s %s\n", crossMark(), step.Name)
+				done := "none"
+				if len(completed) > 0 {
+					done = strings.Join(completed, ", ")
+				}
+				return errors.Wrapf(err, "%s failed (completed steps: %s)", step.Name, done)
+			}
+			fmt.Fprintf(out, "  %s %s\n", checkMark(), step.Name)
+			completed = append(completed, step.Name)
+		}
+	}
+
+	if !dryRun {
+		fmt.Fprintf(out, "\n%s is done\n", target.Ticket.Full)
+	}
+	return nil
+}
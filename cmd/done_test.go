@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"thoreinstein.com/rig/pkg/config"
+	"thoreinstein.com/rig/pkg/github"
+)
+
+func resetDoneFlags() {
+	doneNoMerge = false
+	doneKeepBranch = false
+	doneNoJira = false
+	doneNoCleanup = false
+	doneKeepNote = false
+	doneDryRun = false
+	doneMergeMethod = ""
+}
+
+func TestFindPRForBranch(t *testing.T) {
+	client := &mockGHClient{
+		listPRsFunc: func(ctx context.Context, opts github.ListPRsOptions) ([]github.PRInfo, error) {
+			if opts.State != "all" {
+				t.Errorf("ListPRs state = %q, want all", opts.State)
+			}
+			return []github.PRInfo{
+				{Number: 3, State: "closed", HeadBranch: "proj-123"},
+				{Number: 4, State: "MERGED", HeadBranch: "proj-123"},
+				{Number: 5, State: "open", HeadBranch: "proj-456"},
+			}, nil
+		},
+		getPRFunc: func(ctx context.Context, number int) (*github.PRInfo, error) {
+			return &github.PRInfo{Number: number, State: "MERGED", Mergeable: "UNKNOWN"}, nil
+		},
+	}
+
+	pr, err := findPRForBranch(context.Background(), client, "proj-123")
+	if err != nil {
+		t.Fatalf("findPRForBranch() error = %v", err)
+	}
+	if pr.Number != 4 {
+		t.Errorf("findPRForBranch() = #%d, want #4 (closed PRs are ignored)", pr.Number)
+	}
+
+	if _, err := findPRForBranch(context.Background(), client, "proj-789"); err == nil {
+		t.Error("findPRForBranch() expected error for a branch without a PR")
+	}
+}
+
+func TestPlanDoneSteps(t *testing.T) {
+	target := &doneTarget{
+		Ticket:       &TicketInfo{Full: "proj-123", ID: "proj-123", Type: "proj"},
+		Branch:       "feature/proj-123",
+		PR:           &github.PRInfo{Number: 42, State: "OPEN", Mergeable: "MERGEABLE"},
+		RepoPath:     "/src/repo",
+		WorktreePath: "/src/repo/proj/proj-123",
+		HasWorktree:  true,
+	}
+
+	tests := []struct {
+		name        string
+		setup       func(cfg *config.Config, tgt *doneTarget)
+		wantSkipped []string // Skip reason per step; empty when the step runs
+	}{
+		{
+			name:        "defaults",
+			wantSkipped: []string{"", "github.delete_branch_on_merge is off", "Jira not configured", "", "no note"},
+		},
+		{
+			name: "delete branch on merge",
+			setup: func(cfg *config.Config, tgt *doneTarget) {
+				cfg.GitHub.DeleteBranchOnMerge = true
+			},
+			wantSkipped: []string{"", "", "Jira not configured", "", "no note"},
+		},
+		{
+			name: "already merged without worktree",
+			setup: func(cfg *config.Config, tgt *doneTarget) {
+				tgt.PR = &github.PRInfo{Number: 42, State: "MERGED"}
+				tgt.HasWorktree = false
+			},
+			wantSkipped: []string{"already merged", "github.delete_branch_on_merge is off", "Jira not configured", "no worktree", "no note"},
+		},
+		{
+			name: "all skipped by flags",
+			setup: func(cfg *config.Config, tgt *doneTarget) {
+				cfg.GitHub.DeleteBranchOnMerge = true
+				doneNoMerge = true
+				doneKeepBranch = true
+				doneNoJira = true
+				doneNoCleanup = true
+				doneKeepNote = true
+			},
+			wantSkipped: []string{"--no-merge", "--keep-branch", "--no-jira", "--no-cleanup", "--keep-note"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetDoneFlags()
+			defer resetDoneFlags()
+
+			cfg := &config.Config{}
+			tgt := *target
+			if tt.setup != nil {
+				tt.setup(cfg, &tgt)
+			}
+
+			steps := planDoneSteps(cfg, &tgt, "squash", &mockGHClient{isAuthenticated: true}, nil)
+			if len(steps) != len(tt.wantSkipped) {
+				t.Fatalf("got %d steps, want %d", len(steps), len(tt.wantSkipped))
+			}
+			for i, step := range steps {
+				if step.Skipped != tt.wantSkipped[i] {
+					t.Errorf("step %q skipped = %q, want %q", step.Name, step.Skipped, tt.wantSkipped[i])
+				}
+				if step.Skipped == "" && step.Run == nil {
+					t.Errorf("step %q runs but has no Run func", step.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestPlanDoneSteps_MergeUsesMethod(t *testing.T) {
+	resetDoneFlags()
+
+	var gotMethod string
+	client := &mockGHClient{
+		mergePRFunc: func(ctx context.Context, number int, opts github.MergeOptions) error {
+			gotMethod = opts.Method
+			return nil
+		},
+	}
+	target := &doneTarget{
+		Ticket: &TicketInfo{Full: "proj-123", ID: "proj-123", Type: "proj"},
+		PR:     &github.PRInfo{Number: 42, State: "OPEN", Mergeable: "CONFLICTING"},
+	}
+
+	merge := planDoneSteps(&config.Config{}, target, "rebase", client, nil)[0]
+	if err := merge.Run(context.Background()); err == nil || !strings.Contains(err.Error(), "not mergeable") {
+		t.Errorf("merge of a conflicting PR error = %v, want not mergeable", err)
+	}
+
+	target.PR.Mergeable = "MERGEABLE"
+	merge = planDoneSteps(&config.Config{}, target, "rebase", client, nil)[0]
+	if err := merge.Run(context.Background()); err != nil {
+		t.Fatalf("merge error = %v", err)
+	}
+	if gotMethod != "rebase" {
+		t.Errorf("merge method = %q, want rebase", gotMethod)
+	}
+}
+
+func TestPlanDoneSteps_ArchivesNote(t *testing.T) {
+	resetDoneFlags()
+
+	notesDir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.Notes.Path = notesDir
+	cfg.Notes.ArchiveDir = "done"
+
+	notePath := filepath.Join(notesDir, "proj", "proj-123.md")
+	if err := os.MkdirAll(filepath.Dir(notePath), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(notePath, []byte("# proj-123\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	target := &doneTarget{Ticket: &TicketInfo{Full: "proj-123", ID: "proj-123", Type: "proj"}}
+	steps := planDoneSteps(cfg, target, "squash", nil, nil)
+	archive := steps[len(steps)-1]
+	if archive.Skipped != "" {
+		t.Fatalf("archive step skipped: %s", archive.Skipped)
+	}
+	if err := archive.Run(context.Background()); err != nil {
+		t.Fatalf("archive error = %v", err)
+	}
+
+	if pathExists(notePath) {
+		t.Error("note should have been moved out of its ticket directory")
+	}
+	if archived := filepath.Join(notesDir, "done", "proj", "proj-123.md"); !pathExists(archived) {
+		t.Errorf("note not archived to %s", archived)
+	}
+}
+
+func TestRunDoneSteps(t *testing.T) {
+	target := &doneTarget{Ticket: &TicketInfo{Full: "proj-123"}}
+
+	var ran []string
+	step := func(name string, err error) doneStep {
+		return doneStep{Name: name, Run: func(ctx context.Context) error {
+			ran = append(ran, name)
+			return err
+		}}
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		ran = nil
+		steps := []doneStep{step("Merge", nil), {Name: "Cleanup", Skipped: "--no-cleanup"}}
+
+		var buf bytes.Buffer
+		if err := runDoneSteps(&buf, target, steps, 0, true); err != nil {
+			t.Fatalf("runDoneSteps() error = %v", err)
+		}
+		if len(ran) != 0 {
+			t.Errorf("dry run ran steps: %v", ran)
+		}
+		for _, want := range []string{"Dry run for proj-123", "Merge: would run", "Cleanup: skipped (--no-cleanup)"} {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("output missing %q:\n%s", want, buf.String())
+			}
+		}
+	})
+
+	t.Run("failure partway", func(t *testing.T) {
+		ran = nil
+		steps := []doneStep{
+			step("Merge", nil),
+			{Name: "Delete branch", Skipped: "--keep-branch"},
+			step("Transition", errors.New("no transition")),
+			step("Cleanup", nil),
+		}
+
+		var buf bytes.Buffer
+		err := runDoneSteps(&buf, target, steps, 0, false)
+		if err == nil {
+			t.Fatal("runDoneSteps() expected error")
+		}
+		if !strings.Contains(err.Error(), "Transition failed (completed steps: Merge)") {
+			t.Errorf("error = %q, should name the failed and completed steps", err.Error())
+		}
+		if strings.Join(ran, ",") != "Merge,Transition" {
+			t.Errorf("ran = %v, want Merge then Transition", ran)
+		}
+	})
+}
@@ -4,16 +4,21 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/spf13/cobra"
 
 	"thoreinstein.com/rig/pkg/git"
 	"thoreinstein.com/rig/pkg/notes"
+	"thoreinstein.com/rig/pkg/scaffold"
 	"thoreinstein.com/rig/pkg/tmux"
 )
 
-var hackNoNotes bool
+var (
+	hackNoNotes  bool
+	hackTemplate string
+)
 
 // hackCmd represents the hack command
 var hackCmd = &cobra.Command{
@@ -24,13 +29,15 @@ var hackCmd = &cobra.Command{
 This command creates a simplified workflow without JIRA integration:
 - Creates git worktree at {repo}/hack/{name}
 - Creates branch {name}
+- Copies a project scaffold from hack.templates (with --template)
 - Creates markdown note (use --no-notes to skip)
 - Updates daily note with log entry
 - Creates tmux session
 
 Examples:
   rig hack winter-2025
-  rig hack experiment-auth --no-notes`,
+  rig hack experiment-auth --no-notes
+  rig hack scratch-cli --template go`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runHackCommand(args[0])
@@ -41,6 +48,7 @@ func init() {
 	rootCmd.AddCommand(hackCmd)
 
 	hackCmd.Flags().BoolVar(&hackNoNotes, "no-notes", false, "Skip creating markdown note and note-related tmux window commands")
+	hackCmd.Flags().StringVar(&hackTemplate, "template", "", "Scaffold the worktree from the named hack.templates entry")
 	hackCmd.Flags().StringVarP(&projectFlag, "project", "p", "", "Override project directory")
 }
 
@@ -73,6 +81,21 @@ func runHackCommand(name string) error {
 	if verbose {
 		fmt.Printf("Starting hack workflow for: %s\n", name)
 		fmt.Printf("  No-notes: %v\n", hackNoNotes)
+		fmt.Printf("  Template: %s\n", hackTemplate)
+	}
+
+	// Resolve the template before touching the repo so a typo fails fast
+	var templateDir string
+	if hackTemplate != "" {
+		dir, ok := cfg.Hack.Templates[hackTemplate]
+		if !ok {
+			available := scaffold.Names(cfg.Hack.Templates)
+			if len(available) == 0 {
+				return errors.Newf("unknown hack template %q: no templates configured in hack.templates", hackTemplate)
+			}
+			return errors.Newf("unknown hack template %q: must be one of: %s", hackTemplate, strings.Join(available, ", "))
+		}
+		templateDir = dir
 	}
 
 	// Determine project context and switch to it
@@ -93,6 +116,7 @@ func runHackCommand(name string) error {
 		fmt.Println("Creating git worktree...")
 	}
 	gitManager := git.NewWorktreeManager(cfg.Git.BaseBranch, verbose)
+	gitManager.NetworkTimeout = cfg.Network.Timeout
 
 	// Get repo info for notes
 	repoRoot, err := gitManager.GetRepoRoot()
@@ -109,15 +133,25 @@ func runHackCommand(name string) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to create git worktree")
 	}
-	fmt.Printf("Git worktree created at: %s\n", worktreePath)
+	fmt.Printf("Git worktree ready at: %s\n", worktreePath)
+
+	// Scaffold the worktree from the selected template
+	if templateDir != "" {
+		if verbose {
+			fmt.Printf("Scaffolding from template %s (%s)...\n", hackTemplate, templateDir)
+		}
+		result, err := scaffold.Copy(templateDir, worktreePath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to scaffold template %q", hackTemplate)
+		}
+		fmt.Printf("Scaffolded %d file(s) from template %s\n", len(result.Created), hackTemplate)
+		if verbose && len(result.Skipped) > 0 {
+			fmt.Printf("Kept %d existing file(s): %s\n", len(result.Skipped), strings.Join(result.Skipped, ", "))
+		}
+	}
 
 	// Step 2: Create note (unless --no-notes flag is set)
-	noteManager := notes.NewManager(
-		cfg.Notes.Path,
-		cfg.Notes.DailyDir,
-		cfg.Notes.TemplateDir,
-		verbose,
-	)
+	noteManager := newNoteManager(cfg)
 
 	var notePath string
 	if !hackNoNotes {
@@ -169,14 +203,7 @@ func runHackCommand(name string) error {
 	}
 
 	// Convert config windows to tmux windows
-	tmuxWindows := make([]tmux.WindowConfig, 0, len(cfg.Tmux.Windows))
-	for _, window := range cfg.Tmux.Windows {
-		tmuxWindows = append(tmuxWindows, tmux.WindowConfig{
-			Name:       window.Name,
-			Command:    window.Command,
-			WorkingDir: window.WorkingDir,
-		})
-	}
+	tmuxWindows := tmuxWindowsFromConfig(cfg.Tmux.Windows)
 
 	sessionManager := tmux.NewSessionManager(cfg.Tmux.SessionPrefix, tmuxWindows, verbose)
 	err = sessionManager.CreateSession(name, worktreePath, notePath)
@@ -565,3 +565,57 @@ func TestRunHackCommand_IdempotentWorktree(t *testing.T) {
 		t.Errorf("Worktree should exist at %s after repeated calls", worktreePath)
 	}
 }
+
+func TestRunHackCommand_UnknownTemplate(t *testing.T) {
+	notesDir := t.TempDir()
+	setupTestConfig(t, notesDir)
+	defer viper.Reset()
+	viper.Set("hack.templates", map[string]string{"go": t.TempDir()})
+
+	hackTemplate = "rust"
+	defer func() { hackTemplate = "" }()
+
+	err := runHackCommand("scratch")
+	if err == nil {
+		t.Fatal("runHackCommand() should fail for an unknown template")
+	}
+	if !strings.Contains(err.Error(), `unknown hack template "rust"`) || !strings.Contains(err.Error(), "go") {
+		t.Errorf("error should name the template and list available ones, got: %v", err)
+	}
+}
+
+func TestRunHackCommand_WithTemplate(t *testing.T) {
+	// Skip if git is not available
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH, skipping test")
+	}
+
+	repoDir := setupTestGitRepo(t)
+	notesDir := t.TempDir()
+	setupTestConfig(t, notesDir)
+	defer viper.Reset()
+
+	templateDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(templateDir, "go.mod"), []byte("module scratch\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("hack.templates", map[string]string{"go": templateDir})
+
+	t.Chdir(repoDir)
+	projectFlag = repoDir
+	defer func() { projectFlag = "" }()
+
+	hackNoNotes = true
+	hackTemplate = "go"
+	defer func() {
+		hackNoNotes = false
+		hackTemplate = ""
+	}()
+
+	_ = runHackCommand("scaffolded")
+
+	goMod := filepath.Join(repoDir, "hack", "scaffolded", "go.mod")
+	if _, err := os.Stat(goMod); err != nil {
+		t.Errorf("template file should be copied to %s: %v", goMod, err)
+	}
+}
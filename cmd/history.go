@@ -1,22 +1,32 @@
 package cmd
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/spf13/cobra"
 
+	"thoreinstein.com/rig/pkg/config"
 	"thoreinstein.com/rig/pkg/history"
+	rlog "thoreinstein.com/rig/pkg/log"
+	"thoreinstein.com/rig/pkg/tmux"
 )
 
 // historyCmd represents the history command
 var historyCmd = &cobra.Command{
 	Use:   "history",
 	Short: "Query and manage command history",
-	Long: `Query and manage command history from the SQLite database.
+	Long: `Query and manage command history from the shell history database.
 
-This command provides subcommands to query the history database (zsh-histdb or atuin)
+This command provides subcommands to query the history database (zsh-histdb, atuin, fish, or bash)
 and get information about stored commands.`,
 }
 
@@ -26,22 +36,31 @@ var historyQueryCmd = &cobra.Command{
 	Short: "Query command history",
 	Long: `Query the command history database with optional filters.
 
+--since and --until accept absolute times (2024-01-15, "2024-01-15 14:30",
+"2024-01-15 14:30:45", RFC3339) or relative ones: today, yesterday, or an
+offset from now such as 30m, 24h, 7d, or 2w.
+
 Examples:
   rig history query                     # List recent commands
   rig history query "git"               # Search for commands containing "git"
+  rig history query --regex "^git (push|pull)"
   rig history query --since "2025-08-10"
+  rig history query --since 7d
   rig history query --directory /path/to/dir
   rig history query --ticket PROJ-123
+  rig history query --this-session --failed-only
   rig history query --failed-only
   rig history query --exit-code 1
-  rig history query --min-duration 5s`,
+  rig history query --min-duration 5s
+  rig history query --failed-only --output json
+  rig history query "docker" --output csv > commands.csv`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		pattern := ""
 		if len(args) > 0 {
 			pattern = args[0]
 		}
-		return runHistoryQueryCommand(pattern)
+		return runHistoryQueryCommand(cmd.OutOrStdout(), pattern)
 	},
 }
 
@@ -51,7 +70,28 @@ var historyInfoCmd = &cobra.Command{
 	Short: "Show history database information",
 	Long:  `Display information about the history database including schema, size, and statistics.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runHistoryInfoCommand()
+		return runHistoryInfoCommand(cmd.OutOrStdout())
+	},
+}
+
+// historyStatsCmd summarizes the history database
+var historyStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize command history",
+	Long: `Summarize the command history database.
+
+Shows the total number of commands, the failure rate, the most frequently run
+commands, and the busiest directories and sessions. Accepts the same
+--since, --until, and --directory filters as query.
+
+Examples:
+  rig history stats
+  rig history stats --since "2025-08-01"
+  rig history stats --directory ~/src/rig --top 10
+  rig history stats --output json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runHistoryStatsCommand(cmd.OutOrStdout())
 	},
 }
 
@@ -66,15 +106,24 @@ var (
 	historyExitCode    int
 	historyMinDuration time.Duration
 	historyLimit       int
+	historyOutput      string
+	historyRegex       bool
+	historyThisSession bool
+	historyStatsTop    int
+	historyStatsOutput string
 )
 
+// historyOutputFormats lists the supported --output values for history query
+var historyOutputFormats = []string{"table", "json", "csv"}
+
 func init() {
 	rootCmd.AddCommand(historyCmd)
 	historyCmd.AddCommand(historyQueryCmd)
 	historyCmd.AddCommand(historyInfoCmd)
+	historyCmd.AddCommand(historyStatsCmd)
 
-	historyQueryCmd.Flags().StringVar(&historySince, "since", "", "Start time (YYYY-MM-DD HH:MM or YYYY-MM-DD)")
-	historyQueryCmd.Flags().StringVar(&historyUntil, "until", "", "End time (YYYY-MM-DD HH:MM or YYYY-MM-DD)")
+	historyQueryCmd.Flags().StringVar(&historySince, "since", "", "Start time (YYYY-MM-DD [HH:MM], today, yesterday, or 7d/24h/2w)")
+	historyQueryCmd.Flags().StringVar(&historyUntil, "until", "", "End time (YYYY-MM-DD [HH:MM], today, yesterday, or 7d/24h/2w)")
 	historyQueryCmd.Flags().StringVar(&historyDirectory, "directory", "", "Filter by directory path")
 	historyQueryCmd.Flags().StringVar(&historySession, "session", "", "Filter by session")
 	historyQueryCmd.Flags().StringVar(&historySessionID, "session-id", "", "Filter by exact session ID")
@@ -83,15 +132,53 @@ func init() {
 	historyQueryCmd.Flags().IntVar(&historyExitCode, "exit-code", -1, "Filter by exact exit code")
 	historyQueryCmd.Flags().DurationVar(&historyMinDuration, "min-duration", 0, "Filter by minimum duration (e.g. 5s, 1m)")
 	historyQueryCmd.Flags().IntVar(&historyLimit, "limit", 50, "Maximum number of commands to show")
+	historyQueryCmd.Flags().StringVarP(&historyOutput, "output", "o", "table", "Output format (table, json, csv)")
+	historyQueryCmd.Flags().BoolVar(&historyRegex, "regex", false, "Treat the pattern as a regular expression")
+	historyQueryCmd.Flags().BoolVar(&historyThisSession, "this-session", false, "Filter by the current rig tmux session")
+
+	historyStatsCmd.Flags().StringVar(&historySince, "since", "", "Start time (YYYY-MM-DD [HH:MM], today, yesterday, or 7d/24h/2w)")
+	historyStatsCmd.Flags().StringVar(&historyUntil, "until", "", "End time (YYYY-MM-DD [HH:MM], today, yesterday, or 7d/24h/2w)")
+	historyStatsCmd.Flags().StringVar(&historyDirectory, "directory", "", "Filter by directory path")
+	historyStatsCmd.Flags().IntVar(&historyStatsTop, "top", 20, "Number of top commands to show")
+	historyStatsCmd.Flags().StringVarP(&historyStatsOutput, "output", "o", "table", "Output format (table, json)")
 }
 
-func runHistoryQueryCommand(pattern string) error {
+func runHistoryQueryCommand(w io.Writer, pattern string) error {
+	if err := validateHistoryOutput(historyOutput); err != nil {
+		return err
+	}
+
+	// Compile the regex up front so a bad pattern fails before touching the database
+	var patternRegex *regexp.Regexp
+	if historyRegex && pattern != "" {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return errors.Wrapf(err, "invalid regex pattern %q", pattern)
+		}
+		patternRegex = compiled
+		pattern = ""
+	}
+
 	// Load configuration
 	cfg, err := loadConfig()
 	if err != nil {
 		return errors.Wrap(err, "failed to load configuration")
 	}
 
+	session := historySession
+	if historyThisSession {
+		if historySession != "" {
+			return errors.New("--this-session cannot be combined with --session")
+		}
+		session, err = resolveCurrentHistorySession(cfg)
+		if err != nil {
+			return err
+		}
+		if verbose {
+			rlog.Debug("filtering by current session", "session", session)
+		}
+	}
+
 	// Initialize database manager
 	dbManager := history.NewDatabaseManager(cfg.History.DatabasePath, verbose)
 
@@ -100,22 +187,9 @@ func runHistoryQueryCommand(pattern string) error {
 	}
 
 	// Parse time options
-	var since, until *time.Time
-
-	if historySince != "" {
-		parsedSince, err := parseTimeString(historySince)
-		if err != nil {
-			return errors.Wrap(err, "invalid --since time")
-		}
-		since = &parsedSince
-	}
-
-	if historyUntil != "" {
-		parsedUntil, err := parseTimeString(historyUntil)
-		if err != nil {
-			return errors.Wrap(err, "invalid --until time")
-		}
-		until = &parsedUntil
+	since, until, err := parseHistoryTimeRange()
+	if err != nil {
+		return err
 	}
 
 	// Build query options
@@ -123,11 +197,12 @@ func runHistoryQueryCommand(pattern string) error {
 		Since:       since,
 		Until:       until,
 		Directory:   historyDirectory,
-		Session:     historySession,
+		Session:     session,
 		SessionID:   historySessionID,
 		Ticket:      historyTicket,
 		MinDuration: historyMinDuration,
 		Pattern:     pattern,
+		Regex:       patternRegex,
 		Limit:       historyLimit,
 	}
 
@@ -144,22 +219,142 @@ func runHistoryQueryCommand(pattern string) error {
 		return errors.Wrap(err, "failed to query commands")
 	}
 
+	switch historyOutput {
+	case "json":
+		return renderHistoryJSON(w, commands)
+	case "csv":
+		return renderHistoryCSV(w, commands)
+	}
+
+	renderHistoryTable(w, commands)
+	return nil
+}
+
+// resolveCurrentHistorySession works out which ticket session the caller is
+// in. RIG_TICKET (set in every rig-created tmux session) wins; otherwise the
+// tmux session name is used with the configured prefix stripped.
+func resolveCurrentHistorySession(cfg *config.Config) (string, error) {
+	if ticket := strings.TrimSpace(os.Getenv("RIG_TICKET")); ticket != "" {
+		return ticket, nil
+	}
+
+	sessionManager := tmux.NewSessionManager(cfg.Tmux.SessionPrefix, nil, verbose)
+	sessionName, err := sessionManager.CurrentSessionName()
+	if err != nil {
+		return "", errors.Wrap(err, "--this-session requires running inside a rig tmux session; use --session to filter explicitly")
+	}
+
+	if cfg.Tmux.SessionPrefix != "" && !strings.HasPrefix(sessionName, cfg.Tmux.SessionPrefix) {
+		return "", errors.Newf("tmux session %q was not created by rig (expected prefix %q); use --session to filter explicitly", sessionName, cfg.Tmux.SessionPrefix)
+	}
+
+	return sessionManager.TicketFromSessionName(sessionName), nil
+}
+
+// parseHistoryTimeRange parses the --since and --until flags, returning nil
+// for either bound that wasn't given
+func parseHistoryTimeRange() (*time.Time, *time.Time, error) {
+	var since, until *time.Time
+
+	if historySince != "" {
+		parsedSince, err := parseTimeString(historySince)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid --since time")
+		}
+		since = &parsedSince
+	}
+
+	if historyUntil != "" {
+		parsedUntil, err := parseTimeString(historyUntil)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid --until time")
+		}
+		until = &parsedUntil
+	}
+
+	return since, until, nil
+}
+
+// validateHistoryOutput checks the --output value before any querying happens
+func validateHistoryOutput(format string) error {
+	for _, valid := range historyOutputFormats {
+		if format == valid {
+			return nil
+		}
+	}
+	return errors.Newf("invalid output format %q (valid: %s)", format, strings.Join(historyOutputFormats, ", "))
+}
+
+// renderHistoryJSON writes the commands as a JSON array
+func renderHistoryJSON(w io.Writer, commands []history.Command) error {
+	if commands == nil {
+		commands = []history.Command{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(commands); err != nil {
+		return errors.Wrap(err, "failed to encode commands as JSON")
+	}
+	return nil
+}
+
+// historyCSVHeader is the header row written by renderHistoryCSV
+var historyCSVHeader = []string{"command", "directory", "session", "exit", "duration_ms", "start_time"}
+
+// renderHistoryCSV writes the commands as CSV with a header row. Commands
+// without a recorded timestamp get an empty start_time cell.
+func renderHistoryCSV(w io.Writer, commands []history.Command) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(historyCSVHeader); err != nil {
+		return errors.Wrap(err, "failed to write CSV header")
+	}
+
+	for _, cmd := range commands {
+		startTime := ""
+		if !cmd.Timestamp.IsZero() {
+			startTime = cmd.Timestamp.Format(time.RFC3339)
+		}
+
+		record := []string{
+			cmd.Command,
+			cmd.Directory,
+			cmd.Session,
+			strconv.Itoa(cmd.ExitCode),
+			strconv.FormatInt(cmd.Duration, 10),
+			startTime,
+		}
+		if err := writer.Write(record); err != nil {
+			return errors.Wrap(err, "failed to write CSV row")
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return errors.Wrap(err, "failed to write CSV output")
+	}
+	return nil
+}
+
+// renderHistoryTable writes the commands in the human-readable list format
+func renderHistoryTable(w io.Writer, commands []history.Command) {
 	if len(commands) == 0 {
-		fmt.Println("No commands found matching the criteria.")
-		return nil
+		fmt.Fprintln(w, "No commands found matching the criteria.")
+		return
 	}
 
 	// Display results
-	fmt.Printf("Found %d commands:\n\n", len(commands))
+	fmt.Fprintf(w, "Found %d commands:\n\n", len(commands))
+
+	style := outputStyle(w)
 
 	for i, cmd := range commands {
 		timestamp := cmd.Timestamp.Format("2006-01-02 15:04:05")
 
-		var statusIcon string
-		if cmd.ExitCode == 0 {
-			statusIcon = "✓"
-		} else {
-			statusIcon = "✗"
+		statusIcon := style.CheckMark()
+		if cmd.ExitCode != 0 {
+			statusIcon = style.CrossMark()
 		}
 
 		var durationStr string
@@ -183,32 +378,30 @@ func runHistoryQueryCommand(pattern string) error {
 			directory = "..." + directory[len(directory)-27:]
 		}
 
-		fmt.Printf("%3d. %s %s [%s] %s", i+1, statusIcon, timestamp, durationStr, command)
+		fmt.Fprintf(w, "%3d. %s %s [%s] %s", i+1, statusIcon, timestamp, durationStr, command)
 
 		if directory != "" {
-			fmt.Printf("\n     Directory: %s", directory)
+			fmt.Fprintf(w, "\n     Directory: %s", directory)
 		}
 
 		if cmd.Session != "" {
-			fmt.Printf("\n     Session: %s", cmd.Session)
+			fmt.Fprintf(w, "\n     Session: %s", cmd.Session)
 		}
 
 		if cmd.ExitCode != 0 {
-			fmt.Printf("\n     Exit Code: %d", cmd.ExitCode)
+			fmt.Fprintf(w, "\n     Exit Code: %d", cmd.ExitCode)
 		}
 
-		fmt.Println()
+		fmt.Fprintln(w)
 
 		// Add separator between commands
 		if i < len(commands)-1 {
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 	}
-
-	return nil
 }
 
-func runHistoryInfoCommand() error {
+func runHistoryInfoCommand(w io.Writer) error {
 	// Load configuration
 	cfg, err := loadConfig()
 	if err != nil {
@@ -224,44 +417,118 @@ func runHistoryInfoCommand() error {
 		return errors.Wrap(err, "failed to get database info")
 	}
 
-	fmt.Println("History Database Information")
-	fmt.Println("============================")
+	fmt.Fprintln(w, "History Database Information")
+	fmt.Fprintln(w, "============================")
 
-	fmt.Printf("Path: %s\n", info["path"])
-	fmt.Printf("Exists: %v\n", info["exists"])
+	fmt.Fprintf(w, "Path: %s\n", info["path"])
+	fmt.Fprintf(w, "Exists: %v\n", info["exists"])
 
 	if !info["exists"].(bool) {
-		fmt.Println("Database file does not exist.")
-		fmt.Println("Make sure zsh-histdb or atuin is configured and running.")
+		fmt.Fprintln(w, "Database file does not exist.")
+		fmt.Fprintln(w, "Make sure zsh-histdb, atuin, fish, or bash history is configured and running.")
 		return nil
 	}
 
 	if size, ok := info["size"]; ok {
-		fmt.Printf("Size: %d bytes\n", size)
+		fmt.Fprintf(w, "Size: %d bytes\n", size)
 	}
 
 	if modified, ok := info["modified"]; ok {
-		fmt.Printf("Modified: %s\n", modified.(time.Time).Format("2006-01-02 15:04:05"))
+		fmt.Fprintf(w, "Modified: %s\n", modified.(time.Time).Format("2006-01-02 15:04:05"))
 	}
 
 	if schema, ok := info["schema"]; ok {
-		fmt.Printf("Schema: %s\n", schema)
+		fmt.Fprintf(w, "Schema: %s\n", schema)
 	}
 
 	if count, ok := info["command_count"]; ok {
-		fmt.Printf("Commands: %d\n", count)
+		fmt.Fprintf(w, "Commands: %d\n", count)
 	}
 
 	if errMsg, ok := info["error"]; ok {
-		fmt.Printf("Error: %s\n", errMsg)
+		fmt.Fprintf(w, "Error: %s\n", errMsg)
 	}
 
 	// Test availability
 	if dbManager.IsAvailable() {
-		fmt.Println("Status: Available ✓")
+		fmt.Fprintf(w, "Status: Available %s\n", outputStyle(w).CheckMark())
 	} else {
-		fmt.Println("Status: Not available ✗")
+		fmt.Fprintf(w, "Status: Not available %s\n", outputStyle(w).CrossMark())
+	}
+
+	return nil
+}
+
+func runHistoryStatsCommand(w io.Writer) error {
+	if historyStatsOutput != "table" && historyStatsOutput != "json" {
+		return errors.Newf("invalid output format %q (valid: table, json)", historyStatsOutput)
 	}
 
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	dbManager := history.NewDatabaseManager(cfg.History.DatabasePath, verbose)
+
+	if !dbManager.IsAvailable() {
+		return errors.Newf("history database not available at: %s", cfg.History.DatabasePath)
+	}
+
+	since, until, err := parseHistoryTimeRange()
+	if err != nil {
+		return err
+	}
+
+	options := history.QueryOptions{
+		Since:     since,
+		Until:     until,
+		Directory: historyDirectory,
+	}
+
+	stats, err := dbManager.GetStats(options, historyStatsTop)
+	if err != nil {
+		return errors.Wrap(err, "failed to compute history stats")
+	}
+
+	if historyStatsOutput == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(stats); err != nil {
+			return errors.Wrap(err, "failed to encode stats as JSON")
+		}
+		return nil
+	}
+
+	renderHistoryStats(w, stats)
 	return nil
 }
+
+// renderHistoryStats writes the stats as compact tables
+func renderHistoryStats(w io.Writer, stats *history.Stats) {
+	fmt.Fprintln(w, "History Statistics")
+	fmt.Fprintln(w, "==================")
+	fmt.Fprintf(w, "Total commands:  %d\n", stats.TotalCommands)
+	fmt.Fprintf(w, "Failed commands: %d (%.1f%%)\n", stats.FailedCommands, stats.FailureRate*100)
+
+	printCountTable(w, "Top commands", stats.TopCommands)
+	printCountTable(w, "Busiest directories", stats.TopDirectories)
+	printCountTable(w, "Busiest sessions", stats.TopSessions)
+}
+
+// printCountTable writes a titled COUNT/NAME table, skipping empty lists
+func printCountTable(w io.Writer, title string, entries []history.CountEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s:\n", title)
+	for _, entry := range entries {
+		name := entry.Name
+		if len(name) > 80 {
+			name = name[:77] + "..."
+		}
+		fmt.Fprintf(w, "  %6d  %s\n", entry.Count, name)
+	}
+}
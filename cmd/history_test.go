@@ -1,13 +1,20 @@
 package cmd
 
 import (
+	"bytes"
 	"database/sql"
+	"encoding/json"
+	"io"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	_ "modernc.org/sqlite"
+
+	"thoreinstein.com/rig/pkg/config"
+	"thoreinstein.com/rig/pkg/history"
 )
 
 func TestHistoryCommandStructure(t *testing.T) {
@@ -517,7 +524,7 @@ func TestRunHistoryQueryCommand_DatabaseNotAvailable(t *testing.T) {
 		historyLimit = oldHistoryLimit
 	}()
 
-	err := runHistoryQueryCommand("")
+	err := runHistoryQueryCommand(io.Discard, "")
 	if err == nil {
 		t.Error("runHistoryQueryCommand() expected error for non-existent database")
 	}
@@ -542,7 +549,7 @@ func TestRunHistoryQueryCommand_InvalidSinceTime(t *testing.T) {
 		historySince = oldHistorySince
 	}()
 
-	err := runHistoryQueryCommand("")
+	err := runHistoryQueryCommand(io.Discard, "")
 	if err == nil {
 		t.Error("runHistoryQueryCommand() expected error for invalid --since time")
 	}
@@ -570,7 +577,7 @@ func TestRunHistoryQueryCommand_InvalidUntilTime(t *testing.T) {
 		historyUntil = oldHistoryUntil
 	}()
 
-	err := runHistoryQueryCommand("")
+	err := runHistoryQueryCommand(io.Discard, "")
 	if err == nil {
 		t.Error("runHistoryQueryCommand() expected error for invalid --until time")
 	}
@@ -612,7 +619,7 @@ func TestRunHistoryQueryCommand_SuccessNoResults(t *testing.T) {
 	}()
 
 	// Should not error, just return no results
-	err := runHistoryQueryCommand("")
+	err := runHistoryQueryCommand(io.Discard, "")
 	if err != nil {
 		t.Errorf("runHistoryQueryCommand() error = %v, want nil", err)
 	}
@@ -650,7 +657,7 @@ func TestRunHistoryQueryCommand_SuccessWithResults(t *testing.T) {
 		historyLimit = oldHistoryLimit
 	}()
 
-	err := runHistoryQueryCommand("")
+	err := runHistoryQueryCommand(io.Discard, "")
 	if err != nil {
 		t.Errorf("runHistoryQueryCommand() error = %v, want nil", err)
 	}
@@ -689,10 +696,21 @@ func TestRunHistoryQueryCommand_WithPattern(t *testing.T) {
 	}()
 
 	// Query with pattern "git"
-	err := runHistoryQueryCommand("git")
+	var buf bytes.Buffer
+	err := runHistoryQueryCommand(&buf, "git")
 	if err != nil {
 		t.Errorf("runHistoryQueryCommand(\"git\") error = %v, want nil", err)
 	}
+
+	output := buf.String()
+	for _, want := range []string{"Found 2 commands:", "git status", "git commit", "Directory: /home/user/project", "Session: FRAAS-123"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "make build") {
+		t.Errorf("output should only contain commands matching the pattern:\n%s", output)
+	}
 }
 
 func TestRunHistoryQueryCommand_WithFailedOnly(t *testing.T) {
@@ -727,7 +745,7 @@ func TestRunHistoryQueryCommand_WithFailedOnly(t *testing.T) {
 		historyLimit = oldHistoryLimit
 	}()
 
-	err := runHistoryQueryCommand("")
+	err := runHistoryQueryCommand(io.Discard, "")
 	if err != nil {
 		t.Errorf("runHistoryQueryCommand() with --failed-only error = %v, want nil", err)
 	}
@@ -765,7 +783,7 @@ func TestRunHistoryQueryCommand_WithValidTimeFilter(t *testing.T) {
 		historyLimit = oldHistoryLimit
 	}()
 
-	err := runHistoryQueryCommand("")
+	err := runHistoryQueryCommand(io.Discard, "")
 	if err != nil {
 		t.Errorf("runHistoryQueryCommand() with time filters error = %v, want nil", err)
 	}
@@ -803,7 +821,7 @@ func TestRunHistoryQueryCommand_WithDirectoryFilter(t *testing.T) {
 		historyLimit = oldHistoryLimit
 	}()
 
-	err := runHistoryQueryCommand("")
+	err := runHistoryQueryCommand(io.Discard, "")
 	if err != nil {
 		t.Errorf("runHistoryQueryCommand() with --directory error = %v, want nil", err)
 	}
@@ -841,7 +859,7 @@ func TestRunHistoryQueryCommand_WithSessionFilter(t *testing.T) {
 		historyLimit = oldHistoryLimit
 	}()
 
-	err := runHistoryQueryCommand("")
+	err := runHistoryQueryCommand(io.Discard, "")
 	if err != nil {
 		t.Errorf("runHistoryQueryCommand() with --session error = %v, want nil", err)
 	}
@@ -879,7 +897,7 @@ func TestRunHistoryQueryCommand_WithLimit(t *testing.T) {
 		historyLimit = oldHistoryLimit
 	}()
 
-	err := runHistoryQueryCommand("")
+	err := runHistoryQueryCommand(io.Discard, "")
 	if err != nil {
 		t.Errorf("runHistoryQueryCommand() with --limit error = %v, want nil", err)
 	}
@@ -893,10 +911,14 @@ func TestRunHistoryInfoCommand_DatabaseNotExists(t *testing.T) {
 	defer viper.Reset()
 
 	// Should not error for non-existent database, just report it
-	err := runHistoryInfoCommand()
+	var buf bytes.Buffer
+	err := runHistoryInfoCommand(&buf)
 	if err != nil {
 		t.Errorf("runHistoryInfoCommand() error = %v, want nil", err)
 	}
+	if !strings.Contains(buf.String(), "Database file does not exist.") {
+		t.Errorf("output should report the missing database:\n%s", buf.String())
+	}
 }
 
 func TestRunHistoryInfoCommand_ValidZshHistdb(t *testing.T) {
@@ -907,10 +929,16 @@ func TestRunHistoryInfoCommand_ValidZshHistdb(t *testing.T) {
 	setupHistoryTestConfig(t, dbPath)
 	defer viper.Reset()
 
-	err := runHistoryInfoCommand()
+	var buf bytes.Buffer
+	err := runHistoryInfoCommand(&buf)
 	if err != nil {
 		t.Errorf("runHistoryInfoCommand() error = %v, want nil", err)
 	}
+	for _, want := range []string{"Path: " + dbPath, "Exists: true", "Status: Available ✓"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
 }
 
 func TestRunHistoryInfoCommand_ValidAtuin(t *testing.T) {
@@ -921,7 +949,7 @@ func TestRunHistoryInfoCommand_ValidAtuin(t *testing.T) {
 	setupHistoryTestConfig(t, dbPath)
 	defer viper.Reset()
 
-	err := runHistoryInfoCommand()
+	err := runHistoryInfoCommand(io.Discard)
 	if err != nil {
 		t.Errorf("runHistoryInfoCommand() error = %v, want nil", err)
 	}
@@ -1013,7 +1041,7 @@ func TestRunHistoryQueryCommand_AtuinDatabase(t *testing.T) {
 		historyLimit = oldHistoryLimit
 	}()
 
-	err := runHistoryQueryCommand("")
+	err := runHistoryQueryCommand(io.Discard, "")
 	if err != nil {
 		t.Errorf("runHistoryQueryCommand() with atuin database error = %v, want nil", err)
 	}
@@ -1052,7 +1080,7 @@ func TestRunHistoryQueryCommand_AllFiltersComposed(t *testing.T) {
 		historyLimit = oldHistoryLimit
 	}()
 
-	err := runHistoryQueryCommand("make")
+	err := runHistoryQueryCommand(io.Discard, "make")
 	if err != nil {
 		t.Errorf("runHistoryQueryCommand() with all filters error = %v, want nil", err)
 	}
@@ -1098,8 +1126,28 @@ func TestHistoryQueryTimeFormats(t *testing.T) {
 			expectErr: true,
 		},
 		{
-			name:      "words not time",
+			name:      "relative keyword",
 			timeStr:   "yesterday",
+			expectErr: false,
+		},
+		{
+			name:      "relative offset",
+			timeStr:   "7d",
+			expectErr: false,
+		},
+		{
+			name:      "words not time",
+			timeStr:   "last tuesday",
+			expectErr: true,
+		},
+		{
+			name:      "offset without unit",
+			timeStr:   "7",
+			expectErr: true,
+		},
+		{
+			name:      "unsupported unit",
+			timeStr:   "3y",
 			expectErr: true,
 		},
 	}
@@ -1118,3 +1166,347 @@ func TestHistoryQueryTimeFormats(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateHistoryOutput(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{"table", false},
+		{"json", false},
+		{"csv", false},
+		{"yaml", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			err := validateHistoryOutput(tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateHistoryOutput(%q) error = %v, wantErr %v", tt.format, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRenderHistoryJSON(t *testing.T) {
+	commands := []history.Command{
+		{
+			ID:        1,
+			Command:   "make build",
+			Timestamp: time.Unix(1700000000, 0).UTC(),
+			Duration:  5000,
+			ExitCode:  1,
+			Directory: "/home/user/project",
+			Session:   "FRAAS-123",
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := renderHistoryJSON(&buf, commands); err != nil {
+		t.Fatalf("renderHistoryJSON() error = %v", err)
+	}
+
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if len(decoded) != 1 {
+		t.Fatalf("decoded %d entries, want 1", len(decoded))
+	}
+
+	want := map[string]interface{}{
+		"command":     "make build",
+		"directory":   "/home/user/project",
+		"session":     "FRAAS-123",
+		"exit_status": float64(1),
+		"duration_ms": float64(5000),
+		"start_time":  "2023-11-14T22:13:20Z",
+	}
+	for key, value := range want {
+		if decoded[0][key] != value {
+			t.Errorf("%s = %v, want %v", key, decoded[0][key], value)
+		}
+	}
+	if _, ok := decoded[0]["ID"]; ok {
+		t.Error("ID should not be included in JSON output")
+	}
+
+	buf.Reset()
+	if err := renderHistoryJSON(&buf, nil); err != nil {
+		t.Fatalf("renderHistoryJSON(nil) error = %v", err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("renderHistoryJSON(nil) = %q, want []", buf.String())
+	}
+}
+
+func TestRunHistoryQueryCommand_JSONOutput(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "history.db")
+
+	createTestHistoryDatabaseWithData(t, dbPath)
+	setupHistoryTestConfig(t, dbPath)
+	defer viper.Reset()
+
+	oldHistoryFailedOnly := historyFailedOnly
+	oldHistoryLimit := historyLimit
+	oldHistoryOutput := historyOutput
+
+	historyFailedOnly = true
+	historyLimit = 50
+	historyOutput = "json"
+
+	defer func() {
+		historyFailedOnly = oldHistoryFailedOnly
+		historyLimit = oldHistoryLimit
+		historyOutput = oldHistoryOutput
+	}()
+
+	var runErr error
+	var buf bytes.Buffer
+	runErr = runHistoryQueryCommand(&buf, "")
+	output := buf.String()
+	if runErr != nil {
+		t.Fatalf("runHistoryQueryCommand() error = %v", runErr)
+	}
+
+	var decoded []history.Command
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, output)
+	}
+	if len(decoded) != 1 || decoded[0].Command != "make build" {
+		t.Errorf("decoded = %+v, want only the failed make build", decoded)
+	}
+}
+
+func TestRunHistoryQueryCommand_VerboseJSONOutput(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "history.db")
+
+	createTestHistoryDatabaseWithData(t, dbPath)
+	setupHistoryTestConfig(t, dbPath)
+	defer viper.Reset()
+
+	oldVerbose := verbose
+	oldHistoryLimit := historyLimit
+	oldHistoryOutput := historyOutput
+
+	verbose = true
+	historyLimit = 50
+	historyOutput = "json"
+
+	defer func() {
+		verbose = oldVerbose
+		historyLimit = oldHistoryLimit
+		historyOutput = oldHistoryOutput
+	}()
+
+	// Diagnostics must not reach stdout, where they would corrupt the JSON
+	var runErr error
+	var buf bytes.Buffer
+	stdout := captureOutput(func() {
+		runErr = runHistoryQueryCommand(&buf, "")
+	})
+	if runErr != nil {
+		t.Fatalf("runHistoryQueryCommand() error = %v", runErr)
+	}
+	if stdout != "" {
+		t.Errorf("verbose query wrote to stdout: %q", stdout)
+	}
+
+	var decoded []history.Command
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+}
+
+func TestRunHistoryQueryCommand_InvalidOutput(t *testing.T) {
+	oldHistoryOutput := historyOutput
+	historyOutput = "xml"
+	defer func() { historyOutput = oldHistoryOutput }()
+
+	err := runHistoryQueryCommand(io.Discard, "")
+	if err == nil || !strings.Contains(err.Error(), "invalid output format") {
+		t.Errorf("runHistoryQueryCommand() error = %v, want invalid output format", err)
+	}
+}
+
+func TestRenderHistoryCSV(t *testing.T) {
+	commands := []history.Command{
+		{
+			Command:   `git commit -m "fix, then test"`,
+			Timestamp: time.Unix(1700000000, 0).UTC(),
+			Duration:  200,
+			ExitCode:  0,
+			Directory: "/home/user/project",
+			Session:   "FRAAS-123",
+		},
+		{
+			Command:  "ls",
+			ExitCode: 2,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := renderHistoryCSV(&buf, commands); err != nil {
+		t.Fatalf("renderHistoryCSV() error = %v", err)
+	}
+
+	want := "command,directory,session,exit,duration_ms,start_time\n" +
+		`"git commit -m ""fix, then test""",/home/user/project,FRAAS-123,0,200,2023-11-14T22:13:20Z` + "\n" +
+		"ls,,,2,0,\n"
+	if buf.String() != want {
+		t.Errorf("renderHistoryCSV() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestRunHistoryStatsCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "history.db")
+
+	createTestHistoryDatabaseWithData(t, dbPath)
+	setupHistoryTestConfig(t, dbPath)
+	defer viper.Reset()
+
+	oldHistoryDirectory := historyDirectory
+	oldHistoryStatsTop := historyStatsTop
+	oldHistoryStatsOutput := historyStatsOutput
+
+	historyDirectory = ""
+	historyStatsTop = 20
+
+	defer func() {
+		historyDirectory = oldHistoryDirectory
+		historyStatsTop = oldHistoryStatsTop
+		historyStatsOutput = oldHistoryStatsOutput
+	}()
+
+	historyStatsOutput = "table"
+	var runErr error
+	var buf bytes.Buffer
+	runErr = runHistoryStatsCommand(&buf)
+	output := buf.String()
+	if runErr != nil {
+		t.Fatalf("runHistoryStatsCommand() error = %v", runErr)
+	}
+	for _, want := range []string{"Total commands:  4", "Failed commands: 1 (25.0%)", "Top commands:", "FRAAS-123"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	historyStatsOutput = "json"
+	historyDirectory = "/home/user/other"
+	buf.Reset()
+	runErr = runHistoryStatsCommand(&buf)
+	output = buf.String()
+	if runErr != nil {
+		t.Fatalf("runHistoryStatsCommand() json error = %v", runErr)
+	}
+
+	var stats history.Stats
+	if err := json.Unmarshal([]byte(output), &stats); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, output)
+	}
+	if stats.TotalCommands != 1 {
+		t.Errorf("TotalCommands = %d, want 1 with --directory filter", stats.TotalCommands)
+	}
+
+	historyStatsOutput = "csv"
+	if err := runHistoryStatsCommand(io.Discard); err == nil {
+		t.Error("runHistoryStatsCommand() should reject csv output")
+	}
+}
+
+func TestRunHistoryQueryCommand_InvalidRegex(t *testing.T) {
+	oldHistoryRegex := historyRegex
+	historyRegex = true
+	defer func() { historyRegex = oldHistoryRegex }()
+
+	// The database path doesn't exist; the regex error must come first
+	setupHistoryTestConfig(t, filepath.Join(t.TempDir(), "missing.db"))
+	defer viper.Reset()
+
+	err := runHistoryQueryCommand(io.Discard, "git (push")
+	if err == nil || !strings.Contains(err.Error(), "invalid regex pattern") {
+		t.Errorf("runHistoryQueryCommand() error = %v, want invalid regex pattern", err)
+	}
+}
+
+func TestResolveCurrentHistorySession(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Tmux.SessionPrefix = "rig-"
+
+	t.Run("uses RIG_TICKET", func(t *testing.T) {
+		t.Setenv("RIG_TICKET", "FRAAS-123")
+		got, err := resolveCurrentHistorySession(cfg)
+		if err != nil {
+			t.Fatalf("resolveCurrentHistorySession() error = %v", err)
+		}
+		if got != "FRAAS-123" {
+			t.Errorf("resolveCurrentHistorySession() = %q, want %q", got, "FRAAS-123")
+		}
+	})
+
+	t.Run("errors outside a session", func(t *testing.T) {
+		t.Setenv("RIG_TICKET", "")
+		t.Setenv("TMUX", "")
+		_, err := resolveCurrentHistorySession(cfg)
+		if err == nil || !strings.Contains(err.Error(), "--session") {
+			t.Errorf("resolveCurrentHistorySession() error = %v, want hint about --session", err)
+		}
+	})
+}
+
+func TestRunHistoryQueryCommand_ThisSession(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "history.db")
+
+	createTestHistoryDatabaseWithData(t, dbPath)
+	setupHistoryTestConfig(t, dbPath)
+	defer viper.Reset()
+
+	oldHistoryThisSession := historyThisSession
+	oldHistorySession := historySession
+	oldHistoryFailedOnly := historyFailedOnly
+	oldHistoryOutput := historyOutput
+	oldHistoryLimit := historyLimit
+
+	historyThisSession = true
+	historySession = ""
+	historyFailedOnly = false
+	historyOutput = "json"
+	historyLimit = 50
+
+	defer func() {
+		historyThisSession = oldHistoryThisSession
+		historySession = oldHistorySession
+		historyFailedOnly = oldHistoryFailedOnly
+		historyOutput = oldHistoryOutput
+		historyLimit = oldHistoryLimit
+	}()
+
+	t.Setenv("RIG_TICKET", "other-session")
+
+	var runErr error
+	var buf bytes.Buffer
+	runErr = runHistoryQueryCommand(&buf, "")
+	output := buf.String()
+	if runErr != nil {
+		t.Fatalf("runHistoryQueryCommand() error = %v", runErr)
+	}
+
+	var decoded []history.Command
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, output)
+	}
+	if len(decoded) != 1 || decoded[0].Command != "docker ps" {
+		t.Errorf("decoded = %+v, want only commands from other-session", decoded)
+	}
+
+	historySession = "FRAAS-123"
+	if err := runHistoryQueryCommand(io.Discard, ""); err == nil {
+		t.Error("runHistoryQueryCommand() should reject --this-session with --session")
+	}
+}
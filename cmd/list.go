@@ -203,10 +203,10 @@ func listAllSessions(cfg *config.Config) error {
 	sessionDetails := getSessionDetails()
 
 	for _, session := range sessions {
-		if detail, ok := sessionDetails[session]; ok {
-			fmt.Printf("  %-30s %s\n", session, detail)
+		if detail, ok := sessionDetails[session.Name]; ok {
+			fmt.Printf("  %-30s %s\n", session.Name, detail)
 		} else {
-			fmt.Printf("  %s\n", session)
+			fmt.Printf("  %s\n", session.Name)
 		}
 	}
 	fmt.Println()
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+)
+
+// notesCmd represents the notes command
+var notesCmd = &cobra.Command{
+	Use:   "notes",
+	Short: "Work with markdown notes",
+	Long:  `Commands for working with the markdown notes rig manages.`,
+}
+
+// notesSearchCmd searches notes for a query
+var notesSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search notes for a ticket or text",
+	Long: `Search all markdown notes under the configured notes path.
+
+Matching is a case-insensitive substring match. Each match is printed as
+path:line: text, with paths relative to the notes directory.
+
+Examples:
+  rig notes search PROJ-123
+  rig notes search "connection pool"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runNotesSearchCommand(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(notesCmd)
+	notesCmd.AddCommand(notesSearchCmd)
+}
+
+func runNotesSearchCommand(query string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	noteManager := newObsidianManager(cfg)
+
+	matches, err := noteManager.SearchNotes(query)
+	if err != nil {
+		return err
+	}
+
+	if len(matches) == 0 {
+		fmt.Printf("No notes matching %q\n", query)
+		return nil
+	}
+
+	for _, match := range matches {
+		path := match.Path
+		if rel, relErr := filepath.Rel(cfg.Notes.Path, match.Path); relErr == nil {
+			path = rel
+		}
+		fmt.Printf("%s:%d: %s\n", path, match.Line, match.Text)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestNotesCommandStructure(t *testing.T) {
+	// Not parallel - accesses global notesCmd
+	subcommandNames := make(map[string]bool)
+	for _, sub := range notesCmd.Commands() {
+		subcommandNames[sub.Use] = true
+	}
+
+	if !subcommandNames["search <query>"] {
+		t.Error("notes command missing subcommand: \"search <query>\"")
+	}
+
+	if notesSearchCmd.Args == nil {
+		t.Error("notes search should have Args validation")
+	}
+}
+
+func TestRunNotesSearchCommand(t *testing.T) {
+	notesDir := t.TempDir()
+	notePath := filepath.Join(notesDir, "proj", "proj-123.md")
+	if err := os.MkdirAll(filepath.Dir(notePath), 0755); err != nil {
+		t.Fatalf("Failed to create note dir: %v", err)
+	}
+	if err := os.WriteFile(notePath, []byte("# proj-123\n\nRotate the TLS certs\n"), 0644); err != nil {
+		t.Fatalf("Failed to write note: %v", err)
+	}
+
+	viper.Reset()
+	resetConfig()
+	viper.Set("notes.path", notesDir)
+	defer viper.Reset()
+
+	var runErr error
+	output := captureOutput(func() {
+		runErr = runNotesSearchCommand("tls")
+	})
+	if runErr != nil {
+		t.Fatalf("runNotesSearchCommand() error = %v", runErr)
+	}
+
+	want := filepath.Join("proj", "proj-123.md") + ":3: Rotate the TLS certs"
+	if !strings.Contains(output, want) {
+		t.Errorf("output = %q, want it to contain %q", output, want)
+	}
+
+	output = captureOutput(func() {
+		runErr = runNotesSearchCommand("nonexistent")
+	})
+	if runErr != nil {
+		t.Fatalf("runNotesSearchCommand() error = %v", runErr)
+	}
+	if !strings.Contains(output, "No notes matching") {
+		t.Errorf("output = %q, want no-match message", output)
+	}
+}
@@ -18,6 +18,7 @@ Examples:
   rig pr view                # View PR for current branch
   rig pr view 123            # View PR #123
   rig pr list                # List open PRs
+  rig pr describe            # Generate a title and description with AI
   rig pr merge 123           # Full merge workflow with AI debrief`,
 }
 
@@ -3,14 +3,17 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"thoreinstein.com/rig/pkg/ai"
 	"thoreinstein.com/rig/pkg/config"
 	rigerrors "thoreinstein.com/rig/pkg/errors"
+	"thoreinstein.com/rig/pkg/git"
 	"thoreinstein.com/rig/pkg/github"
 )
 
@@ -21,6 +24,8 @@ type CreateOptions struct {
 	Reviewers  []string
 	BaseBranch string
 	NoBrowser  bool
+	NoPush     bool
+	Describe   bool
 }
 
 var prCreateOptions CreateOptions
@@ -31,13 +36,20 @@ var prCreateCmd = &cobra.Command{
 	Short: "Create a pull request",
 	Long: `Create a new pull request from the current branch.
 
-If no title is provided, the last commit message subject is used.
+The branch is pushed to origin first (use --no-push if it is already there)
+and the PR targets git.base_branch or the repository's detected default
+branch unless --base is given.
+
+If no title is provided, the last commit message subject is used. With
+--describe, the title and body are generated by the AI provider, as with
+'rig pr describe'; --title and --body still take precedence.
 After creation, the PR URL is opened in the default browser.
 
 Examples:
   rig pr create                           # Use last commit message as title
   rig pr create --title "Add feature X"   # Specify title
   rig pr create --draft                   # Create as draft PR
+  rig pr create --describe                # Generate title and body with AI
   rig pr create --reviewer user1,user2    # Request reviewers`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -54,7 +66,38 @@ Examples:
 			return err
 		}
 
-		return runPRCreate(prCreateOptions, ghClient, cfg)
+		cm := git.NewCommitManager(".", verbose)
+		opts := prCreateOptions
+
+		if opts.BaseBranch == "" {
+			base, err := defaultPRBase(cfg)
+			if err != nil && opts.Describe {
+				return err
+			}
+			if err != nil && verbose {
+				fmt.Printf("Warning: %v; using the repository default on GitHub\n", err)
+			}
+			opts.BaseBranch = base
+		}
+
+		if opts.Describe && (opts.Title == "" || opts.Body == "") {
+			provider, err := ai.NewProvider(&cfg.AI, verbose)
+			if err != nil {
+				return err
+			}
+			title, body, err := generatePRDescription(cmd.Context(), cm, provider, opts.BaseBranch, "", os.Stderr)
+			if err != nil {
+				return err
+			}
+			if opts.Title == "" {
+				opts.Title = title
+			}
+			if opts.Body == "" {
+				opts.Body = body
+			}
+		}
+
+		return runPRCreate(opts, ghClient, cm, cfg)
 	},
 }
 
@@ -67,14 +110,34 @@ func init() {
 	prCreateCmd.Flags().StringSliceVarP(&prCreateOptions.Reviewers, "reviewer", "r", nil, "Request reviewers (comma-separated)")
 	prCreateCmd.Flags().StringVar(&prCreateOptions.BaseBranch, "base", "", "Base branch (defaults to repo default)")
 	prCreateCmd.Flags().BoolVar(&prCreateOptions.NoBrowser, "no-browser", false, "Don't open PR URL in browser")
+	prCreateCmd.Flags().BoolVar(&prCreateOptions.NoPush, "no-push", false, "Don't push the branch first; it must already be on origin")
+	prCreateCmd.Flags().BoolVar(&prCreateOptions.Describe, "describe", false, "Generate the title and body with AI")
 }
 
-func runPRCreate(opts CreateOptions, ghClient github.Client, cfg *config.Config) error {
+func runPRCreate(opts CreateOptions, ghClient github.Client, cm *git.CommitManager, cfg *config.Config) error {
 	ctx := context.Background()
 
 	// Check authentication
 	if !ghClient.IsAuthenticated() {
-		return rigerrors.NewGitHubError("Auth", "not authenticated with GitHub. Run 'gh auth login' first")
+		return rigerrors.NewGitHubError("Auth", "not authenticated with GitHub. Set GITHUB_TOKEN or run 'gh auth login' first")
+	}
+
+	// The PR head must exist on origin before GitHub can open a PR from it
+	branch, err := cm.CurrentBranch()
+	if err != nil {
+		return rigerrors.NewWorkflowErrorWithCause("PRCreate", "failed to determine the branch to open a PR from", err)
+	}
+	if opts.NoPush {
+		if !cm.HasUpstream(branch) {
+			return rigerrors.NewWorkflowError("PRCreate",
+				fmt.Sprintf("branch %s has not been pushed. Run 'git push -u origin %s' or drop --no-push", branch, branch))
+		}
+	} else {
+		fmt.Printf("Pushing %s to origin...\n", branch)
+		if err := cm.Push(branch); err != nil {
+			return rigerrors.NewWorkflowErrorWithCause("PRCreate",
+				fmt.Sprintf("failed to push %s to origin. Check the remote and your git credentials", branch), err)
+		}
 	}
 
 	// Get title from last commit if not provided
@@ -94,6 +157,7 @@ func runPRCreate(opts CreateOptions, ghClient github.Client, cfg *config.Config)
 	ghOpts := github.CreatePROptions{
 		Title:      title,
 		Body:       opts.Body,
+		HeadBranch: branch,
 		BaseBranch: opts.BaseBranch,
 		Draft:      opts.Draft,
 		Reviewers:  opts.Reviewers,
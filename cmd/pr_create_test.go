@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"thoreinstein.com/rig/pkg/config"
+	"thoreinstein.com/rig/pkg/git"
+	"thoreinstein.com/rig/pkg/github"
 )
 
 func TestRunPRCreate(t *testing.T) {
@@ -56,10 +61,88 @@ func TestRunPRCreate(t *testing.T) {
 				mockClient.isAuthenticated = true
 			}
 
-			err := runPRCreate(tt.opts, mockClient, cfg)
+			runner := &fakeGitRunner{outputs: map[string]string{"rev-parse --abbrev-ref HEAD": "feature/login\n"}}
+			cm := git.NewCommitManagerWithRunner(".", false, runner)
+
+			err := runPRCreate(tt.opts, mockClient, cm, cfg)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("runPRCreate() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestRunPRCreate_Push(t *testing.T) {
+	tests := []struct {
+		name     string
+		noPush   bool
+		upstream string
+		pushErr  error
+		wantPush bool
+		wantErr  string
+	}{
+		{
+			name:     "pushes branch",
+			wantPush: true,
+		},
+		{
+			name:    "push fails",
+			pushErr: errors.New("rejected"),
+			wantErr: "failed to push feature/login to origin",
+		},
+		{
+			name:     "no push with upstream",
+			noPush:   true,
+			upstream: "origin/feature/login\n",
+		},
+		{
+			name:    "no push without upstream",
+			noPush:  true,
+			wantErr: "branch feature/login has not been pushed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &fakeGitRunner{
+				outputs: map[string]string{
+					"rev-parse --abbrev-ref HEAD":                     "feature/login\n",
+					"rev-parse --abbrev-ref feature/login@{upstream}": tt.upstream,
+				},
+				runErr: tt.pushErr,
+			}
+			cm := git.NewCommitManagerWithRunner(".", false, runner)
+
+			var gotHead string
+			mockClient := &mockGHClient{
+				isAuthenticated: true,
+				createPRFunc: func(ctx context.Context, opts github.CreatePROptions) (*github.PRInfo, error) {
+					gotHead = opts.HeadBranch
+					return &github.PRInfo{Number: 1, Title: opts.Title}, nil
+				},
+			}
+
+			err := runPRCreate(CreateOptions{Title: "Login", NoPush: tt.noPush, NoBrowser: true}, mockClient, cm, &config.Config{})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("runPRCreate() error = %v, want containing %q", err, tt.wantErr)
+				}
+				if gotHead != "" {
+					t.Error("CreatePR should not be called when the branch is not on origin")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runPRCreate() unexpected error: %v", err)
+			}
+
+			pushed := len(runner.runs) == 1 && strings.Join(runner.runs[0], " ") == "push --set-upstream origin feature/login"
+			if pushed != tt.wantPush {
+				t.Errorf("pushed = %v, want %v (runs: %v)", pushed, tt.wantPush, runner.runs)
+			}
+			if gotHead != "feature/login" {
+				t.Errorf("HeadBranch = %q, want %q", gotHead, "feature/login")
+			}
+		})
+	}
+}
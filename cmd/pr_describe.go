@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"thoreinstein.com/rig/pkg/ai"
+	"thoreinstein.com/rig/pkg/config"
+	rigerrors "thoreinstein.com/rig/pkg/errors"
+	"thoreinstein.com/rig/pkg/git"
+)
+
+// prDescribeSystemPrompt asks the provider for a title line followed by a body
+const prDescribeSystemPrompt = `You write GitHub pull request descriptions from a list of commits.
+
+Respond with:
+- First line: a concise PR title (at most 72 characters, no trailing period, no "Title:" prefix)
+- A blank line
+- A Markdown body with a short "## Summary" of what changed and why, followed by a "## Changes" bullet list
+
+Output only the title and body, with no code fences or commentary.`
+
+type PRDescribeOptions struct {
+	Base     string
+	Template string
+}
+
+var prDescribeOptions PRDescribeOptions
+
+// prDescribeCmd generates a PR title and body from the branch's commits.
+var prDescribeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Generate a PR title and description with AI",
+	Long: `Generate a pull request title and description for the current branch.
+
+Collects the commits between the base branch and HEAD and asks the configured
+AI provider to summarize them. The base branch is git.base_branch when set,
+otherwise the repository's detected default branch.
+
+The title is printed on the first line, followed by a blank line and the body,
+so the output can be piped into other tools. Progress messages go to stderr.
+
+Examples:
+  rig pr describe
+  rig pr describe --base develop
+  rig pr describe --template .github/pull_request_template.md
+  rig pr describe > /tmp/pr.md`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return rigerrors.NewConfigErrorWithCause("", "failed to load configuration", err)
+		}
+
+		provider, err := ai.NewProvider(&cfg.AI, verbose)
+		if err != nil {
+			return err
+		}
+
+		base := prDescribeOptions.Base
+		if base == "" {
+			base, err = defaultPRBase(cfg)
+			if err != nil {
+				return err
+			}
+		}
+
+		var template string
+		if prDescribeOptions.Template != "" {
+			data, err := os.ReadFile(prDescribeOptions.Template)
+			if err != nil {
+				return rigerrors.Wrapf(err, "failed to read template %s", prDescribeOptions.Template)
+			}
+			template = string(data)
+		}
+
+		commitManager := git.NewCommitManager(".", verbose)
+
+		return runPRDescribe(context.Background(), commitManager, provider, base, template, os.Stdout, os.Stderr)
+	},
+}
+
+func init() {
+	prCmd.AddCommand(prDescribeCmd)
+
+	prDescribeCmd.Flags().StringVar(&prDescribeOptions.Base, "base", "", "Base branch (defaults to git.base_branch or the detected default)")
+	prDescribeCmd.Flags().StringVar(&prDescribeOptions.Template, "template", "", "PR template file to prepend to the body")
+}
+
+// defaultPRBase returns git.base_branch when set, otherwise the repository's
+// detected default branch
+func defaultPRBase(cfg *config.Config) (string, error) {
+	gitManager := git.NewWorktreeManager(cfg.Git.BaseBranch, verbose)
+	base, err := gitManager.GetDefaultBranch()
+	if err != nil {
+		return "", rigerrors.Wrap(err, "failed to detect base branch (use --base)")
+	}
+	return base, nil
+}
+
+// runPRDescribe writes a generated title and body to out. Status and token
+// usage go to errOut so out stays clean for piping.
+func runPRDescribe(ctx context.Context, cm *git.CommitManager, provider ai.Provider, base, template string, out, errOut io.Writer) error {
+	title, body, err := generatePRDescription(ctx, cm, provider, base, template, errOut)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, title)
+	if body != "" {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, body)
+	}
+
+	return nil
+}
+
+// generatePRDescription asks provider for a title and body describing the
+// commits since base, with template prepended to the body when set
+func generatePRDescription(ctx context.Context, cm *git.CommitManager, provider ai.Provider, base, template string, errOut io.Writer) (string, string, error) {
+	log, err := cm.CommitLog(base)
+	if err != nil {
+		return "", "", err
+	}
+	if strings.TrimSpace(log) == "" {
+		return "", "", rigerrors.Newf("no commits between %s and HEAD", base)
+	}
+
+	fmt.Fprintf(errOut, "Describing commits since %s with %s...\n", base, provider.Name())
+
+	resp, err := provider.Chat(ctx, []ai.Message{
+		{Role: "system", Content: prDescribeSystemPrompt},
+		{Role: "user", Content: "Commits on this branch (oldest first):\n\n" + log},
+	})
+	if err != nil {
+		return "", "", rigerrors.Wrap(err, "failed to generate PR description")
+	}
+
+	if verbose {
+		fmt.Fprintf(errOut, "Tokens: %d input, %d output\n", resp.InputTokens, resp.OutputTokens)
+	}
+
+	title, body := splitPRDescription(cleanCommitMessage(resp.Content))
+	if title == "" {
+		return "", "", rigerrors.New("AI provider returned an empty description")
+	}
+
+	if strings.TrimSpace(template) != "" {
+		body = strings.TrimSpace(template) + "\n\n" + body
+	}
+
+	return title, body, nil
+}
+
+// splitPRDescription separates the first line (title) from the rest (body),
+// dropping a "Title:" prefix if the model added one anyway
+func splitPRDescription(content string) (string, string) {
+	title, body, _ := strings.Cut(content, "\n")
+
+	title = strings.TrimSpace(title)
+	title = strings.TrimPrefix(title, "Title:")
+	title = strings.TrimSpace(strings.TrimLeft(title, "# "))
+
+	return title, strings.TrimSpace(body)
+}
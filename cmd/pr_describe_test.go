@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"thoreinstein.com/rig/pkg/git"
+)
+
+func TestSplitPRDescription(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantTitle string
+		wantBody  string
+	}{
+		{"title and body", "Add widgets\n\n## Summary\nStuff", "Add widgets", "## Summary\nStuff"},
+		{"title only", "Add widgets", "Add widgets", ""},
+		{"title prefix", "Title: Add widgets\n\nBody", "Add widgets", "Body"},
+		{"markdown heading", "# Add widgets\n\nBody", "Add widgets", "Body"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, body := splitPRDescription(tt.content)
+			if title != tt.wantTitle || body != tt.wantBody {
+				t.Errorf("splitPRDescription() = (%q, %q), want (%q, %q)", title, body, tt.wantTitle, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestRunPRDescribe(t *testing.T) {
+	runner := &fakeGitRunner{outputs: map[string]string{
+		"log --reverse --no-color --format=commit %h%n%s%n%n%b main..HEAD": "commit abc123\nfeat: add widgets\n\n",
+	}}
+	cm := git.NewCommitManagerWithRunner(".", false, runner)
+	provider := &fakeAIProvider{replies: []string{"Add widgets\n\n## Summary\nAdds widgets."}}
+
+	var out, errOut bytes.Buffer
+	err := runPRDescribe(context.Background(), cm, provider, "main", "## Checklist\n- [ ] Tests\n", &out, &errOut)
+	if err != nil {
+		t.Fatalf("runPRDescribe() error = %v", err)
+	}
+
+	want := "Add widgets\n\n## Checklist\n- [ ] Tests\n\n## Summary\nAdds widgets.\n"
+	if out.String() != want {
+		t.Errorf("output =\n%q\nwant\n%q", out.String(), want)
+	}
+	if !strings.Contains(provider.received[0][1].Content, "feat: add widgets") {
+		t.Error("prompt should include the commit log")
+	}
+	if !strings.Contains(errOut.String(), "since main") {
+		t.Errorf("status should go to stderr, got %q", errOut.String())
+	}
+}
+
+func TestRunPRDescribe_NoCommits(t *testing.T) {
+	runner := &fakeGitRunner{outputs: map[string]string{}}
+	cm := git.NewCommitManagerWithRunner(".", false, runner)
+
+	var out, errOut bytes.Buffer
+	err := runPRDescribe(context.Background(), cm, &fakeAIProvider{}, "main", "", &out, &errOut)
+	if err == nil || !strings.Contains(err.Error(), "no commits") {
+		t.Errorf("runPRDescribe() error = %v, want no commits", err)
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
 	"strconv"
 	"strings"
@@ -222,12 +223,12 @@ func stateToIcon(state string) string {
 	}
 }
 
-// checkMark returns a check mark symbol.
+// checkMark returns a check mark symbol for stdout.
 func checkMark() string {
-	return "\u2713" // ✓
+	return outputStyle(os.Stdout).CheckMark()
 }
 
-// crossMark returns a cross mark symbol.
+// crossMark returns a cross mark symbol for stdout.
 func crossMark() string {
-	return "\u2717" // ✗
+	return outputStyle(os.Stdout).CrossMark()
 }
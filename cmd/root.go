@@ -5,16 +5,24 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"thoreinstein.com/rig/pkg/config"
+	rlog "thoreinstein.com/rig/pkg/log"
+	"thoreinstein.com/rig/pkg/ui"
 )
 
 var cfgFile string
 var verbose bool
 var appConfig *config.Config
+var logLevel string
+var logFormat string
+var colorFlag string
+var colorMode ui.ColorMode
+var networkTimeout time.Duration
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -46,8 +54,12 @@ func init() {
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/rig/config.toml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $XDG_CONFIG_HOME/rig/config.toml, falling back to $HOME/.config/rig/config.toml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "diagnostic log level: debug, info, warn, error (default info, debug with --verbose)")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "diagnostic log format: text or json")
+	rootCmd.PersistentFlags().StringVar(&colorFlag, "color", "auto", "colorize output: auto, always, never (auto honors NO_COLOR)")
+	rootCmd.PersistentFlags().DurationVar(&networkTimeout, "timeout", 0, "deadline for Jira, AI and git network operations, e.g. 30s (overrides network.timeout)")
 
 	// Remove the example toggle flag
 	// rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
@@ -56,53 +68,84 @@ func init() {
 // initConfig reads in config file and ENV variables if set.
 // Config precedence (highest to lowest):
 // 1. Environment variables (RIG_*)
-// 2. Repository-local config (.rig.toml in current dir or git root)
-// 3. User config (~/.config/rig/config.toml)
-// 4. Defaults
+// 2. Secrets file (secrets_file, credential keys only)
+// 3. Repository-local config (.rig.toml or .rig.yaml in current dir or git root)
+// 4. User config (config.toml, config.yaml or config.json in $XDG_CONFIG_HOME/rig, then $XDG_CONFIG_DIRS)
+// 5. Defaults
 func initConfig() {
+	// Diagnostics always go to stderr so stdout stays clean for command output
+	cobra.CheckErr(rlog.Setup(effectiveLogLevel(logLevel, verbose), logFormat))
+
+	var err error
+	colorMode, err = ui.ParseColorMode(colorFlag)
+	cobra.CheckErr(err)
+
 	if cfgFile != "" {
-		// Use config file from the flag.
+		// Use config file from the flag; viper detects the format from its extension.
 		viper.SetConfigFile(cfgFile)
 	} else {
-		// Find home directory.
-		home, err := os.UserHomeDir()
+		// Search the XDG config directories, user config first. The format
+		// is taken from the extension of whichever file is found.
+		dirs, err := config.ConfigSearchDirs()
 		cobra.CheckErr(err)
-
-		// Search config in home directory with name ".config/rig" (without extension).
-		viper.AddConfigPath(home + "/.config/rig")
-		viper.SetConfigType("toml")
-		viper.SetConfigName("config")
+		if path := config.FindConfigFile(dirs); path != "" {
+			viper.SetConfigFile(path)
+		}
 	}
 
 	viper.SetEnvPrefix("RIG")                              // Only bind RIG_* environment variables
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_")) // RIG_NOTES_PATH -> notes.path
 	viper.AutomaticEnv()                                   // read in environment variables that match
 
+	// --timeout outranks config and RIG_NETWORK_TIMEOUT
+	if networkTimeout > 0 {
+		viper.Set("network.timeout", networkTimeout)
+	}
+
 	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil && verbose {
-		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+	if err := viper.ReadInConfig(); err == nil {
+		rlog.Debug("Using config file: " + viper.ConfigFileUsed())
 	}
 
-	// Load repository-local config (.rig.toml) if present
+	// Load repository-local config (.rig.toml or .rig.yaml) if present
 	// This merges on top of the user config, allowing per-repo overrides
 	loadRepoLocalConfig()
 
+	// Load credentials from secrets_file if configured
+	secretKeys := loadSecretsFile()
+
+	for _, old := range config.DeprecatedKeys(viper.IsSet) {
+		rlog.Debug("deprecated config key, run 'rig config migrate'", "key", old, "replacement", config.RenamedKeys[old])
+	}
+
 	// Check for security warnings (tokens in config file)
-	var err error
 	appConfig, err = config.Load()
 	if err != nil {
-		if verbose {
-			fmt.Fprintf(os.Stderr, "Warning: could not load config for security checks: %v\n", err)
-		}
+		rlog.Debug("could not load config for security checks", "error", err)
 		return
 	}
 
 	warnings := config.CheckSecurityWarnings(appConfig)
 	for _, w := range warnings {
+		if secretKeys[w.Field] {
+			continue // Stored in the secrets file, which is where it belongs
+		}
 		fmt.Fprintf(os.Stderr, "Warning: %s\n", w.Message)
 	}
 }
 
+// effectiveLogLevel returns the --log-level value, defaulting to debug when
+// --verbose is set and info otherwise
+func effectiveLogLevel(level string, verbose bool) string {
+	if level != "" {
+		return level
+	}
+	if verbose {
+		return "debug"
+	}
+	return "info"
+}
+
 // loadConfig returns the already loaded configuration or loads it if it hasn't been yet.
 // In test environments (detected via GO_TEST env var), it always reloads to ensure test isolation.
 func loadConfig() (*config.Config, error) {
@@ -118,26 +161,10 @@ func resetConfig() {
 	appConfig = nil
 }
 
-// loadRepoLocalConfig loads .rig.toml from current directory or git root.
+// loadRepoLocalConfig loads .rig.toml or .rig.yaml from current directory or git root.
 // Values from the local config merge on top of the user config.
 func loadRepoLocalConfig() {
-	var localConfigPaths []string
-
-	// Try to find git root first (parent config)
-	if gitRoot, err := findGitRoot(); err == nil && gitRoot != "" {
-		localConfigPaths = append(localConfigPaths, filepath.Join(gitRoot, ".rig.toml"))
-
-		// If we are not in the root, also check current directory (child config)
-		cwd, _ := os.Getwd()
-		if cwd != gitRoot {
-			localConfigPaths = append(localConfigPaths, ".rig.toml")
-		}
-	} else {
-		// Fallback if no git root found
-		localConfigPaths = append(localConfigPaths, ".rig.toml")
-	}
-
-	for _, configPath := range localConfigPaths {
+	for _, configPath := range repoLocalConfigPaths() {
 		if _, err := os.Stat(configPath); err == nil {
 			// Create a new viper instance to read the local config
 			localViper := viper.New()
@@ -164,6 +191,86 @@ func loadRepoLocalConfig() {
 	}
 }
 
+// loadSecretsFile merges credential keys from the file named by secrets_file.
+// It is loaded after all other config files so it wins over them, while
+// environment variables still take precedence. Returns the dotted keys that
+// were loaded.
+func loadSecretsFile() map[string]bool {
+	path := viper.GetString("secrets_file")
+	if path == "" {
+		return nil
+	}
+
+	secrets, ignored, err := config.LoadSecretsFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return nil
+	}
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "Using secrets file: %s\n", path)
+		if worldReadable, err := config.IsWorldReadable(path); err == nil && worldReadable {
+			fmt.Fprintf(os.Stderr, "Warning: secrets file %s is world-readable; run: chmod 600 %s\n", path, path)
+		}
+		for _, key := range ignored {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring non-credential key %q in secrets file\n", key)
+		}
+	}
+
+	if err := viper.MergeConfigMap(secrets); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not merge secrets file: %v\n", err)
+		return nil
+	}
+
+	keys := make(map[string]bool)
+	for section, values := range secrets {
+		if m, ok := values.(map[string]interface{}); ok {
+			for key := range m {
+				keys[section+"."+key] = true
+			}
+		}
+	}
+	return keys
+}
+
+// repoLocalConfigNames lists the repository config file names, in priority
+// order. Only the first one present in a directory is loaded.
+var repoLocalConfigNames = []string{".rig.toml", ".rig.yaml"}
+
+// repoLocalConfigPaths returns the candidate repository config paths in merge
+// order: the git root first, then the current directory when it differs.
+func repoLocalConfigPaths() []string {
+	var localConfigPaths []string
+
+	// Try to find git root first (parent config)
+	if gitRoot, err := findGitRoot(); err == nil && gitRoot != "" {
+		localConfigPaths = append(localConfigPaths, repoLocalConfigIn(gitRoot))
+
+		// If we are not in the root, also check current directory (child config)
+		cwd, _ := os.Getwd()
+		if cwd != gitRoot {
+			localConfigPaths = append(localConfigPaths, repoLocalConfigIn(""))
+		}
+	} else {
+		// Fallback if no git root found
+		localConfigPaths = append(localConfigPaths, repoLocalConfigIn(""))
+	}
+
+	return localConfigPaths
+}
+
+// repoLocalConfigIn returns the repository config file in dir, or the
+// .rig.toml path when none exists. An empty dir means the current directory.
+func repoLocalConfigIn(dir string) string {
+	for _, name := range repoLocalConfigNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dir, repoLocalConfigNames[0])
+}
+
 // findGitRoot finds the root of the current git repository
 func findGitRoot() (string, error) {
 	cwd, err := os.Getwd()
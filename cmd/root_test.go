@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -184,6 +185,76 @@ base_branch = "develop"
 	}
 }
 
+func TestInitConfig_XDGConfigHome(t *testing.T) {
+	// Don't run in parallel - modifies global viper state
+	tmpDir := t.TempDir()
+	xdgHome := filepath.Join(tmpDir, "xdg")
+
+	configDir := filepath.Join(xdgHome, "rig")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte("[git]\nbase_branch = \"xdg\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	// A config in the HOME fallback must be ignored when XDG_CONFIG_HOME is set
+	homeConfigDir := filepath.Join(tmpDir, ".config", "rig")
+	if err := os.MkdirAll(homeConfigDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(homeConfigDir, "config.toml"), []byte("[git]\nbase_branch = \"home\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", xdgHome)
+
+	oldCfgFile := cfgFile
+	cfgFile = ""
+	defer func() { cfgFile = oldCfgFile }()
+
+	initConfig()
+
+	if got := viper.GetString("git.base_branch"); got != "xdg" {
+		t.Errorf("git.base_branch = %q, want %q from XDG_CONFIG_HOME", got, "xdg")
+	}
+}
+
+func TestInitConfig_XDGConfigDirs(t *testing.T) {
+	// Don't run in parallel - modifies global viper state
+	tmpDir := t.TempDir()
+	systemDir := filepath.Join(tmpDir, "etc", "xdg")
+
+	configDir := filepath.Join(systemDir, "rig")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte("[git]\nbase_branch = \"system\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+
+	// No user config exists, so the system-wide one is used
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_DIRS", systemDir)
+
+	oldCfgFile := cfgFile
+	cfgFile = ""
+	defer func() { cfgFile = oldCfgFile }()
+
+	initConfig()
+
+	if got := viper.GetString("git.base_branch"); got != "system" {
+		t.Errorf("git.base_branch = %q, want %q from XDG_CONFIG_DIRS", got, "system")
+	}
+}
+
 func TestInitConfig_NoConfigFile(t *testing.T) {
 	// Don't run in parallel - modifies global viper state
 	tmpDir := t.TempDir()
@@ -490,6 +561,113 @@ key = "toml_value"
 	}
 }
 
+func TestInitConfig_DefaultLocationFormats(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name:  "yaml",
+			files: map[string]string{"config.yaml": "git:\n  base_branch: from-yaml\n"},
+			want:  "from-yaml",
+		},
+		{
+			name:  "json",
+			files: map[string]string{"config.json": `{"git": {"base_branch": "from-json"}}`},
+			want:  "from-json",
+		},
+		{
+			name: "toml wins over yaml and json",
+			files: map[string]string{
+				"config.toml": "[git]\nbase_branch = \"from-toml\"\n",
+				"config.yaml": "git:\n  base_branch: from-yaml\n",
+				"config.json": `{"git": {"base_branch": "from-json"}}`,
+			},
+			want: "from-toml",
+		},
+		{
+			name: "yaml wins over json",
+			files: map[string]string{
+				"config.yaml": "git:\n  base_branch: from-yaml\n",
+				"config.json": `{"git": {"base_branch": "from-json"}}`,
+			},
+			want: "from-yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Don't run in parallel - modifies global viper state
+			tmpDir := t.TempDir()
+			configDir := filepath.Join(tmpDir, ".config", "rig")
+			if err := os.MkdirAll(configDir, 0755); err != nil {
+				t.Fatalf("Failed to create config dir: %v", err)
+			}
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(configDir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", name, err)
+				}
+			}
+
+			viper.Reset()
+			defer viper.Reset()
+
+			t.Setenv("HOME", tmpDir)
+			t.Chdir(tmpDir)
+
+			oldCfgFile := cfgFile
+			cfgFile = ""
+			defer func() { cfgFile = oldCfgFile }()
+
+			initConfig()
+
+			if got := viper.GetString("git.base_branch"); got != tt.want {
+				t.Errorf("git.base_branch = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInitConfig_ConfigFlagFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{name: "yaml", file: "custom.yaml", content: "notes:\n  path: /flag/notes\n"},
+		{name: "yml", file: "custom.yml", content: "notes:\n  path: /flag/notes\n"},
+		{name: "json", file: "custom.json", content: `{"notes": {"path": "/flag/notes"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Don't run in parallel - modifies global viper state
+			tmpDir := t.TempDir()
+			configPath := filepath.Join(tmpDir, tt.file)
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			viper.Reset()
+			defer viper.Reset()
+
+			t.Setenv("HOME", tmpDir)
+			t.Chdir(tmpDir)
+
+			oldCfgFile := cfgFile
+			cfgFile = configPath
+			defer func() { cfgFile = oldCfgFile }()
+
+			initConfig()
+
+			if got := viper.GetString("notes.path"); got != "/flag/notes" {
+				t.Errorf("notes.path = %q, want %q", got, "/flag/notes")
+			}
+		})
+	}
+}
+
 // Note: containsSubstring helper is already defined in work_test.go
 
 func TestInitConfig_TMUXEnvVarDoesNotOverrideConfig(t *testing.T) {
@@ -743,6 +921,52 @@ ollama_model = "codellama"
 	}
 }
 
+func TestLoadRepoLocalConfig_YAML(t *testing.T) {
+	// Don't run in parallel - modifies global viper state
+	tmpDir := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".git"), 0755); err != nil {
+		t.Fatalf("Failed to create .git dir: %v", err)
+	}
+
+	rigConfig := `github:
+  default_merge_method: rebase
+ai:
+  provider: ollama
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".rig.yaml"), []byte(rigConfig), 0644); err != nil {
+		t.Fatalf("Failed to write .rig.yaml: %v", err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+
+	t.Chdir(tmpDir)
+
+	loadRepoLocalConfig()
+
+	if got := viper.GetString("github.default_merge_method"); got != "rebase" {
+		t.Errorf("github.default_merge_method = %q, want %q", got, "rebase")
+	}
+	if got := viper.GetString("ai.provider"); got != "ollama" {
+		t.Errorf("ai.provider = %q, want %q", got, "ollama")
+	}
+
+	// .rig.toml takes priority when both exist
+	if err := os.WriteFile(filepath.Join(tmpDir, ".rig.toml"), []byte("[ai]\nprovider = \"groq\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write .rig.toml: %v", err)
+	}
+	viper.Reset()
+	loadRepoLocalConfig()
+
+	if got := viper.GetString("ai.provider"); got != "groq" {
+		t.Errorf("ai.provider = %q, want %q from .rig.toml", got, "groq")
+	}
+	if viper.IsSet("github.default_merge_method") {
+		t.Error(".rig.yaml should not be loaded when .rig.toml exists")
+	}
+}
+
 func TestLoadRepoLocalConfig_FromSubdirectory(t *testing.T) {
 	// Don't run in parallel - modifies global viper state
 	tmpDir := t.TempDir()
@@ -1515,3 +1739,154 @@ path = "/fallback/path"
 		})
 	}
 }
+
+func TestConfigPrecedence_SecretsFile(t *testing.T) {
+	// Don't run in parallel - modifies global viper state
+	// Tests: env var > secrets file > user config, for credential keys only
+	tmpDir := t.TempDir()
+
+	userConfigDir := filepath.Join(tmpDir, ".config", "rig")
+	if err := os.MkdirAll(userConfigDir, 0755); err != nil {
+		t.Fatalf("Failed to create user config dir: %v", err)
+	}
+
+	secretsPath := filepath.Join(userConfigDir, "secrets.toml")
+	secrets := `[jira]
+token = "file-jira-token"
+email = "ignored@example.com"
+
+[github]
+token = "file-github-token"
+`
+	if err := os.WriteFile(secretsPath, []byte(secrets), 0600); err != nil {
+		t.Fatalf("Failed to write secrets file: %v", err)
+	}
+
+	userConfig := `secrets_file = "` + secretsPath + `"
+
+[jira]
+token = "config-jira-token"
+email = "user@example.com"
+`
+	if err := os.WriteFile(filepath.Join(userConfigDir, "config.toml"), []byte(userConfig), 0644); err != nil {
+		t.Fatalf("Failed to write user config: %v", err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("RIG_GITHUB_TOKEN", "env-github-token")
+
+	oldCfgFile := cfgFile
+	cfgFile = ""
+	defer func() { cfgFile = oldCfgFile }()
+
+	t.Chdir(tmpDir)
+
+	initConfig()
+
+	if got := viper.GetString("jira.token"); got != "file-jira-token" {
+		t.Errorf("jira.token = %q, want %q (secrets file should override user config)", got, "file-jira-token")
+	}
+	if got := viper.GetString("github.token"); got != "env-github-token" {
+		t.Errorf("github.token = %q, want %q (env var should override secrets file)", got, "env-github-token")
+	}
+	if got := viper.GetString("jira.email"); got != "user@example.com" {
+		t.Errorf("jira.email = %q, want %q (non-credential keys in secrets file are ignored)", got, "user@example.com")
+	}
+}
+
+func TestLoadSecretsFile_WorldReadableVerbose(t *testing.T) {
+	tmpDir := t.TempDir()
+	secretsPath := filepath.Join(tmpDir, "secrets.env")
+	if err := os.WriteFile(secretsPath, []byte("RIG_JIRA_TOKEN=abc\n"), 0600); err != nil {
+		t.Fatalf("Failed to write secrets file: %v", err)
+	}
+	if err := os.Chmod(secretsPath, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("secrets_file", secretsPath)
+
+	oldVerbose := verbose
+	verbose = true
+	defer func() { verbose = oldVerbose }()
+
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	keys := loadSecretsFile()
+
+	w.Close()
+	os.Stderr = oldStderr
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+
+	if !keys["jira.token"] {
+		t.Errorf("loadSecretsFile() keys = %v, want jira.token", keys)
+	}
+	if !strings.Contains(buf.String(), "world-readable") {
+		t.Errorf("expected world-readable warning, got: %q", buf.String())
+	}
+}
+
+func TestEffectiveLogLevel(t *testing.T) {
+	tests := []struct {
+		level   string
+		verbose bool
+		want    string
+	}{
+		{"", false, "info"},
+		{"", true, "debug"},
+		{"warn", true, "warn"},
+		{"error", false, "error"},
+	}
+
+	for _, tt := range tests {
+		if got := effectiveLogLevel(tt.level, tt.verbose); got != tt.want {
+			t.Errorf("effectiveLogLevel(%q, %v) = %q, want %q", tt.level, tt.verbose, got, tt.want)
+		}
+	}
+
+	for _, name := range []string{"log-level", "log-format"} {
+		if rootCmd.PersistentFlags().Lookup(name) == nil {
+			t.Errorf("root command should have persistent --%s flag", name)
+		}
+	}
+}
+
+func TestNetworkContext(t *testing.T) {
+	if flag := rootCmd.PersistentFlags().Lookup("timeout"); flag == nil {
+		t.Fatal("root command should have persistent --timeout flag")
+	}
+
+	ctx, cancel := networkContext(0)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("networkContext() should have no deadline without a timeout")
+	}
+
+	ctx, cancel = networkContext(time.Minute)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("networkContext() should carry the timeout as a deadline")
+	}
+	if remaining := time.Until(deadline); remaining <= 0 || remaining > time.Minute {
+		t.Errorf("deadline in %s, want within %s", remaining, time.Minute)
+	}
+}
+
+func TestColorFlag(t *testing.T) {
+	flag := rootCmd.PersistentFlags().Lookup("color")
+	if flag == nil {
+		t.Fatal("root command should have persistent --color flag")
+	}
+	if flag.DefValue != "auto" {
+		t.Errorf("--color default = %q, want %q", flag.DefValue, "auto")
+	}
+}
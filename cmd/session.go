@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/cockroachdb/errors"
@@ -24,9 +27,12 @@ created by the rig workflow.`,
 var sessionListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all tmux sessions",
-	Long:  `List all active tmux sessions.`,
+	Long: `List all active tmux sessions with their window count, attached state,
+and creation time. The current session is marked with an arrow.
+
+Use --json for machine-readable output.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runSessionListCommand()
+		return runSessionListCommand(cmd.OutOrStdout())
 	},
 }
 
@@ -34,8 +40,12 @@ var sessionListCmd = &cobra.Command{
 var sessionAttachCmd = &cobra.Command{
 	Use:   "attach <ticket>",
 	Short: "Attach to a tmux session for a ticket",
-	Long:  `Attach to an existing tmux session for the specified ticket.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Attach to an existing tmux session for the specified ticket.
+
+When run from inside tmux, the current client is switched to the session
+instead of nesting a new attach.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTickets,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runSessionAttachCommand(args[0])
 	},
@@ -45,21 +55,83 @@ var sessionAttachCmd = &cobra.Command{
 var sessionKillCmd = &cobra.Command{
 	Use:   "kill <ticket>",
 	Short: "Kill a tmux session for a ticket",
-	Long:  `Kill the tmux session associated with the specified ticket.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Kill the tmux session associated with the specified ticket.
+
+Use --all instead of a ticket to kill every session whose name starts with
+the configured tmux.session_prefix. Sessions without the prefix are never
+touched, and --all refuses to run when no prefix is configured. Combine
+with --dry-run to list the sessions that would be killed.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if sessionKillAll {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	ValidArgsFunction: completeTickets,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if sessionKillAll {
+			return runSessionKillAllCommand(sessionKillDryRun)
+		}
 		return runSessionKillCommand(args[0])
 	},
 }
 
+// sessionRenameCmd renames a tmux session
+var sessionRenameCmd = &cobra.Command{
+	Use:   "rename <old-ticket> <new-ticket>",
+	Short: "Rename the tmux session for a re-keyed ticket",
+	Long: `Rename the tmux session for a ticket, e.g. after the ticket moved to
+another Jira project. The configured session prefix is applied to both names.`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeTickets,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSessionRenameCommand(args[0], args[1])
+	},
+}
+
+// sessionSendCmd sends a command to a tmux session
+var sessionSendCmd = &cobra.Command{
+	Use:   "send <ticket> -- <command...>",
+	Short: "Type a command into a ticket's tmux session",
+	Long: `Type a command into the active pane of the tmux session for a ticket
+and press Enter, as if typed by hand.
+
+Separate the command from rig's own flags with --. Arguments are quoted
+for the shell when rejoined, so the following are equivalent:
+
+  rig session send PROJ-123 -- git commit -m "fix bug"
+  rig session send PROJ-123 -- 'git commit -m "fix bug"'
+
+Use --no-enter to type the command without submitting it.`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeTickets,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSessionSendCommand(args[0], args[1:], sessionSendNoEnter)
+	},
+}
+
+var (
+	sessionSendNoEnter bool
+	sessionListJSON    bool
+	sessionKillAll     bool
+	sessionKillDryRun  bool
+)
+
 func init() {
 	rootCmd.AddCommand(sessionCmd)
 	sessionCmd.AddCommand(sessionListCmd)
 	sessionCmd.AddCommand(sessionAttachCmd)
 	sessionCmd.AddCommand(sessionKillCmd)
+	sessionCmd.AddCommand(sessionRenameCmd)
+	sessionCmd.AddCommand(sessionSendCmd)
+
+	sessionListCmd.Flags().BoolVar(&sessionListJSON, "json", false, "output sessions as JSON")
+	sessionKillCmd.Flags().BoolVar(&sessionKillAll, "all", false, "kill every session matching tmux.session_prefix")
+	sessionKillCmd.Flags().BoolVar(&sessionKillDryRun, "dry-run", false, "list sessions that --all would kill without killing them")
+	sessionSendCmd.Flags().BoolVar(&sessionSendNoEnter, "no-enter", false, "type the command without pressing Enter")
 }
 
-func runSessionListCommand() error {
+func runSessionListCommand(w io.Writer) error {
 	cfg, err := loadConfig()
 	if err != nil {
 		return errors.Wrap(err, "failed to load configuration")
@@ -71,21 +143,56 @@ func runSessionListCommand() error {
 		return errors.Wrap(err, "failed to list sessions")
 	}
 
+	if sessionListJSON {
+		if sessions == nil {
+			sessions = []tmux.SessionInfo{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(sessions)
+	}
+
+	// Current session is only known when running inside tmux
+	current, _ := sessionManager.CurrentSessionName()
+	formatSessionList(w, sessions, current)
+
+	return nil
+}
+
+// formatSessionList writes one line per session with its window count,
+// attached state and creation time, marking the current session with an arrow
+func formatSessionList(w io.Writer, sessions []tmux.SessionInfo, current string) {
 	if len(sessions) == 0 {
-		fmt.Println("No tmux sessions found.")
-		return nil
+		fmt.Fprintln(w, "No tmux sessions found.")
+		return
 	}
 
-	fmt.Println("Active tmux sessions:")
-	for i, session := range sessions {
+	style := outputStyle(w)
+
+	fmt.Fprintln(w, "Active tmux sessions:")
+	for _, session := range sessions {
 		prefix := "  "
-		if i == 0 {
-			prefix = "→ "
+		if session.Name == current {
+			prefix = style.Arrow() + " "
 		}
-		fmt.Printf("%s%s\n", prefix, session)
-	}
 
-	return nil
+		windows := fmt.Sprintf("%d windows", session.Windows)
+		if session.Windows == 1 {
+			windows = "1 window"
+		}
+
+		state := "detached"
+		if session.Attached {
+			state = "attached"
+		}
+
+		created := "-"
+		if !session.Created.IsZero() {
+			created = session.Created.Format("2006-01-02 15:04")
+		}
+
+		fmt.Fprintf(w, "%s%-30s %-10s %-8s  created %s\n", prefix, session.Name, windows, state, created)
+	}
 }
 
 func runSessionAttachCommand(ticket string) error {
@@ -110,10 +217,6 @@ func runSessionAttachCommand(ticket string) error {
 		return errors.Newf("tmux session '%s' does not exist for ticket '%s'", sessionName, ticket)
 	}
 
-	if verbose {
-		fmt.Printf("Attaching to session: %s\n", sessionName)
-	}
-
 	return sessionManager.AttachToSession(sessionName)
 }
 
@@ -146,6 +249,137 @@ func runSessionKillCommand(ticket string) error {
 		return errors.Wrap(err, "failed to kill session")
 	}
 
-	fmt.Printf("✓ Session for ticket '%s' killed successfully.\n", ticket)
+	fmt.Printf("%s Session for ticket '%s' killed successfully.\n", outputStyle(os.Stdout).CheckMark(), ticket)
+	return nil
+}
+
+func runSessionKillAllCommand(dryRun bool) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	if cfg.Tmux.SessionPrefix == "" {
+		return errors.New("--all requires tmux.session_prefix to be set, otherwise every tmux session would match")
+	}
+
+	sessionManager := tmux.NewSessionManager(cfg.Tmux.SessionPrefix, nil, verbose)
+
+	sessions, err := sessionManager.ListSessions()
+	if err != nil {
+		return errors.Wrap(err, "failed to list sessions")
+	}
+
+	managed := sessionManager.ManagedSessions(sessions)
+	if len(managed) == 0 {
+		fmt.Printf("No sessions matching prefix '%s' found.\n", cfg.Tmux.SessionPrefix)
+		return nil
+	}
+
+	if dryRun {
+		fmt.Printf("Would kill %d session(s):\n", len(managed))
+		for _, session := range managed {
+			fmt.Printf("  %s\n", session.Name)
+		}
+		return nil
+	}
+
+	var failed []string
+	for _, session := range managed {
+		if err := sessionManager.KillSession(sessionManager.TicketFromSessionName(session.Name)); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to kill session %s: %v\n", session.Name, err)
+			failed = append(failed, session.Name)
+			continue
+		}
+		fmt.Printf("%s Killed session %s\n", outputStyle(os.Stdout).CheckMark(), session.Name)
+	}
+
+	fmt.Printf("Killed %d of %d session(s).\n", len(managed)-len(failed), len(managed))
+	if len(failed) > 0 {
+		return errors.Newf("failed to kill %d session(s): %s", len(failed), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func runSessionRenameCommand(oldTicket, newTicket string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	// Parse tickets to handle optional project prefix
+	oldInfo, err := parseTicket(oldTicket)
+	if err != nil {
+		return err
+	}
+	newInfo, err := parseTicket(newTicket)
+	if err != nil {
+		return err
+	}
+
+	sessionManager := tmux.NewSessionManager(cfg.Tmux.SessionPrefix, nil, verbose)
+
+	err = sessionManager.RenameSession(oldInfo.SessionID(), newInfo.SessionID())
+	if err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			fmt.Printf("Session for ticket '%s' does not exist.\n", oldTicket)
+			return nil
+		}
+		return errors.Wrap(err, "failed to rename session")
+	}
+
+	fmt.Printf("%s Session for ticket '%s' renamed to '%s'.\n", outputStyle(os.Stdout).CheckMark(), oldTicket, newTicket)
 	return nil
 }
+
+func runSessionSendCommand(ticket string, commandArgs []string, noEnter bool) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	// Parse ticket to handle optional project prefix
+	ticketInfo, err := parseTicket(ticket)
+	if err != nil {
+		return err
+	}
+
+	sessionManager := tmux.NewSessionManager(cfg.Tmux.SessionPrefix, nil, verbose)
+
+	err = sessionManager.SendKeys(ticketInfo.SessionID(), shellJoin(commandArgs), !noEnter)
+	if err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			return errors.Newf("tmux session '%s' does not exist for ticket '%s'",
+				sessionManager.GetSessionName(ticketInfo.SessionID()), ticket)
+		}
+		return errors.Wrap(err, "failed to send command")
+	}
+
+	return nil
+}
+
+// shellJoin rebuilds a command line from arguments, single-quoting any that
+// the shell would otherwise split or expand. A single argument is taken as an
+// already-formed command line and returned unchanged.
+func shellJoin(args []string) string {
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shellQuote quotes s for a POSIX shell when it contains special characters
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	if !strings.ContainsAny(s, " \t\n'\"\\$`!*?[]{}()<>|&;#~") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -6,6 +6,11 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"thoreinstein.com/rig/pkg/tmux"
 )
 
 func TestSessionCommandStructure(t *testing.T) {
@@ -344,6 +349,40 @@ func TestSessionKillArgValidation(t *testing.T) {
 	}
 }
 
+func TestSessionKillAllArgValidation(t *testing.T) {
+	// Not parallel - modifies global sessionKillAll
+	sessionKillAll = true
+	defer func() { sessionKillAll = false }()
+
+	if err := sessionKillCmd.ValidateArgs([]string{}); err != nil {
+		t.Errorf("--all with no arguments should be valid, got: %v", err)
+	}
+	if err := sessionKillCmd.ValidateArgs([]string{"FRAAS-123"}); err == nil {
+		t.Error("--all with a ticket argument should be rejected")
+	}
+
+	for _, name := range []string{"all", "dry-run"} {
+		if sessionKillCmd.Flags().Lookup(name) == nil {
+			t.Errorf("kill command should have --%s flag", name)
+		}
+	}
+}
+
+func TestRunSessionKillAllCommand_RequiresPrefix(t *testing.T) {
+	viper.Reset()
+	resetConfig()
+	defer viper.Reset()
+	viper.Set("tmux.session_prefix", "")
+
+	err := runSessionKillAllCommand(true)
+	if err == nil {
+		t.Fatal("runSessionKillAllCommand() should fail without a session prefix")
+	}
+	if !strings.Contains(err.Error(), "tmux.session_prefix") {
+		t.Errorf("error should mention tmux.session_prefix, got: %v", err)
+	}
+}
+
 func TestSessionKillSuccessOutput(t *testing.T) {
 	t.Parallel()
 
@@ -452,7 +491,7 @@ func TestSessionAttachVerboseOutput(t *testing.T) {
 func TestSessionSubcommandCount(t *testing.T) {
 	// Not parallel - accesses global sessionCmd
 	subcommands := sessionCmd.Commands()
-	expectedCount := 3 // list, attach, kill
+	expectedCount := 5 // list, attach, kill, rename, send
 
 	if len(subcommands) != expectedCount {
 		t.Errorf("session command has %d subcommands, want %d", len(subcommands), expectedCount)
@@ -625,6 +664,20 @@ func TestSessionCommandUsageStrings(t *testing.T) {
 			hasLongDesc:  true,
 			hasShortDesc: true,
 		},
+		{
+			name:         "session rename command",
+			cmd:          "rename",
+			expectedUse:  "rename <old-ticket> <new-ticket>",
+			hasLongDesc:  true,
+			hasShortDesc: true,
+		},
+		{
+			name:         "session send command",
+			cmd:          "send",
+			expectedUse:  "send <ticket> -- <command...>",
+			hasLongDesc:  true,
+			hasShortDesc: true,
+		},
 	}
 
 	// Map command names to actual commands
@@ -637,6 +690,8 @@ func TestSessionCommandUsageStrings(t *testing.T) {
 		"list":    {sessionListCmd.Use, sessionListCmd.Short, sessionListCmd.Long},
 		"attach":  {sessionAttachCmd.Use, sessionAttachCmd.Short, sessionAttachCmd.Long},
 		"kill":    {sessionKillCmd.Use, sessionKillCmd.Short, sessionKillCmd.Long},
+		"rename":  {sessionRenameCmd.Use, sessionRenameCmd.Short, sessionRenameCmd.Long},
+		"send":    {sessionSendCmd.Use, sessionSendCmd.Short, sessionSendCmd.Long},
 	}
 
 	for _, tt := range tests {
@@ -688,3 +743,113 @@ func TestSessionVerboseKillOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestShellJoin(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "single preformed command", args: []string{`git commit -m "fix bug"`}, want: `git commit -m "fix bug"`},
+		{name: "plain words", args: []string{"make", "test"}, want: "make test"},
+		{name: "argument with spaces", args: []string{"git", "commit", "-m", "fix bug"}, want: "git commit -m 'fix bug'"},
+		{name: "argument with single quote", args: []string{"echo", "it's"}, want: `echo 'it'\''s'`},
+		{name: "shell metacharacters", args: []string{"echo", "$HOME", "a|b"}, want: "echo '$HOME' 'a|b'"},
+		{name: "empty argument", args: []string{"printf", ""}, want: "printf ''"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shellJoin(tt.args); got != tt.want {
+				t.Errorf("shellJoin(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionSendCommandFlags(t *testing.T) {
+	flag := sessionSendCmd.Flags().Lookup("no-enter")
+	if flag == nil {
+		t.Fatal("send command should have --no-enter flag")
+	}
+	if flag.DefValue != "false" {
+		t.Errorf("--no-enter default = %s, want false", flag.DefValue)
+	}
+
+	if err := sessionSendCmd.Args(sessionSendCmd, []string{"PROJ-1"}); err == nil {
+		t.Error("send should require a command after the ticket")
+	}
+	if err := sessionSendCmd.Args(sessionSendCmd, []string{"PROJ-1", "make"}); err != nil {
+		t.Errorf("send with ticket and command should be valid: %v", err)
+	}
+}
+
+func TestFormatSessionList(t *testing.T) {
+	created := time.Date(2026, 3, 4, 9, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		sessions []tmux.SessionInfo
+		current  string
+		want     []string
+		notWant  []string
+	}{
+		{
+			name:     "empty session list",
+			sessions: nil,
+			want:     []string{"No tmux sessions found.\n"},
+		},
+		{
+			name: "marks current session",
+			sessions: []tmux.SessionInfo{
+				{Name: "rig-PROJ-1", Windows: 3, Attached: false, Created: created},
+				{Name: "rig-PROJ-2", Windows: 1, Attached: true, Created: created},
+			},
+			current: "rig-PROJ-2",
+			want: []string{
+				"Active tmux sessions:\n",
+				"  rig-PROJ-1",
+				"→ rig-PROJ-2",
+				"3 windows",
+				"1 window ",
+				"attached",
+				"detached",
+				"created 2026-03-04 09:30",
+			},
+		},
+		{
+			name:     "no arrow outside tmux",
+			sessions: []tmux.SessionInfo{{Name: "rig-PROJ-1", Windows: 2}},
+			want:     []string{"  rig-PROJ-1", "created -"},
+			notWant:  []string{"→"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			formatSessionList(&buf, tt.sessions, tt.current)
+
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, buf.String())
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(buf.String(), notWant) {
+					t.Errorf("output should not contain %q:\n%s", notWant, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestSessionListJSONFlag(t *testing.T) {
+	flag := sessionListCmd.Flags().Lookup("json")
+	if flag == nil {
+		t.Fatal("list command should have --json flag")
+	}
+	if flag.DefValue != "false" {
+		t.Errorf("--json default = %s, want false", flag.DefValue)
+	}
+}
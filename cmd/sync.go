@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -10,8 +11,11 @@ import (
 	"github.com/spf13/cobra"
 
 	"thoreinstein.com/rig/pkg/config"
+	"thoreinstein.com/rig/pkg/events"
+	"thoreinstein.com/rig/pkg/git"
+	"thoreinstein.com/rig/pkg/hooks"
 	"thoreinstein.com/rig/pkg/jira"
-	"thoreinstein.com/rig/pkg/notes"
+	"thoreinstein.com/rig/pkg/notefile"
 )
 
 // syncCmd represents the sync command
@@ -29,8 +33,12 @@ Examples:
   rig sync                    # Interactive mode - prompts for ticket
   rig sync proj-123           # Sync specific ticket
   rig sync proj-123 --jira    # Force JIRA refresh
-  rig sync --daily            # Update today's daily note`,
-	Args: cobra.MaximumNArgs(1),
+  rig sync proj-123 --edit    # Sync, then open the note in $EDITOR
+  rig sync --daily            # Update today's daily note
+  rig sync proj-123 --weekly  # Also log the ticket in this week's note
+  rig sync --all              # Sync every ticket with a worktree in this repo`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTickets,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ticket := ""
 		if len(args) > 0 {
@@ -41,9 +49,12 @@ Examples:
 }
 
 var (
-	syncJira  bool
-	syncDaily bool
-	syncForce bool
+	syncJira   bool
+	syncDaily  bool
+	syncWeekly bool
+	syncForce  bool
+	syncEdit   bool
+	syncAll    bool
 )
 
 func init() {
@@ -51,6 +62,9 @@ func init() {
 
 	syncCmd.Flags().BoolVar(&syncJira, "jira", false, "Force refresh of JIRA information")
 	syncCmd.Flags().BoolVar(&syncDaily, "daily", false, "Update daily note")
+	syncCmd.Flags().BoolVar(&syncWeekly, "weekly", false, "Also log the ticket in this week's note (notes.weekly_dir)")
+	syncCmd.Flags().BoolVar(&syncEdit, "edit", false, "Open the note in $EDITOR (or notes.editor) after syncing")
+	syncCmd.Flags().BoolVar(&syncAll, "all", false, "Sync every ticket with a worktree in the current repository")
 	syncCmd.Flags().BoolVar(&syncForce, "force", false, "Force update even if note was recently modified")
 }
 
@@ -66,6 +80,13 @@ func runSyncCommand(ticket string) error {
 		return syncDailyNote(cfg)
 	}
 
+	if syncAll {
+		if ticket != "" {
+			return errors.New("cannot combine a ticket argument with --all")
+		}
+		return syncAllTickets(cfg)
+	}
+
 	// Handle ticket sync
 	if ticket == "" {
 		return errors.New("ticket required (or use --daily flag)")
@@ -86,15 +107,13 @@ func syncTicketNote(cfg *config.Config, ticket string) error {
 	}
 
 	// Initialize note manager
-	noteManager := notes.NewManager(
-		cfg.Notes.Path,
-		cfg.Notes.DailyDir,
-		cfg.Notes.TemplateDir,
-		verbose,
-	)
+	noteManager := newNoteManager(cfg)
 
 	// Get note path
-	notePath := noteManager.GetNotePath(ticketInfo.Type, ticketInfo.Full)
+	notePath, err := noteManager.GetNotePath(ticketInfo.Type, ticketInfo.Full)
+	if err != nil {
+		return err
+	}
 
 	// Check if note exists
 	if _, err := os.Stat(notePath); os.IsNotExist(err) {
@@ -112,13 +131,15 @@ func syncTicketNote(cfg *config.Config, ticket string) error {
 				fmt.Println("Refreshing JIRA information...")
 			}
 
-			jiraClient, err := jira.NewJiraClient(&cfg.Jira, verbose)
+			jiraClient, err := jira.NewJiraClientForTicket(&cfg.Jira, ticketInfo.ID, verbose)
 			if err != nil {
 				if verbose {
 					fmt.Printf("Warning: Invalid JIRA CLI command: %v\n", err)
 				}
 			} else {
-				jiraInfo, err := jiraClient.FetchTicketDetails(ticketInfo.Full)
+				ctx, cancel := networkContext(cfg.Jira.Timeout)
+				jiraInfo, err := jira.FetchTicketDetailsContext(ctx, jiraClient, ticketInfo.Full)
+				cancel()
 				if err != nil {
 					if verbose {
 						fmt.Printf("Warning: Could not fetch JIRA details: %v\n", err)
@@ -130,6 +151,7 @@ func syncTicketNote(cfg *config.Config, ticket string) error {
 						return errors.Wrap(err, "failed to update note with JIRA info")
 					}
 					fmt.Println("JIRA information updated")
+					lifecycleEvents().Emit(events.NoteUpdated, ticketInfo.ID, map[string]string{"path": notePath})
 					updated = true
 				}
 			}
@@ -151,26 +173,101 @@ func syncTicketNote(cfg *config.Config, ticket string) error {
 		updated = true
 	}
 
+	if syncWeekly {
+		if err := newObsidianManager(cfg).UpdateWeeklyNote(ticketInfo.Full); err != nil {
+			if verbose {
+				fmt.Printf("Warning: Could not update weekly note: %v\n", err)
+			}
+		} else {
+			fmt.Println("Weekly note updated")
+			updated = true
+		}
+	}
+
 	if !updated {
 		fmt.Println("No updates were made.")
 	} else {
 		fmt.Printf("Sync completed for: %s\n", ticketInfo.Full)
 	}
 
+	if err := runHook(cfg, "post_sync", cfg.Hooks.PostSync, hooks.Env{Ticket: ticketInfo.ID}); err != nil {
+		return err
+	}
+
+	if syncEdit {
+		return editNote(cfg, notePath)
+	}
+
+	return nil
+}
+
+// syncAllTickets syncs the note of every ticket that has a worktree in the
+// current repository, continuing past individual failures
+func syncAllTickets(cfg *config.Config) error {
+	gitManager := git.NewWorktreeManager(cfg.Git.BaseBranch, verbose)
+	worktrees, err := gitManager.ListWorktrees()
+	if err != nil {
+		return errors.Wrap(err, "failed to list worktrees")
+	}
+
+	tickets := ticketsFromWorktrees(worktrees)
+	if len(tickets) == 0 {
+		fmt.Println("No ticket worktrees found.")
+		return nil
+	}
+
+	var failed []string
+	for _, ticket := range tickets {
+		fmt.Printf("\n=== %s ===\n", ticket)
+		if err := syncTicketNote(cfg, ticket); err != nil {
+			fmt.Printf("Warning: Could not sync %s: %v\n", ticket, err)
+			failed = append(failed, ticket)
+		}
+	}
+
+	fmt.Printf("\nSynced %d of %d ticket(s)\n", len(tickets)-len(failed), len(tickets))
+	if len(failed) > 0 {
+		return errors.Newf("failed to sync %d ticket(s): %s", len(failed), strings.Join(failed, ", "))
+	}
 	return nil
 }
 
+// ticketsFromWorktrees returns the ticket for each worktree laid out as
+// {repo}/{type}/{ticket}. The directory name is used rather than the branch,
+// which git.branch_template may have decorated. Other worktrees (the repo
+// itself, hacks) are skipped.
+func ticketsFromWorktrees(paths []string) []string {
+	var tickets []string
+	for _, p := range paths {
+		ticket, ok := ticketFromWorktreePath(p)
+		if !ok {
+			if verbose {
+				fmt.Printf("Skipping worktree %s: not a ticket worktree\n", p)
+			}
+			continue
+		}
+		tickets = append(tickets, ticket)
+	}
+	return tickets
+}
+
+// ticketFromWorktreePath returns the ticket for a worktree at
+// {repo}/{type}/{ticket}, and false for any other worktree
+func ticketFromWorktreePath(path string) (string, bool) {
+	name := filepath.Base(path)
+	ticketInfo, err := parseTicket(name)
+	if err != nil || filepath.Base(filepath.Dir(path)) != ticketInfo.Type {
+		return "", false
+	}
+	return name, true
+}
+
 func syncDailyNote(cfg *config.Config) error {
 	if verbose {
 		fmt.Println("Syncing today's daily note...")
 	}
 
-	noteManager := notes.NewManager(
-		cfg.Notes.Path,
-		cfg.Notes.DailyDir,
-		cfg.Notes.TemplateDir,
-		verbose,
-	)
+	noteManager := newNoteManager(cfg)
 
 	// For now, just verify the daily note exists
 	today := time.Now().Format("2006-01-02")
@@ -193,6 +290,14 @@ func syncDailyNote(cfg *config.Config) error {
 
 // updateNoteWithJiraInfo updates a note file with fresh JIRA information
 func updateNoteWithJiraInfo(notePath string, jiraInfo *jira.TicketInfo) error {
+	// Hold the note's lock across the read-modify-write so concurrent rig
+	// processes don't drop each other's edits
+	unlock, err := notefile.Lock(notePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock note")
+	}
+	defer unlock()
+
 	// Read existing content
 	content, err := os.ReadFile(notePath)
 	if err != nil {
@@ -210,7 +315,7 @@ func updateNoteWithJiraInfo(notePath string, jiraInfo *jira.TicketInfo) error {
 	noteContent = updateJiraDetailsSection(noteContent, jiraInfo)
 
 	// Write back to file with restricted permissions
-	err = os.WriteFile(notePath, []byte(noteContent), 0600)
+	err = notefile.WriteAtomic(notePath, []byte(noteContent), 0600)
 	if err != nil {
 		return errors.Wrap(err, "failed to write updated note")
 	}
@@ -318,6 +423,30 @@ func buildJiraDetailsSection(jiraInfo *jira.TicketInfo) string {
 		section.WriteString(fmt.Sprintf("**Priority:** %s\n", jiraInfo.Priority))
 	}
 
+	if jiraInfo.Assignee != "" {
+		section.WriteString(fmt.Sprintf("**Assignee:** %s\n", jiraInfo.Assignee))
+	}
+
+	if jiraInfo.Reporter != "" {
+		section.WriteString(fmt.Sprintf("**Reporter:** %s\n", jiraInfo.Reporter))
+	}
+
+	if jiraInfo.ParentKey != "" {
+		section.WriteString(fmt.Sprintf("**Parent:** [[%s]]\n", jiraInfo.ParentKey))
+	}
+
+	if jiraInfo.EpicKey != "" && jiraInfo.EpicKey != jiraInfo.ParentKey {
+		section.WriteString(fmt.Sprintf("**Epic:** [[%s]]\n", jiraInfo.EpicKey))
+	}
+
+	if len(jiraInfo.Labels) > 0 {
+		section.WriteString(fmt.Sprintf("**Labels:** %s\n", strings.Join(jiraInfo.Labels, ", ")))
+	}
+
+	if len(jiraInfo.Components) > 0 {
+		section.WriteString(fmt.Sprintf("**Components:** %s\n", strings.Join(jiraInfo.Components, ", ")))
+	}
+
 	// Display custom fields if present
 	if len(jiraInfo.CustomFields) > 0 {
 		for fieldName, fieldValue := range jiraInfo.CustomFields {
@@ -327,7 +456,7 @@ func buildJiraDetailsSection(jiraInfo *jira.TicketInfo) string {
 		}
 	}
 
-	if jiraInfo.Description != "" {
+	if strings.TrimSpace(jiraInfo.Description) != "" {
 		section.WriteString("\n**Description:**\n" + jiraInfo.Description)
 	}
 
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"github.com/spf13/viper"
 
 	"thoreinstein.com/rig/pkg/jira"
+	"thoreinstein.com/rig/pkg/notefile"
 )
 
 func TestUpdateNoteTitle(t *testing.T) {
@@ -125,6 +127,15 @@ func TestBuildJiraDetailsSection(t *testing.T) {
 			contains: []string{},
 			missing:  []string{"**Type:**", "**Status:**", "**Description:**"},
 		},
+		{
+			name: "whitespace-only description",
+			jiraInfo: &jira.TicketInfo{
+				Type:        "Task",
+				Description: "\n\n  \n",
+			},
+			contains: []string{"**Type:** Task"},
+			missing:  []string{"**Description:**"},
+		},
 		{
 			name: "multiline description",
 			jiraInfo: &jira.TicketInfo{
@@ -185,6 +196,72 @@ func TestBuildJiraDetailsSection(t *testing.T) {
 			},
 			missing: []string{"**EmptyField:**"},
 		},
+		{
+			name: "labels and components",
+			jiraInfo: &jira.TicketInfo{
+				Type:       "Task",
+				Labels:     []string{"backend", "oncall"},
+				Components: []string{"API", "Database"},
+			},
+			contains: []string{
+				"**Labels:** backend, oncall",
+				"**Components:** API, Database",
+			},
+		},
+		{
+			name: "parent and epic links",
+			jiraInfo: &jira.TicketInfo{
+				Type:      "Sub-task",
+				ParentKey: "PROJ-10",
+				EpicKey:   "PROJ-1",
+			},
+			contains: []string{
+				"**Parent:** [[PROJ-10]]",
+				"**Epic:** [[PROJ-1]]",
+			},
+		},
+		{
+			name: "epic same as parent rendered once",
+			jiraInfo: &jira.TicketInfo{
+				ParentKey: "PROJ-1",
+				EpicKey:   "PROJ-1",
+			},
+			contains: []string{"**Parent:** [[PROJ-1]]"},
+			missing:  []string{"**Epic:**"},
+		},
+		{
+			name: "assignee and reporter",
+			jiraInfo: &jira.TicketInfo{
+				Type:     "Task",
+				Assignee: "Jane Doe",
+				Reporter: "John Roe",
+			},
+			contains: []string{
+				"**Assignee:** Jane Doe",
+				"**Reporter:** John Roe",
+			},
+		},
+		{
+			name:     "unassigned ticket omits assignee",
+			jiraInfo: &jira.TicketInfo{Type: "Task", Reporter: "John Roe"},
+			contains: []string{"**Reporter:** John Roe"},
+			missing:  []string{"**Assignee:**"},
+		},
+		{
+			name:     "missing parent omitted",
+			jiraInfo: &jira.TicketInfo{Type: "Task"},
+			missing:  []string{"**Parent:**", "**Epic:**"},
+		},
+		{
+			name: "empty labels and components omitted",
+			jiraInfo: &jira.TicketInfo{
+				Type:       "Task",
+				Labels:     []string{},
+				Components: nil,
+			},
+			contains: []string{"**Type:** Task"},
+			missing:  []string{"**Labels:**", "**Components:**"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -453,6 +530,44 @@ func TestUpdateNoteWithJiraInfo_NonExistentFile(t *testing.T) {
 	}
 }
 
+func TestUpdateNoteWithJiraInfo_WaitsForLock(t *testing.T) {
+	notePath := filepath.Join(t.TempDir(), "test-note.md")
+	if err := os.WriteFile(notePath, []byte("# Old Title\n"), 0600); err != nil {
+		t.Fatalf("Failed to write test note: %v", err)
+	}
+
+	// Another rig process is mid-update
+	unlock, err := notefile.Lock(notePath)
+	if err != nil {
+		t.Fatalf("notefile.Lock() error: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- updateNoteWithJiraInfo(notePath, &jira.TicketInfo{Summary: "New Title"})
+	}()
+
+	select {
+	case err := <-done:
+		unlock()
+		t.Fatalf("updateNoteWithJiraInfo() returned %v while the note was locked", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unlock()
+	if err := <-done; err != nil {
+		t.Fatalf("updateNoteWithJiraInfo() error: %v", err)
+	}
+
+	content, err := os.ReadFile(notePath)
+	if err != nil {
+		t.Fatalf("Failed to read updated note: %v", err)
+	}
+	if !strings.Contains(string(content), "# New Title") {
+		t.Errorf("note not updated after lock was released:\n%s", content)
+	}
+}
+
 // ============================================================================
 // Tests for sync command and flags
 // ============================================================================
@@ -513,6 +628,49 @@ func TestSyncCommandFlags(t *testing.T) {
 	if forceFlag != nil && forceFlag.DefValue != "false" {
 		t.Errorf("--force default should be false, got %s", forceFlag.DefValue)
 	}
+
+	// Check --all, --edit and --weekly flags exist
+	for _, name := range []string{"all", "edit", "weekly"} {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			t.Errorf("sync command should have --%s flag", name)
+		} else if flag.DefValue != "false" {
+			t.Errorf("--%s default should be false, got %s", name, flag.DefValue)
+		}
+	}
+}
+
+func TestTicketsFromWorktrees(t *testing.T) {
+	worktrees := []string{
+		"/src/repo",
+		"/src/repo/proj/proj-123",
+		"/src/repo/ops/OPS-42",
+		"/src/repo/hack/winter-2025",
+		"/src/repo/main",
+		"/src/repo/proj/not-a-ticket-dir",
+	}
+
+	got := ticketsFromWorktrees(worktrees)
+	want := []string{"proj-123", "OPS-42"}
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ticketsFromWorktrees() = %v, want %v", got, want)
+	}
+}
+
+func TestRunSyncCommand_AllRejectsTicket(t *testing.T) {
+	// Not parallel - modifies global syncAll
+	viper.Reset()
+	resetConfig()
+	defer viper.Reset()
+
+	syncAll = true
+	defer func() { syncAll = false }()
+
+	err := runSyncCommand("proj-123")
+	if err == nil || !strings.Contains(err.Error(), "--all") {
+		t.Errorf("runSyncCommand() with ticket and --all error = %v, want --all error", err)
+	}
 }
 
 func TestSyncCommandMaxArgs(t *testing.T) {
@@ -824,6 +982,41 @@ Test content.`
 	}
 }
 
+func TestSyncTicketNote_WeeklyNote(t *testing.T) {
+	notesDir := t.TempDir()
+	setupSyncTestConfig(t, notesDir)
+	viper.Set("notes.weekly_dir", "Weeks")
+	defer viper.Reset()
+
+	syncJira = false
+	syncDaily = false
+	syncWeekly = true
+	syncForce = false
+	defer func() { syncWeekly = false }()
+
+	ticketDir := filepath.Join(notesDir, "proj")
+	if err := os.MkdirAll(ticketDir, 0755); err != nil {
+		t.Fatalf("Failed to create ticket dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(ticketDir, "proj-456.md"), []byte("# proj-456\n"), 0644); err != nil {
+		t.Fatalf("Failed to write test note: %v", err)
+	}
+
+	if err := runSyncCommand("proj-456"); err != nil {
+		t.Fatalf("runSyncCommand() error: %v", err)
+	}
+
+	year, week := time.Now().ISOWeek()
+	weeklyNotePath := filepath.Join(notesDir, "Weeks", fmt.Sprintf("%d-W%02d.md", year, week))
+	weeklyContent, err := os.ReadFile(weeklyNotePath)
+	if err != nil {
+		t.Fatalf("weekly note should be created in notes.weekly_dir: %v", err)
+	}
+	if !strings.Contains(string(weeklyContent), "[[proj-456]]") {
+		t.Errorf("weekly note should link the ticket, got: %s", weeklyContent)
+	}
+}
+
 func TestSyncTicketNote_WithJiraDisabled(t *testing.T) {
 	notesDir := t.TempDir()
 	setupSyncTestConfig(t, notesDir)
@@ -13,6 +13,10 @@ func TestMain(m *testing.M) {
 	// Signal that we are in a test environment
 	os.Setenv("GO_TEST", "true")
 
+	// Tests point HOME at temp dirs; keep the developer's XDG config out of them
+	os.Unsetenv("XDG_CONFIG_HOME")
+	os.Unsetenv("XDG_CONFIG_DIRS")
+
 	// Configure all SessionManagers to use isolated test socket
 	tmux.SetupTestSocket()
 
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,7 +15,7 @@ import (
 	"thoreinstein.com/rig/pkg/config"
 	"thoreinstein.com/rig/pkg/git"
 	"thoreinstein.com/rig/pkg/history"
-	"thoreinstein.com/rig/pkg/notes"
+	"thoreinstein.com/rig/pkg/notefile"
 )
 
 // timelineCmd represents the timeline command
@@ -32,9 +34,30 @@ Examples:
   rig timeline proj-123 --until "2025-08-10 18:00"
   rig timeline proj-123 --failed-only
   rig timeline proj-123 --directory /path/to/worktree
-  rig timeline proj-123 --min-duration 5s`,
-	Args: cobra.ExactArgs(1),
+  rig timeline proj-123 --min-duration 5s
+  rig timeline proj-123 --format mermaid --no-update
+
+Use --format mermaid to render the timeline as a Mermaid gantt chart with
+one bar per block of continuous activity, ready to paste into docs.
+
+Use --sessions instead of a ticket for a "what did I work on" view: commands
+are grouped by history session and summarized per day, showing when each
+session was active and how many commands it ran. It covers the last 7 days
+unless --since is given, prints to the console (or --output), and --session
+narrows it to sessions whose name contains a ticket:
+
+  rig timeline --sessions
+  rig timeline --sessions --since yesterday --session proj-123`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if timelineSessions {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if timelineSessions {
+			return runTimelineSessionsCommand()
+		}
 		return runTimelineCommand(args[0])
 	},
 }
@@ -49,14 +72,17 @@ var (
 	timelineMinDuration time.Duration
 	timelineLimit       int
 	timelineOutput      string
+	timelineFormat      string
 	timelineNoUpdate    bool
+	timelineSessions    bool
+	timelineSession     string
 )
 
 func init() {
 	rootCmd.AddCommand(timelineCmd)
 
-	timelineCmd.Flags().StringVar(&timelineSince, "since", "", "Start time (YYYY-MM-DD HH:MM or YYYY-MM-DD)")
-	timelineCmd.Flags().StringVar(&timelineUntil, "until", "", "End time (YYYY-MM-DD HH:MM or YYYY-MM-DD)")
+	timelineCmd.Flags().StringVar(&timelineSince, "since", "", "Start time (YYYY-MM-DD [HH:MM], today, yesterday, or 7d/24h/2w)")
+	timelineCmd.Flags().StringVar(&timelineUntil, "until", "", "End time (YYYY-MM-DD [HH:MM], today, yesterday, or 7d/24h/2w)")
 	timelineCmd.Flags().StringVar(&timelineDirectory, "directory", "", "Filter by directory path")
 	timelineCmd.Flags().StringVar(&timelineSessionID, "session-id", "", "Filter by exact session ID")
 	timelineCmd.Flags().BoolVar(&timelineFailedOnly, "failed-only", false, "Show only failed commands (exit code != 0)")
@@ -64,7 +90,10 @@ func init() {
 	timelineCmd.Flags().DurationVar(&timelineMinDuration, "min-duration", 0, "Filter by minimum duration (e.g. 5s, 1m)")
 	timelineCmd.Flags().IntVar(&timelineLimit, "limit", 1000, "Maximum number of commands to retrieve")
 	timelineCmd.Flags().StringVar(&timelineOutput, "output", "", "Output file path (default: update ticket note)")
+	timelineCmd.Flags().StringVar(&timelineFormat, "format", "text", "Timeline format: text or mermaid")
 	timelineCmd.Flags().BoolVar(&timelineNoUpdate, "no-update", false, "Don't update the ticket note, only output to console")
+	timelineCmd.Flags().BoolVar(&timelineSessions, "sessions", false, "Summarize activity per history session instead of a ticket")
+	timelineCmd.Flags().StringVar(&timelineSession, "session", "", "With --sessions, only include sessions matching this ticket")
 }
 
 func runTimelineCommand(ticket string) error {
@@ -80,6 +109,11 @@ func runTimelineCommand(ticket string) error {
 		return err
 	}
 
+	formatter, err := history.NewTimelineFormatter(timelineFormat)
+	if err != nil {
+		return err
+	}
+
 	if verbose {
 		fmt.Printf("Generating timeline for ticket: %s\n", ticketInfo.Full)
 	}
@@ -92,22 +126,9 @@ func runTimelineCommand(ticket string) error {
 	}
 
 	// Parse time options
-	var since, until *time.Time
-
-	if timelineSince != "" {
-		parsedSince, err := parseTimeString(timelineSince)
-		if err != nil {
-			return errors.Wrap(err, "invalid --since time")
-		}
-		since = &parsedSince
-	}
-
-	if timelineUntil != "" {
-		parsedUntil, err := parseTimeString(timelineUntil)
-		if err != nil {
-			return errors.Wrap(err, "invalid --until time")
-		}
-		until = &parsedUntil
+	since, until, err := parseTimelineTimeRange()
+	if err != nil {
+		return err
 	}
 
 	// Get worktree path to include directory-based commands
@@ -167,8 +188,8 @@ func runTimelineCommand(ticket string) error {
 		fmt.Printf("Found %d commands\n", len(commands))
 	}
 
-	// Generate timeline markdown using the new formatter
-	timeline := history.FormatTimeline(commands, ticketInfo.Full)
+	// Render timeline in the requested format
+	timeline := formatter.Format(history.BuildTimeline(commands, ticketInfo.Full))
 
 	// Output timeline
 	if timelineNoUpdate {
@@ -200,8 +221,106 @@ func runTimelineCommand(ticket string) error {
 	return nil
 }
 
+// sessionTimelineDefaultSince bounds --sessions when --since is not given
+const sessionTimelineDefaultSince = "7d"
+
+// runTimelineSessionsCommand prints command activity grouped by history session
+func runTimelineSessionsCommand() error {
+	if timelineFormat != "text" {
+		return errors.Newf("--format %s is not supported with --sessions", timelineFormat)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	dbManager := history.NewDatabaseManager(cfg.History.DatabasePath, verbose)
+	if !dbManager.IsAvailable() {
+		return errors.Newf("history database not available at: %s", cfg.History.DatabasePath)
+	}
+
+	since, until, err := parseTimelineTimeRange()
+	if err != nil {
+		return err
+	}
+	if since == nil {
+		defaultSince, err := parseTimeString(sessionTimelineDefaultSince)
+		if err != nil {
+			return err
+		}
+		since = &defaultSince
+	}
+
+	options := history.QueryOptions{
+		Since:       since,
+		Until:       until,
+		Directory:   timelineDirectory,
+		Session:     timelineSession,
+		SessionID:   timelineSessionID,
+		MinDuration: timelineMinDuration,
+	}
+
+	commands, err := dbManager.QueryCommands(options)
+	if err != nil {
+		return errors.Wrap(err, "failed to query commands")
+	}
+
+	if len(commands) == 0 {
+		fmt.Println("No commands found in the selected time range.")
+		return nil
+	}
+
+	if verbose {
+		fmt.Printf("Found %d commands\n", len(commands))
+	}
+
+	timeline := history.FormatSessionTimeline(history.SummarizeSessions(commands))
+
+	if timelineOutput == "" {
+		fmt.Print(timeline)
+		return nil
+	}
+
+	if err := validateOutputPath(timelineOutput); err != nil {
+		return errors.Wrap(err, "invalid output path")
+	}
+	if err := writeTimelineToFile(timeline, timelineOutput); err != nil {
+		return errors.Wrap(err, "failed to write timeline to file")
+	}
+	fmt.Printf("Timeline written to: %s\n", timelineOutput)
+	return nil
+}
+
+// parseTimelineTimeRange parses the --since and --until flags
+func parseTimelineTimeRange() (*time.Time, *time.Time, error) {
+	var since, until *time.Time
+
+	if timelineSince != "" {
+		parsedSince, err := parseTimeString(timelineSince)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid --since time")
+		}
+		since = &parsedSince
+	}
+
+	if timelineUntil != "" {
+		parsedUntil, err := parseTimeString(timelineUntil)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid --until time")
+		}
+		until = &parsedUntil
+	}
+
+	return since, until, nil
+}
+
 // parseTimeString parses various time string formats
 func parseTimeString(timeStr string) (time.Time, error) {
+	if t, ok := parseRelativeTime(timeStr, time.Now()); ok {
+		return t, nil
+	}
+
 	formats := []string{
 		"2006-01-02 15:04",
 		"2006-01-02 15:04:05",
@@ -218,6 +337,44 @@ func parseTimeString(timeStr string) (time.Time, error) {
 	return time.Time{}, errors.Newf("unable to parse time: %s", timeStr)
 }
 
+// relativeTimePattern matches durations like "30m", "24h", "7d", or "2w"
+var relativeTimePattern = regexp.MustCompile(`^(\d+)([mhdw])$`)
+
+// parseRelativeTime resolves "today", "yesterday", and "<n><unit>" offsets
+// (m, h, d, w) against now. Day-based keywords resolve to local midnight.
+func parseRelativeTime(timeStr string, now time.Time) (time.Time, bool) {
+	value := strings.ToLower(strings.TrimSpace(timeStr))
+
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch value {
+	case "today":
+		return midnight, true
+	case "yesterday":
+		return midnight.AddDate(0, 0, -1), true
+	}
+
+	match := relativeTimePattern.FindStringSubmatch(value)
+	if match == nil {
+		return time.Time{}, false
+	}
+
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	switch match[2] {
+	case "m":
+		return now.Add(-time.Duration(n) * time.Minute), true
+	case "h":
+		return now.Add(-time.Duration(n) * time.Hour), true
+	case "d":
+		return now.AddDate(0, 0, -n), true
+	default:
+		return now.AddDate(0, 0, -7*n), true
+	}
+}
+
 // validateOutputPath validates that the output path is safe to write to.
 // It ensures the path:
 // - Does not contain path traversal sequences
@@ -299,14 +456,25 @@ func writeTimelineToFile(timeline, filename string) error {
 // updateTicketNoteWithTimeline updates the ticket's note with the timeline
 func updateTicketNoteWithTimeline(cfg *config.Config, ticketInfo *TicketInfo, timeline string) error {
 	// Get note path using notes manager
-	notesMgr := notes.NewManager(cfg.Notes.Path, cfg.Notes.DailyDir, cfg.Notes.TemplateDir, verbose)
-	notePath := notesMgr.GetNotePath(ticketInfo.Type, ticketInfo.Full)
+	notesMgr := newNoteManager(cfg)
+	notePath, err := notesMgr.GetNotePath(ticketInfo.Type, ticketInfo.Full)
+	if err != nil {
+		return err
+	}
 
 	// Check if note exists
 	if _, err := os.Stat(notePath); os.IsNotExist(err) {
 		return errors.Newf("ticket note not found: %s", notePath)
 	}
 
+	// Hold the note's lock across the read-modify-write so concurrent rig
+	// processes don't drop each other's edits
+	unlock, err := notefile.Lock(notePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock note")
+	}
+	defer unlock()
+
 	// Read existing note content
 	content, err := os.ReadFile(notePath)
 	if err != nil {
@@ -322,7 +490,7 @@ func updateTicketNoteWithTimeline(cfg *config.Config, ticketInfo *TicketInfo, ti
 	updatedContent := noteContent + "\n" + timeline
 
 	// Write back to file with restricted permissions (may contain command history)
-	err = os.WriteFile(notePath, []byte(updatedContent), 0600)
+	err = notefile.WriteAtomic(notePath, []byte(updatedContent), 0600)
 	if err != nil {
 		return errors.Wrap(err, "failed to write updated note")
 	}
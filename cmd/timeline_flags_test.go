@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -14,6 +15,9 @@ func TestTimelineCommandNewFlags(t *testing.T) {
 		{"exit-code", "-1"},
 		{"min-duration", "0s"},
 		{"session-id", ""},
+		{"format", "text"},
+		{"sessions", "false"},
+		{"session", ""},
 	}
 
 	for _, expected := range expectedFlags {
@@ -27,3 +31,34 @@ func TestTimelineCommandNewFlags(t *testing.T) {
 		}
 	}
 }
+
+func TestTimelineSessionsArgValidation(t *testing.T) {
+	// Not parallel - modifies global timelineSessions
+	if err := timelineCmd.ValidateArgs([]string{}); err == nil {
+		t.Error("timeline without --sessions should require a ticket")
+	}
+
+	timelineSessions = true
+	defer func() { timelineSessions = false }()
+
+	if err := timelineCmd.ValidateArgs([]string{}); err != nil {
+		t.Errorf("--sessions with no arguments should be valid, got: %v", err)
+	}
+	if err := timelineCmd.ValidateArgs([]string{"proj-123"}); err == nil {
+		t.Error("--sessions with a ticket argument should be rejected")
+	}
+}
+
+func TestRunTimelineSessionsCommand_RejectsMermaid(t *testing.T) {
+	// Not parallel - modifies global timelineFormat
+	timelineFormat = "mermaid"
+	defer func() { timelineFormat = "text" }()
+
+	err := runTimelineSessionsCommand()
+	if err == nil {
+		t.Fatal("runTimelineSessionsCommand() should reject --format mermaid")
+	}
+	if !strings.Contains(err.Error(), "--sessions") {
+		t.Errorf("error should mention --sessions, got: %v", err)
+	}
+}
@@ -597,3 +597,37 @@ Final notes.`
 		t.Error("removeExistingTimeline() should preserve Notes content")
 	}
 }
+
+func TestParseRelativeTime(t *testing.T) {
+	now := time.Date(2025, time.August, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input  string
+		want   time.Time
+		wantOK bool
+	}{
+		{"today", time.Date(2025, time.August, 10, 0, 0, 0, 0, time.UTC), true},
+		{"Yesterday", time.Date(2025, time.August, 9, 0, 0, 0, 0, time.UTC), true},
+		{"30m", now.Add(-30 * time.Minute), true},
+		{"24h", now.Add(-24 * time.Hour), true},
+		{"7d", time.Date(2025, time.August, 3, 15, 30, 0, 0, time.UTC), true},
+		{"2w", time.Date(2025, time.July, 27, 15, 30, 0, 0, time.UTC), true},
+		{"7", time.Time{}, false},
+		{"d", time.Time{}, false},
+		{"-7d", time.Time{}, false},
+		{"7 days", time.Time{}, false},
+		{"2025-08-10", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := parseRelativeTime(tt.input, now)
+			if ok != tt.wantOK {
+				t.Fatalf("parseRelativeTime(%q) ok = %v, want %v", tt.input, ok, tt.wantOK)
+			}
+			if ok && !got.Equal(tt.want) {
+				t.Errorf("parseRelativeTime(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
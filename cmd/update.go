@@ -2,12 +2,18 @@ package cmd
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 	"os"
 	"runtime"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/cockroachdb/errors"
 	"github.com/creativeprojects/go-selfupdate"
 	"github.com/spf13/cobra"
@@ -16,13 +22,19 @@ import (
 const (
 	repoOwner = "thoreinstein"
 	repoName  = "rig"
+
+	// checksumsAsset is the release asset listing the SHA256 of every artifact
+	checksumsAsset = "checksums.txt"
 )
 
 var (
-	updateCheck bool
-	updateForce bool
-	updatePre   bool
-	updateYes   bool
+	updateCheck      bool
+	updateForce      bool
+	updatePre        bool
+	updateYes        bool
+	updateSkipVerify bool
+	updatePublicKey  string
+	updateVersion    string
 )
 
 var updateCmd = &cobra.Command{
@@ -34,15 +46,22 @@ This command connects to GitHub to check for newer releases. If a newer
 version is found, it downloads the appropriate binary for your platform
 and replaces the current executable.
 
-The update process validates the download using checksums to ensure
-integrity before replacing the binary.
+The downloaded artifact is verified against the release's checksums.txt
+before anything is replaced, and the update aborts if the checksum is
+missing or does not match. With --public-key, checksums.txt itself must
+also carry a valid ECDSA signature (checksums.txt.sig, as produced by
+cosign sign-blob). The new binary is written to a temporary file and
+renamed over the old one, so a failed update leaves the installed binary
+untouched. --skip-verify disables verification and is not recommended.
 
 Examples:
   rig update           # Check and update (with confirmation)
   rig update --check   # Only check for updates, don't install
   rig update --yes     # Update without confirmation prompt
   rig update --force   # Force reinstall even if on latest version
-  rig update --pre     # Include pre-release versions`,
+  rig update --pre     # Include pre-release versions
+  rig update --version v1.2.3  # Install an exact release, including downgrades
+  rig update --public-key cosign.pub  # Also verify the checksums signature`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runUpdateCommand(cmd.Context())
 	},
@@ -59,6 +78,12 @@ func init() {
 		"Include pre-release versions")
 	updateCmd.Flags().BoolVarP(&updateYes, "yes", "y", false,
 		"Skip confirmation prompt")
+	updateCmd.Flags().StringVar(&updateVersion, "version", "",
+		"Install a specific release tag (e.g. v1.2.3) instead of the latest")
+	updateCmd.Flags().BoolVar(&updateSkipVerify, "skip-verify", false,
+		"Skip checksum and signature verification (not recommended)")
+	updateCmd.Flags().StringVar(&updatePublicKey, "public-key", "",
+		"PEM ECDSA public key used to verify the checksums.txt signature")
 }
 
 func runUpdateCommand(ctx context.Context) error {
@@ -67,22 +92,39 @@ func runUpdateCommand(ctx context.Context) error {
 		fmt.Printf("Platform: %s/%s\n", runtime.GOOS, runtime.GOARCH)
 	}
 
+	// Resolve the target tag before touching the network so typos fail fast
+	var targetTag string
+	if updateVersion != "" {
+		var err error
+		targetTag, err = normalizeVersionTag(updateVersion)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Create GitHub source
 	source, err := selfupdate.NewGitHubSource(selfupdate.GitHubConfig{})
 	if err != nil {
 		return errors.Wrap(err, "failed to create GitHub source")
 	}
 
-	// Create updater with checksum validation
+	validator, err := newUpdateValidator(updateSkipVerify, updatePublicKey)
+	if err != nil {
+		return err
+	}
+	if validator == nil {
+		fmt.Fprintln(os.Stderr, "Warning: --skip-verify set; the downloaded binary will not be verified")
+	}
+
 	updater, err := selfupdate.NewUpdater(selfupdate.Config{
 		Source:    source,
-		Validator: &selfupdate.ChecksumValidator{UniqueFilename: "checksums.txt"},
+		Validator: validator,
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed to create updater")
 	}
 
-	// Detect latest release
+	// Detect latest (or requested) release
 	if verbose {
 		fmt.Println("Checking for updates...")
 	}
@@ -93,13 +135,17 @@ func runUpdateCommand(ctx context.Context) error {
 	// For now, DetectLatest handles both cases the same way
 	_ = updatePre // silence unused warning until pre-release support is added
 
-	latest, found, err := updater.DetectLatest(ctx, repo)
+	// DetectVersion with an empty tag is DetectLatest
+	latest, found, err := updater.DetectVersion(ctx, repo, targetTag)
 
 	if err != nil {
-		return errors.Wrap(err, "failed to detect latest version")
+		return verificationError(err, "failed to detect latest version")
 	}
 
 	if !found {
+		if targetTag != "" {
+			return errors.Newf("release %s not found for %s/%s", targetTag, runtime.GOOS, runtime.GOARCH)
+		}
 		return errors.Newf("no release found for %s/%s", runtime.GOOS, runtime.GOARCH)
 	}
 
@@ -112,21 +158,12 @@ func runUpdateCommand(ctx context.Context) error {
 	// Compare versions
 	currentVersion := Version
 
-	// Handle "dev" version - always consider it older than any release
-	isDevVersion := currentVersion == "dev"
-
-	if !isDevVersion && latest.LessOrEqual(currentVersion) && !updateForce {
-		fmt.Printf("Current version (%s) is up to date\n", currentVersion)
+	install, message := planUpdate(currentVersion, latest.Version(), targetTag != "", updateForce)
+	fmt.Println(message)
+	if !install {
 		return nil
 	}
 
-	// Report available update
-	if isDevVersion {
-		fmt.Printf("Development version detected, latest release is %s\n", latest.Version())
-	} else {
-		fmt.Printf("Update available: %s -> %s\n", currentVersion, latest.Version())
-	}
-
 	// If --check, just report and exit
 	if updateCheck {
 		return nil
@@ -153,13 +190,156 @@ func runUpdateCommand(ctx context.Context) error {
 
 	// Perform update
 	if err := updater.UpdateTo(ctx, latest, exe); err != nil {
-		return errors.Wrap(err, "failed to update binary")
+		return verificationError(err, "failed to update binary")
 	}
 
 	fmt.Printf("Successfully updated to version %s\n", latest.Version())
 	return nil
 }
 
+// normalizeVersionTag validates a --version value as semver and returns it
+// as a release tag with the leading "v" the releases are tagged with
+func normalizeVersionTag(version string) (string, error) {
+	v, err := semver.StrictNewVersion(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return "", errors.Newf("invalid version %q: expected a release tag like v1.2.3", version)
+	}
+	return "v" + v.String(), nil
+}
+
+// planUpdate decides whether to install target over current and describes
+// the decision. A dev build always updates. Without pinned only a newer
+// release is installed; a pinned version is installed unless it is already
+// the current one, so downgrades are allowed. force always installs.
+func planUpdate(current, target string, pinned, force bool) (bool, string) {
+	if current == "dev" {
+		if pinned {
+			return true, fmt.Sprintf("Development version detected, installing %s", target)
+		}
+		return true, fmt.Sprintf("Development version detected, latest release is %s", target)
+	}
+
+	currentVer, err := semver.NewVersion(current)
+	if err != nil {
+		// Unparseable builds are treated like dev builds
+		return true, fmt.Sprintf("Current version (%s) is not a release, installing %s", current, target)
+	}
+	targetVer, err := semver.NewVersion(target)
+	if err != nil {
+		return false, fmt.Sprintf("Release version %s is not valid semver, skipping", target)
+	}
+
+	cmp := targetVer.Compare(currentVer)
+	switch {
+	case cmp == 0 && !force:
+		return false, fmt.Sprintf("Current version (%s) is up to date", current)
+	case cmp < 0 && !pinned && !force:
+		return false, fmt.Sprintf("Current version (%s) is up to date", current)
+	case cmp < 0:
+		return true, fmt.Sprintf("Downgrade available: %s -> %s", current, target)
+	case cmp == 0:
+		return true, fmt.Sprintf("Reinstalling %s", target)
+	default:
+		return true, fmt.Sprintf("Update available: %s -> %s", current, target)
+	}
+}
+
+// newUpdateValidator returns the validator applied to downloaded release
+// assets: the checksums file by default, additionally its ECDSA signature when
+// publicKeyPath is set, and nil when verification is skipped
+func newUpdateValidator(skipVerify bool, publicKeyPath string) (selfupdate.Validator, error) {
+	if skipVerify {
+		if publicKeyPath != "" {
+			return nil, errors.New("--skip-verify cannot be combined with --public-key")
+		}
+		return nil, nil
+	}
+
+	checksums := &selfupdate.ChecksumValidator{UniqueFilename: checksumsAsset}
+	if publicKeyPath == "" {
+		return checksums, nil
+	}
+
+	data, err := os.ReadFile(publicKeyPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read public key %s", publicKeyPath)
+	}
+	key, err := parseECDSAPublicKey(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid public key %s", publicKeyPath)
+	}
+
+	return new(selfupdate.PatternValidator).
+		Add(checksumsAsset, &signatureValidator{selfupdate.ECDSAValidator{PublicKey: key}}).
+		Add("*", checksums).
+		SkipValidation("*.sig"), nil
+}
+
+// parseECDSAPublicKey reads an ECDSA key from a PEM "PUBLIC KEY" block, as
+// written by cosign generate-key-pair, or from a PEM certificate
+func parseECDSAPublicKey(data []byte) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var pub interface{}
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse public key")
+		}
+		pub = key
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse certificate")
+		}
+		pub = cert.PublicKey
+	default:
+		return nil, errors.Newf("unsupported PEM block type %q", block.Type)
+	}
+
+	key, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("not an ECDSA public key")
+	}
+	return key, nil
+}
+
+// signatureValidator checks an ECDSA signature that may be raw DER or, as
+// cosign writes it, base64-encoded DER
+type signatureValidator struct {
+	selfupdate.ECDSAValidator
+}
+
+// Validate implements selfupdate.Validator
+func (v *signatureValidator) Validate(filename string, input, signature []byte) error {
+	if decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature))); err == nil {
+		signature = decoded
+	}
+	return v.ECDSAValidator.Validate(filename, input, signature)
+}
+
+// verificationError wraps err, replacing the library's verification failures
+// with a message that says the installed binary was left alone
+func verificationError(err error, msg string) error {
+	switch {
+	case errors.Is(err, selfupdate.ErrValidationAssetNotFound):
+		return errors.Wrapf(err, "release does not publish the files needed to verify it; refusing to install an unverified binary (use --skip-verify to override)")
+	case errors.Is(err, selfupdate.ErrChecksumValidationFailed),
+		errors.Is(err, selfupdate.ErrHashNotFound),
+		errors.Is(err, selfupdate.ErrIncorrectChecksumFile):
+		return errors.Wrap(err, "checksum verification failed; the installed binary was not modified")
+	case errors.Is(err, selfupdate.ErrECDSAValidationFailed),
+		errors.Is(err, selfupdate.ErrInvalidECDSASignature):
+		return errors.Wrap(err, "signature verification failed; the installed binary was not modified")
+	default:
+		return errors.Wrap(err, msg)
+	}
+}
+
 // confirmUpdate prompts the user for confirmation before updating.
 func confirmUpdate(currentVersion, newVersion string) bool {
 	var prompt string
@@ -2,10 +2,22 @@ package cmd
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/creativeprojects/go-selfupdate"
 )
 
 func TestUpdateCommandFlags(t *testing.T) {
@@ -21,6 +33,9 @@ func TestUpdateCommandFlags(t *testing.T) {
 		{"force", "f", "false"},
 		{"pre", "p", "false"},
 		{"yes", "y", "false"},
+		{"version", "", ""},
+		{"skip-verify", "", "false"},
+		{"public-key", "", ""},
 	}
 
 	for _, tt := range tests {
@@ -528,3 +543,179 @@ func TestUpdateCommandInheritsConfigFlag(t *testing.T) {
 		t.Error("update command should inherit --config persistent flag from root")
 	}
 }
+
+func TestNewUpdateValidator(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(t.TempDir(), "cosign.pub")
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("checksums by default", func(t *testing.T) {
+		v, err := newUpdateValidator(false, "")
+		if err != nil {
+			t.Fatalf("newUpdateValidator() error = %v", err)
+		}
+		if got := v.GetValidationAssetName("rig_linux_amd64.tar.gz"); got != checksumsAsset {
+			t.Errorf("validation asset = %q, want %q", got, checksumsAsset)
+		}
+	})
+
+	t.Run("skip verify", func(t *testing.T) {
+		v, err := newUpdateValidator(true, "")
+		if err != nil {
+			t.Fatalf("newUpdateValidator() error = %v", err)
+		}
+		if v != nil {
+			t.Errorf("newUpdateValidator(skip) = %T, want nil", v)
+		}
+	})
+
+	t.Run("skip verify with key", func(t *testing.T) {
+		if _, err := newUpdateValidator(true, keyPath); err == nil {
+			t.Error("--skip-verify with --public-key should be rejected")
+		}
+	})
+
+	t.Run("signed checksums", func(t *testing.T) {
+		v, err := newUpdateValidator(false, keyPath)
+		if err != nil {
+			t.Fatalf("newUpdateValidator() error = %v", err)
+		}
+		if got := v.GetValidationAssetName("rig_linux_amd64.tar.gz"); got != checksumsAsset {
+			t.Errorf("asset validation = %q, want %q", got, checksumsAsset)
+		}
+		if got := v.GetValidationAssetName(checksumsAsset); got != checksumsAsset+".sig" {
+			t.Errorf("checksums validation = %q, want %q", got, checksumsAsset+".sig")
+		}
+
+		asset := []byte("binary")
+		checksums := []byte(fmt.Sprintf("%x  rig_linux_amd64.tar.gz\n", sha256.Sum256(asset)))
+		if err := v.Validate("rig_linux_amd64.tar.gz", asset, checksums); err != nil {
+			t.Errorf("asset validation error = %v", err)
+		}
+		if err := v.Validate("rig_linux_amd64.tar.gz", []byte("tampered"), checksums); !errors.Is(err, selfupdate.ErrChecksumValidationFailed) {
+			t.Errorf("tampered asset error = %v, want checksum failure", err)
+		}
+
+		digest := sha256.Sum256(checksums)
+		sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := v.Validate(checksumsAsset, checksums, sig); err != nil {
+			t.Errorf("DER signature error = %v", err)
+		}
+		if err := v.Validate(checksumsAsset, checksums, []byte(base64.StdEncoding.EncodeToString(sig)+"\n")); err != nil {
+			t.Errorf("base64 signature error = %v", err)
+		}
+		if err := v.Validate(checksumsAsset, []byte("other"), sig); !errors.Is(err, selfupdate.ErrECDSAValidationFailed) {
+			t.Errorf("wrong content error = %v, want signature failure", err)
+		}
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		badPath := filepath.Join(t.TempDir(), "bad.pub")
+		if err := os.WriteFile(badPath, []byte("not a key"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := newUpdateValidator(false, badPath); err == nil {
+			t.Error("newUpdateValidator() should reject a malformed key")
+		}
+	})
+}
+
+func TestVerificationError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"missing checksums", fmt.Errorf("%w: %q", selfupdate.ErrValidationAssetNotFound, "checksums.txt"), "--skip-verify"},
+		{"checksum mismatch", fmt.Errorf("failed validating asset content: %w", selfupdate.ErrChecksumValidationFailed), "checksum verification failed"},
+		{"hash missing", selfupdate.ErrHashNotFound, "checksum verification failed"},
+		{"bad signature", selfupdate.ErrECDSAValidationFailed, "signature verification failed"},
+		{"other", errors.New("network down"), "failed to update binary"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verificationError(tt.err, "failed to update binary")
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("verificationError() = %q, want it to contain %q", err, tt.want)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Error("verificationError() should wrap the original error")
+			}
+		})
+	}
+}
+
+func TestNormalizeVersionTag(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"v1.2.3", "v1.2.3", false},
+		{"1.2.3", "v1.2.3", false},
+		{"v2.0.0-rc.1", "v2.0.0-rc.1", false},
+		{"latest", "", true},
+		{"v1.2", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := normalizeVersionTag(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeVersionTag(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeVersionTag(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     string
+		target      string
+		pinned      bool
+		force       bool
+		wantInstall bool
+		wantMessage string
+	}{
+		{"newer release", "1.0.0", "1.1.0", false, false, true, "Update available: 1.0.0 -> 1.1.0"},
+		{"up to date", "1.1.0", "1.1.0", false, false, false, "is up to date"},
+		{"ahead of latest", "1.2.0", "1.1.0", false, false, false, "is up to date"},
+		{"force reinstall", "1.1.0", "1.1.0", false, true, true, "Reinstalling 1.1.0"},
+		{"pinned downgrade", "1.2.0", "1.0.0", true, false, true, "Downgrade available: 1.2.0 -> 1.0.0"},
+		{"pinned upgrade", "1.0.0", "1.2.0", true, false, true, "Update available"},
+		{"pinned current", "1.2.0", "1.2.0", true, false, false, "is up to date"},
+		{"v prefix on current", "v1.0.0", "1.1.0", false, false, true, "Update available"},
+		{"dev build", "dev", "1.1.0", false, false, true, "Development version detected"},
+		{"non-release build", "abc123", "1.1.0", false, false, true, "not a release"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			install, message := planUpdate(tt.current, tt.target, tt.pinned, tt.force)
+			if install != tt.wantInstall {
+				t.Errorf("planUpdate() install = %v, want %v", install, tt.wantInstall)
+			}
+			if !strings.Contains(message, tt.wantMessage) {
+				t.Errorf("planUpdate() message = %q, want it to contain %q", message, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -1,12 +1,24 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 
 	"thoreinstein.com/rig/pkg/config"
 	"thoreinstein.com/rig/pkg/discovery"
+	"thoreinstein.com/rig/pkg/events"
+	"thoreinstein.com/rig/pkg/hooks"
+	"thoreinstein.com/rig/pkg/notes"
+	"thoreinstein.com/rig/pkg/obsidian"
+	"thoreinstein.com/rig/pkg/tmux"
 	"thoreinstein.com/rig/pkg/ui"
 )
 
@@ -55,3 +67,193 @@ func resolveProjectContext(cfg *config.Config, flagValue string, nameOverride st
 
 	return selected.Path, nil
 }
+
+// pathExists reports whether a file or directory exists at path
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// networkContext returns a context bounded by timeout, such as
+// network.timeout (--timeout) or a subsystem's own timeout, or an unbounded
+// one when timeout is zero
+func networkContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// outputStyle returns the styler for output written to w, following --color
+func outputStyle(w io.Writer) *ui.Styler {
+	return ui.NewStyler(colorMode, w)
+}
+
+// findEditor returns the user's editor from $EDITOR or $VISUAL, falling back
+// to the first common editor found on PATH
+func findEditor() (string, error) {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = os.Getenv("VISUAL")
+	}
+	if editor == "" {
+		// Check for common editors
+		for _, e := range []string{"vim", "vi", "nano"} {
+			if _, err := exec.LookPath(e); err == nil {
+				editor = e
+				break
+			}
+		}
+	}
+	if editor == "" {
+		return "", errors.New("no editor found: set $EDITOR environment variable")
+	}
+	return editor, nil
+}
+
+// openInEditor opens path in the user's editor attached to the terminal
+func openInEditor(path string) error {
+	editor, err := findEditor()
+	if err != nil {
+		return err
+	}
+	return runEditor(editor, path)
+}
+
+// runEditor runs editor on path and waits for it to exit. editor may carry
+// arguments, e.g. "code --wait". Terminal editors need a controlling tty, so
+// when stdin is not a terminal (rig's input was piped) /dev/tty is used
+// instead. A non-zero exit is returned as an error carrying the status.
+func runEditor(editor, path string) error {
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		return errors.New("editor command is empty")
+	}
+
+	cmd := exec.Command(fields[0], append(fields[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if !isTerminal(os.Stdin) {
+		if tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0); err == nil {
+			defer tty.Close()
+			cmd.Stdin = tty
+			cmd.Stdout = tty
+		}
+	}
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return errors.Newf("editor %s exited with status %d", fields[0], exitErr.ExitCode())
+		}
+		return errors.Wrapf(err, "failed to run editor %s", fields[0])
+	}
+	return nil
+}
+
+// isTerminal reports whether f is a character device such as a tty
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// noteEditor returns the editor for notes: notes.editor, then $EDITOR or
+// $VISUAL. Unlike findEditor it does not guess, and returns "" when none is set.
+func noteEditor(cfg *config.Config) string {
+	if cfg.Notes.Editor != "" {
+		return cfg.Notes.Editor
+	}
+	if editor := os.Getenv("EDITOR"); editor != "" {
+		return editor
+	}
+	return os.Getenv("VISUAL")
+}
+
+// editNote opens a note in the configured editor. Without one it prints the
+// path and returns nil so the calling command still succeeds.
+func editNote(cfg *config.Config, path string) error {
+	editor := noteEditor(cfg)
+	if editor == "" {
+		fmt.Printf("No editor configured (set $EDITOR or notes.editor); note is at %s\n", path)
+		return nil
+	}
+
+	if err := runEditor(editor, path); err != nil {
+		return errors.Wrap(err, "failed to open note in editor")
+	}
+	return nil
+}
+
+// newNoteManager creates a note manager from the notes configuration
+func newNoteManager(cfg *config.Config) *notes.Manager {
+	noteManager := notes.NewManager(cfg.Notes.Path, cfg.Notes.DailyDir, cfg.Notes.TemplateDir, verbose)
+	noteManager.LogTimeFormat = cfg.Notes.LogTimeFormat
+	noteManager.Subdirs = cfg.Notes.Subdirs
+	noteManager.DefaultSubdir = cfg.Notes.DefaultSubdir
+	noteManager.FrontMatter = cfg.Notes.FrontMatter
+	if cfg.Notes.ArchiveDir != "" {
+		noteManager.ArchiveDir = cfg.Notes.ArchiveDir
+	}
+	return noteManager
+}
+
+// newObsidianManager creates an Obsidian note manager for the notes vault,
+// configured from cfg.Notes
+func newObsidianManager(cfg *config.Config) *obsidian.NoteManager {
+	noteManager := obsidian.NewNoteManager(cfg.Notes.Path, "", "", cfg.Notes.DailyDir, verbose)
+	noteManager.LogTimeFormat = cfg.Notes.LogTimeFormat
+	if cfg.Notes.WeeklyDir != "" {
+		noteManager.SetWeeklyDir(cfg.Notes.WeeklyDir)
+	}
+	return noteManager
+}
+
+var (
+	lifecycleEventsOnce sync.Once
+	lifecycleEmitter    *events.Emitter
+)
+
+// lifecycleEvents returns the event emitter configured by RIG_EVENTS_FD. An
+// invalid destination is reported once and disables events rather than
+// failing the command.
+func lifecycleEvents() *events.Emitter {
+	lifecycleEventsOnce.Do(func() {
+		e, err := events.FromEnv()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: lifecycle events disabled: %v\n", err)
+		}
+		lifecycleEmitter = e
+	})
+	return lifecycleEmitter
+}
+
+// runHook runs the configured hook command called name. It only returns an
+// error for a failed hook when hooks.fail_on_error is set.
+func runHook(cfg *config.Config, name, command string, env hooks.Env) error {
+	return hooks.NewRunner(&cfg.Hooks).Run(context.Background(), name, command, env)
+}
+
+// tmuxWindowsFromConfig converts configured windows to tmux window configs
+func tmuxWindowsFromConfig(windows []config.TmuxWindow) []tmux.WindowConfig {
+	tmuxWindows := make([]tmux.WindowConfig, 0, len(windows))
+	for _, window := range windows {
+		panes := make([]tmux.PaneConfig, 0, len(window.Panes))
+		for _, pane := range window.Panes {
+			panes = append(panes, tmux.PaneConfig{
+				Command:    pane.Command,
+				WorkingDir: pane.WorkingDir,
+			})
+		}
+
+		tmuxWindows = append(tmuxWindows, tmux.WindowConfig{
+			Name:       window.Name,
+			Command:    window.Command,
+			WorkingDir: window.WorkingDir,
+			Layout:     window.Layout,
+			Panes:      panes,
+		})
+	}
+	return tmuxWindows
+}
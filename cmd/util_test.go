@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"thoreinstein.com/rig/pkg/config"
+)
+
+func TestRunEditor(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "args.log")
+	script := filepath.Join(dir, "editor.sh")
+	content := "#!/bin/sh\nprintf '%s\\n' \"$@\" > " + logPath + "\n"
+	if err := os.WriteFile(script, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	notePath := filepath.Join(dir, "note.md")
+	if err := runEditor(script+" --wait", notePath); err != nil {
+		t.Fatalf("runEditor() error = %v", err)
+	}
+
+	got, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("editor was not run: %v", err)
+	}
+	if string(got) != "--wait\n"+notePath+"\n" {
+		t.Errorf("editor args = %q, want editor arguments followed by the path", got)
+	}
+}
+
+func TestRunEditor_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		editor  string
+		wantErr string
+	}{
+		{name: "empty command", editor: "  ", wantErr: "editor command is empty"},
+		{name: "non-zero exit", editor: "false", wantErr: "exited with status 1"},
+		{name: "missing binary", editor: "rig-no-such-editor", wantErr: "failed to run editor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runEditor(tt.editor, filepath.Join(t.TempDir(), "note.md"))
+			if err == nil {
+				t.Fatal("runEditor() expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("runEditor() error = %q, want to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNoteEditor(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		editorEnv  string
+		visualEnv  string
+		want       string
+	}{
+		{name: "config wins", configured: "nvim", editorEnv: "vim", visualEnv: "code", want: "nvim"},
+		{name: "EDITOR before VISUAL", editorEnv: "vim", visualEnv: "code", want: "vim"},
+		{name: "VISUAL fallback", visualEnv: "code --wait", want: "code --wait"},
+		{name: "nothing set", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("EDITOR", tt.editorEnv)
+			t.Setenv("VISUAL", tt.visualEnv)
+
+			cfg := &config.Config{Notes: config.NotesConfig{Editor: tt.configured}}
+			if got := noteEditor(cfg); got != tt.want {
+				t.Errorf("noteEditor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditNote_NoEditorPrintsPath(t *testing.T) {
+	t.Setenv("EDITOR", "")
+	t.Setenv("VISUAL", "")
+
+	var err error
+	output := captureOutput(func() {
+		err = editNote(&config.Config{}, "/notes/proj/proj-123.md")
+	})
+
+	if err != nil {
+		t.Fatalf("editNote() error = %v, want nil when no editor is configured", err)
+	}
+	if !strings.Contains(output, "/notes/proj/proj-123.md") {
+		t.Errorf("editNote() should print the note path, got: %q", output)
+	}
+}
@@ -1,43 +1,78 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/user"
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/cockroachdb/errors"
 	"github.com/spf13/cobra"
 
 	"thoreinstein.com/rig/pkg/beads"
+	"thoreinstein.com/rig/pkg/config"
+	"thoreinstein.com/rig/pkg/events"
 	"thoreinstein.com/rig/pkg/git"
+	"thoreinstein.com/rig/pkg/github"
+	"thoreinstein.com/rig/pkg/hooks"
 	"thoreinstein.com/rig/pkg/jira"
 	"thoreinstein.com/rig/pkg/notes"
+	"thoreinstein.com/rig/pkg/ticket"
 	"thoreinstein.com/rig/pkg/tmux"
 	"thoreinstein.com/rig/pkg/workflow"
 )
 
-var workNoNotes bool
+var (
+	workNoNotes   bool
+	workNoSession bool
+	workRecreate  bool
+	workDryRun    bool
+	workEdit      bool
+	workPR        string
+)
 
 // workCmd represents the work command
 var workCmd = &cobra.Command{
-	Use:   "work <ticket>",
+	Use:   "work [ticket]",
 	Short: "Start workflow for a ticket",
 	Long: `Start the complete workflow for a given ticket.
 
 This command performs the following actions:
 - Parses ticket type and number
-- Creates git worktree and branch
+- Creates git worktree and branch (reusing them if they already exist)
 - Creates/updates markdown note with JIRA integration (use --no-notes to skip)
 - Updates daily note with log entry
-- Creates tmux session with configured windows
+- Creates tmux session with configured windows (use --no-session to skip;
+  skipped automatically when tmux is not installed)
+
+With --pr, the ticket is optional: rig fetches the pull request from GitHub,
+checks out its head branch in the worktree and detects the ticket from the
+branch name, the title or the first linked issue.
 
 Examples:
   rig work proj-123
   rig work ops-456
-  rig work incident-789 --no-notes`,
-	Args: cobra.ExactArgs(1),
+  rig work incident-789 --no-notes
+  rig work proj-123 --no-session
+  rig work proj-123 --recreate
+  rig work proj-123 --dry-run
+  rig work --pr https://github.com/owner/repo/pull/42`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if workPR != "" {
+			var ticketArg string
+			if len(args) == 1 {
+				ticketArg = args[0]
+			}
+			return runWorkFromPR(workPR, ticketArg)
+		}
+		if len(args) == 0 {
+			return errors.New("requires a ticket argument (or --pr <url>)")
+		}
 		return runWorkCommand(args[0])
 	},
 }
@@ -46,6 +81,11 @@ func init() {
 	rootCmd.AddCommand(workCmd)
 
 	workCmd.Flags().BoolVar(&workNoNotes, "no-notes", false, "Skip creating markdown note and note-related tmux window commands")
+	workCmd.Flags().BoolVar(&workNoSession, "no-session", false, "Skip creating the tmux session")
+	workCmd.Flags().BoolVar(&workDryRun, "dry-run", false, "Print the worktree, branch, note and session that would be used without creating anything")
+	workCmd.Flags().BoolVar(&workEdit, "edit", false, "Open the note in $EDITOR (or notes.editor) when done")
+	workCmd.Flags().BoolVar(&workRecreate, "recreate", false, "Remove an existing worktree for the ticket and create it again")
+	workCmd.Flags().StringVar(&workPR, "pr", "", "Start work on a GitHub pull request URL, using its head branch")
 	workCmd.Flags().StringVarP(&projectFlag, "project", "p", "", "Override project directory")
 }
 
@@ -69,36 +109,208 @@ func (t *TicketInfo) SessionID() string {
 // parseTicket parses a ticket string into type and number/identifier components.
 // Supports both traditional Jira-style tickets (proj-123) and beads-style tickets (rig-abc123).
 // Also supports optional project prefix (project:ticket).
-func parseTicket(ticket string) (*TicketInfo, error) {
+func parseTicket(input string) (*TicketInfo, error) {
 	var project string
-	fullInput := ticket
+	id := input
 
 	// Check for optional project prefix
-	if p, t, ok := strings.Cut(ticket, ":"); ok {
+	if p, t, ok := strings.Cut(input, ":"); ok {
 		if p == "" {
 			return nil, errors.New("invalid ticket format. Project name cannot be empty when using ':'")
 		}
 		project = p
-		ticket = t
+		id = t
 	}
 
-	// Match pattern: TYPE-ID where ID can be digits or alphanumeric (e.g., proj-123, rig-abc, beads-42f)
-	re := regexp.MustCompile(`^([a-zA-Z]+)-([a-zA-Z0-9]+)$`)
-	matches := re.FindStringSubmatch(ticket)
-
-	if len(matches) != 3 {
-		return nil, errors.New("invalid ticket format. Expected format: [project:]TYPE-ID (e.g., proj-123, rig:proj-123 or rig-abc)")
+	ticketType, number, err := ticket.Parse(id)
+	if err != nil {
+		return nil, err
 	}
 
 	return &TicketInfo{
-		Full:    fullInput,
+		Full:    input,
 		Project: project,
-		ID:      ticket,
-		Type:    strings.ToLower(matches[1]),
-		Number:  matches[2],
+		ID:      id,
+		Type:    ticketType,
+		Number:  number,
 	}, nil
 }
 
+// branchTemplateToken matches a {name} placeholder in git.branch_template
+var branchTemplateToken = regexp.MustCompile(`\{([a-zA-Z_]+)\}`)
+
+// branchTemplateVars returns the values for the git.branch_template tokens.
+// {slug} is derived from the Jira summary and is empty when none is available.
+func branchTemplateVars(ticketInfo *TicketInfo, jiraInfo *jira.TicketInfo) map[string]string {
+	vars := map[string]string{
+		"ticket": ticketInfo.ID,
+		"type":   ticketInfo.Type,
+		"slug":   "",
+		"user":   os.Getenv("USER"),
+	}
+	if jiraInfo != nil {
+		vars["slug"] = slugify(jiraInfo.Summary)
+	}
+	if vars["user"] == "" {
+		if u, err := user.Current(); err == nil {
+			vars["user"] = u.Username
+		}
+	}
+	return vars
+}
+
+// renderBranchName expands the tokens in template from vars and sanitizes
+// the result into a valid git branch name. Tokens without a value in vars
+// are an error.
+func renderBranchName(template string, vars map[string]string) (string, error) {
+	if template == "" {
+		template = "{ticket}"
+	}
+
+	var unknown []string
+	name := branchTemplateToken.ReplaceAllStringFunc(template, func(token string) string {
+		value, ok := vars[token[1:len(token)-1]]
+		if !ok {
+			unknown = append(unknown, token)
+		}
+		return value
+	})
+	if len(unknown) > 0 {
+		return "", errors.Newf("unknown token(s) in git.branch_template %q: %s", template, strings.Join(unknown, ", "))
+	}
+
+	name = sanitizeRefName(name)
+	if name == "" {
+		return "", errors.Newf("git.branch_template %q produced an empty branch name", template)
+	}
+	return name, nil
+}
+
+// sanitizeRefName rewrites name to satisfy git check-ref-format: characters
+// git forbids become "-", and each path component is stripped of "..",
+// leading dots and dashes, and a trailing ".lock"
+func sanitizeRefName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r <= ' ' || r == 0x7f || strings.ContainsRune("~^:?*[\\", r) {
+			b.WriteRune('-')
+			continue
+		}
+		b.WriteRune(r)
+	}
+	name = strings.ReplaceAll(b.String(), "@{", "-")
+
+	var parts []string
+	for _, part := range strings.Split(name, "/") {
+		for strings.Contains(part, "..") {
+			part = strings.ReplaceAll(part, "..", ".")
+		}
+		for strings.Contains(part, "--") {
+			part = strings.ReplaceAll(part, "--", "-")
+		}
+		part = strings.TrimSuffix(part, ".lock")
+		part = strings.Trim(part, ".-")
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	name = strings.Join(parts, "/")
+	if name == "@" {
+		return ""
+	}
+	return name
+}
+
+// maxSlugLength caps the {slug} token so branch names stay readable
+const maxSlugLength = 40
+
+// slugify lowercases s and joins its words with dashes
+func slugify(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	slug := ""
+	for _, w := range words {
+		next := w
+		if slug != "" {
+			next = slug + "-" + w
+		}
+		if len(next) > maxSlugLength {
+			if slug == "" {
+				slug = w[:maxSlugLength]
+			}
+			break
+		}
+		slug = next
+	}
+	return slug
+}
+
+// prTicketPattern matches a Jira-style ticket key in a branch name or PR title
+var prTicketPattern = regexp.MustCompile(`(?i)\b([a-z]+-[0-9]+)\b`)
+
+// ticketFromPR picks the ticket a pull request belongs to: a ticket key in
+// the head branch or title, then the first linked issue, and finally the PR
+// number itself.
+func ticketFromPR(pr *github.PRInfo) string {
+	for _, candidate := range []string{pr.HeadBranch, pr.Title} {
+		if m := prTicketPattern.FindStringSubmatch(candidate); m != nil {
+			return m[1]
+		}
+	}
+	if len(pr.LinkedIssues) > 0 {
+		return fmt.Sprintf("issue-%d", pr.LinkedIssues[0])
+	}
+	return fmt.Sprintf("pr-%d", pr.Number)
+}
+
+// workSource is the pull request rig work --pr starts from
+type workSource struct {
+	Repo        *git.RepoURL
+	PullRequest *github.PRInfo
+}
+
+// runWorkFromPR starts work on the head branch of the pull request at prURL.
+// ticketArg overrides the ticket detected from the pull request.
+func runWorkFromPR(prURL, ticketArg string) error {
+	repoURL, number, err := git.ParsePullRequestURL(prURL)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	ghClient, err := github.NewClient(&cfg.GitHub, verbose)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := networkContext(cfg.Network.Timeout)
+	pr, err := ghClient.GetPullRequest(ctx, repoURL.Owner, repoURL.Repo, number)
+	cancel()
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch pull request #%d", number)
+	}
+	if pr.HeadBranch == "" {
+		return errors.Newf("pull request #%d has no head branch", number)
+	}
+
+	ticketID := ticketArg
+	if ticketID == "" {
+		ticketID = ticketFromPR(pr)
+		if verbose {
+			fmt.Printf("Detected ticket %s from pull request #%d\n", ticketID, number)
+		}
+	}
+
+	return runWork(cfg, ticketID, &workSource{Repo: repoURL, PullRequest: pr})
+}
+
 func runWorkCommand(ticket string) error {
 	// Load configuration
 	cfg, err := loadConfig()
@@ -106,6 +318,12 @@ func runWorkCommand(ticket string) error {
 		return errors.Wrap(err, "failed to load configuration")
 	}
 
+	return runWork(cfg, ticket, nil)
+}
+
+// runWork runs the workflow for ticket. source is set when starting from a
+// pull request and nil otherwise.
+func runWork(cfg *config.Config, ticket string, source *workSource) error {
 	// Parse ticket
 	ticketInfo, err := parseTicket(ticket)
 	if err != nil {
@@ -121,8 +339,13 @@ func runWorkCommand(ticket string) error {
 		fmt.Printf("  Number: %s\n", ticketInfo.Number)
 	}
 
-	// Determine project context and switch to it
-	repoPath, err := resolveProjectContext(cfg, projectFlag, ticketInfo.Project)
+	// Determine project context and switch to it. A pull request names its
+	// repository, which stands in for a missing project prefix.
+	projectName := ticketInfo.Project
+	if projectName == "" && source != nil {
+		projectName = source.Repo.Repo
+	}
+	repoPath, err := resolveProjectContext(cfg, projectFlag, projectName)
 	if err != nil {
 		return err
 	}
@@ -134,41 +357,23 @@ func runWorkCommand(ticket string) error {
 		return errors.Wrapf(err, "failed to chdir to %s", repoPath)
 	}
 
-	// Step 1: Create git worktree
-	if verbose {
-		fmt.Printf("Creating git worktree in %s...\n", repoPath)
-	}
-	gitManager := git.NewWorktreeManagerAtPath(repoPath, cfg.Git.BaseBranch, verbose)
-
-	// Get repo info for notes
-	repoRoot, err := gitManager.GetRepoRoot()
-	if err != nil {
-		return err
-	}
-	repoName, err := gitManager.GetRepoName()
-	if err != nil {
-		return err
-	}
-
-	worktreePath, err := gitManager.CreateWorktree(ticketInfo.Type, ticketInfo.ID)
-	if err != nil {
-		return errors.Wrap(err, "failed to create git worktree")
-	}
-	fmt.Printf("Git worktree created at: %s\n", worktreePath)
-
-	// Step 2: Fetch JIRA details (if enabled)
+	// Step 1: Fetch JIRA details (if enabled); the summary feeds the branch name
 	var jiraInfo *jira.TicketInfo
+	var jiraClient jira.JiraClient
 	if cfg.Jira.Enabled {
 		if verbose {
 			fmt.Println("Fetching JIRA details...")
 		}
-		jiraClient, err := jira.NewJiraClient(&cfg.Jira, verbose)
+		jiraClient, err = jira.NewJiraClientForTicket(&cfg.Jira, ticketInfo.ID, verbose)
 		if err != nil {
 			if verbose {
 				fmt.Printf("Warning: Could not initialize JIRA client: %v\n", err)
 			}
+			jiraClient = nil
 		} else {
-			jiraInfo, err = jiraClient.FetchTicketDetails(ticketInfo.ID)
+			ctx, cancel := networkContext(cfg.Jira.Timeout)
+			jiraInfo, err = jira.FetchTicketDetailsContext(ctx, jiraClient, ticketInfo.ID)
+			cancel()
 			if err != nil {
 				if verbose {
 					fmt.Printf("Warning: Could not fetch JIRA details: %v\n", err)
@@ -181,7 +386,181 @@ func runWorkCommand(ticket string) error {
 		}
 	}
 
-	// Step 2b: Update beads status (if beads project detected)
+	// Step 2: Plan the worktree, note and session without side effects
+	gitManager := git.NewWorktreeManagerAtPath(repoPath, cfg.Git.BaseBranch, verbose)
+	gitManager.NetworkTimeout = cfg.Network.Timeout
+	plan, err := planWork(cfg, gitManager, ticketInfo, jiraInfo)
+	if err != nil {
+		return err
+	}
+	if source != nil {
+		plan.BranchName = source.PullRequest.HeadBranch
+		plan.PullRequest = source.PullRequest
+	}
+
+	if workDryRun {
+		printWorkPlan(os.Stdout, plan)
+		return nil
+	}
+
+	return executeWorkPlan(cfg, gitManager, plan, jiraClient, jiraInfo)
+}
+
+// workPlan describes what rig work will create or reuse for a ticket
+type workPlan struct {
+	Ticket         *TicketInfo
+	RepoRoot       string
+	RepoName       string
+	BranchName     string
+	WorktreePath   string
+	WorktreeExists bool
+	Recreate       bool
+	NotePath       string // Empty when notes are skipped
+	NoteExists     bool
+	DailyNotePath  string
+	SessionName    string
+	SessionExists  bool
+	SessionSkipped string         // Why no session will be created; empty when one will be
+	PullRequest    *github.PRInfo // Set by --pr; its head branch is checked out
+}
+
+// planWork resolves the names and paths rig work would use for a ticket.
+// It only inspects the repository, notes directory and tmux; nothing is created.
+func planWork(cfg *config.Config, gitManager *git.WorktreeManager, ticketInfo *TicketInfo, jiraInfo *jira.TicketInfo) (*workPlan, error) {
+	repoRoot, err := gitManager.GetRepoRoot()
+	if err != nil {
+		return nil, err
+	}
+	repoName, err := gitManager.GetRepoName()
+	if err != nil {
+		return nil, err
+	}
+
+	branchName, err := renderBranchName(cfg.Git.BranchTemplate, branchTemplateVars(ticketInfo, jiraInfo))
+	if err != nil {
+		return nil, err
+	}
+
+	// The worktree directory keeps the raw ticket so sync and clean can match it
+	worktreePath, err := gitManager.GetWorktreePath(ticketInfo.Type, ticketInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &workPlan{
+		Ticket:         ticketInfo,
+		RepoRoot:       repoRoot,
+		RepoName:       repoName,
+		BranchName:     branchName,
+		WorktreePath:   worktreePath,
+		WorktreeExists: pathExists(worktreePath),
+		Recreate:       workRecreate,
+	}
+
+	noteManager := newNoteManager(cfg)
+	if !workNoNotes {
+		plan.NotePath, err = noteManager.GetNotePath(ticketInfo.Type, ticketInfo.ID)
+		if err != nil {
+			return nil, err
+		}
+		plan.NoteExists = pathExists(plan.NotePath)
+	}
+	plan.DailyNotePath = noteManager.GetDailyNotePath()
+
+	sessionManager := tmux.NewSessionManager(cfg.Tmux.SessionPrefix, nil, verbose)
+	plan.SessionName = sessionManager.GetSessionName(ticketInfo.SessionID())
+	switch {
+	case workNoSession:
+		plan.SessionSkipped = "--no-session"
+	case !sessionManager.IsAvailable():
+		// Degrade rather than fail so rig work still runs on headless machines
+		plan.SessionSkipped = "tmux not installed"
+	default:
+		plan.SessionExists = sessionManager.SessionExists(plan.SessionName)
+	}
+
+	return plan, nil
+}
+
+// printWorkPlan describes the actions in plan for --dry-run
+func printWorkPlan(w io.Writer, plan *workPlan) {
+	fmt.Fprintf(w, "Dry run for %s (no changes made)\n\n", plan.Ticket.Full)
+	fmt.Fprintf(w, "  Repository:   %s (%s)\n", plan.RepoName, plan.RepoRoot)
+
+	worktreeAction := "would be created"
+	switch {
+	case plan.WorktreeExists && plan.Recreate:
+		worktreeAction = "exists, would be removed and recreated"
+	case plan.WorktreeExists:
+		worktreeAction = "exists, would be reused"
+	}
+	fmt.Fprintf(w, "  Worktree:     %s (%s)\n", plan.WorktreePath, worktreeAction)
+	fmt.Fprintf(w, "  Branch:       %s\n", plan.BranchName)
+	if plan.PullRequest != nil {
+		fmt.Fprintf(w, "  Pull request: #%d %s\n", plan.PullRequest.Number, plan.PullRequest.Title)
+	}
+
+	switch {
+	case plan.NotePath == "":
+		fmt.Fprintln(w, "  Note:         skipped (--no-notes)")
+	case plan.NoteExists:
+		fmt.Fprintf(w, "  Note:         %s (exists, would be reused)\n", plan.NotePath)
+	default:
+		fmt.Fprintf(w, "  Note:         %s (would be created)\n", plan.NotePath)
+	}
+	fmt.Fprintf(w, "  Daily note:   %s (would be updated)\n", plan.DailyNotePath)
+
+	switch {
+	case plan.SessionSkipped != "":
+		fmt.Fprintf(w, "  Tmux session: skipped (%s)\n", plan.SessionSkipped)
+	case plan.SessionExists:
+		fmt.Fprintf(w, "  Tmux session: %s (exists, would attach)\n", plan.SessionName)
+	default:
+		fmt.Fprintf(w, "  Tmux session: %s (would be created)\n", plan.SessionName)
+	}
+}
+
+// executeWorkPlan creates the worktree, note and session described by plan
+func executeWorkPlan(cfg *config.Config, gitManager *git.WorktreeManager, plan *workPlan, jiraClient jira.JiraClient, jiraInfo *jira.TicketInfo) error {
+	ticketInfo := plan.Ticket
+	repoRoot := plan.RepoRoot
+	repoName := plan.RepoName
+
+	hookEnv := hooks.Env{Ticket: ticketInfo.ID, Worktree: plan.WorktreePath, GitRoot: repoRoot}
+	if err := runHook(cfg, "pre_work", cfg.Hooks.PreWork, hookEnv); err != nil {
+		return err
+	}
+
+	// Step 3: Create git worktree
+	if verbose {
+		fmt.Printf("Creating git worktree in %s...\n", repoRoot)
+	}
+
+	if plan.Recreate {
+		if err := recreateWorktree(gitManager, ticketInfo); err != nil {
+			return err
+		}
+	}
+
+	if plan.PullRequest != nil {
+		if err := gitManager.FetchPullRequest(plan.PullRequest.Number, plan.BranchName); err != nil {
+			return err
+		}
+	}
+
+	worktreePath, err := gitManager.CreateWorktreeWithBranch(ticketInfo.Type, ticketInfo.ID, plan.BranchName)
+	if err != nil {
+		return errors.Wrap(err, "failed to create git worktree")
+	}
+	fmt.Printf("Git worktree ready at: %s\n", worktreePath)
+	if !plan.WorktreeExists || plan.Recreate {
+		lifecycleEvents().Emit(events.WorktreeCreated, ticketInfo.ID, map[string]string{
+			"path":   worktreePath,
+			"branch": plan.BranchName,
+		})
+	}
+
+	// Step 3b: Update beads status (if beads project detected)
 	var beadsInfo *beads.IssueInfo
 	router := workflow.NewTicketRouter(cfg, worktreePath, verbose)
 	ticketSource := router.RouteTicket(ticketInfo.ID)
@@ -217,13 +596,8 @@ func runWorkCommand(ticket string) error {
 		}
 	}
 
-	// Step 3: Create/update note (unless --no-notes flag is set)
-	noteManager := notes.NewManager(
-		cfg.Notes.Path,
-		cfg.Notes.DailyDir,
-		cfg.Notes.TemplateDir,
-		verbose,
-	)
+	// Step 4: Create/update note (unless --no-notes flag is set)
+	noteManager := newNoteManager(cfg)
 
 	var notePath string
 	if !workNoNotes {
@@ -242,13 +616,18 @@ func runWorkCommand(ticket string) error {
 
 		// Add issue info if available (beads takes precedence over JIRA)
 		if beadsInfo != nil {
+			noteData.IssueType = beadsInfo.Type
 			noteData.Summary = beadsInfo.Title
 			noteData.Status = beadsInfo.Status
 			noteData.Description = beadsInfo.Description
 		} else if jiraInfo != nil {
+			noteData.IssueType = jiraInfo.Type
 			noteData.Summary = jiraInfo.Summary
 			noteData.Status = jiraInfo.Status
 			noteData.Description = jiraInfo.Description
+		} else if plan.PullRequest != nil {
+			noteData.Summary = plan.PullRequest.Title
+			noteData.Description = plan.PullRequest.URL
 		}
 
 		result, err := noteManager.CreateTicketNote(noteData)
@@ -257,13 +636,14 @@ func runWorkCommand(ticket string) error {
 		}
 		if result.Created {
 			fmt.Printf("Note created at: %s\n", result.Path)
+			lifecycleEvents().Emit(events.NoteCreated, ticketInfo.ID, map[string]string{"path": result.Path})
 		} else {
 			fmt.Printf("Opened existing note: %s\n", result.Path)
 		}
 		notePath = result.Path
 	}
 
-	// Step 4: Update daily note
+	// Step 5: Update daily note
 	if verbose {
 		fmt.Println("Updating daily note...")
 	}
@@ -277,27 +657,58 @@ func runWorkCommand(ticket string) error {
 		fmt.Println("Daily note updated")
 	}
 
-	// Step 5: Create tmux session
+	// Step 5b: Move the Jira ticket to its started status (if configured)
+	if ticketSource != workflow.TicketSourceBeads {
+		ctx, cancel := networkContext(cfg.Jira.Timeout)
+		startJiraTransition(ctx, jiraClient, cfg.Jira.ForTicket(ticketInfo.ID).StartTransition, ticketInfo)
+		if cfg.Jira.AssignOnStart {
+			assignJiraTicket(ctx, jiraClient, ticketInfo)
+		}
+		cancel()
+	}
+
+	// Step 6: Create tmux session
+	if plan.SessionSkipped != "" {
+		if verbose {
+			fmt.Printf("Warning: Skipping tmux session (%s)\n", plan.SessionSkipped)
+		}
+	} else {
+		createWorkSession(cfg, ticketInfo, worktreePath, notePath)
+	}
+
+	fmt.Printf("\nWorkflow initialization for %s completed successfully!\n", ticketInfo.Full)
+	fmt.Printf("Worktree: %s\n", worktreePath)
+	if notePath != "" {
+		fmt.Printf("Note: %s\n", notePath)
+	}
+
+	hookEnv.Worktree = worktreePath
+	if err := runHook(cfg, "post_work", cfg.Hooks.PostWork, hookEnv); err != nil {
+		return err
+	}
+
+	if notePath != "" && (workEdit || cfg.Notes.OpenAfterCreate) {
+		return editNote(cfg, notePath)
+	}
+
+	return nil
+}
+
+// createWorkSession creates the tmux session for a ticket. Failures only warn
+// so the rest of the workflow still completes.
+func createWorkSession(cfg *config.Config, ticketInfo *TicketInfo, worktreePath, notePath string) {
 	if verbose {
 		fmt.Println("Creating tmux session...")
 	}
 
 	// Convert config windows to tmux windows
-	tmuxWindows := make([]tmux.WindowConfig, 0, len(cfg.Tmux.Windows))
-	for _, window := range cfg.Tmux.Windows {
-		tmuxWindows = append(tmuxWindows, tmux.WindowConfig{
-			Name:       window.Name,
-			Command:    window.Command,
-			WorkingDir: window.WorkingDir,
-		})
-	}
+	tmuxWindows := tmuxWindowsFromConfig(cfg.Tmux.Windows)
 
 	// Use sanitized ticket for session name (no colons)
 	sessionID := ticketInfo.SessionID()
 
 	sessionManager := tmux.NewSessionManager(cfg.Tmux.SessionPrefix, tmuxWindows, verbose)
-	err = sessionManager.CreateSession(sessionID, worktreePath, notePath)
-	if err != nil {
+	if err := sessionManager.CreateSession(sessionID, worktreePath, notePath); err != nil {
 		// Don't fail the entire process if tmux session creation fails
 		if verbose {
 			fmt.Printf("Warning: Could not create tmux session: %v\n", err)
@@ -305,13 +716,74 @@ func runWorkCommand(ticket string) error {
 		fmt.Println("Warning: Tmux session creation failed, but other steps completed successfully")
 	} else {
 		fmt.Println("Tmux session created successfully")
+		lifecycleEvents().Emit(events.SessionStarted, ticketInfo.ID, map[string]string{
+			"session": sessionManager.GetSessionName(sessionID),
+		})
 	}
+}
 
-	fmt.Printf("\nWorkflow initialization for %s completed successfully!\n", ticketInfo.Full)
-	fmt.Printf("Worktree: %s\n", worktreePath)
-	if notePath != "" {
-		fmt.Printf("Note: %s\n", notePath)
+// startJiraTransition moves the ticket to the status named by
+// jira.start_transition. Incident tickets are skipped, as sync skips Jira for
+// them, and failures only warn so the rest of the workflow still completes.
+func startJiraTransition(ctx context.Context, jiraClient jira.JiraClient, status string, ticketInfo *TicketInfo) {
+	if jiraClient == nil || status == "" || ticketInfo.Type == "incident" {
+		return
+	}
+
+	if verbose {
+		fmt.Printf("Transitioning %s to %q...\n", ticketInfo.ID, status)
+	}
+	if err := jira.TransitionTicketByNameContext(ctx, jiraClient, ticketInfo.ID, status); err != nil {
+		if verbose {
+			fmt.Printf("Warning: Could not transition JIRA ticket to %q: %v\n", status, err)
+		}
+		return
+	}
+	if verbose {
+		fmt.Printf("JIRA ticket transitioned to %q\n", status)
+	}
+	lifecycleEvents().Emit(events.JiraTransitioned, ticketInfo.ID, map[string]string{"status": status})
+}
+
+// assignJiraTicket assigns the ticket to the current Jira user. Like the
+// start transition, failures are reported with --verbose and never stop the
+// workflow.
+func assignJiraTicket(ctx context.Context, jiraClient jira.JiraClient, ticketInfo *TicketInfo) {
+	if jiraClient == nil || ticketInfo.Type == "incident" {
+		return
+	}
+
+	if verbose {
+		fmt.Printf("Assigning %s to you...\n", ticketInfo.ID)
+	}
+	if err := jira.AssignToCurrentUser(ctx, jiraClient, ticketInfo.ID); err != nil {
+		if verbose {
+			fmt.Printf("Warning: Could not assign JIRA ticket: %v\n", err)
+		}
+		return
+	}
+	if verbose {
+		fmt.Println("JIRA ticket assigned to you")
 	}
+}
 
+// recreateWorktree removes the ticket's existing worktree so it is created
+// again from scratch. The branch is kept, so committed work is not lost, and
+// git refuses to remove a worktree with uncommitted changes.
+func recreateWorktree(gitManager *git.WorktreeManager, ticketInfo *TicketInfo) error {
+	path, err := gitManager.GetWorktreePath(ticketInfo.Type, ticketInfo.ID)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	if verbose {
+		fmt.Printf("Removing existing worktree at %s...\n", path)
+	}
+	if err := gitManager.RemoveWorktree(ticketInfo.Type, ticketInfo.ID); err != nil {
+		return errors.Wrap(err, "failed to remove existing worktree (commit or stash changes first)")
+	}
 	return nil
 }
@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -9,6 +13,11 @@ import (
 	"time"
 
 	"github.com/spf13/viper"
+
+	"thoreinstein.com/rig/pkg/events"
+	"thoreinstein.com/rig/pkg/git"
+	"thoreinstein.com/rig/pkg/github"
+	"thoreinstein.com/rig/pkg/jira"
 )
 
 func TestParseTicket(t *testing.T) {
@@ -206,8 +215,8 @@ func TestTicketInfo(t *testing.T) {
 func TestWorkCommandDescription(t *testing.T) {
 	cmd := workCmd
 
-	if cmd.Use != "work <ticket>" {
-		t.Errorf("work command Use = %q, want %q", cmd.Use, "work <ticket>")
+	if cmd.Use != "work [ticket]" {
+		t.Errorf("work command Use = %q, want %q", cmd.Use, "work [ticket]")
 	}
 
 	if cmd.Short == "" {
@@ -749,3 +758,447 @@ func TestRunWorkCommand_PreservesOriginalTicketCase(t *testing.T) {
 		t.Errorf("Note content should preserve original ticket case, got: %s", string(noteContent))
 	}
 }
+
+func TestWorkCommandRecreateFlag(t *testing.T) {
+	flag := workCmd.Flags().Lookup("recreate")
+	if flag == nil {
+		t.Fatal("work command should have --recreate flag")
+	}
+	if flag.DefValue != "false" {
+		t.Errorf("--recreate default = %s, want false", flag.DefValue)
+	}
+}
+
+func TestRenderBranchName(t *testing.T) {
+	vars := map[string]string{
+		"ticket": "FRAAS-123",
+		"type":   "fraas",
+		"slug":   "fix-login-redirect",
+		"user":   "jdoe",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		vars     map[string]string
+		want     string
+		wantErr  bool
+	}{
+		{name: "default template", template: "{ticket}", vars: vars, want: "FRAAS-123"},
+		{name: "empty template uses default", template: "", vars: vars, want: "FRAAS-123"},
+		{name: "feature prefix with slug", template: "feature/{ticket}-{slug}", vars: vars, want: "feature/FRAAS-123-fix-login-redirect"},
+		{name: "user and type", template: "{user}/{type}/{ticket}", vars: vars, want: "jdoe/fraas/FRAAS-123"},
+		{
+			name:     "empty slug leaves no trailing dash",
+			template: "feature/{ticket}-{slug}",
+			vars:     map[string]string{"ticket": "FRAAS-123", "slug": ""},
+			want:     "feature/FRAAS-123",
+		},
+		{
+			name:     "invalid characters sanitized",
+			template: "{user}/{ticket}",
+			vars:     map[string]string{"user": "John Doe", "ticket": "a..b:c"},
+			want:     "John-Doe/a.b-c",
+		},
+		{name: "unknown token", template: "{ticket}-{sprint}", vars: vars, wantErr: true},
+		{name: "empty result", template: "{slug}", vars: map[string]string{"slug": ""}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderBranchName(tt.template, tt.vars)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("renderBranchName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("renderBranchName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeRefName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"FRAAS-123", "FRAAS-123"},
+		{"feature//FRAAS-123", "feature/FRAAS-123"},
+		{"/leading/and/trailing/", "leading/and/trailing"},
+		{".hidden/name.lock", "hidden/name"},
+		{"a~b^c:d?e*f[g\\h", "a-b-c-d-e-f-g-h"},
+		{"ref@{1}", "ref-1}"},
+		{"dots...here", "dots.here"},
+		{"@", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := sanitizeRefName(tt.input); got != tt.want {
+				t.Errorf("sanitizeRefName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Fix login redirect", "fix-login-redirect"},
+		{"  [API] Handle 500's gracefully!  ", "api-handle-500-s-gracefully"},
+		{"", ""},
+		{"Refactor the authentication middleware to support multiple providers", "refactor-the-authentication-middleware"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := slugify(tt.input)
+			if got != tt.want {
+				t.Errorf("slugify(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if len(got) > maxSlugLength {
+				t.Errorf("slugify(%q) length = %d, want <= %d", tt.input, len(got), maxSlugLength)
+			}
+		})
+	}
+}
+
+func TestPlanWork(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	if out, err := exec.Command("git", "init", "--bare", repoDir).CombinedOutput(); err != nil {
+		t.Fatalf("git init --bare failed: %v\n%s", err, out)
+	}
+	notesDir := t.TempDir()
+	setupWorkTestConfig(t, notesDir)
+	viper.Set("git.branch_template", "feature/{ticket}-{slug}")
+	defer viper.Reset()
+
+	// An existing note is reported as reused
+	if err := os.MkdirAll(filepath.Join(notesDir, "proj"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(notesDir, "proj", "proj-123.md"), []byte("# proj-123\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+	ticketInfo, err := parseTicket("proj-123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gitManager := git.NewWorktreeManagerAtPath(repoDir, "", false)
+	plan, err := planWork(cfg, gitManager, ticketInfo, &jira.TicketInfo{Summary: "Fix login redirect"})
+	if err != nil {
+		t.Fatalf("planWork() error = %v", err)
+	}
+
+	if plan.WorktreePath != filepath.Join(repoDir, "proj", "proj-123") {
+		t.Errorf("WorktreePath = %q", plan.WorktreePath)
+	}
+	if plan.WorktreeExists {
+		t.Error("WorktreeExists = true, want false")
+	}
+	if plan.BranchName != "feature/proj-123-fix-login-redirect" {
+		t.Errorf("BranchName = %q", plan.BranchName)
+	}
+	if plan.NotePath != filepath.Join(notesDir, "proj", "proj-123.md") || !plan.NoteExists {
+		t.Errorf("NotePath = %q, NoteExists = %v", plan.NotePath, plan.NoteExists)
+	}
+	if plan.SessionName != "test-proj-123" {
+		t.Errorf("SessionName = %q, want test-proj-123", plan.SessionName)
+	}
+
+	// Planning must not create the worktree or type directory
+	if _, err := os.Stat(filepath.Join(repoDir, "proj")); !os.IsNotExist(err) {
+		t.Errorf("planWork() should not create %s", filepath.Join(repoDir, "proj"))
+	}
+}
+
+func TestPrintWorkPlan(t *testing.T) {
+	plan := &workPlan{
+		Ticket:        &TicketInfo{Full: "proj-123", ID: "proj-123", Type: "proj"},
+		RepoRoot:      "/src/repo",
+		RepoName:      "repo",
+		BranchName:    "feature/proj-123",
+		WorktreePath:  "/src/repo/proj/proj-123",
+		NotePath:      "/notes/proj/proj-123.md",
+		DailyNotePath: "/notes/daily/2026-01-02.md",
+		SessionName:   "rig-proj-123",
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *workPlan)
+		want   []string
+	}{
+		{
+			name: "fresh ticket",
+			want: []string{
+				"Dry run for proj-123 (no changes made)",
+				"/src/repo/proj/proj-123 (would be created)",
+				"Branch:       feature/proj-123",
+				"/notes/proj/proj-123.md (would be created)",
+				"/notes/daily/2026-01-02.md (would be updated)",
+				"rig-proj-123 (would be created)",
+			},
+		},
+		{
+			name: "existing worktree and session",
+			modify: func(p *workPlan) {
+				p.WorktreeExists = true
+				p.SessionExists = true
+				p.NoteExists = true
+			},
+			want: []string{
+				"(exists, would be reused)",
+				"/notes/proj/proj-123.md (exists, would be reused)",
+				"rig-proj-123 (exists, would attach)",
+			},
+		},
+		{
+			name: "recreate and no notes",
+			modify: func(p *workPlan) {
+				p.WorktreeExists = true
+				p.Recreate = true
+				p.NotePath = ""
+			},
+			want: []string{
+				"(exists, would be removed and recreated)",
+				"Note:         skipped (--no-notes)",
+			},
+		},
+		{
+			name: "session skipped",
+			modify: func(p *workPlan) {
+				p.SessionSkipped = "tmux not installed"
+			},
+			want: []string{
+				"Tmux session: skipped (tmux not installed)",
+			},
+		},
+		{
+			name: "pull request",
+			modify: func(p *workPlan) {
+				p.PullRequest = &github.PRInfo{Number: 42, Title: "Add login"}
+			},
+			want: []string{
+				"Pull request: #42 Add login",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := *plan
+			if tt.modify != nil {
+				tt.modify(&p)
+			}
+
+			var buf bytes.Buffer
+			printWorkPlan(&buf, &p)
+
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestTicketFromPR(t *testing.T) {
+	tests := []struct {
+		name string
+		pr   *github.PRInfo
+		want string
+	}{
+		{
+			name: "ticket in head branch",
+			pr:   &github.PRInfo{Number: 42, HeadBranch: "feature/PROJ-123-login", Title: "OPS-9 Login"},
+			want: "PROJ-123",
+		},
+		{
+			name: "ticket in title",
+			pr:   &github.PRInfo{Number: 42, HeadBranch: "login", Title: "[OPS-9] Add login"},
+			want: "OPS-9",
+		},
+		{
+			name: "linked issue",
+			pr:   &github.PRInfo{Number: 42, HeadBranch: "login", Title: "Add login", LinkedIssues: []int{17, 18}},
+			want: "issue-17",
+		},
+		{
+			name: "falls back to PR number",
+			pr:   &github.PRInfo{Number: 42, HeadBranch: "login", Title: "Add login"},
+			want: "pr-42",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ticketFromPR(tt.pr)
+			if got != tt.want {
+				t.Errorf("ticketFromPR() = %q, want %q", got, tt.want)
+			}
+			if _, err := parseTicket(got); err != nil {
+				t.Errorf("ticketFromPR() = %q is not a valid ticket: %v", got, err)
+			}
+		})
+	}
+}
+
+// fakeJiraClient records transitions requested by the work command
+type fakeJiraClient struct {
+	transitionErr error
+	transitioned  []string
+}
+
+func (f *fakeJiraClient) IsAvailable() bool { return true }
+
+func (f *fakeJiraClient) FetchTicketDetails(ticket string) (*jira.TicketInfo, error) {
+	return &jira.TicketInfo{}, nil
+}
+
+func (f *fakeJiraClient) GetTransitions(ticket string) ([]jira.Transition, error) {
+	return nil, nil
+}
+
+func (f *fakeJiraClient) TransitionTicket(ticket string, transitionID string) error {
+	return nil
+}
+
+func (f *fakeJiraClient) TransitionTicketByName(ticket string, statusName string) error {
+	f.transitioned = append(f.transitioned, ticket+"->"+statusName)
+	return f.transitionErr
+}
+
+func TestStartJiraTransition(t *testing.T) {
+	tests := []struct {
+		name          string
+		ticket        string
+		status        string
+		transitionErr error
+		want          []string
+	}{
+		{name: "transitions ticket", ticket: "proj-123", status: "In Progress", want: []string{"proj-123->In Progress"}},
+		{name: "no status configured", ticket: "proj-123", status: ""},
+		{name: "incident skipped", ticket: "incident-42", status: "In Progress"},
+		{
+			name:          "failure does not panic",
+			ticket:        "proj-123",
+			status:        "In Progress",
+			transitionErr: errors.New("no transition to In Progress"),
+			want:          []string{"proj-123->In Progress"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticketInfo, err := parseTicket(tt.ticket)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := &fakeJiraClient{transitionErr: tt.transitionErr}
+			startJiraTransition(context.Background(), client, tt.status, ticketInfo)
+
+			if strings.Join(client.transitioned, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("transitions = %v, want %v", client.transitioned, tt.want)
+			}
+		})
+	}
+
+	// A nil client (Jira disabled or unavailable) is a no-op
+	ticketInfo, _ := parseTicket("proj-123")
+	startJiraTransition(context.Background(), nil, "In Progress", ticketInfo)
+}
+
+func TestStartJiraTransition_EmitsEvent(t *testing.T) {
+	var buf bytes.Buffer
+	lifecycleEventsOnce.Do(func() {})
+	saved := lifecycleEmitter
+	lifecycleEmitter = events.NewEmitter(&buf)
+	defer func() { lifecycleEmitter = saved }()
+
+	ticketInfo, _ := parseTicket("proj-123")
+	startJiraTransition(context.Background(), &fakeJiraClient{}, "In Progress", ticketInfo)
+
+	var got events.Event
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("expected one JSON event, got %q: %v", buf.String(), err)
+	}
+	if got.Type != events.JiraTransitioned || got.Ticket != "proj-123" || got.Data["status"] != "In Progress" {
+		t.Errorf("unexpected event: %+v", got)
+	}
+
+	buf.Reset()
+	startJiraTransition(context.Background(), &fakeJiraClient{transitionErr: errors.New("denied")}, "In Progress", ticketInfo)
+	if buf.Len() != 0 {
+		t.Errorf("failed transition emitted %q", buf.String())
+	}
+}
+
+// fakeAssigningJiraClient records assignments requested by the work command
+type fakeAssigningJiraClient struct {
+	fakeJiraClient
+	assignErr error
+	assigned  []string
+}
+
+func (f *fakeAssigningJiraClient) GetCurrentUserContext(ctx context.Context) (string, error) {
+	return "me", nil
+}
+
+func (f *fakeAssigningJiraClient) AssignTicketContext(ctx context.Context, ticket string, accountID string) error {
+	f.assigned = append(f.assigned, ticket+"->"+accountID)
+	return f.assignErr
+}
+
+func TestAssignJiraTicket(t *testing.T) {
+	tests := []struct {
+		name      string
+		ticket    string
+		assignErr error
+		want      []string
+	}{
+		{name: "assigns ticket", ticket: "proj-123", want: []string{"proj-123->me"}},
+		{name: "incident skipped", ticket: "incident-42"},
+		{
+			name:      "failure does not panic",
+			ticket:    "proj-123",
+			assignErr: errors.New("access denied"),
+			want:      []string{"proj-123->me"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticketInfo, err := parseTicket(tt.ticket)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := &fakeAssigningJiraClient{assignErr: tt.assignErr}
+			assignJiraTicket(context.Background(), client, ticketInfo)
+
+			if strings.Join(client.assigned, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("assignments = %v, want %v", client.assigned, tt.want)
+			}
+		})
+	}
+
+	// Clients without assignment support (acli mode) and nil clients are no-ops
+	ticketInfo, _ := parseTicket("proj-123")
+	assignJiraTicket(context.Background(), &fakeJiraClient{}, ticketInfo)
+	assignJiraTicket(context.Background(), nil, ticketInfo)
+}
@@ -201,7 +201,7 @@ func (p *AnthropicProvider) StreamChat(ctx context.Context, messages []Message)
 
 	p.setHeaders(req)
 
-	resp, err := p.client.Do(req)
+	resp, err := doWithRetry(p.client, req, p.logDebug)
 	if err != nil {
 		return nil, rigerrors.NewAIErrorWithCause(ProviderAnthropic, "StreamChat",
 			"request failed", err)
@@ -336,7 +336,7 @@ func (p *AnthropicProvider) doRequest(ctx context.Context, reqBody anthropicRequ
 
 	p.setHeaders(req)
 
-	resp, err := p.client.Do(req)
+	resp, err := doWithRetry(p.client, req, p.logDebug)
 	if err != nil {
 		return nil, rigerrors.NewAIErrorWithCause(ProviderAnthropic, "Chat",
 			"request failed", err)
@@ -98,6 +98,14 @@ func (c *Conversation) collectStreamResponse(ctx context.Context, in <-chan Stre
 	}
 }
 
+// RemoveLastMessage drops the most recent message from the history.
+// It is used to roll back a user turn that never received a reply.
+func (c *Conversation) RemoveLastMessage() {
+	if len(c.messages) > 0 {
+		c.messages = c.messages[:len(c.messages)-1]
+	}
+}
+
 // Clear resets the conversation history while keeping the system prompt.
 func (c *Conversation) Clear() {
 	c.messages = make([]Message, 0)
@@ -184,7 +184,7 @@ func (p *GroqProvider) StreamChat(ctx context.Context, messages []Message) (<-ch
 
 	p.setHeaders(req)
 
-	resp, err := p.client.Do(req)
+	resp, err := doWithRetry(p.client, req, p.logDebug)
 	if err != nil {
 		return nil, rigerrors.NewAIErrorWithCause(ProviderGroq, "StreamChat",
 			"request failed", err)
@@ -307,7 +307,7 @@ func (p *GroqProvider) doRequest(ctx context.Context, reqBody openAIRequest) ([]
 
 	p.setHeaders(req)
 
-	resp, err := p.client.Do(req)
+	resp, err := doWithRetry(p.client, req, p.logDebug)
 	if err != nil {
 		return nil, rigerrors.NewAIErrorWithCause(ProviderGroq, "Chat",
 			"request failed", err)
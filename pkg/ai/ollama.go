@@ -159,7 +159,7 @@ func (p *OllamaProvider) StreamChat(ctx context.Context, messages []Message) (<-
 
 	p.setHeaders(req)
 
-	resp, err := p.client.Do(req)
+	resp, err := doWithRetry(p.client, req, p.logDebug)
 	if err != nil {
 		return nil, rigerrors.NewAIErrorWithCause(ProviderOllama, "StreamChat",
 			"request failed", err)
@@ -268,7 +268,7 @@ func (p *OllamaProvider) doRequest(ctx context.Context, reqBody ollamaRequest) (
 
 	p.setHeaders(req)
 
-	resp, err := p.client.Do(req)
+	resp, err := doWithRetry(p.client, req, p.logDebug)
 	if err != nil {
 		return nil, rigerrors.NewAIErrorWithCause(ProviderOllama, "Chat",
 			"request failed", err)
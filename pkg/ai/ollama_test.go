@@ -194,6 +194,8 @@ func TestOllamaProvider_Chat_IncompleteResponse(t *testing.T) {
 }
 
 func TestOllamaProvider_Chat_HTTPErrors(t *testing.T) {
+	useFastRetry(t)
+
 	tests := []struct {
 		name           string
 		statusCode     int
@@ -577,6 +579,8 @@ func TestOllamaProvider_Chat_MultipleMessages(t *testing.T) {
 }
 
 func TestOllamaProvider_handleErrorResponse(t *testing.T) {
+	useFastRetry(t)
+
 	tests := []struct {
 		name           string
 		statusCode     int
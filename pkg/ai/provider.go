@@ -12,6 +12,7 @@ import (
 
 	"thoreinstein.com/rig/pkg/config"
 	rigerrors "thoreinstein.com/rig/pkg/errors"
+	rlog "thoreinstein.com/rig/pkg/log"
 )
 
 // Message represents a conversation message.
@@ -62,7 +63,10 @@ const (
 // NewProvider creates an AI provider based on config.
 // Environment variables take precedence over config file values for API keys.
 // When model is empty, provider-specific default models from config are used.
-func NewProvider(cfg *config.AIConfig, verbose bool) (Provider, error) {
+// A positive cfg.Timeout bounds each request (see WithTimeout).
+// Providers log through pkg/log, so debug output follows --log-level (and
+// --verbose) rather than the verbose argument.
+func NewProvider(cfg *config.AIConfig, _ bool) (Provider, error) {
 	if cfg == nil {
 		return nil, rigerrors.NewConfigError("ai", "config is nil")
 	}
@@ -71,11 +75,16 @@ func NewProvider(cfg *config.AIConfig, verbose bool) (Provider, error) {
 		return nil, rigerrors.NewConfigError("ai.enabled", "AI is disabled in configuration")
 	}
 
-	var logger *slog.Logger
-	if verbose {
-		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	provider, err := newProvider(cfg, rlog.Logger())
+	if err != nil {
+		return nil, err
 	}
+	return WithTimeout(provider, cfg.Timeout), nil
+}
 
+// newProvider constructs the provider named by cfg.Provider. Debug output is
+// governed by the process-wide --log-level.
+func newProvider(cfg *config.AIConfig, logger *slog.Logger) (Provider, error) {
 	switch cfg.Provider {
 	case ProviderAnthropic:
 		apiKey := resolveAnthropicAPIKey(cfg.APIKey)
@@ -0,0 +1,49 @@
+package ai
+
+import (
+	"net/http"
+	"time"
+
+	"thoreinstein.com/rig/pkg/retry"
+)
+
+// providerRetry is the retry policy used by the HTTP providers. A
+// server-supplied Retry-After is capped at the same 30s as the backoff.
+var providerRetry = retry.Policy{
+	MaxRetries:    retry.DefaultMaxRetries,
+	BaseDelay:     retry.DefaultBaseDelay,
+	MaxDelay:      retry.DefaultMaxDelay,
+	Jitter:        retry.DefaultJitter,
+	MaxRetryAfter: retry.DefaultMaxDelay,
+}
+
+// isRetryableStatus reports whether a provider response is worth retrying:
+// rate limiting (429), gateway and availability errors (502, 503, 504), and
+// Anthropic's overloaded status (529). The request was not processed in any
+// of these cases, so retrying does not repeat work.
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout, 529:
+		return true
+	default:
+		return false
+	}
+}
+
+// doWithRetry sends req with client under providerRetry, resending it on a
+// retryable status. When retries run out the last response is returned as
+// is, so the caller's error handling reports the provider's message.
+// Streaming callers get their retries here too, since nothing has been read
+// from the stream yet.
+func doWithRetry(client *http.Client, req *http.Request, logDebug func(msg string, args ...any)) (*http.Response, error) {
+	policy := providerRetry
+	policy.OnRetry = func(attempt int, delay time.Duration, err error) {
+		logDebug("retrying request", "error", err,
+			"delay", delay.Round(time.Millisecond), "attempt", attempt+1, "max_attempts", policy.MaxRetries)
+	}
+
+	return retry.RetryableHTTP(client, req, policy, func(resp *http.Response) bool {
+		return isRetryableStatus(resp.StatusCode)
+	})
+}
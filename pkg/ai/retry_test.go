@@ -0,0 +1,181 @@
+package ai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"thoreinstein.com/rig/pkg/retry"
+)
+
+// useFastRetry shortens providerRetry delays for the duration of a test
+func useFastRetry(t *testing.T) {
+	t.Helper()
+	saved := providerRetry
+	providerRetry = retry.Policy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond, MaxRetryAfter: 10 * time.Millisecond}
+	t.Cleanup(func() { providerRetry = saved })
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusBadRequest, false},
+		{http.StatusUnauthorized, false},
+		{http.StatusInternalServerError, false},
+		{http.StatusTooManyRequests, true},
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusGatewayTimeout, true},
+		{529, true},
+	}
+
+	for _, tt := range tests {
+		if got := isRetryableStatus(tt.status); got != tt.want {
+			t.Errorf("isRetryableStatus(%d) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestDoWithRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantStatus   int
+		wantRequests int32
+	}{
+		{name: "success needs no retry", statuses: []int{200}, wantStatus: 200, wantRequests: 1},
+		{name: "retries rate limit", statuses: []int{429, 429, 200}, wantStatus: 200, wantRequests: 3},
+		{name: "retries unavailable", statuses: []int{503, 200}, wantStatus: 200, wantRequests: 2},
+		{name: "client error not retried", statuses: []int{400}, wantStatus: 400, wantRequests: 1},
+		{name: "returns last response when exhausted", statuses: []int{429, 429, 429, 429, 200}, wantStatus: 429, wantRequests: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := requests.Add(1)
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != "payload" {
+					t.Errorf("request %d body = %q, want it resent intact", n, body)
+				}
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer server.Close()
+
+			useFastRetry(t)
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, server.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := doWithRetry(server.Client(), req, func(string, ...any) {})
+			if err != nil {
+				t.Fatalf("doWithRetry() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestDoWithRetry_CapsRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	useFastRetry(t)
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+
+	start := time.Now()
+	resp, err := doWithRetry(server.Client(), req, func(string, ...any) {})
+	if err != nil {
+		t.Fatalf("doWithRetry() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("doWithRetry() waited %v, want Retry-After capped at maxDelay", elapsed)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestDoWithRetry_ContextCancelledDuringWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	saved := providerRetry
+	providerRetry = retry.Policy{MaxRetries: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}
+	defer func() { providerRetry = saved }()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	if _, err := doWithRetry(server.Client(), req, func(string, ...any) {}); err == nil {
+		t.Fatal("doWithRetry() expected error when context ends during backoff")
+	}
+}
+
+func TestOllamaProvider_RetriesTransientErrors(t *testing.T) {
+	useFastRetry(t)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path != ollamaChatPath {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"message":{"role":"assistant","content":"Hi"},"done":true}` + "\n"))
+	}))
+	defer server.Close()
+
+	p := NewOllamaProvider(server.URL, "llama3.2", nil)
+
+	resp, err := p.Chat(t.Context(), []Message{{Role: "user", Content: "Hello"}})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Content != "Hi" {
+		t.Errorf("Content = %q, want %q", resp.Content, "Hi")
+	}
+
+	chunks, err := p.StreamChat(t.Context(), []Message{{Role: "user", Content: "Hello"}})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+	for range chunks {
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want 3 (one retried Chat, one StreamChat)", got)
+	}
+}
@@ -0,0 +1,53 @@
+package ai
+
+import (
+	"context"
+	"time"
+)
+
+// timeoutProvider bounds every request to the wrapped provider with a deadline
+type timeoutProvider struct {
+	Provider
+	timeout time.Duration
+}
+
+// WithTimeout returns p with each Chat and StreamChat call bounded by timeout,
+// in addition to any deadline already on the caller's context. A timeout of
+// zero or less returns p unchanged.
+func WithTimeout(p Provider, timeout time.Duration) Provider {
+	if timeout <= 0 {
+		return p
+	}
+	return &timeoutProvider{Provider: p, timeout: timeout}
+}
+
+// Chat performs a single-turn chat completion within the timeout.
+func (p *timeoutProvider) Chat(ctx context.Context, messages []Message) (*Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	return p.Provider.Chat(ctx, messages)
+}
+
+// StreamChat performs a streaming chat completion that must finish within the
+// timeout. The deadline covers the whole stream, not just the first chunk.
+func (p *timeoutProvider) StreamChat(ctx context.Context, messages []Message) (<-chan StreamChunk, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+
+	in, err := p.Provider.StreamChat(ctx, messages)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Forward chunks so the context stays alive until the stream is drained
+	out := make(chan StreamChunk)
+	go func() {
+		defer cancel()
+		defer close(out)
+		for chunk := range in {
+			out <- chunk
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,118 @@
+package ai
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// deadlineProvider reports whether calls arrive with a deadline and blocks
+// until the context is done when block is set
+type deadlineProvider struct {
+	block       bool
+	hadDeadline bool
+}
+
+func (p *deadlineProvider) IsAvailable() bool { return true }
+func (p *deadlineProvider) Name() string      { return "fake" }
+
+func (p *deadlineProvider) Chat(ctx context.Context, _ []Message) (*Response, error) {
+	_, p.hadDeadline = ctx.Deadline()
+	if p.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &Response{Content: "ok"}, nil
+}
+
+func (p *deadlineProvider) StreamChat(ctx context.Context, _ []Message) (<-chan StreamChunk, error) {
+	_, p.hadDeadline = ctx.Deadline()
+	chunks := make(chan StreamChunk)
+	go func() {
+		defer close(chunks)
+		if p.block {
+			<-ctx.Done()
+			chunks <- StreamChunk{Error: ctx.Err(), Done: true}
+			return
+		}
+		chunks <- StreamChunk{Content: "ok"}
+		chunks <- StreamChunk{Done: true}
+	}()
+	return chunks, nil
+}
+
+func TestWithTimeout_Disabled(t *testing.T) {
+	inner := &deadlineProvider{}
+	if got := WithTimeout(inner, 0); got != Provider(inner) {
+		t.Errorf("WithTimeout(p, 0) = %T, want the provider unchanged", got)
+	}
+}
+
+func TestWithTimeout_Chat(t *testing.T) {
+	inner := &deadlineProvider{}
+	p := WithTimeout(inner, time.Minute)
+
+	resp, err := p.Chat(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Content != "ok" {
+		t.Errorf("Content = %q, want %q", resp.Content, "ok")
+	}
+	if !inner.hadDeadline {
+		t.Error("Chat() context should carry a deadline")
+	}
+	if p.Name() != "fake" {
+		t.Errorf("Name() = %q, want the wrapped provider's name", p.Name())
+	}
+}
+
+func TestWithTimeout_ChatExpires(t *testing.T) {
+	p := WithTimeout(&deadlineProvider{block: true}, 10*time.Millisecond)
+
+	_, err := p.Chat(context.Background(), nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Chat() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestWithTimeout_StreamChat(t *testing.T) {
+	inner := &deadlineProvider{}
+	p := WithTimeout(inner, time.Minute)
+
+	chunks, err := p.StreamChat(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	var content string
+	for chunk := range chunks {
+		if chunk.Error != nil {
+			t.Fatalf("unexpected stream error: %v", chunk.Error)
+		}
+		content += chunk.Content
+	}
+	if content != "ok" {
+		t.Errorf("streamed content = %q, want %q", content, "ok")
+	}
+	if !inner.hadDeadline {
+		t.Error("StreamChat() context should carry a deadline")
+	}
+}
+
+func TestWithTimeout_StreamChatExpires(t *testing.T) {
+	p := WithTimeout(&deadlineProvider{block: true}, 10*time.Millisecond)
+
+	chunks, err := p.StreamChat(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	var last StreamChunk
+	for chunk := range chunks {
+		last = chunk
+	}
+	if last.Error != context.DeadlineExceeded {
+		t.Errorf("final chunk error = %v, want %v", last.Error, context.DeadlineExceeded)
+	}
+}
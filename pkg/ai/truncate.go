@@ -0,0 +1,30 @@
+package ai
+
+import "strings"
+
+// charsPerToken is a rough average used to estimate token counts without a
+// provider-specific tokenizer
+const charsPerToken = 4
+
+// EstimateTokens returns an approximate token count for text.
+func EstimateTokens(text string) int {
+	return (len(text) + charsPerToken - 1) / charsPerToken
+}
+
+// TruncateToTokenBudget shortens text to roughly fit within budget tokens,
+// cutting at a line boundary and appending a marker so the model knows the
+// input is incomplete. It reports whether truncation happened. A budget of
+// zero or less disables truncation.
+func TruncateToTokenBudget(text string, budget int) (string, bool) {
+	if budget <= 0 || EstimateTokens(text) <= budget {
+		return text, false
+	}
+
+	limit := budget * charsPerToken
+	cut := text[:limit]
+	if idx := strings.LastIndex(cut, "\n"); idx > 0 {
+		cut = cut[:idx+1]
+	}
+
+	return cut + "\n[... truncated to fit token budget ...]\n", true
+}
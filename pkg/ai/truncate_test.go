@@ -0,0 +1,65 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"abc", 1},
+		{"abcd", 1},
+		{"abcde", 2},
+	}
+
+	for _, tt := range tests {
+		if got := EstimateTokens(tt.text); got != tt.want {
+			t.Errorf("EstimateTokens(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateToTokenBudget(t *testing.T) {
+	text := strings.Repeat("line of text\n", 10) // 130 chars, ~33 tokens
+
+	tests := []struct {
+		name          string
+		budget        int
+		wantTruncated bool
+	}{
+		{"disabled", 0, false},
+		{"fits", 100, false},
+		{"exact fit", 33, false},
+		{"too long", 10, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, truncated := TruncateToTokenBudget(text, tt.budget)
+			if truncated != tt.wantTruncated {
+				t.Fatalf("truncated = %v, want %v", truncated, tt.wantTruncated)
+			}
+			if !truncated {
+				if got != text {
+					t.Error("text should be unchanged when not truncated")
+				}
+				return
+			}
+
+			body := strings.TrimSuffix(got, "\n[... truncated to fit token budget ...]\n")
+			if body == got {
+				t.Fatalf("truncated text missing marker: %q", got)
+			}
+			if len(body) > tt.budget*charsPerToken {
+				t.Errorf("truncated body has %d chars, want <= %d", len(body), tt.budget*charsPerToken)
+			}
+			if !strings.HasSuffix(body, "\n") {
+				t.Errorf("truncation should cut at a line boundary, got %q", body)
+			}
+		})
+	}
+}
@@ -2,7 +2,10 @@ package config
 
 import (
 	"os"
+	"os/user"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/spf13/viper"
@@ -14,6 +17,7 @@ type Config struct {
 	Notes     NotesConfig     `mapstructure:"notes"`
 	Git       GitConfig       `mapstructure:"git"`
 	Clone     CloneConfig     `mapstructure:"clone"`
+	Hack      HackConfig      `mapstructure:"hack"`
 	History   HistoryConfig   `mapstructure:"history"`
 	Jira      JiraConfig      `mapstructure:"jira"`
 	Beads     BeadsConfig     `mapstructure:"beads"`
@@ -22,13 +26,26 @@ type Config struct {
 	AI        AIConfig        `mapstructure:"ai"`
 	Workflow  WorkflowConfig  `mapstructure:"workflow"`
 	Discovery DiscoveryConfig `mapstructure:"discovery"`
+	Network   NetworkConfig   `mapstructure:"network"`
+	Hooks     HooksConfig     `mapstructure:"hooks"`
+
+	SecretsFile string `mapstructure:"secrets_file"` // Optional file holding credentials, merged after other config
 }
 
 // NotesConfig holds markdown notes configuration
 type NotesConfig struct {
-	Path        string `mapstructure:"path"`         // Base directory for notes
-	DailyDir    string `mapstructure:"daily_dir"`    // Subdirectory for daily notes
-	TemplateDir string `mapstructure:"template_dir"` // Optional user template directory
+	Path            string `mapstructure:"path"`              // Base directory for notes
+	DailyDir        string `mapstructure:"daily_dir"`         // Subdirectory for daily notes
+	WeeklyDir       string `mapstructure:"weekly_dir"`        // Subdirectory for weekly rollup notes
+	TemplateDir     string `mapstructure:"template_dir"`      // Optional user template directory
+	FrontMatter     bool   `mapstructure:"frontmatter"`       // Prepend YAML front matter to new ticket notes
+	LogTimeFormat   string `mapstructure:"log_time_format"`   // Daily note log timestamp (Go layout or named format)
+	ArchiveDir      string `mapstructure:"archive_dir"`       // Subdirectory for archived ticket notes
+	Editor          string `mapstructure:"editor"`            // Editor for --edit (default: $EDITOR)
+	OpenAfterCreate bool   `mapstructure:"open_after_create"` // Open the note in the editor after rig work
+
+	Subdirs       map[string]string `mapstructure:"subdirs"`        // Ticket type to note subdirectory, e.g. incident = "Incidents"
+	DefaultSubdir string            `mapstructure:"default_subdir"` // Subdirectory for types missing from subdirs
 }
 
 // DiscoveryConfig holds project discovery configuration
@@ -40,7 +57,8 @@ type DiscoveryConfig struct {
 
 // GitConfig holds optional git configuration overrides
 type GitConfig struct {
-	BaseBranch string `mapstructure:"base_branch"` // Optional override for default branch
+	BaseBranch     string `mapstructure:"base_branch"`     // Optional override for default branch
+	BranchTemplate string `mapstructure:"branch_template"` // Branch name template for rig work (default: {ticket})
 }
 
 // CloneConfig holds clone command configuration
@@ -48,6 +66,11 @@ type CloneConfig struct {
 	BasePath string `mapstructure:"base_path"` // Base directory for clones (default: ~/src)
 }
 
+// HackConfig holds hack command configuration
+type HackConfig struct {
+	Templates map[string]string `mapstructure:"templates"` // Template name to directory copied into new hack worktrees
+}
+
 // HistoryConfig holds command history configuration
 type HistoryConfig struct {
 	DatabasePath   string   `mapstructure:"database_path"`
@@ -56,13 +79,85 @@ type HistoryConfig struct {
 
 // JiraConfig holds JIRA integration configuration
 type JiraConfig struct {
-	Enabled      bool              `mapstructure:"enabled"`
-	Mode         string            `mapstructure:"mode"`          // "api" or "acli"
-	BaseURL      string            `mapstructure:"base_url"`      // e.g., "https://your-domain.atlassian.net"
-	Email        string            `mapstructure:"email"`         // User email for Basic Auth
-	Token        string            `mapstructure:"token"`         // API token (JIRA_TOKEN env var takes precedence)
-	CliCommand   string            `mapstructure:"cli_command"`   // For acli mode
-	CustomFields map[string]string `mapstructure:"custom_fields"` // Map of field name to customfield_ID
+	Enabled              bool              `mapstructure:"enabled"`
+	Mode                 string            `mapstructure:"mode"`                   // "api" or "acli"
+	BaseURL              string            `mapstructure:"base_url"`               // e.g., "https://your-domain.atlassian.net"
+	Email                string            `mapstructure:"email"`                  // User email for Basic Auth
+	Token                string            `mapstructure:"token"`                  // API token (JIRA_TOKEN env var takes precedence)
+	TokenKeychainService string            `mapstructure:"token_keychain_service"` // Keychain item holding the token (account = email)
+	CliCommand           string            `mapstructure:"cli_command"`            // For acli mode
+	CustomFields         map[string]string `mapstructure:"custom_fields"`          // Map of field name to customfield_ID
+	DescriptionFormat    string            `mapstructure:"description_format"`     // "text" or "markdown"
+	CacheTTL             time.Duration     `mapstructure:"cache_ttl"`              // How long fetched tickets are reused (0 disables)
+	EpicLinkField        string            `mapstructure:"epic_link_field"`        // Classic-project epic link customfield_ID
+	StartTransition      string            `mapstructure:"start_transition"`       // Status to move tickets to on rig work (empty disables)
+	AssignOnStart        bool              `mapstructure:"assign_on_start"`        // Assign tickets to the current user on rig work
+	Timeout              time.Duration     `mapstructure:"timeout"`                // Per-request deadline (default: network.timeout, then 30s)
+
+	Profiles       map[string]JiraProfile `mapstructure:"profiles"`        // Named Jira instances selected by ticket project key
+	DefaultProfile string                 `mapstructure:"default_profile"` // Profile used when no profile lists the ticket's project
+}
+
+// JiraProfile overrides the connection settings in JiraConfig for tickets
+// whose project key is listed in Projects. Empty fields inherit the value
+// from the top-level [jira] table.
+type JiraProfile struct {
+	Projects             []string          `mapstructure:"projects"` // Project keys routed to this profile, e.g. ["OPS"]
+	Mode                 string            `mapstructure:"mode"`
+	BaseURL              string            `mapstructure:"base_url"`
+	Email                string            `mapstructure:"email"`
+	Token                string            `mapstructure:"token"`
+	TokenKeychainService string            `mapstructure:"token_keychain_service"`
+	CliCommand           string            `mapstructure:"cli_command"`
+	CustomFields         map[string]string `mapstructure:"custom_fields"`
+	EpicLinkField        string            `mapstructure:"epic_link_field"`
+	StartTransition      string            `mapstructure:"start_transition"`
+}
+
+// ProfileFor returns the name of the profile that handles ticket: the
+// profile listing the ticket's project key (matched case-insensitively),
+// otherwise DefaultProfile. An empty name means the top-level settings apply.
+func (c *JiraConfig) ProfileFor(ticket string) string {
+	project, _, _ := strings.Cut(ticket, "-")
+
+	for _, name := range sortedKeys(c.Profiles) {
+		for _, p := range c.Profiles[name].Projects {
+			if strings.EqualFold(p, project) {
+				return name
+			}
+		}
+	}
+	return c.DefaultProfile
+}
+
+// ForTicket returns the Jira settings for ticket, with the matching profile
+// (see ProfileFor) applied over the top-level settings. The receiver is not
+// modified.
+func (c *JiraConfig) ForTicket(ticket string) *JiraConfig {
+	resolved := *c
+	profile, ok := c.Profiles[c.ProfileFor(ticket)]
+	if !ok {
+		return &resolved
+	}
+
+	override := func(dst *string, value string) {
+		if value != "" {
+			*dst = value
+		}
+	}
+	override(&resolved.Mode, profile.Mode)
+	override(&resolved.BaseURL, profile.BaseURL)
+	override(&resolved.Email, profile.Email)
+	override(&resolved.Token, profile.Token)
+	override(&resolved.TokenKeychainService, profile.TokenKeychainService)
+	override(&resolved.CliCommand, profile.CliCommand)
+	override(&resolved.EpicLinkField, profile.EpicLinkField)
+	override(&resolved.StartTransition, profile.StartTransition)
+	if len(profile.CustomFields) > 0 {
+		resolved.CustomFields = profile.CustomFields
+	}
+
+	return &resolved
 }
 
 // BeadsConfig holds beads issue tracking configuration
@@ -73,7 +168,15 @@ type BeadsConfig struct {
 
 // TmuxWindow represents a tmux window configuration
 type TmuxWindow struct {
-	Name       string `mapstructure:"name"`
+	Name       string     `mapstructure:"name"`
+	Command    string     `mapstructure:"command"`
+	WorkingDir string     `mapstructure:"working_dir"`
+	Layout     string     `mapstructure:"layout"` // Optional tmux layout, e.g. "main-vertical"
+	Panes      []TmuxPane `mapstructure:"panes"`  // Extra panes split from the window's first pane
+}
+
+// TmuxPane represents an additional pane within a tmux window
+type TmuxPane struct {
 	Command    string `mapstructure:"command"`
 	WorkingDir string `mapstructure:"working_dir"`
 }
@@ -109,6 +212,28 @@ type AIConfig struct {
 	OllamaEndpoint string `mapstructure:"ollama_endpoint"` // Default: http://localhost:11434
 	GeminiModel    string `mapstructure:"gemini_model"`
 	GeminiAPIKey   string `mapstructure:"gemini_api_key"` // Gemini API key (GOOGLE_GENAI_API_KEY env var takes precedence)
+
+	DiffTokenBudget int `mapstructure:"diff_token_budget"` // Approximate token cap for diffs sent to the provider
+
+	Timeout time.Duration `mapstructure:"timeout"` // Per-request deadline (default: network.timeout, 0 means none)
+}
+
+// NetworkConfig holds settings shared by network operations
+type NetworkConfig struct {
+	Timeout time.Duration `mapstructure:"timeout"` // Default deadline for Jira, AI and git remote operations (0 means none)
+}
+
+// HooksConfig holds shell commands run around rig operations. Each hook is
+// run with sh -c; an empty command is skipped.
+type HooksConfig struct {
+	PreWork     string        `mapstructure:"pre_work"`      // Before rig work creates the worktree
+	PostWork    string        `mapstructure:"post_work"`     // After rig work completes
+	PostSync    string        `mapstructure:"post_sync"`     // After rig sync updates a ticket
+	PreClean    string        `mapstructure:"pre_clean"`     // Before rig clean removes each worktree
+	PostClean   string        `mapstructure:"post_clean"`    // After rig clean removes each worktree
+	PostDone    string        `mapstructure:"post_done"`     // After rig done completes
+	Timeout     time.Duration `mapstructure:"timeout"`       // Per-hook deadline (0 means none)
+	FailOnError bool          `mapstructure:"fail_on_error"` // Stop the operation when a hook fails instead of warning
 }
 
 // WorkflowConfig holds PR workflow automation configuration
@@ -126,6 +251,22 @@ type SecurityWarning struct {
 
 // Load loads the configuration from file and environment variables
 func Load() (*Config, error) {
+	config, err := LoadUnvalidated()
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate configuration
+	if err := config.Validate(); err != nil {
+		return nil, errors.Wrap(err, "config validation failed")
+	}
+
+	return config, nil
+}
+
+// LoadUnvalidated loads the configuration like Load but skips validation,
+// so callers can report every problem rather than the first.
+func LoadUnvalidated() (*Config, error) {
 	config := &Config{}
 
 	// Set defaults
@@ -141,10 +282,7 @@ func Load() (*Config, error) {
 		return nil, errors.Wrap(err, "failed to expand paths")
 	}
 
-	// Validate configuration
-	if err := config.Validate(); err != nil {
-		return nil, errors.Wrap(err, "config validation failed")
-	}
+	applyNetworkTimeout(config)
 
 	return config, nil
 }
@@ -215,11 +353,30 @@ func ValidateMergeMethod(method string) error {
 	return errors.Newf("invalid merge method %q: must be one of: merge, squash, rebase", method)
 }
 
+// ValidDescriptionFormats is the list of supported Jira description formats.
+var ValidDescriptionFormats = []string{"text", "markdown"}
+
+// ValidateDescriptionFormat validates that a Jira description format is supported.
+func ValidateDescriptionFormat(format string) error {
+	if format == "" {
+		return nil // Empty is allowed, will use default
+	}
+	for _, valid := range ValidDescriptionFormats {
+		if format == valid {
+			return nil
+		}
+	}
+	return errors.Newf("invalid description format %q: must be one of: text, markdown", format)
+}
+
 // Validate validates the configuration and returns any validation errors.
 func (c *Config) Validate() error {
 	if err := ValidateMergeMethod(c.GitHub.DefaultMergeMethod); err != nil {
 		return errors.Wrap(err, "github.default_merge_method")
 	}
+	if err := ValidateDescriptionFormat(c.Jira.DescriptionFormat); err != nil {
+		return errors.Wrap(err, "jira.description_format")
+	}
 	return nil
 }
 
@@ -231,17 +388,32 @@ func setDefaults() {
 		homeDir = "."
 	}
 
+	// Secrets file (empty means credentials come only from config and env)
+	viper.SetDefault("secrets_file", "")
+
 	// Notes defaults
 	viper.SetDefault("notes.path", filepath.Join(homeDir, "Documents", "Notes"))
 	viper.SetDefault("notes.daily_dir", "daily")
+	viper.SetDefault("notes.weekly_dir", "weekly")
 	viper.SetDefault("notes.template_dir", filepath.Join(homeDir, ".config", "rig", "templates"))
+	viper.SetDefault("notes.frontmatter", false)
+	viper.SetDefault("notes.log_time_format", "15:04")
+	viper.SetDefault("notes.archive_dir", "archive")
+	viper.SetDefault("notes.editor", "")
+	viper.SetDefault("notes.open_after_create", false)
+	viper.SetDefault("notes.subdirs", map[string]string{}) // Empty keeps one directory per ticket type
+	viper.SetDefault("notes.default_subdir", "Tickets")
 
 	// Git defaults (empty means auto-detect)
 	viper.SetDefault("git.base_branch", "")
+	viper.SetDefault("git.branch_template", "{ticket}")
 
 	// Clone defaults (empty means ~/src)
 	viper.SetDefault("clone.base_path", "")
 
+	// Hack defaults (no templates; worktrees start empty)
+	viper.SetDefault("hack.templates", map[string]string{})
+
 	// History defaults
 	viper.SetDefault("history.database_path", filepath.Join(homeDir, ".histdb", "zsh-history.db"))
 	viper.SetDefault("history.ignore_patterns", []string{"ls", "cd", "pwd", "clear"})
@@ -252,8 +424,16 @@ func setDefaults() {
 	viper.SetDefault("jira.base_url", "")
 	viper.SetDefault("jira.email", "")
 	viper.SetDefault("jira.token", "")
+	viper.SetDefault("jira.token_keychain_service", "")
 	viper.SetDefault("jira.cli_command", "acli")
 	viper.SetDefault("jira.custom_fields", map[string]string{})
+	viper.SetDefault("jira.description_format", "text")
+	viper.SetDefault("jira.cache_ttl", "60s")
+	viper.SetDefault("jira.epic_link_field", "")
+	viper.SetDefault("jira.start_transition", "")
+	viper.SetDefault("jira.assign_on_start", false)
+	viper.SetDefault("jira.timeout", "0s")
+	viper.SetDefault("jira.default_profile", "")
 
 	// Beads defaults
 	viper.SetDefault("beads.enabled", true)
@@ -288,6 +468,8 @@ func setDefaults() {
 	viper.SetDefault("ai.ollama_model", "llama3.2")
 	viper.SetDefault("ai.ollama_endpoint", "http://localhost:11434")
 	viper.SetDefault("ai.gemini_model", "")
+	viper.SetDefault("ai.diff_token_budget", 8000)
+	viper.SetDefault("ai.timeout", "0s")
 
 	// Workflow defaults
 	viper.SetDefault("workflow.transition_jira", true)
@@ -298,40 +480,73 @@ func setDefaults() {
 	viper.SetDefault("discovery.search_paths", []string{filepath.Join(homeDir, "src")})
 	viper.SetDefault("discovery.max_depth", 3)
 	viper.SetDefault("discovery.cache_path", filepath.Join(homeDir, ".cache", "rig", "projects.json"))
+
+	// Network defaults (0 leaves each subsystem on its own default)
+	viper.SetDefault("network.timeout", "0s")
+
+	// Hook defaults (no hooks configured)
+	viper.SetDefault("hooks.timeout", "1m")
+	viper.SetDefault("hooks.fail_on_error", false)
+}
+
+// applyNetworkTimeout fills subsystem timeouts that weren't set explicitly
+// from network.timeout, so jira.timeout and ai.timeout act as overrides
+func applyNetworkTimeout(config *Config) {
+	if config.Network.Timeout <= 0 {
+		return
+	}
+	if config.Jira.Timeout == 0 {
+		config.Jira.Timeout = config.Network.Timeout
+	}
+	if config.AI.Timeout == 0 {
+		config.AI.Timeout = config.Network.Timeout
+	}
 }
 
 // expandPaths expands ~ and environment variables in paths
 func expandPaths(config *Config) error {
 	var err error
 
-	config.Notes.Path, err = expandPath(config.Notes.Path)
+	config.Notes.Path, err = ExpandPath(config.Notes.Path)
 	if err != nil {
 		return err
 	}
 
-	config.Notes.TemplateDir, err = expandPath(config.Notes.TemplateDir)
+	config.Notes.TemplateDir, err = ExpandPath(config.Notes.TemplateDir)
 	if err != nil {
 		return err
 	}
 
-	config.History.DatabasePath, err = expandPath(config.History.DatabasePath)
+	config.History.DatabasePath, err = ExpandPath(config.History.DatabasePath)
 	if err != nil {
 		return err
 	}
 
-	config.Clone.BasePath, err = expandPath(config.Clone.BasePath)
+	config.Clone.BasePath, err = ExpandPath(config.Clone.BasePath)
 	if err != nil {
 		return err
 	}
 
+	for name, path := range config.Hack.Templates {
+		config.Hack.Templates[name], err = ExpandPath(path)
+		if err != nil {
+			return err
+		}
+	}
+
 	for i, path := range config.Discovery.SearchPaths {
-		config.Discovery.SearchPaths[i], err = expandPath(path)
+		config.Discovery.SearchPaths[i], err = ExpandPath(path)
 		if err != nil {
 			return err
 		}
 	}
 
-	config.Discovery.CachePath, err = expandPath(config.Discovery.CachePath)
+	config.Discovery.CachePath, err = ExpandPath(config.Discovery.CachePath)
+	if err != nil {
+		return err
+	}
+
+	config.SecretsFile, err = ExpandPath(config.SecretsFile)
 	if err != nil {
 		return err
 	}
@@ -339,16 +554,101 @@ func expandPaths(config *Config) error {
 	return nil
 }
 
-// expandPath expands ~ to home directory
-func expandPath(path string) (string, error) {
+// ExpandPath expands a leading ~ or ~user to that user's home directory and
+// then $VAR and ${VAR} references. Referencing an unset variable is an error,
+// so a typo can't silently turn "$CODE/src" into "/src".
+func ExpandPath(path string) (string, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.Contains(path, "$") {
+		return path, nil
+	}
+
+	var unset []string
+	path = os.Expand(path, func(name string) string {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			unset = append(unset, name)
+		}
+		return value
+	})
+	if len(unset) > 0 {
+		return "", errors.Newf("environment variable %s in path is not set", strings.Join(unset, ", "))
+	}
+
+	return filepath.Clean(path), nil
+}
+
+// expandHome expands a leading ~ (current user) or ~user in path
+func expandHome(path string) (string, error) {
 	if len(path) == 0 || path[0] != '~' {
 		return path, nil
 	}
 
+	name, rest, _ := strings.Cut(path[1:], "/")
+	if name == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(homeDir, rest), nil
+	}
+
+	u, err := user.Lookup(name)
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot expand ~%s", name)
+	}
+	return filepath.Join(u.HomeDir, rest), nil
+}
+
+// UserConfigDir returns rig's user config directory: $XDG_CONFIG_HOME/rig when
+// XDG_CONFIG_HOME is set to an absolute path, otherwise ~/.config/rig
+func UserConfigDir() (string, error) {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(xdg) {
+		return filepath.Join(xdg, "rig"), nil
+	}
+
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return "", err
+		return "", errors.Wrap(err, "failed to get home directory")
+	}
+	return filepath.Join(homeDir, ".config", "rig"), nil
+}
+
+// ConfigSearchDirs returns the directories searched for the user config file,
+// highest precedence first: UserConfigDir, then rig under each absolute entry
+// of XDG_CONFIG_DIRS.
+func ConfigSearchDirs() ([]string, error) {
+	userDir, err := UserConfigDir()
+	if err != nil {
+		return nil, err
 	}
 
-	return filepath.Join(homeDir, path[1:]), nil
+	dirs := []string{userDir}
+	for _, dir := range filepath.SplitList(os.Getenv("XDG_CONFIG_DIRS")) {
+		if filepath.IsAbs(dir) {
+			dirs = append(dirs, filepath.Join(dir, "rig"))
+		}
+	}
+	return dirs, nil
+}
+
+// ConfigFileNames lists the user config file names, in priority order.
+var ConfigFileNames = []string{"config.toml", "config.yaml", "config.json"}
+
+// FindConfigFile returns the first of ConfigFileNames that exists in dirs,
+// checking each directory in turn. It returns "" when there is none.
+func FindConfigFile(dirs []string) string {
+	for _, dir := range dirs {
+		for _, name := range ConfigFileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+	}
+	return ""
 }
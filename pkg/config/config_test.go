@@ -2,8 +2,11 @@ package config
 
 import (
 	"os"
+	"os/user"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -33,24 +36,148 @@ func TestExpandPath(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := expandPath(tt.input)
+			result, err := ExpandPath(tt.input)
 			if err != nil {
-				t.Fatalf("expandPath() error: %v", err)
+				t.Fatalf("ExpandPath() error: %v", err)
 			}
 
 			if tt.expectTilde {
 				if result[0] == '~' {
-					t.Errorf("expandPath(%q) still contains ~: %q", tt.input, result)
+					t.Errorf("ExpandPath(%q) still contains ~: %q", tt.input, result)
 				}
 			} else if tt.input != "" {
 				if result != tt.input {
-					t.Errorf("expandPath(%q) = %q, want %q", tt.input, result, tt.input)
+					t.Errorf("ExpandPath(%q) = %q, want %q", tt.input, result, tt.input)
 				}
 			}
 		})
 	}
 }
 
+func TestExpandPath_HomeAndEnv(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("RIG_TEST_CODE", "/work/code")
+
+	current, err := user.Current()
+	if err != nil {
+		t.Skipf("cannot look up current user: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{name: "tilde alone", input: "~", want: home},
+		{name: "tilde path", input: "~/code", want: filepath.Join(home, "code")},
+		{name: "HOME variable", input: "$HOME/src", want: filepath.Join(home, "src")},
+		{name: "braced variable", input: "${RIG_TEST_CODE}/rig", want: "/work/code/rig"},
+		{name: "tilde then variable", input: "~/$RIG_TEST_CODE", want: filepath.Join(home, "work/code")},
+		{name: "named user", input: "~" + current.Username + "/notes", want: filepath.Join(current.HomeDir, "notes")},
+		{name: "unknown user", input: "~rig-no-such-user/notes", wantErr: "cannot expand ~rig-no-such-user"},
+		{name: "unset variable", input: "$RIG_TEST_UNSET/src", wantErr: "RIG_TEST_UNSET"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandPath(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ExpandPath(%q) error = %v, want error containing %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExpandPath(%q) error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ExpandPath(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserConfigDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		name string
+		xdg  string
+		want string
+	}{
+		{name: "unset", xdg: "", want: filepath.Join(home, ".config", "rig")},
+		{name: "absolute", xdg: "/custom/config", want: filepath.Join("/custom/config", "rig")},
+		{name: "relative is ignored", xdg: "relative/config", want: filepath.Join(home, ".config", "rig")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", tt.xdg)
+
+			got, err := UserConfigDir()
+			if err != nil {
+				t.Fatalf("UserConfigDir() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("UserConfigDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigSearchDirs(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/home/me/.cfg")
+	t.Setenv("XDG_CONFIG_DIRS", "/etc/xdg"+string(filepath.ListSeparator)+"relative"+string(filepath.ListSeparator)+"/opt/xdg")
+
+	got, err := ConfigSearchDirs()
+	if err != nil {
+		t.Fatalf("ConfigSearchDirs() error = %v", err)
+	}
+	want := []string{"/home/me/.cfg/rig", "/etc/xdg/rig", "/opt/xdg/rig"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ConfigSearchDirs() = %v, want %v", got, want)
+	}
+}
+
+func TestFindConfigFile(t *testing.T) {
+	userDir := t.TempDir()
+	systemDir := t.TempDir()
+	write := func(dir, name string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(""), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := FindConfigFile([]string{userDir, systemDir}); got != "" {
+		t.Errorf("FindConfigFile() = %q, want empty when no file exists", got)
+	}
+
+	write(systemDir, "config.toml")
+	if got, want := FindConfigFile([]string{userDir, systemDir}), filepath.Join(systemDir, "config.toml"); got != want {
+		t.Errorf("FindConfigFile() = %q, want %q", got, want)
+	}
+
+	// Any format in an earlier directory wins over a later directory
+	write(userDir, "config.json")
+	if got, want := FindConfigFile([]string{userDir, systemDir}), filepath.Join(userDir, "config.json"); got != want {
+		t.Errorf("FindConfigFile() = %q, want %q", got, want)
+	}
+
+	write(userDir, "config.yaml")
+	if got, want := FindConfigFile([]string{userDir, systemDir}), filepath.Join(userDir, "config.yaml"); got != want {
+		t.Errorf("FindConfigFile() = %q, want %q", got, want)
+	}
+
+	write(userDir, "config.toml")
+	if got, want := FindConfigFile([]string{userDir, systemDir}), filepath.Join(userDir, "config.toml"); got != want {
+		t.Errorf("FindConfigFile() = %q, want %q", got, want)
+	}
+}
+
 func TestLoad_WithDefaults(t *testing.T) {
 	// Reset viper to clean state
 	viper.Reset()
@@ -68,12 +195,24 @@ func TestLoad_WithDefaults(t *testing.T) {
 	if config.Jira.CliCommand != "acli" {
 		t.Errorf("Expected jira.cli_command to default to 'acli', got %q", config.Jira.CliCommand)
 	}
+	if config.Jira.DescriptionFormat != "text" {
+		t.Errorf("Expected jira.description_format to default to 'text', got %q", config.Jira.DescriptionFormat)
+	}
+	if config.Jira.CacheTTL != 60*time.Second {
+		t.Errorf("Expected jira.cache_ttl to default to 60s, got %v", config.Jira.CacheTTL)
+	}
 	if config.Git.BaseBranch != "" {
 		t.Errorf("Expected git.base_branch to default to empty (auto-detect), got %q", config.Git.BaseBranch)
 	}
+	if config.Git.BranchTemplate != "{ticket}" {
+		t.Errorf("Expected git.branch_template to default to {ticket}, got %q", config.Git.BranchTemplate)
+	}
 	if config.Notes.DailyDir != "daily" {
 		t.Errorf("Expected notes.daily_dir to default to 'daily', got %q", config.Notes.DailyDir)
 	}
+	if config.Notes.WeeklyDir != "weekly" {
+		t.Errorf("Expected notes.weekly_dir to default to 'weekly', got %q", config.Notes.WeeklyDir)
+	}
 
 	// Verify default tmux windows are properly loaded (regression test for type mismatch bug)
 	if len(config.Tmux.Windows) != 3 {
@@ -180,6 +319,273 @@ discovery:
 	}
 }
 
+func TestLoad_TmuxWindowPanes(t *testing.T) {
+	configContent := `
+[[tmux.windows]]
+name = "code"
+command = "nvim"
+layout = "main-vertical"
+
+[[tmux.windows.panes]]
+command = "go test ./..."
+working_dir = "{worktree_path}/cmd"
+
+[[tmux.windows.panes]]
+
+[[tmux.windows]]
+name = "term"
+`
+
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigFile(configPath)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	if len(config.Tmux.Windows) != 2 {
+		t.Fatalf("Tmux.Windows len = %d, want 2", len(config.Tmux.Windows))
+	}
+
+	code := config.Tmux.Windows[0]
+	if code.Layout != "main-vertical" {
+		t.Errorf("Layout = %q, want %q", code.Layout, "main-vertical")
+	}
+	if len(code.Panes) != 2 {
+		t.Fatalf("Panes len = %d, want 2", len(code.Panes))
+	}
+	if code.Panes[0].Command != "go test ./..." || code.Panes[0].WorkingDir != "{worktree_path}/cmd" {
+		t.Errorf("Panes[0] = %+v", code.Panes[0])
+	}
+
+	// Single-command windows are unchanged
+	term := config.Tmux.Windows[1]
+	if term.Layout != "" || len(term.Panes) != 0 {
+		t.Errorf("term window should have no layout or panes, got %+v", term)
+	}
+}
+
+func TestLoad_NetworkTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantNetwork time.Duration
+		wantJira    time.Duration
+		wantAI      time.Duration
+	}{
+		{
+			name: "unset leaves subsystems on their defaults",
+		},
+		{
+			name:        "network timeout applies to jira and ai",
+			content:     "[network]\ntimeout = \"45s\"\n",
+			wantNetwork: 45 * time.Second,
+			wantJira:    45 * time.Second,
+			wantAI:      45 * time.Second,
+		},
+		{
+			name:        "subsystem timeout overrides network timeout",
+			content:     "[network]\ntimeout = \"45s\"\n\n[ai]\ntimeout = \"2m\"\n",
+			wantNetwork: 45 * time.Second,
+			wantJira:    45 * time.Second,
+			wantAI:      2 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.toml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+
+			viper.Reset()
+			defer viper.Reset()
+			viper.SetConfigFile(configPath)
+			if err := viper.ReadInConfig(); err != nil {
+				t.Fatalf("Failed to read config: %v", err)
+			}
+
+			config, err := Load()
+			if err != nil {
+				t.Fatalf("Load() error: %v", err)
+			}
+
+			if config.Network.Timeout != tt.wantNetwork {
+				t.Errorf("Network.Timeout = %s, want %s", config.Network.Timeout, tt.wantNetwork)
+			}
+			if config.Jira.Timeout != tt.wantJira {
+				t.Errorf("Jira.Timeout = %s, want %s", config.Jira.Timeout, tt.wantJira)
+			}
+			if config.AI.Timeout != tt.wantAI {
+				t.Errorf("AI.Timeout = %s, want %s", config.AI.Timeout, tt.wantAI)
+			}
+		})
+	}
+}
+
+func TestLoad_JiraProfiles(t *testing.T) {
+	content := `[jira]
+mode = "api"
+base_url = "https://cloud.atlassian.net"
+email = "me@example.com"
+
+[jira.profiles.dc]
+projects = ["OPS", "infra"]
+base_url = "https://jira.example.com"
+start_transition = "In Progress"
+`
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigFile(configPath)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	profile, ok := config.Jira.Profiles["dc"]
+	if !ok {
+		t.Fatalf("Jira.Profiles = %v, want a dc profile", config.Jira.Profiles)
+	}
+	if profile.BaseURL != "https://jira.example.com" || len(profile.Projects) != 2 {
+		t.Errorf("dc profile = %+v", profile)
+	}
+	if got := config.Jira.ForTicket("OPS-12").BaseURL; got != "https://jira.example.com" {
+		t.Errorf("ForTicket(OPS-12).BaseURL = %q, want the dc instance", got)
+	}
+}
+
+func TestJiraConfig_ForTicket(t *testing.T) {
+	cfg := &JiraConfig{
+		Mode:            "api",
+		BaseURL:         "https://cloud.atlassian.net",
+		Email:           "me@example.com",
+		StartTransition: "In Progress",
+		CustomFields:    map[string]string{"points": "customfield_10016"},
+		Profiles: map[string]JiraProfile{
+			"dc": {
+				Projects:     []string{"OPS", "infra"},
+				BaseURL:      "https://jira.example.com",
+				Email:        "me@corp.example.com",
+				CustomFields: map[string]string{"points": "customfield_10002"},
+			},
+			"legacy": {
+				Projects:   []string{"OLD"},
+				Mode:       "acli",
+				CliCommand: "acli-old",
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		ticket      string
+		defaultName string
+		wantProfile string
+		wantBaseURL string
+		wantMode    string
+		wantEmail   string
+		wantPoints  string
+	}{
+		{
+			name:        "no matching profile uses top-level settings",
+			ticket:      "FRAAS-1",
+			wantBaseURL: "https://cloud.atlassian.net",
+			wantMode:    "api",
+			wantEmail:   "me@example.com",
+			wantPoints:  "customfield_10016",
+		},
+		{
+			name:        "project key selects profile",
+			ticket:      "OPS-42",
+			wantProfile: "dc",
+			wantBaseURL: "https://jira.example.com",
+			wantMode:    "api",
+			wantEmail:   "me@corp.example.com",
+			wantPoints:  "customfield_10002",
+		},
+		{
+			name:        "project key matched case-insensitively",
+			ticket:      "INFRA-7",
+			wantProfile: "dc",
+			wantBaseURL: "https://jira.example.com",
+			wantMode:    "api",
+			wantEmail:   "me@corp.example.com",
+			wantPoints:  "customfield_10002",
+		},
+		{
+			name:        "profile inherits unset fields",
+			ticket:      "OLD-3",
+			wantProfile: "legacy",
+			wantBaseURL: "https://cloud.atlassian.net",
+			wantMode:    "acli",
+			wantEmail:   "me@example.com",
+			wantPoints:  "customfield_10016",
+		},
+		{
+			name:        "default profile when nothing matches",
+			ticket:      "FRAAS-1",
+			defaultName: "dc",
+			wantProfile: "dc",
+			wantBaseURL: "https://jira.example.com",
+			wantMode:    "api",
+			wantEmail:   "me@corp.example.com",
+			wantPoints:  "customfield_10002",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := *cfg
+			c.DefaultProfile = tt.defaultName
+
+			if got := c.ProfileFor(tt.ticket); got != tt.wantProfile {
+				t.Errorf("ProfileFor(%q) = %q, want %q", tt.ticket, got, tt.wantProfile)
+			}
+
+			got := c.ForTicket(tt.ticket)
+			if got.BaseURL != tt.wantBaseURL {
+				t.Errorf("BaseURL = %q, want %q", got.BaseURL, tt.wantBaseURL)
+			}
+			if got.Mode != tt.wantMode {
+				t.Errorf("Mode = %q, want %q", got.Mode, tt.wantMode)
+			}
+			if got.Email != tt.wantEmail {
+				t.Errorf("Email = %q, want %q", got.Email, tt.wantEmail)
+			}
+			if got.CustomFields["points"] != tt.wantPoints {
+				t.Errorf("CustomFields[points] = %q, want %q", got.CustomFields["points"], tt.wantPoints)
+			}
+			if got.StartTransition != "In Progress" {
+				t.Errorf("StartTransition = %q, want it inherited", got.StartTransition)
+			}
+		})
+	}
+
+	if cfg.BaseURL != "https://cloud.atlassian.net" {
+		t.Errorf("ForTicket modified the receiver: BaseURL = %q", cfg.BaseURL)
+	}
+}
+
 func TestExpandPaths(t *testing.T) {
 	homeDir, _ := os.UserHomeDir()
 
@@ -470,6 +876,24 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid description format",
+			config: &Config{
+				Jira: JiraConfig{
+					DescriptionFormat: "markdown",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid description format",
+			config: &Config{
+				Jira: JiraConfig{
+					DescriptionFormat: "html",
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// RenamedKeys maps deprecated configuration keys to their current names.
+var RenamedKeys = map[string]string{
+	"vault.path":             "notes.path",
+	"vault.templates_dir":    "notes.template_dir",
+	"vault.daily_dir":        "notes.daily_dir",
+	"repository.base_path":   "clone.base_path",
+	"repository.base_branch": "git.base_branch",
+}
+
+// KeyRename records a deprecated key rewritten by MigrateTOML.
+// Conflict is set when the current key was already present, in which case
+// its value is kept and the deprecated value is dropped.
+type KeyRename struct {
+	Old      string
+	New      string
+	Conflict bool
+}
+
+// DeprecatedKeys returns the deprecated keys for which isSet reports true,
+// sorted by name. Pass viper.IsSet to check the loaded configuration.
+func DeprecatedKeys(isSet func(key string) bool) []string {
+	var found []string
+	for old := range RenamedKeys {
+		if isSet(old) {
+			found = append(found, old)
+		}
+	}
+	sort.Strings(found)
+	return found
+}
+
+// MigrateTOML rewrites deprecated keys in a TOML document to their current
+// names and returns the new document along with the renames applied. When no
+// deprecated keys are present the original data is returned unchanged.
+// Comments are not preserved in a rewritten document.
+func MigrateTOML(data []byte) ([]byte, []KeyRename, error) {
+	var settings map[string]interface{}
+	if err := toml.Unmarshal(data, &settings); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse config")
+	}
+
+	olds := make([]string, 0, len(RenamedKeys))
+	for old := range RenamedKeys {
+		olds = append(olds, old)
+	}
+	sort.Strings(olds)
+
+	var renames []KeyRename
+	for _, old := range olds {
+		value, ok := lookupNested(settings, old)
+		if !ok {
+			continue
+		}
+
+		rename := KeyRename{Old: old, New: RenamedKeys[old]}
+		if _, exists := lookupNested(settings, rename.New); exists {
+			rename.Conflict = true
+		} else {
+			setNested(settings, rename.New, value)
+		}
+		deleteNested(settings, old)
+		renames = append(renames, rename)
+	}
+
+	if len(renames) == 0 {
+		return data, nil, nil
+	}
+
+	out, err := toml.Marshal(settings)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to write migrated config")
+	}
+	return out, renames, nil
+}
+
+// lookupNested returns the value stored under a dotted key.
+func lookupNested(m map[string]interface{}, key string) (interface{}, bool) {
+	parts := strings.Split(key, ".")
+	for _, p := range parts[:len(parts)-1] {
+		next, ok := m[p].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		m = next
+	}
+	value, ok := m[parts[len(parts)-1]]
+	return value, ok
+}
+
+// deleteNested removes a dotted key, pruning tables left empty.
+func deleteNested(m map[string]interface{}, key string) {
+	parts := strings.SplitN(key, ".", 2)
+	if len(parts) == 1 {
+		delete(m, key)
+		return
+	}
+
+	next, ok := m[parts[0]].(map[string]interface{})
+	if !ok {
+		return
+	}
+	deleteNested(next, parts[1])
+	if len(next) == 0 {
+		delete(m, parts[0])
+	}
+}
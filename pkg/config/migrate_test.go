@@ -0,0 +1,119 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+func TestMigrateTOML(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantRenames []KeyRename
+		wantValues  map[string]interface{}
+		wantAbsent  []string
+	}{
+		{
+			name:        "no deprecated keys",
+			input:       "[notes]\npath = \"/notes\"\n",
+			wantRenames: nil,
+			wantValues:  map[string]interface{}{"notes.path": "/notes"},
+		},
+		{
+			name: "renames across tables",
+			input: `[vault]
+path = "/vault"
+daily_dir = "Daily"
+
+[repository]
+base_path = "~/src"
+base_branch = "main"
+
+[jira]
+enabled = false
+`,
+			wantRenames: []KeyRename{
+				{Old: "repository.base_branch", New: "git.base_branch"},
+				{Old: "repository.base_path", New: "clone.base_path"},
+				{Old: "vault.daily_dir", New: "notes.daily_dir"},
+				{Old: "vault.path", New: "notes.path"},
+			},
+			wantValues: map[string]interface{}{
+				"notes.path":      "/vault",
+				"notes.daily_dir": "Daily",
+				"clone.base_path": "~/src",
+				"git.base_branch": "main",
+				"jira.enabled":    false,
+			},
+			wantAbsent: []string{"vault", "repository"},
+		},
+		{
+			name: "current key wins on conflict",
+			input: `[vault]
+path = "/old"
+areas_dir = "Areas"
+
+[notes]
+path = "/new"
+`,
+			wantRenames: []KeyRename{{Old: "vault.path", New: "notes.path", Conflict: true}},
+			wantValues: map[string]interface{}{
+				"notes.path":      "/new",
+				"vault.areas_dir": "Areas",
+			},
+			wantAbsent: []string{"vault.path"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, renames, err := MigrateTOML([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("MigrateTOML() error = %v", err)
+			}
+
+			if len(renames) != len(tt.wantRenames) {
+				t.Fatalf("renames = %+v, want %+v", renames, tt.wantRenames)
+			}
+			for i := range renames {
+				if renames[i] != tt.wantRenames[i] {
+					t.Errorf("renames[%d] = %+v, want %+v", i, renames[i], tt.wantRenames[i])
+				}
+			}
+
+			var settings map[string]interface{}
+			if err := toml.Unmarshal(out, &settings); err != nil {
+				t.Fatalf("migrated output does not parse: %v\n%s", err, out)
+			}
+			for key, want := range tt.wantValues {
+				got, ok := lookupNested(settings, key)
+				if !ok || got != want {
+					t.Errorf("%s = %v, want %v", key, got, want)
+				}
+			}
+			for _, key := range tt.wantAbsent {
+				if _, ok := lookupNested(settings, key); ok {
+					t.Errorf("%s should have been removed:\n%s", key, out)
+				}
+			}
+		})
+	}
+}
+
+func TestMigrateTOML_Malformed(t *testing.T) {
+	if _, _, err := MigrateTOML([]byte("[vault\n")); err == nil {
+		t.Error("MigrateTOML() should fail on malformed TOML")
+	}
+}
+
+func TestDeprecatedKeys(t *testing.T) {
+	set := map[string]bool{"vault.path": true, "repository.base_branch": true, "notes.path": true}
+
+	got := DeprecatedKeys(func(key string) bool { return set[key] })
+	want := "repository.base_branch,vault.path"
+	if strings.Join(got, ",") != want {
+		t.Errorf("DeprecatedKeys() = %v, want %s", got, want)
+	}
+}
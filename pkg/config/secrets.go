@@ -0,0 +1,169 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// SecretKeys lists the configuration keys known to hold credentials.
+var SecretKeys = []string{
+	"github.token",
+	"jira.token",
+	"ai.api_key",
+	"ai.gemini_api_key",
+}
+
+// IsSecretKey reports whether key holds a credential that should be redacted
+// when configuration is displayed. Besides SecretKeys, any key ending in
+// "token" or "api_key" is treated as secret.
+func IsSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range SecretKeys {
+		if key == k {
+			return true
+		}
+	}
+	name := key[strings.LastIndex(key, ".")+1:]
+	return name == "token" || strings.HasSuffix(name, "_token") ||
+		name == "api_key" || strings.HasSuffix(name, "_api_key")
+}
+
+// LoadSecretsFile reads credentials from a secrets file and returns them as a
+// nested settings map suitable for viper.MergeConfigMap. Files ending in
+// ".toml" are parsed as TOML; anything else is read as an env file of
+// KEY=VALUE lines, where KEY is either a dotted config key (jira.token) or its
+// RIG_ environment variable form (RIG_JIRA_TOKEN). path may start with ~ and
+// reference environment variables (see ExpandPath).
+//
+// Only keys recognized by IsSecretKey are returned; the names of any other
+// keys are reported as ignored so callers can warn about them.
+func LoadSecretsFile(path string) (map[string]interface{}, []string, error) {
+	path, err := ExpandPath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read secrets file %s", path)
+	}
+
+	flat := make(map[string]interface{})
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		var settings map[string]interface{}
+		if err := toml.Unmarshal(data, &settings); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to parse secrets file %s", path)
+		}
+		flattenSettings("", settings, flat)
+	} else {
+		if err := parseEnvFile(data, flat); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to parse secrets file %s", path)
+		}
+	}
+
+	secrets := make(map[string]interface{})
+	var ignored []string
+	for key, value := range flat {
+		if !IsSecretKey(key) {
+			ignored = append(ignored, key)
+			continue
+		}
+		setNested(secrets, key, value)
+	}
+	sort.Strings(ignored)
+
+	return secrets, ignored, nil
+}
+
+// IsWorldReadable reports whether the file at path can be read by any user.
+func IsWorldReadable(path string) (bool, error) {
+	path, err := ExpandPath(path)
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	return info.Mode().Perm()&0o004 != 0, nil
+}
+
+// parseEnvFile parses KEY=VALUE lines into flat, normalizing keys to dotted
+// lowercase config keys. Blank lines, comments and an "export " prefix are
+// accepted.
+func parseEnvFile(data []byte, flat map[string]interface{}) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return errors.Newf("line %d: expected KEY=VALUE", n)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+
+		flat[envKeyToConfigKey(key)] = value
+	}
+	return scanner.Err()
+}
+
+// envKeyToConfigKey converts RIG_JIRA_TOKEN to jira.token. The first segment
+// after the prefix names the section; section names never contain
+// underscores, so the remainder is the key. Dotted keys pass through
+// lowercased.
+func envKeyToConfigKey(key string) string {
+	key = strings.ToLower(key)
+	if strings.Contains(key, ".") {
+		return key
+	}
+	key = strings.TrimPrefix(key, "rig_")
+	if section, rest, ok := strings.Cut(key, "_"); ok {
+		return section + "." + rest
+	}
+	return key
+}
+
+// flattenSettings converts nested settings into dotted lowercase keys.
+func flattenSettings(prefix string, settings map[string]interface{}, flat map[string]interface{}) {
+	for k, v := range settings {
+		key := strings.ToLower(k)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			flattenSettings(key, nested, flat)
+			continue
+		}
+		flat[key] = v
+	}
+}
+
+// setNested stores value in m under a dotted key, creating intermediate maps.
+func setNested(m map[string]interface{}, key string, value interface{}) {
+	parts := strings.Split(key, ".")
+	for _, p := range parts[:len(parts)-1] {
+		next, ok := m[p].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			m[p] = next
+		}
+		m = next
+	}
+	m[parts[len(parts)-1]] = value
+}
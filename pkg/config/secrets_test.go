@@ -0,0 +1,149 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsSecretKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"jira.token", true},
+		{"github.token", true},
+		{"ai.api_key", true},
+		{"ai.gemini_api_key", true},
+		{"AI.API_KEY", true},
+		{"jira.refresh_token", true},
+		{"jira.email", false},
+		{"jira.token_keychain_service", false},
+		{"ai.diff_token_budget", false},
+		{"notes.path", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := IsSecretKey(tt.key); got != tt.want {
+				t.Errorf("IsSecretKey(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSecretsFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		filename    string
+		content     string
+		wantJira    string
+		wantAIKey   string
+		wantIgnored []string
+		wantErr     bool
+	}{
+		{
+			name:     "toml file",
+			filename: "secrets.toml",
+			content: `[jira]
+token = "jira-secret"
+base_url = "https://example.atlassian.net"
+
+[ai]
+gemini_api_key = "gem-secret"
+`,
+			wantJira:    "jira-secret",
+			wantAIKey:   "gem-secret",
+			wantIgnored: []string{"jira.base_url"},
+		},
+		{
+			name:     "env file",
+			filename: "secrets.env",
+			content: `# credentials
+export RIG_JIRA_TOKEN="jira-secret"
+RIG_AI_GEMINI_API_KEY='gem-secret'
+RIG_NOTES_PATH=/tmp/notes
+`,
+			wantJira:    "jira-secret",
+			wantAIKey:   "gem-secret",
+			wantIgnored: []string{"notes.path"},
+		},
+		{
+			name:     "env file with dotted keys",
+			filename: "secrets",
+			content:  "jira.token=jira-secret\n",
+			wantJira: "jira-secret",
+		},
+		{
+			name:     "malformed env file",
+			filename: "secrets.env",
+			content:  "RIG_JIRA_TOKEN\n",
+			wantErr:  true,
+		},
+		{
+			name:     "malformed toml file",
+			filename: "secrets.toml",
+			content:  "[jira\n",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.filename)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			secrets, ignored, err := LoadSecretsFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadSecretsFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			jira, _ := secrets["jira"].(map[string]interface{})
+			if got, _ := jira["token"].(string); got != tt.wantJira {
+				t.Errorf("jira.token = %q, want %q", got, tt.wantJira)
+			}
+			ai, _ := secrets["ai"].(map[string]interface{})
+			if got, _ := ai["gemini_api_key"].(string); got != tt.wantAIKey {
+				t.Errorf("ai.gemini_api_key = %q, want %q", got, tt.wantAIKey)
+			}
+			if strings.Join(ignored, ",") != strings.Join(tt.wantIgnored, ",") {
+				t.Errorf("ignored = %v, want %v", ignored, tt.wantIgnored)
+			}
+		})
+	}
+}
+
+func TestLoadSecretsFile_Missing(t *testing.T) {
+	if _, _, err := LoadSecretsFile(filepath.Join(t.TempDir(), "missing.toml")); err == nil {
+		t.Error("LoadSecretsFile() should fail for a missing file")
+	}
+}
+
+func TestIsWorldReadable(t *testing.T) {
+	dir := t.TempDir()
+
+	private := filepath.Join(dir, "private")
+	if err := os.WriteFile(private, []byte("x"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	public := filepath.Join(dir, "public")
+	if err := os.WriteFile(public, []byte("x"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(public, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := IsWorldReadable(private); err != nil || got {
+		t.Errorf("IsWorldReadable(0600) = %v, %v; want false", got, err)
+	}
+	if got, err := IsWorldReadable(public); err != nil || !got {
+		t.Errorf("IsWorldReadable(0644) = %v, %v; want true", got, err)
+	}
+}
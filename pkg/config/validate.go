@@ -0,0 +1,274 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/viper"
+)
+
+// ValidAIProviders is the list of supported AI providers.
+var ValidAIProviders = []string{"anthropic", "groq", "ollama", "gemini"}
+
+// ValidJiraModes is the list of supported Jira integration modes.
+var ValidJiraModes = []string{"api", "acli"}
+
+// ValidGitHubAuthMethods is the list of supported GitHub authentication methods.
+var ValidGitHubAuthMethods = []string{"token", "oauth", "gh_cli"}
+
+// Problem describes a single configuration issue.
+// File and Line are set when the problem can be traced to a config file.
+type Problem struct {
+	Key     string
+	Message string
+	File    string
+	Line    int
+}
+
+// String formats the problem as "file:line: key: message", omitting the
+// location when it is unknown.
+func (p Problem) String() string {
+	var loc string
+	switch {
+	case p.File != "" && p.Line > 0:
+		loc = fmt.Sprintf("%s:%d: ", p.File, p.Line)
+	case p.File != "":
+		loc = p.File + ": "
+	}
+	if p.Key == "" {
+		return loc + p.Message
+	}
+	return loc + p.Key + ": " + p.Message
+}
+
+// Problems returns every issue found in the configuration. Unlike Validate,
+// which stops at the first error, it checks required fields for each enabled
+// feature as well as enumerated values.
+func (c *Config) Problems() []Problem {
+	var problems []Problem
+
+	add := func(key, format string, args ...interface{}) {
+		problems = append(problems, Problem{Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if err := ValidateMergeMethod(c.GitHub.DefaultMergeMethod); err != nil {
+		add("github.default_merge_method", "%v", err)
+	}
+	if c.GitHub.AuthMethod != "" && !contains(ValidGitHubAuthMethods, c.GitHub.AuthMethod) {
+		add("github.auth_method", "invalid auth method %q: must be one of: %s",
+			c.GitHub.AuthMethod, strings.Join(ValidGitHubAuthMethods, ", "))
+	}
+	if c.GitHub.AuthMethod == "token" && c.GitHub.Token == "" {
+		add("github.token", "required when github.auth_method is \"token\" (or set RIG_GITHUB_TOKEN)")
+	}
+
+	if c.Jira.Enabled {
+		if c.Jira.Mode != "" && !contains(ValidJiraModes, c.Jira.Mode) {
+			add("jira.mode", "invalid mode %q: must be one of: %s",
+				c.Jira.Mode, strings.Join(ValidJiraModes, ", "))
+		}
+		if c.Jira.Mode == "api" {
+			if c.Jira.BaseURL == "" {
+				add("jira.base_url", "required when jira is enabled in api mode")
+			}
+			if c.Jira.Email == "" {
+				add("jira.email", "required when jira is enabled in api mode")
+			}
+			if c.Jira.Token == "" && c.Jira.TokenKeychainService == "" && os.Getenv("JIRA_TOKEN") == "" {
+				add("jira.token", "required when jira is enabled in api mode (or set JIRA_TOKEN or jira.token_keychain_service)")
+			}
+		}
+	}
+	if err := ValidateDescriptionFormat(c.Jira.DescriptionFormat); err != nil {
+		add("jira.description_format", "%v", err)
+	}
+	if c.Jira.CacheTTL < 0 {
+		add("jira.cache_ttl", "must not be negative, got %s", c.Jira.CacheTTL)
+	}
+	if c.Jira.Timeout < 0 {
+		add("jira.timeout", "must not be negative, got %s", c.Jira.Timeout)
+	}
+	if c.Jira.DefaultProfile != "" {
+		if _, ok := c.Jira.Profiles[c.Jira.DefaultProfile]; !ok {
+			add("jira.default_profile", "unknown profile %q", c.Jira.DefaultProfile)
+		}
+	}
+	for _, name := range sortedKeys(c.Jira.Profiles) {
+		if mode := c.Jira.Profiles[name].Mode; mode != "" && !contains(ValidJiraModes, mode) {
+			add("jira.profiles."+name+".mode", "invalid mode %q: must be one of: %s",
+				mode, strings.Join(ValidJiraModes, ", "))
+		}
+	}
+
+	if c.AI.Enabled && !contains(ValidAIProviders, c.AI.Provider) {
+		add("ai.provider", "invalid provider %q: must be one of: %s",
+			c.AI.Provider, strings.Join(ValidAIProviders, ", "))
+	}
+	if c.AI.DiffTokenBudget < 0 {
+		add("ai.diff_token_budget", "must not be negative, got %d", c.AI.DiffTokenBudget)
+	}
+	if c.AI.Timeout < 0 {
+		add("ai.timeout", "must not be negative, got %s", c.AI.Timeout)
+	}
+
+	for i, w := range c.Tmux.Windows {
+		if w.Name == "" {
+			add(fmt.Sprintf("tmux.windows[%d]", i), "window name is required")
+		}
+	}
+
+	if c.Discovery.MaxDepth < 0 {
+		add("discovery.max_depth", "must not be negative, got %d", c.Discovery.MaxDepth)
+	}
+
+	if c.Network.Timeout < 0 {
+		add("network.timeout", "must not be negative, got %s", c.Network.Timeout)
+	}
+
+	if c.Hooks.Timeout < 0 {
+		add("hooks.timeout", "must not be negative, got %s", c.Hooks.Timeout)
+	}
+
+	return problems
+}
+
+// CheckFile parses the config file at path and reports a Problem describing
+// the first syntax error. TOML errors include their line number. It returns
+// nil when the file parses cleanly.
+func CheckFile(path string) (*Problem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+
+	format := FileFormat(path)
+	if format != "toml" {
+		v := viper.New()
+		v.SetConfigType(format)
+		if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+			return &Problem{File: path, Message: "malformed " + strings.ToUpper(format) + ": " + err.Error()}, nil
+		}
+		return nil, nil
+	}
+
+	var v map[string]interface{}
+	err = toml.Unmarshal(data, &v)
+	if err == nil {
+		return nil, nil
+	}
+
+	problem := &Problem{File: path, Message: "malformed TOML: " + err.Error()}
+	var decodeErr *toml.DecodeError
+	if errors.As(err, &decodeErr) {
+		problem.Line, _ = decodeErr.Position()
+	}
+	return problem, nil
+}
+
+// FileFormat returns the config format of path from its extension: toml,
+// yaml, yml or json. Files without an extension are treated as TOML.
+func FileFormat(path string) string {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	if ext == "" {
+		return "toml"
+	}
+	return ext
+}
+
+// KeyLines maps each dotted key defined in TOML data to the line it appears
+// on. Table headers are recorded under the table name, so a missing key can
+// be attributed to its enclosing section. It is a line-oriented scan meant for
+// error reporting, not a full parser.
+func KeyLines(data []byte) map[string]int {
+	lines := make(map[string]int)
+	table := ""
+	inMultiline := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if inMultiline != "" {
+			if strings.Contains(line, inMultiline) {
+				inMultiline = ""
+			}
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			name := strings.Trim(stripComment(line), "[] \t")
+			table = normalizeKey(name)
+			if _, ok := lines[table]; !ok {
+				lines[table] = n
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		full := normalizeKey(key)
+		if table != "" {
+			full = table + "." + full
+		}
+		if _, exists := lines[full]; !exists {
+			lines[full] = n
+		}
+
+		value = strings.TrimSpace(value)
+		for _, delim := range []string{`"""`, `'''`} {
+			if strings.HasPrefix(value, delim) && !strings.Contains(value[len(delim):], delim) {
+				inMultiline = delim
+			}
+		}
+	}
+
+	return lines
+}
+
+// normalizeKey strips whitespace and quotes from each segment of a dotted key.
+func normalizeKey(key string) string {
+	parts := strings.Split(strings.TrimSpace(key), ".")
+	for i, p := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(p), `"'`)
+	}
+	return strings.Join(parts, ".")
+}
+
+// stripComment removes a trailing # comment from a table header line.
+func stripComment(line string) string {
+	if i := strings.Index(line, "#"); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,271 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfig_Problems(t *testing.T) {
+	t.Setenv("JIRA_TOKEN", "")
+
+	tests := []struct {
+		name     string
+		config   *Config
+		wantKeys []string
+	}{
+		{
+			name:     "empty config",
+			config:   &Config{},
+			wantKeys: nil,
+		},
+		{
+			name: "jira api mode missing credentials",
+			config: &Config{
+				Jira: JiraConfig{Enabled: true, Mode: "api"},
+			},
+			wantKeys: []string{"jira.base_url", "jira.email", "jira.token"},
+		},
+		{
+			name: "jira api mode complete",
+			config: &Config{
+				Jira: JiraConfig{Enabled: true, Mode: "api", BaseURL: "https://x.atlassian.net", Email: "a@b.c", Token: "t"},
+			},
+			wantKeys: nil,
+		},
+		{
+			name: "jira token from keychain",
+			config: &Config{
+				Jira: JiraConfig{Enabled: true, Mode: "api", BaseURL: "https://x.atlassian.net", Email: "a@b.c", TokenKeychainService: "rig-jira"},
+			},
+			wantKeys: nil,
+		},
+		{
+			name: "jira disabled skips required fields",
+			config: &Config{
+				Jira: JiraConfig{Enabled: false, Mode: "api"},
+			},
+			wantKeys: nil,
+		},
+		{
+			name: "jira acli mode needs no credentials",
+			config: &Config{
+				Jira: JiraConfig{Enabled: true, Mode: "acli"},
+			},
+			wantKeys: nil,
+		},
+		{
+			name: "invalid enums",
+			config: &Config{
+				GitHub: GitHubConfig{DefaultMergeMethod: "ff", AuthMethod: "password"},
+				Jira:   JiraConfig{Enabled: true, Mode: "web", DescriptionFormat: "html"},
+				AI:     AIConfig{Enabled: true, Provider: "openai"},
+			},
+			wantKeys: []string{"github.default_merge_method", "github.auth_method", "jira.mode", "jira.description_format", "ai.provider"},
+		},
+		{
+			name: "ai provider ignored when disabled",
+			config: &Config{
+				AI: AIConfig{Enabled: false, Provider: "openai"},
+			},
+			wantKeys: nil,
+		},
+		{
+			name: "token auth without token",
+			config: &Config{
+				GitHub: GitHubConfig{AuthMethod: "token"},
+			},
+			wantKeys: []string{"github.token"},
+		},
+		{
+			name: "unnamed tmux window",
+			config: &Config{
+				Tmux: TmuxConfig{Windows: []TmuxWindow{{Name: "code"}, {Command: "htop"}}},
+			},
+			wantKeys: []string{"tmux.windows[1]"},
+		},
+		{
+			name: "negative timeouts",
+			config: &Config{
+				Jira:    JiraConfig{Timeout: -time.Second},
+				AI:      AIConfig{Timeout: -time.Second},
+				Network: NetworkConfig{Timeout: -time.Second},
+				Hooks:   HooksConfig{Timeout: -time.Second},
+			},
+			wantKeys: []string{"jira.timeout", "ai.timeout", "network.timeout", "hooks.timeout"},
+		},
+		{
+			name: "jira profiles",
+			config: &Config{
+				Jira: JiraConfig{
+					DefaultProfile: "missing",
+					Profiles: map[string]JiraProfile{
+						"cloud": {Mode: "api"},
+						"dc":    {Mode: "web"},
+					},
+				},
+			},
+			wantKeys: []string{"jira.default_profile", "jira.profiles.dc.mode"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := tt.config.Problems()
+
+			var gotKeys []string
+			for _, p := range problems {
+				gotKeys = append(gotKeys, p.Key)
+			}
+			if strings.Join(gotKeys, ",") != strings.Join(tt.wantKeys, ",") {
+				t.Errorf("Problems() keys = %v, want %v", gotKeys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestConfig_Problems_JiraTokenFromEnv(t *testing.T) {
+	t.Setenv("JIRA_TOKEN", "env-token")
+
+	cfg := &Config{Jira: JiraConfig{Enabled: true, Mode: "api", BaseURL: "https://x.atlassian.net", Email: "a@b.c"}}
+	if problems := cfg.Problems(); len(problems) != 0 {
+		t.Errorf("Problems() = %v, want none when JIRA_TOKEN is set", problems)
+	}
+}
+
+func TestProblem_String(t *testing.T) {
+	tests := []struct {
+		problem Problem
+		want    string
+	}{
+		{Problem{Key: "ai.provider", Message: "bad", File: "/c.toml", Line: 4}, "/c.toml:4: ai.provider: bad"},
+		{Problem{Key: "ai.provider", Message: "bad", File: "/c.toml"}, "/c.toml: ai.provider: bad"},
+		{Problem{Key: "ai.provider", Message: "bad"}, "ai.provider: bad"},
+		{Problem{Message: "malformed TOML", File: "/c.toml", Line: 2}, "/c.toml:2: malformed TOML"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.problem.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.toml")
+	if err := os.WriteFile(valid, []byte("[jira]\nenabled = true\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	problem, err := CheckFile(valid)
+	if err != nil {
+		t.Fatalf("CheckFile() error = %v", err)
+	}
+	if problem != nil {
+		t.Errorf("CheckFile() = %v, want nil for valid TOML", problem)
+	}
+
+	malformed := filepath.Join(dir, "bad.toml")
+	if err := os.WriteFile(malformed, []byte("[jira]\nenabled = true\nbase_url = \n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	problem, err = CheckFile(malformed)
+	if err != nil {
+		t.Fatalf("CheckFile() error = %v", err)
+	}
+	if problem == nil {
+		t.Fatal("CheckFile() = nil, want problem for malformed TOML")
+	}
+	if problem.Line != 3 {
+		t.Errorf("problem.Line = %d, want 3", problem.Line)
+	}
+	if !strings.Contains(problem.Message, "malformed TOML") {
+		t.Errorf("problem.Message = %q, want malformed TOML", problem.Message)
+	}
+
+	if _, err := CheckFile(filepath.Join(dir, "missing.toml")); err == nil {
+		t.Error("CheckFile() should fail for a missing file")
+	}
+}
+
+func TestCheckFile_OtherFormats(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		file        string
+		content     string
+		wantProblem string
+	}{
+		{file: "valid.yaml", content: "jira:\n  enabled: true\n"},
+		{file: "valid.json", content: `{"jira": {"enabled": true}}`},
+		{file: "bad.yaml", content: "jira:\n  enabled: [true\n", wantProblem: "malformed YAML"},
+		{file: "bad.json", content: `{"jira": `, wantProblem: "malformed JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			problem, err := CheckFile(path)
+			if err != nil {
+				t.Fatalf("CheckFile() error = %v", err)
+			}
+			if tt.wantProblem == "" {
+				if problem != nil {
+					t.Errorf("CheckFile() = %v, want nil", problem)
+				}
+				return
+			}
+			if problem == nil || !strings.Contains(problem.Message, tt.wantProblem) {
+				t.Errorf("CheckFile() = %v, want problem containing %q", problem, tt.wantProblem)
+			}
+		})
+	}
+}
+
+func TestKeyLines(t *testing.T) {
+	data := `# Rig config
+[github]
+default_merge_method = "ff" # comment
+
+[jira]
+enabled = true
+"base_url" = "https://x"
+notes = """
+fake = "not a key"
+"""
+
+[[tmux.windows]]
+name = "code"
+
+[[tmux.windows]]
+name = "term"
+`
+
+	lines := KeyLines([]byte(data))
+
+	want := map[string]int{
+		"github":                      2,
+		"github.default_merge_method": 3,
+		"jira":                        5,
+		"jira.enabled":                6,
+		"jira.base_url":               7,
+		"jira.notes":                  8,
+		"tmux.windows":                12,
+		"tmux.windows.name":           13,
+	}
+	for key, line := range want {
+		if lines[key] != line {
+			t.Errorf("KeyLines()[%q] = %d, want %d", key, lines[key], line)
+		}
+	}
+	if _, ok := lines["jira.fake"]; ok {
+		t.Error("KeyLines() should skip content inside multi-line strings")
+	}
+}
@@ -2,9 +2,9 @@ package errors
 
 import (
 	"context"
-	"math"
-	"math/rand/v2"
 	"time"
+
+	"thoreinstein.com/rig/pkg/retry"
 )
 
 // Retry configuration defaults.
@@ -124,19 +124,7 @@ func RetryWithResult[T any](ctx context.Context, cfg RetryConfig, fn func() (T,
 // CalculateBackoff computes the delay for a retry attempt using exponential backoff with jitter.
 // Formula: delay = min(base * 2^attempt, max) * (1 - jitter/2 + jitter*rand())
 // For jitter=0.4, this produces a multiplier range of [0.8, 1.2], which is ±20% variation.
+// It is retry.Backoff, kept here for existing callers.
 func CalculateBackoff(base, max time.Duration, attempt int, jitter float64) time.Duration {
-	// Calculate exponential delay: base * 2^attempt
-	expDelay := float64(base) * math.Pow(2, float64(attempt))
-
-	// Cap at max delay
-	if expDelay > float64(max) {
-		expDelay = float64(max)
-	}
-
-	// Apply jitter: multiply by (1 - jitter/2 + jitter*rand())
-	// For jitter=0.4, this gives range [0.8, 1.2] which is ±20%
-	jitterMultiplier := 1.0 - jitter/2 + jitter*rand.Float64()
-	delay := time.Duration(expDelay * jitterMultiplier)
-
-	return delay
+	return retry.Backoff(base, max, attempt, jitter)
 }
@@ -0,0 +1,114 @@
+// Package events emits machine-readable JSON events at points in the work
+// lifecycle, so other tooling can follow rig without parsing its output.
+//
+// Events are written one JSON object per line to the destination named by
+// RIG_EVENTS_FD: a number is taken as an already-open file descriptor, and
+// anything else as a file path to append to. When it is unset, nothing is
+// emitted.
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// EnvVar names the environment variable that enables events
+const EnvVar = "RIG_EVENTS_FD"
+
+// Type identifies what happened
+type Type string
+
+const (
+	WorktreeCreated  Type = "worktree.created"
+	WorktreeRemoved  Type = "worktree.removed"
+	SessionStarted   Type = "session.started"
+	NoteCreated      Type = "note.created"
+	NoteUpdated      Type = "note.updated"
+	JiraTransitioned Type = "jira.transitioned"
+)
+
+// Event is a single lifecycle event
+type Event struct {
+	Type      Type              `json:"type"`
+	Timestamp time.Time         `json:"timestamp"`
+	Ticket    string            `json:"ticket"`
+	Data      map[string]string `json:"data,omitempty"` // Event-specific details such as paths
+}
+
+// Emitter writes events. The zero value and a nil *Emitter are disabled and
+// emit nothing.
+type Emitter struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+// NewEmitter creates an Emitter that writes to w. A nil w disables it.
+func NewEmitter(w io.Writer) *Emitter {
+	return &Emitter{w: w, now: time.Now}
+}
+
+// FromEnv creates an Emitter for the destination in RIG_EVENTS_FD. It is
+// disabled when the variable is unset.
+func FromEnv() (*Emitter, error) {
+	dest := strings.TrimSpace(os.Getenv(EnvVar))
+	if dest == "" {
+		return NewEmitter(nil), nil
+	}
+
+	if fd, err := strconv.Atoi(dest); err == nil {
+		if fd < 0 {
+			return nil, errors.Newf("invalid %s %q: file descriptor must not be negative", EnvVar, dest)
+		}
+		return NewEmitter(os.NewFile(uintptr(fd), "rig-events")), nil
+	}
+
+	f, err := os.OpenFile(dest, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s file %s", EnvVar, dest)
+	}
+	return NewEmitter(f), nil
+}
+
+// Enabled reports whether events are written anywhere
+func (e *Emitter) Enabled() bool {
+	return e != nil && e.w != nil
+}
+
+// Emit writes an event of type t for ticket. Events are best effort: a failed
+// write is logged at debug level and never interrupts the workflow.
+func (e *Emitter) Emit(t Type, ticket string, data map[string]string) {
+	if !e.Enabled() {
+		return
+	}
+
+	now := time.Now
+	if e.now != nil {
+		now = e.now
+	}
+	line, err := json.Marshal(Event{
+		Type:      t,
+		Timestamp: now().UTC(),
+		Ticket:    ticket,
+		Data:      data,
+	})
+	if err != nil {
+		slog.Debug("failed to encode event", "type", t, "error", err)
+		return
+	}
+
+	// One write per line keeps events whole when the destination is shared
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, err := e.w.Write(append(line, '\n')); err != nil {
+		slog.Debug("failed to write event", "type", t, "error", err)
+	}
+}
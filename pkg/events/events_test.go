@@ -0,0 +1,117 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEmit(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEmitter(&buf)
+	e.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	e.Emit(WorktreeCreated, "proj-123", map[string]string{"path": "/src/repo/proj/proj-123"})
+	e.Emit(NoteCreated, "proj-123", nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+
+	var got Event
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("event is not valid JSON: %v", err)
+	}
+	if got.Type != WorktreeCreated || got.Ticket != "proj-123" || got.Data["path"] != "/src/repo/proj/proj-123" {
+		t.Errorf("unexpected event: %+v", got)
+	}
+	if !got.Timestamp.Equal(e.now()) {
+		t.Errorf("Timestamp = %v, want %v", got.Timestamp, e.now())
+	}
+
+	if strings.Contains(lines[1], `"data"`) {
+		t.Errorf("event without data should omit it: %s", lines[1])
+	}
+}
+
+func TestEmit_Disabled(t *testing.T) {
+	var nilEmitter *Emitter
+	if nilEmitter.Enabled() {
+		t.Error("nil emitter should be disabled")
+	}
+	nilEmitter.Emit(NoteCreated, "proj-123", nil) // must not panic
+
+	if NewEmitter(nil).Enabled() {
+		t.Error("emitter without a writer should be disabled")
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Run("unset", func(t *testing.T) {
+		t.Setenv(EnvVar, "")
+		e, err := FromEnv()
+		if err != nil {
+			t.Fatalf("FromEnv() error = %v", err)
+		}
+		if e.Enabled() {
+			t.Error("emitter should be disabled when the variable is unset")
+		}
+	})
+
+	t.Run("file path", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.jsonl")
+		t.Setenv(EnvVar, path)
+
+		e, err := FromEnv()
+		if err != nil {
+			t.Fatalf("FromEnv() error = %v", err)
+		}
+		e.Emit(SessionStarted, "proj-123", nil)
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading events file: %v", err)
+		}
+		if !strings.Contains(string(data), `"type":"session.started"`) {
+			t.Errorf("events file = %q, want a session.started event", data)
+		}
+	})
+
+	t.Run("file descriptor", func(t *testing.T) {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Close()
+		defer w.Close()
+		t.Setenv(EnvVar, strconv.FormatUint(uint64(w.Fd()), 10))
+
+		e, err := FromEnv()
+		if err != nil {
+			t.Fatalf("FromEnv() error = %v", err)
+		}
+		e.Emit(JiraTransitioned, "proj-123", map[string]string{"status": "Done"})
+
+		buf := make([]byte, 4096)
+		n, err := r.Read(buf)
+		if err != nil {
+			t.Fatalf("reading pipe: %v", err)
+		}
+		if !strings.Contains(string(buf[:n]), `"status":"Done"`) {
+			t.Errorf("pipe got %q, want the transition status", buf[:n])
+		}
+	})
+
+	t.Run("negative descriptor", func(t *testing.T) {
+		t.Setenv(EnvVar, "-1")
+		if _, err := FromEnv(); err == nil {
+			t.Error("FromEnv() expected error for a negative descriptor")
+		}
+	})
+}
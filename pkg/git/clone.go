@@ -1,13 +1,20 @@
 package git
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
+
+	"thoreinstein.com/rig/pkg/config"
 )
 
 // RepoURL represents a parsed GitHub repository URL
@@ -33,6 +40,10 @@ var (
 	// Shorthand format: owner/repo (interpreted as SSH by default)
 	// Restrict owner to alphanumeric and hyphens to avoid matching domains like github.com/owner
 	ownerRepoRegex = regexp.MustCompile(`^([a-zA-Z0-9-]+)/([a-zA-Z0-9_.-]+?)(?:\.git)?$`)
+
+	// Pull request URL: https://github.com/owner/repo/pull/42, optionally
+	// followed by a tab such as /files
+	pullRequestURLRegex = regexp.MustCompile(`^((?:https://)?github\.com/[^/]+/[^/]+)/pull/([0-9]+)(?:/.*)?$`)
 )
 
 // ParseGitHubURL parses various GitHub URL formats and returns a normalized RepoURL.
@@ -94,12 +105,70 @@ func ParseGitHubURL(input string) (*RepoURL, error) {
 	return nil, errors.Newf("invalid GitHub URL format: %q\n\nSupported formats:\n  git@github.com:owner/repo.git (SSH)\n  https://github.com/owner/repo (HTTPS)\n  github.com/owner/repo (shorthand)\n  owner/repo (shorthand)", input)
 }
 
+// ParsePullRequestURL parses a GitHub pull request URL such as
+// https://github.com/owner/repo/pull/42 and returns the repository and PR number.
+func ParsePullRequestURL(input string) (*RepoURL, int, error) {
+	input = strings.TrimSpace(input)
+	matches := pullRequestURLRegex.FindStringSubmatch(input)
+	if len(matches) != 3 {
+		return nil, 0, errors.Newf("invalid pull request URL %q: expected https://github.com/owner/repo/pull/<number>", input)
+	}
+
+	repoURL, err := ParseGitHubURL(matches[1])
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := repoURL.Validate(); err != nil {
+		return nil, 0, err
+	}
+	repoURL.Original = input
+
+	number, err := strconv.Atoi(matches[2])
+	if err != nil || number <= 0 {
+		return nil, 0, errors.Newf("invalid pull request number in %q", input)
+	}
+
+	return repoURL, number, nil
+}
+
+// Validate checks that Owner and Repo are safe to use as directory names, so
+// that a crafted URL such as git@github.com:../.. cannot place a clone
+// outside the base path
+func (u *RepoURL) Validate() error {
+	if err := validatePathComponent("owner", u.Owner); err != nil {
+		return err
+	}
+	return validatePathComponent("repository name", u.Repo)
+}
+
+// validatePathComponent rejects values that could escape the directory they
+// are joined onto: empty names, "." and "..", and anything containing a path
+// separator or NUL byte
+func validatePathComponent(kind, value string) error {
+	if value == "" || value == "." || value == ".." || strings.ContainsAny(value, "/\\\x00") {
+		return errors.Newf("invalid %s %q: must be a single directory name", kind, value)
+	}
+	return nil
+}
+
 // CloneManager handles repository cloning operations
 type CloneManager struct {
 	BasePath string // Base path for clones (default: ~/src)
 	Verbose  bool
+
+	// BaseBranch, when set, is used as the default branch instead of
+	// detecting it; it must exist on origin
+	BaseBranch string
+
 	runner   CommandRunner
 	homedir  func() (string, error) // For testing; defaults to os.UserHomeDir
+	progress io.Writer              // Receives git clone/fetch progress when Verbose; defaults to os.Stderr
+
+	// NetworkTimeout bounds each clone started by CloneAll; zero means no limit
+	NetworkTimeout time.Duration
+
+	mu              sync.Mutex
+	defaultBranches map[string]string // detectDefaultBranch results by repo path
 }
 
 // NewCloneManager creates a new CloneManager with default settings
@@ -109,6 +178,7 @@ func NewCloneManager(basePath string, verbose bool) *CloneManager {
 		Verbose:  verbose,
 		runner:   &RealCommandRunner{Verbose: verbose},
 		homedir:  os.UserHomeDir,
+		progress: os.Stderr,
 	}
 }
 
@@ -119,6 +189,7 @@ func NewCloneManagerWithRunner(basePath string, verbose bool, runner CommandRunn
 		Verbose:  verbose,
 		runner:   runner,
 		homedir:  os.UserHomeDir,
+		progress: os.Stderr,
 	}
 }
 
@@ -127,18 +198,22 @@ func NewCloneManagerWithRunner(basePath string, verbose bool, runner CommandRunn
 // For HTTPS URLs: standard git clone
 // Returns the path to the cloned repository
 func (cm *CloneManager) Clone(url *RepoURL) (string, error) {
+	return cm.CloneContext(context.Background(), url)
+}
+
+// CloneContext is Clone with the network commands (clone and fetch) bound to
+// ctx, so a deadline or cancellation stops them
+func (cm *CloneManager) CloneContext(ctx context.Context, url *RepoURL) (string, error) {
 	if url == nil {
 		return "", errors.New("nil URL provided")
 	}
+	if err := url.Validate(); err != nil {
+		return "", err
+	}
 
-	// Determine base path
-	basePath := cm.BasePath
-	if basePath == "" {
-		home, err := cm.homedir()
-		if err != nil {
-			return "", errors.Wrap(err, "failed to get home directory")
-		}
-		basePath = filepath.Join(home, "src")
+	basePath, err := cm.ResolveBasePath()
+	if err != nil {
+		return "", err
 	}
 
 	// Create target directory structure: basePath/<owner>/<repo>
@@ -159,19 +234,100 @@ func (cm *CloneManager) Clone(url *RepoURL) (string, error) {
 	}
 
 	if url.Protocol == "ssh" {
-		return cm.cloneSSH(url, repoPath)
+		return cm.cloneSSH(ctx, url, repoPath)
 	}
-	return cm.cloneHTTPS(url, repoPath)
+	return cm.cloneHTTPS(ctx, url, repoPath)
+}
+
+// ResolveBasePath returns the directory clones are placed under: BasePath
+// with ~, ~user and environment variables expanded, or ~/src when BasePath is
+// empty
+func (cm *CloneManager) ResolveBasePath() (string, error) {
+	if cm.BasePath == "" {
+		home, err := cm.homedir()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get home directory")
+		}
+		return filepath.Join(home, "src"), nil
+	}
+
+	basePath, err := config.ExpandPath(cm.BasePath)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid clone base path %q", cm.BasePath)
+	}
+	return basePath, nil
+}
+
+// CloneResult is the outcome of cloning one repository with CloneAll
+type CloneResult struct {
+	URL  *RepoURL
+	Path string // Set when Err is nil
+	Err  error
+}
+
+// CloneAll clones urls concurrently, running at most jobs clones at a time
+// (all at once when jobs <= 0). A failed clone does not stop the others.
+// Results are returned in the order of urls; progress, when non-nil, is
+// called once per finished clone with the number done so far, one call at a
+// time.
+func (cm *CloneManager) CloneAll(ctx context.Context, urls []*RepoURL, jobs int, progress func(done int, result CloneResult)) []CloneResult {
+	results := make([]CloneResult, len(urls))
+	if jobs <= 0 || jobs > len(urls) {
+		jobs = len(urls)
+	}
+
+	var (
+		mu   sync.Mutex
+		done int
+		wg   sync.WaitGroup
+	)
+	indexes := make(chan int)
+	for range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result := CloneResult{URL: urls[i]}
+				result.Path, result.Err = cm.cloneWithTimeout(ctx, urls[i])
+				results[i] = result
+
+				mu.Lock()
+				done++
+				if progress != nil {
+					progress(done, result)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range urls {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// cloneWithTimeout runs CloneContext bounded by NetworkTimeout
+func (cm *CloneManager) cloneWithTimeout(ctx context.Context, url *RepoURL) (string, error) {
+	if cm.NetworkTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cm.NetworkTimeout)
+		defer cancel()
+	}
+	return cm.CloneContext(ctx, url)
 }
 
 // cloneSSH performs a bare clone + worktree setup for SSH URLs
-func (cm *CloneManager) cloneSSH(url *RepoURL, repoPath string) (string, error) {
+func (cm *CloneManager) cloneSSH(ctx context.Context, url *RepoURL, repoPath string) (string, error) {
 	if cm.Verbose {
 		fmt.Printf("Cloning (bare) %s to %s...\n", url.Canonical, repoPath)
 	}
 
 	// Clone as bare repository
-	if err := cm.runner.Run("", "git", "clone", "--bare", url.Canonical, repoPath); err != nil {
+	if err := cm.runNetwork(ctx, "", "clone", "--bare", url.Canonical, repoPath); err != nil {
 		return "", errors.Wrapf(err, "git clone --bare failed for %s", url.Canonical)
 	}
 
@@ -187,7 +343,7 @@ func (cm *CloneManager) cloneSSH(url *RepoURL, repoPath string) (string, error)
 	if cm.Verbose {
 		fmt.Println("Fetching remote branches...")
 	}
-	if err := cm.runner.Run(repoPath, "git", "fetch", "origin"); err != nil {
+	if err := cm.runNetwork(ctx, repoPath, "fetch", "origin"); err != nil {
 		if cm.Verbose {
 			fmt.Printf("Warning: git fetch failed: %v\n", err)
 		}
@@ -217,18 +373,31 @@ func (cm *CloneManager) cloneSSH(url *RepoURL, repoPath string) (string, error)
 }
 
 // cloneHTTPS performs a standard git clone for HTTPS URLs
-func (cm *CloneManager) cloneHTTPS(url *RepoURL, repoPath string) (string, error) {
+func (cm *CloneManager) cloneHTTPS(ctx context.Context, url *RepoURL, repoPath string) (string, error) {
 	if cm.Verbose {
 		fmt.Printf("Cloning %s to %s...\n", url.Canonical, repoPath)
 	}
 
-	if err := cm.runner.Run("", "git", "clone", url.Canonical, repoPath); err != nil {
+	if err := cm.runNetwork(ctx, "", "clone", url.Canonical, repoPath); err != nil {
 		return "", errors.Wrapf(err, "git clone failed for %s", url.Canonical)
 	}
 
 	return repoPath, nil
 }
 
+// runNetwork runs a git clone or fetch bound to ctx. In verbose mode git's
+// progress is streamed to cm.progress; git only reports progress to a
+// terminal unless asked, so --progress is passed. Otherwise the command runs
+// quietly.
+func (cm *CloneManager) runNetwork(ctx context.Context, dir string, subcommand string, args ...string) error {
+	if !cm.Verbose {
+		return runContext(ctx, cm.runner, dir, "git", append([]string{subcommand}, args...)...)
+	}
+
+	gitArgs := append([]string{subcommand, "--progress"}, args...)
+	return streamContext(ctx, cm.runner, cm.progress, cm.progress, dir, "git", gitArgs...)
+}
+
 // ensureFetchRefspec ensures the fetch refspec is configured for the origin remote.
 // Bare repos created with `git clone --bare` don't have this configured by default.
 func (cm *CloneManager) ensureFetchRefspec(repoPath string) error {
@@ -252,8 +421,43 @@ func (cm *CloneManager) ensureFetchRefspec(repoPath string) error {
 }
 
 // detectDefaultBranch determines the default branch of the cloned repository.
-// Priority: symbolic-ref HEAD > main > master > first remote branch
+// Priority: BaseBranch > symbolic-ref HEAD > main > master > first remote branch.
+// Successful results are cached per repo path for the life of the manager.
 func (cm *CloneManager) detectDefaultBranch(repoPath string) (string, error) {
+	cm.mu.Lock()
+	branch, ok := cm.defaultBranches[repoPath]
+	cm.mu.Unlock()
+	if ok {
+		return branch, nil
+	}
+
+	// The lock is not held across the git calls so that concurrent clones of
+	// different repos (CloneAll) don't wait on each other
+	branch, err := cm.lookupDefaultBranch(repoPath)
+	if err != nil {
+		return "", err
+	}
+
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if cm.defaultBranches == nil {
+		cm.defaultBranches = make(map[string]string)
+	}
+	cm.defaultBranches[repoPath] = branch
+	return branch, nil
+}
+
+// lookupDefaultBranch runs the git commands behind detectDefaultBranch
+func (cm *CloneManager) lookupDefaultBranch(repoPath string) (string, error) {
+	// A configured branch skips detection, but a typo shouldn't silently
+	// produce a worktree on some other branch
+	if cm.BaseBranch != "" {
+		if !cm.remoteBranchExists(repoPath, cm.BaseBranch) {
+			return "", errors.Newf("configured base branch %q does not exist on origin (check git.base_branch)", cm.BaseBranch)
+		}
+		return cm.BaseBranch, nil
+	}
+
 	// Try to get default branch from remote HEAD (symbolic-ref)
 	output, err := cm.runner.Output(repoPath, "git", "symbolic-ref", "refs/remotes/origin/HEAD")
 	if err == nil {
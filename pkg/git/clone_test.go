@@ -1,10 +1,19 @@
 package git
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestParseGitHubURL_SSH(t *testing.T) {
@@ -284,6 +293,78 @@ func TestParseGitHubURL_Invalid(t *testing.T) {
 	}
 }
 
+func TestParsePullRequestURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantOwner  string
+		wantRepo   string
+		wantNumber int
+		wantErr    bool
+	}{
+		{
+			name:       "HTTPS URL",
+			input:      "https://github.com/thoreinstein/rig/pull/42",
+			wantOwner:  "thoreinstein",
+			wantRepo:   "rig",
+			wantNumber: 42,
+		},
+		{
+			name:       "without scheme",
+			input:      "github.com/thoreinstein/rig/pull/7",
+			wantOwner:  "thoreinstein",
+			wantRepo:   "rig",
+			wantNumber: 7,
+		},
+		{
+			name:       "with trailing path",
+			input:      "https://github.com/thoreinstein/rig/pull/42/files",
+			wantOwner:  "thoreinstein",
+			wantRepo:   "rig",
+			wantNumber: 42,
+		},
+		{
+			name:    "repository URL",
+			input:   "https://github.com/thoreinstein/rig",
+			wantErr: true,
+		},
+		{
+			name:    "issue URL",
+			input:   "https://github.com/thoreinstein/rig/issues/42",
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric number",
+			input:   "https://github.com/thoreinstein/rig/pull/abc",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoURL, number, err := ParsePullRequestURL(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParsePullRequestURL(%q) expected error, got %+v #%d", tt.input, repoURL, number)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePullRequestURL(%q) unexpected error: %v", tt.input, err)
+			}
+			if repoURL.Owner != tt.wantOwner || repoURL.Repo != tt.wantRepo {
+				t.Errorf("repo = %s/%s, want %s/%s", repoURL.Owner, repoURL.Repo, tt.wantOwner, tt.wantRepo)
+			}
+			if number != tt.wantNumber {
+				t.Errorf("number = %d, want %d", number, tt.wantNumber)
+			}
+			if repoURL.Original != tt.input {
+				t.Errorf("Original = %q, want %q", repoURL.Original, tt.input)
+			}
+		})
+	}
+}
+
 func TestCloneManager_Clone_SSH(t *testing.T) {
 	t.Parallel()
 
@@ -461,6 +542,151 @@ func TestCloneManager_Clone_CustomBasePath(t *testing.T) {
 	}
 }
 
+func TestCloneManager_ResolveBasePath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("RIG_TEST_SRC", "/work/src")
+
+	tests := []struct {
+		name     string
+		basePath string
+		want     string
+		wantErr  bool
+	}{
+		{name: "default", basePath: "", want: filepath.Join(home, "src")},
+		{name: "absolute", basePath: "/opt/code", want: "/opt/code"},
+		{name: "tilde", basePath: "~/code", want: filepath.Join(home, "code")},
+		{name: "HOME variable", basePath: "$HOME/src", want: filepath.Join(home, "src")},
+		{name: "other variable", basePath: "${RIG_TEST_SRC}/github", want: "/work/src/github"},
+		{name: "unset variable", basePath: "$RIG_TEST_UNSET/src", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := NewCloneManagerWithRunner(tt.basePath, false, &MockCommandRunner{})
+			cm.homedir = func() (string, error) { return home, nil }
+
+			got, err := cm.ResolveBasePath()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ResolveBasePath() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveBasePath() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveBasePath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCloneManager_Clone_ExpandsBasePath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	mock := &MockCommandRunner{
+		RunFunc: func(dir string, name string, args ...string) error {
+			if len(args) > 0 && args[0] == "clone" {
+				return os.MkdirAll(args[len(args)-1], 0755)
+			}
+			return nil
+		},
+	}
+
+	cm := NewCloneManagerWithRunner("~/code", false, mock)
+	path, err := cm.Clone(&RepoURL{
+		Canonical: "https://github.com/owner/repo.git",
+		Protocol:  "https",
+		Owner:     "owner",
+		Repo:      "repo",
+	})
+	if err != nil {
+		t.Fatalf("Clone() error = %v", err)
+	}
+	if want := filepath.Join(home, "code", "owner", "repo"); path != want {
+		t.Errorf("Clone() path = %q, want %q", path, want)
+	}
+}
+
+func TestCloneManager_Clone_PathTraversal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		owner string
+		repo  string
+	}{
+		{"dotdot owner and repo", "..", ".."},
+		{"dotdot repo", "owner", ".."},
+		{"dot owner", ".", "repo"},
+		{"traversal in owner", "../../etc", "repo"},
+		{"traversal in repo", "owner", "../../../tmp/evil"},
+		{"absolute repo", "owner", "/tmp/evil"},
+		{"backslash owner", `..\..`, "repo"},
+		{"empty owner", "", "repo"},
+		{"empty repo", "owner", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			base := filepath.Join(root, "src")
+			mock := &MockCommandRunner{}
+			cm := NewCloneManagerWithRunner(base, false, mock)
+
+			_, err := cm.Clone(&RepoURL{
+				Canonical: "git@github.com:" + tt.owner + "/" + tt.repo + ".git",
+				Protocol:  "ssh",
+				Owner:     tt.owner,
+				Repo:      tt.repo,
+			})
+			if err == nil || !strings.Contains(err.Error(), "must be a single directory name") {
+				t.Fatalf("Clone() error = %v, want path component error", err)
+			}
+			if len(mock.Calls) != 0 {
+				t.Errorf("no git commands should run, got %+v", mock.Calls)
+			}
+
+			entries, err := os.ReadDir(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 0 {
+				t.Errorf("no directories should be created, found %v", entries)
+			}
+		})
+	}
+}
+
+func TestRepoURL_Validate_ParsedDotSegments(t *testing.T) {
+	t.Parallel()
+
+	// The URL patterns allow dots, so ".." parses as a repository name
+	url, err := ParseGitHubURL("https://github.com/owner/..")
+	if err != nil {
+		t.Fatalf("ParseGitHubURL() error = %v", err)
+	}
+	if url.Repo != ".." {
+		t.Fatalf("Repo = %q, want %q", url.Repo, "..")
+	}
+	if err := url.Validate(); err == nil {
+		t.Error("Validate() should reject a \"..\" repository name")
+	}
+
+	valid, err := ParseGitHubURL("owner/my.repo")
+	if err != nil {
+		t.Fatalf("ParseGitHubURL() error = %v", err)
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v for a normal repository", err)
+	}
+}
+
 func TestCloneManager_Clone_NilURL(t *testing.T) {
 	t.Parallel()
 
@@ -509,6 +735,285 @@ func TestCloneManager_Clone_CloneError(t *testing.T) {
 	}
 }
 
+func TestCloneManager_CloneAll(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	mock := &MockCommandRunner{
+		RunFunc: func(dir string, name string, args ...string) error {
+			if name != "git" || len(args) < 3 || args[0] != "clone" {
+				return nil
+			}
+
+			mu.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				running--
+				mu.Unlock()
+			}()
+			time.Sleep(10 * time.Millisecond)
+
+			if strings.Contains(args[1], "broken") {
+				return errors.New("repository not found")
+			}
+			return os.MkdirAll(args[2], 0755)
+		},
+	}
+
+	cm := NewCloneManagerWithRunner(tmpDir, false, mock)
+
+	var urls []*RepoURL
+	for _, repo := range []string{"one", "broken", "two", "three", "four"} {
+		urls = append(urls, &RepoURL{
+			Canonical: "https://github.com/owner/" + repo + ".git",
+			Protocol:  "https",
+			Owner:     "owner",
+			Repo:      repo,
+		})
+	}
+
+	var progressCalls []int
+	results := cm.CloneAll(context.Background(), urls, 2, func(done int, result CloneResult) {
+		progressCalls = append(progressCalls, done)
+	})
+
+	if len(results) != len(urls) {
+		t.Fatalf("CloneAll() returned %d results, want %d", len(results), len(urls))
+	}
+	for i, result := range results {
+		if result.URL != urls[i] {
+			t.Errorf("result %d URL = %v, want %v (results must keep input order)", i, result.URL, urls[i])
+		}
+		if urls[i].Repo == "broken" {
+			if result.Err == nil {
+				t.Errorf("result %d: expected error for broken repo", i)
+			}
+			continue
+		}
+		if result.Err != nil {
+			t.Errorf("result %d (%s) error = %v", i, urls[i].Repo, result.Err)
+		}
+		if want := tmpDir + "/owner/" + urls[i].Repo; result.Path != want {
+			t.Errorf("result %d path = %q, want %q", i, result.Path, want)
+		}
+	}
+
+	if maxRunning > 2 {
+		t.Errorf("at most 2 clones should run at once, saw %d", maxRunning)
+	}
+	if want := []int{1, 2, 3, 4, 5}; !slices.Equal(progressCalls, want) {
+		t.Errorf("progress calls = %v, want %v", progressCalls, want)
+	}
+}
+
+func TestCloneManager_CloneAll_Empty(t *testing.T) {
+	t.Parallel()
+
+	cm := NewCloneManagerWithRunner(t.TempDir(), false, &MockCommandRunner{})
+	if results := cm.CloneAll(context.Background(), nil, 4, nil); len(results) != 0 {
+		t.Errorf("CloneAll(nil) = %v, want no results", results)
+	}
+}
+
+func TestCloneManager_Clone_VerboseStreamsProgress(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	var streamed []string
+	mock := &MockCommandRunner{
+		StreamFunc: func(stdout, stderr io.Writer, dir string, name string, args ...string) error {
+			streamed = append(streamed, strings.Join(args, " "))
+			fmt.Fprintln(stderr, "Receiving objects: 100% (10/10), done.")
+			return os.MkdirAll(args[len(args)-1], 0755)
+		},
+	}
+
+	cm := NewCloneManagerWithRunner(tmpDir, true, mock)
+	var progress bytes.Buffer
+	cm.progress = &progress
+
+	url := &RepoURL{
+		Canonical: "https://github.com/owner/repo.git",
+		Protocol:  "https",
+		Owner:     "owner",
+		Repo:      "repo",
+	}
+	if _, err := cm.Clone(url); err != nil {
+		t.Fatalf("Clone() error = %v", err)
+	}
+
+	want := "clone --progress https://github.com/owner/repo.git " + tmpDir + "/owner/repo"
+	if len(streamed) != 1 || streamed[0] != want {
+		t.Errorf("streamed commands = %q, want [%q]", streamed, want)
+	}
+	if !strings.Contains(progress.String(), "Receiving objects") {
+		t.Errorf("progress output = %q, want git progress", progress.String())
+	}
+}
+
+func TestCloneManager_Clone_QuietDoesNotStream(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	mock := &MockCommandRunner{
+		RunFunc: func(dir string, name string, args ...string) error {
+			if len(args) > 0 && args[0] == "clone" {
+				return os.MkdirAll(args[len(args)-1], 0755)
+			}
+			return nil
+		},
+	}
+
+	cm := NewCloneManagerWithRunner(tmpDir, false, mock)
+	url := &RepoURL{
+		Canonical: "https://github.com/owner/repo.git",
+		Protocol:  "https",
+		Owner:     "owner",
+		Repo:      "repo",
+	}
+	if _, err := cm.Clone(url); err != nil {
+		t.Fatalf("Clone() error = %v", err)
+	}
+
+	for _, call := range mock.Calls {
+		if call.Method == "Stream" || slices.Contains(call.Args, "--progress") {
+			t.Errorf("non-verbose clone should not stream progress, got %+v", call)
+		}
+	}
+}
+
+func TestRealCommandRunner_Stream(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not found in PATH")
+	}
+
+	var stdout, stderr bytes.Buffer
+	runner := &RealCommandRunner{}
+	err := runner.Stream(context.Background(), &stdout, &stderr, "", "sh", "-c", "echo out; echo progress >&2")
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	if stdout.String() != "out\n" {
+		t.Errorf("stdout = %q, want %q", stdout.String(), "out\n")
+	}
+	if stderr.String() != "progress\n" {
+		t.Errorf("stderr = %q, want %q", stderr.String(), "progress\n")
+	}
+}
+
+func TestCloneManager_Clone_ErrorIncludesStderr(t *testing.T) {
+	t.Parallel()
+
+	mock := &MockCommandRunner{
+		RunFunc: func(dir string, name string, args ...string) error {
+			if len(args) > 0 && args[0] == "clone" {
+				return &CommandError{
+					Err:    errors.New("exit status 128"),
+					Stderr: "Cloning into bare repository...\ngit@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.\n",
+				}
+			}
+			return nil
+		},
+	}
+
+	cm := NewCloneManagerWithRunner(t.TempDir(), false, mock)
+	_, err := cm.Clone(&RepoURL{
+		Canonical: "git@github.com:owner/repo.git",
+		Protocol:  "ssh",
+		Owner:     "owner",
+		Repo:      "repo",
+	})
+	if err == nil {
+		t.Fatal("Clone() should return error on clone failure")
+	}
+	if !strings.HasPrefix(err.Error(), "git clone --bare failed for git@github.com:owner/repo.git") {
+		t.Errorf("Error = %q, want the existing git clone prefix", err.Error())
+	}
+	if !strings.Contains(err.Error(), "Permission denied (publickey)") {
+		t.Errorf("Error = %q, want git's stderr", err.Error())
+	}
+}
+
+func TestCloneManager_ensureFetchRefspec_ErrorIncludesStderr(t *testing.T) {
+	t.Parallel()
+
+	mock := &MockCommandRunner{
+		OutputFunc: func(dir string, name string, args ...string) ([]byte, error) {
+			return nil, errors.New("exit status 1")
+		},
+		RunFunc: func(dir string, name string, args ...string) error {
+			return &CommandError{Err: errors.New("exit status 255"), Stderr: "error: could not lock config file config: Permission denied\n"}
+		},
+	}
+
+	cm := NewCloneManagerWithRunner("", false, mock)
+	err := cm.ensureFetchRefspec("/repo")
+	if err == nil {
+		t.Fatal("ensureFetchRefspec() expected error")
+	}
+	if !strings.HasPrefix(err.Error(), "failed to configure fetch refspec") {
+		t.Errorf("Error = %q, want 'failed to configure fetch refspec' prefix", err.Error())
+	}
+	if !strings.Contains(err.Error(), "could not lock config file") {
+		t.Errorf("Error = %q, want git's stderr", err.Error())
+	}
+}
+
+func TestCloneManager_CloneContext_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	mock := &MockCommandRunner{}
+	cm := NewCloneManagerWithRunner(tmpDir, false, mock)
+
+	url := &RepoURL{
+		Original:  "https://github.com/owner/repo",
+		Canonical: "https://github.com/owner/repo.git",
+		Protocol:  "https",
+		Owner:     "owner",
+		Repo:      "repo",
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := cm.CloneContext(ctx, url)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("CloneContext() error = %v, want context.Canceled", err)
+	}
+	if len(mock.Calls) != 0 {
+		t.Errorf("git should not run after cancellation, got %d calls", len(mock.Calls))
+	}
+}
+
+func TestRealCommandRunner_RunContext_Deadline(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("sleep"); err != nil {
+		t.Skip("sleep not found in PATH")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := runContext(ctx, &RealCommandRunner{}, "", "sleep", "5")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("runContext() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("runContext() took %s, command should be killed at the deadline", elapsed)
+	}
+}
+
 func TestCloneManager_ensureFetchRefspec_AlreadyConfigured(t *testing.T) {
 	t.Parallel()
 
@@ -593,6 +1098,63 @@ func TestCloneManager_detectDefaultBranch_SymbolicRef(t *testing.T) {
 	}
 }
 
+func TestCloneManager_detectDefaultBranch_ConfiguredBranch(t *testing.T) {
+	t.Parallel()
+
+	mock := &MockCommandRunner{
+		OutputFunc: func(dir string, name string, args ...string) ([]byte, error) {
+			t.Errorf("detection should be skipped, got git %v", args)
+			return []byte{}, nil
+		},
+		RunFunc: func(dir string, name string, args ...string) error {
+			if len(args) > 3 && args[0] == "show-ref" && args[3] == "refs/remotes/origin/trunk" {
+				return nil
+			}
+			return errors.New("not found")
+		},
+	}
+
+	cm := NewCloneManagerWithRunner("", false, mock)
+	cm.BaseBranch = "trunk"
+	branch, err := cm.detectDefaultBranch("/repo")
+	if err != nil {
+		t.Fatalf("detectDefaultBranch() error = %v", err)
+	}
+	if branch != "trunk" {
+		t.Errorf("detectDefaultBranch() = %q, want %q", branch, "trunk")
+	}
+}
+
+func TestCloneManager_detectDefaultBranch_ConfiguredBranchMissing(t *testing.T) {
+	t.Parallel()
+
+	mock := &MockCommandRunner{
+		OutputFunc: func(dir string, name string, args ...string) ([]byte, error) {
+			// origin/HEAD points at main, which must not be used instead
+			if len(args) > 0 && args[0] == "symbolic-ref" {
+				return []byte("refs/remotes/origin/main\n"), nil
+			}
+			return []byte{}, nil
+		},
+		RunFunc: func(dir string, name string, args ...string) error {
+			if len(args) > 3 && args[0] == "show-ref" && args[3] == "refs/remotes/origin/main" {
+				return nil
+			}
+			return errors.New("not found")
+		},
+	}
+
+	cm := NewCloneManagerWithRunner("", false, mock)
+	cm.BaseBranch = "trunk"
+	_, err := cm.detectDefaultBranch("/repo")
+	if err == nil {
+		t.Fatal("detectDefaultBranch() should fail when the configured branch is missing")
+	}
+	if !strings.Contains(err.Error(), `"trunk"`) || !strings.Contains(err.Error(), "git.base_branch") {
+		t.Errorf("error should name the branch and config key, got: %v", err)
+	}
+}
+
 func TestCloneManager_detectDefaultBranch_FallbackMain(t *testing.T) {
 	t.Parallel()
 
@@ -653,6 +1215,74 @@ func TestCloneManager_detectDefaultBranch_FallbackMaster(t *testing.T) {
 	}
 }
 
+func TestCloneManager_detectDefaultBranch_Cached(t *testing.T) {
+	t.Parallel()
+
+	mock := &MockCommandRunner{
+		OutputFunc: func(dir string, name string, args ...string) ([]byte, error) {
+			return []byte("refs/remotes/origin/develop\n"), nil
+		},
+	}
+
+	cm := NewCloneManagerWithRunner("", false, mock)
+	for i := range 2 {
+		branch, err := cm.detectDefaultBranch("/repo")
+		if err != nil {
+			t.Fatalf("detectDefaultBranch() call %d error = %v", i+1, err)
+		}
+		if branch != "develop" {
+			t.Errorf("detectDefaultBranch() call %d = %q, want %q", i+1, branch, "develop")
+		}
+	}
+
+	// symbolic-ref plus the show-ref check, from the first call only
+	if len(mock.Calls) != 2 {
+		t.Errorf("Expected 2 git calls for two lookups, got %d: %+v", len(mock.Calls), mock.Calls)
+	}
+
+	// A different repo is detected separately
+	if _, err := cm.detectDefaultBranch("/other"); err != nil {
+		t.Fatalf("detectDefaultBranch(/other) error = %v", err)
+	}
+	if len(mock.Calls) != 4 {
+		t.Errorf("Expected 4 git calls after a second repo, got %d", len(mock.Calls))
+	}
+}
+
+func TestCloneManager_detectDefaultBranch_ErrorNotCached(t *testing.T) {
+	t.Parallel()
+
+	failing := true
+	mock := &MockCommandRunner{
+		OutputFunc: func(dir string, name string, args ...string) ([]byte, error) {
+			if failing {
+				return nil, errors.New("not found")
+			}
+			return []byte("refs/remotes/origin/main\n"), nil
+		},
+		RunFunc: func(dir string, name string, args ...string) error {
+			if failing {
+				return errors.New("not found")
+			}
+			return nil
+		},
+	}
+
+	cm := NewCloneManagerWithRunner("", false, mock)
+	if _, err := cm.detectDefaultBranch("/repo"); err == nil {
+		t.Fatal("detectDefaultBranch() expected error with no remote branches")
+	}
+
+	failing = false
+	branch, err := cm.detectDefaultBranch("/repo")
+	if err != nil {
+		t.Fatalf("detectDefaultBranch() retry error = %v", err)
+	}
+	if branch != "main" {
+		t.Errorf("detectDefaultBranch() = %q, want %q", branch, "main")
+	}
+}
+
 func TestCloneManager_detectDefaultBranch_FirstRemote(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,114 @@
+package git
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// CommitManager reads staged changes and creates commits in a working tree
+type CommitManager struct {
+	Dir     string // Working tree directory ("." for the current directory)
+	Verbose bool
+	runner  CommandRunner
+}
+
+// NewCommitManager creates a CommitManager for the given working tree
+func NewCommitManager(dir string, verbose bool) *CommitManager {
+	return NewCommitManagerWithRunner(dir, verbose, &RealCommandRunner{Verbose: verbose})
+}
+
+// NewCommitManagerWithRunner creates a CommitManager with a custom CommandRunner (for testing)
+func NewCommitManagerWithRunner(dir string, verbose bool, runner CommandRunner) *CommitManager {
+	if dir == "" {
+		dir = "."
+	}
+	return &CommitManager{
+		Dir:     dir,
+		Verbose: verbose,
+		runner:  runner,
+	}
+}
+
+// StagedDiff returns the output of `git diff --cached`
+func (cm *CommitManager) StagedDiff() (string, error) {
+	output, err := cm.runner.Output(cm.Dir, "git", "diff", "--cached", "--no-color")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read staged diff")
+	}
+	return string(output), nil
+}
+
+// StagedFiles returns the paths of files with staged changes
+func (cm *CommitManager) StagedFiles() ([]string, error) {
+	output, err := cm.runner.Output(cm.Dir, "git", "diff", "--cached", "--name-only")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list staged files")
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// Commit runs `git commit -m` with the given message
+func (cm *CommitManager) Commit(message string) error {
+	if strings.TrimSpace(message) == "" {
+		return errors.New("commit message cannot be empty")
+	}
+
+	if err := cm.runner.Run(cm.Dir, "git", "commit", "-m", message); err != nil {
+		return errors.Wrap(err, "git commit failed")
+	}
+	return nil
+}
+
+// CommitLog returns the subjects and bodies of commits reachable from HEAD but
+// not from base, oldest first
+func (cm *CommitManager) CommitLog(base string) (string, error) {
+	if base == "" {
+		return "", errors.New("base branch is required")
+	}
+
+	output, err := cm.runner.Output(cm.Dir, "git", "log", "--reverse", "--no-color", "--format=commit %h%n%s%n%n%b", base+"..HEAD")
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read commit log since %s", base)
+	}
+	return string(output), nil
+}
+
+// CurrentBranch returns the branch checked out in the working tree
+func (cm *CommitManager) CurrentBranch() (string, error) {
+	output, err := cm.runner.Output(cm.Dir, "git", "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read current branch")
+	}
+
+	branch := strings.TrimSpace(string(output))
+	if branch == "" || branch == "HEAD" {
+		return "", errors.New("not on a branch (detached HEAD)")
+	}
+	return branch, nil
+}
+
+// HasUpstream reports whether branch tracks a remote branch
+func (cm *CommitManager) HasUpstream(branch string) bool {
+	output, err := cm.runner.Output(cm.Dir, "git", "rev-parse", "--abbrev-ref", branch+"@{upstream}")
+	return err == nil && strings.TrimSpace(string(output)) != ""
+}
+
+// Push pushes branch to origin and sets it as the branch's upstream
+func (cm *CommitManager) Push(branch string) error {
+	if branch == "" {
+		return errors.New("branch name is required")
+	}
+
+	if err := cm.runner.Run(cm.Dir, "git", "push", "--set-upstream", "origin", branch); err != nil {
+		return errors.Wrapf(err, "git push of %s to origin failed", branch)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCommitManager_StagedDiff(t *testing.T) {
+	mock := &MockCommandRunner{
+		OutputFunc: func(dir string, name string, args ...string) ([]byte, error) {
+			return []byte("diff --git a/main.go b/main.go\n"), nil
+		},
+	}
+	cm := NewCommitManagerWithRunner("", false, mock)
+
+	diff, err := cm.StagedDiff()
+	if err != nil {
+		t.Fatalf("StagedDiff() error = %v", err)
+	}
+	if diff != "diff --git a/main.go b/main.go\n" {
+		t.Errorf("StagedDiff() = %q", diff)
+	}
+
+	if len(mock.Calls) != 1 {
+		t.Fatalf("expected 1 call, got %d", len(mock.Calls))
+	}
+	call := mock.Calls[0]
+	if call.Dir != "." || !reflect.DeepEqual(call.Args, []string{"diff", "--cached", "--no-color"}) {
+		t.Errorf("unexpected call: %+v", call)
+	}
+}
+
+func TestCommitManager_StagedDiffError(t *testing.T) {
+	mock := &MockCommandRunner{
+		OutputFunc: func(dir string, name string, args ...string) ([]byte, error) {
+			return nil, errors.New("fatal: not a git repository")
+		},
+	}
+	cm := NewCommitManagerWithRunner("/repo", false, mock)
+
+	if _, err := cm.StagedDiff(); err == nil {
+		t.Error("StagedDiff() expected error, got nil")
+	}
+}
+
+func TestCommitManager_StagedFiles(t *testing.T) {
+	mock := &MockCommandRunner{
+		OutputFunc: func(dir string, name string, args ...string) ([]byte, error) {
+			return []byte("cmd/commit.go\npkg/git/commit.go\n\n"), nil
+		},
+	}
+	cm := NewCommitManagerWithRunner("/repo", false, mock)
+
+	files, err := cm.StagedFiles()
+	if err != nil {
+		t.Fatalf("StagedFiles() error = %v", err)
+	}
+	want := []string{"cmd/commit.go", "pkg/git/commit.go"}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("StagedFiles() = %v, want %v", files, want)
+	}
+}
+
+func TestCommitManager_Commit(t *testing.T) {
+	mock := &MockCommandRunner{}
+	cm := NewCommitManagerWithRunner("/repo", false, mock)
+
+	if err := cm.Commit("feat: add thing"); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	want := []string{"commit", "-m", "feat: add thing"}
+	if len(mock.Calls) != 1 || mock.Calls[0].Method != "Run" || !reflect.DeepEqual(mock.Calls[0].Args, want) {
+		t.Errorf("unexpected calls: %+v", mock.Calls)
+	}
+
+	if err := cm.Commit("  "); err == nil {
+		t.Error("Commit() with empty message should fail")
+	}
+}
+
+func TestCommitManager_CommitLog(t *testing.T) {
+	mock := &MockCommandRunner{
+		OutputFunc: func(dir string, name string, args ...string) ([]byte, error) {
+			return []byte("commit abc123\nfeat: add thing\n\n"), nil
+		},
+	}
+	cm := NewCommitManagerWithRunner("/repo", false, mock)
+
+	log, err := cm.CommitLog("main")
+	if err != nil {
+		t.Fatalf("CommitLog() error = %v", err)
+	}
+	if log != "commit abc123\nfeat: add thing\n\n" {
+		t.Errorf("CommitLog() = %q", log)
+	}
+
+	args := mock.Calls[0].Args
+	if args[0] != "log" || args[len(args)-1] != "main..HEAD" {
+		t.Errorf("unexpected git args: %v", args)
+	}
+
+	if _, err := cm.CommitLog(""); err == nil {
+		t.Error("CommitLog() with empty base should fail")
+	}
+}
+
+func TestCommitManager_CurrentBranch(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{name: "branch", output: "feature/login\n", want: "feature/login"},
+		{name: "detached HEAD", output: "HEAD\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &MockCommandRunner{
+				OutputFunc: func(dir string, name string, args ...string) ([]byte, error) {
+					return []byte(tt.output), nil
+				},
+			}
+			cm := NewCommitManagerWithRunner("", false, mock)
+
+			got, err := cm.CurrentBranch()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CurrentBranch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CurrentBranch() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitManager_HasUpstream(t *testing.T) {
+	mock := &MockCommandRunner{
+		OutputFunc: func(dir string, name string, args ...string) ([]byte, error) {
+			if args[len(args)-1] == "pushed@{upstream}" {
+				return []byte("origin/pushed\n"), nil
+			}
+			return nil, errors.New("fatal: no upstream configured")
+		},
+	}
+	cm := NewCommitManagerWithRunner("", false, mock)
+
+	if !cm.HasUpstream("pushed") {
+		t.Error("HasUpstream(pushed) = false, want true")
+	}
+	if cm.HasUpstream("local") {
+		t.Error("HasUpstream(local) = true, want false")
+	}
+}
+
+func TestCommitManager_Push(t *testing.T) {
+	mock := &MockCommandRunner{}
+	cm := NewCommitManagerWithRunner("/repo", false, mock)
+
+	if err := cm.Push("feature/login"); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	want := []string{"push", "--set-upstream", "origin", "feature/login"}
+	if len(mock.Calls) != 1 || !reflect.DeepEqual(mock.Calls[0].Args, want) {
+		t.Errorf("unexpected calls: %+v", mock.Calls)
+	}
+
+	mock.RunFunc = func(dir string, name string, args ...string) error {
+		return errors.New("rejected")
+	}
+	if err := cm.Push("feature/login"); err == nil {
+		t.Error("Push() expected error, got nil")
+	}
+	if err := cm.Push(""); err == nil {
+		t.Error("Push(\"\") expected error, got nil")
+	}
+}
@@ -1,11 +1,16 @@
 package git
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/cockroachdb/errors"
 )
@@ -17,35 +22,178 @@ type CommandRunner interface {
 	Output(dir string, name string, args ...string) ([]byte, error)
 }
 
+// ContextRunner is implemented by CommandRunners that can stop a command when
+// its context is done. Network operations use it when available.
+type ContextRunner interface {
+	RunContext(ctx context.Context, dir string, name string, args ...string) error
+}
+
+// runContext runs a command through runner, bounded by ctx when the runner
+// implements ContextRunner. Other runners run the command unbounded once ctx
+// is checked.
+func runContext(ctx context.Context, runner CommandRunner, dir string, name string, args ...string) error {
+	if cr, ok := runner.(ContextRunner); ok {
+		err := cr.RunContext(ctx, dir, name, args...)
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return runner.Run(dir, name, args...)
+}
+
+// StreamingRunner is implemented by CommandRunners that can attach a
+// command's stdout and stderr to writers, so long-running commands such as
+// git clone show their progress as it happens instead of buffering it.
+type StreamingRunner interface {
+	Stream(ctx context.Context, stdout, stderr io.Writer, dir string, name string, args ...string) error
+}
+
+// streamContext runs a command through runner with its output streamed to
+// stdout and stderr when the runner implements StreamingRunner, and through
+// runContext otherwise
+func streamContext(ctx context.Context, runner CommandRunner, stdout, stderr io.Writer, dir string, name string, args ...string) error {
+	sr, ok := runner.(StreamingRunner)
+	if !ok {
+		return runContext(ctx, runner, dir, name, args...)
+	}
+	if err := sr.Stream(ctx, stdout, stderr, dir, name, args...); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
+
+// networkContext returns a context bounded by timeout, or an unbounded one
+// when timeout is zero or less
+func networkContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// maxStderrDetail caps how much of a failed command's stderr CommandError
+// includes in its message
+const maxStderrDetail = 512
+
+// CommandError is returned by RealCommandRunner when a command fails. Its
+// message appends the command's stderr, so that wrapped errors such as
+// "git clone failed" say why (e.g. an authentication failure).
+type CommandError struct {
+	Err    error  // Underlying error, usually an *exec.ExitError
+	Stderr string // Everything the command wrote to stderr
+}
+
+// Error returns the underlying error followed by the trimmed stderr
+func (e *CommandError) Error() string {
+	detail := stderrDetail(e.Stderr)
+	if detail == "" {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + ": " + detail
+}
+
+// Unwrap returns the underlying error
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// commandError attaches stderr to a failed command's error
+func commandError(err error, stderr []byte) error {
+	if err == nil {
+		return nil
+	}
+	return &CommandError{Err: err, Stderr: string(stderr)}
+}
+
+// stderrDetail flattens stderr onto one line, keeping only the final state
+// of progress lines redrawn with \r, and keeps at most maxStderrDetail bytes
+// from the end, where git puts its fatal message
+func stderrDetail(stderr string) string {
+	var lines []string
+	for _, line := range strings.Split(stderr, "\n") {
+		if i := strings.LastIndex(strings.TrimRight(line, "\r"), "\r"); i >= 0 {
+			line = line[i+1:]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	detail := strings.Join(lines, "; ")
+	if len(detail) <= maxStderrDetail {
+		return detail
+	}
+
+	cut := len(detail) - maxStderrDetail
+	for cut < len(detail) && !utf8.RuneStart(detail[cut]) {
+		cut++
+	}
+	return "..." + detail[cut:]
+}
+
 // RealCommandRunner executes actual shell commands
 type RealCommandRunner struct {
 	Verbose bool
 }
 
-// Run executes a command without capturing output
+// Run executes a command without capturing output. Stderr is still recorded
+// so a failure is reported as a *CommandError.
 func (r *RealCommandRunner) Run(dir string, name string, args ...string) error {
-	cmd := exec.Command(name, args...)
+	return r.run(exec.Command(name, args...), dir)
+}
+
+// RunContext executes a command without capturing output, killing it when
+// ctx is done
+func (r *RealCommandRunner) RunContext(ctx context.Context, dir string, name string, args ...string) error {
+	return r.run(exec.CommandContext(ctx, name, args...), dir)
+}
+
+func (r *RealCommandRunner) run(cmd *exec.Cmd, dir string) error {
+	var stderr bytes.Buffer
 	cmd.Dir = dir
+	cmd.Stderr = &stderr
 	if r.Verbose {
 		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+		cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
 	}
-	return cmd.Run()
+	return commandError(cmd.Run(), stderr.Bytes())
+}
+
+// Stream executes a command with its stdout and stderr attached to the given
+// writers, killing it when ctx is done
+func (r *RealCommandRunner) Stream(ctx context.Context, stdout, stderr io.Writer, dir string, name string, args ...string) error {
+	var captured bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.Stdout = stdout
+	cmd.Stderr = io.MultiWriter(stderr, &captured)
+	return commandError(cmd.Run(), captured.Bytes())
 }
 
 // Output executes a command and returns its output
 func (r *RealCommandRunner) Output(dir string, name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
 	cmd := exec.Command(name, args...)
 	cmd.Dir = dir
-	return cmd.Output()
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	return output, commandError(err, stderr.Bytes())
 }
 
 // WorktreeManager handles Git worktree operations
 // Repository information is derived from git itself, or an explicit path
 type WorktreeManager struct {
 	Verbose          bool
-	BaseBranchConfig string // Optional config override for base branch
-	RepoPath         string // Optional explicit repository path
+	BaseBranchConfig string        // Optional config override for base branch
+	RepoPath         string        // Optional explicit repository path
+	NetworkTimeout   time.Duration // Deadline for each fetch and pull (0 means none)
 	runner           CommandRunner
 	getwd            func() (string, error) // For testing; defaults to os.Getwd
 }
@@ -208,6 +356,34 @@ func (wm *WorktreeManager) CreateWorktreeWithBranch(ticketType, name, branchName
 		return worktreePath, nil
 	}
 
+	relativePath := filepath.Join(ticketType, name)
+
+	// Reuse a worktree that already has the branch checked out, wherever it lives
+	if existing, ok := wm.worktreeForBranch(repoRoot, branchName); ok {
+		if _, err := os.Stat(existing); err == nil {
+			if wm.Verbose {
+				fmt.Printf("Branch %s is already checked out at %s\n", branchName, existing)
+			}
+			return existing, nil
+		}
+
+		// The worktree directory was deleted by hand; drop the stale registration
+		if err := wm.runner.Run(repoRoot, "git", "worktree", "prune"); err != nil {
+			return "", errors.Wrap(err, "failed to prune stale worktrees")
+		}
+	}
+
+	// Attach a branch left behind by a removed worktree instead of recreating it
+	if wm.branchExists(repoRoot, branchName) {
+		if wm.Verbose {
+			fmt.Printf("Creating git worktree for %s using existing branch %s...\n", name, branchName)
+		}
+		if err := wm.runner.Run(repoRoot, "git", "worktree", "add", relativePath, branchName); err != nil {
+			return "", errors.Wrap(err, "failed to create worktree")
+		}
+		return worktreePath, nil
+	}
+
 	// Determine base branch to use
 	baseBranch, err := wm.GetDefaultBranch()
 	if err != nil {
@@ -227,7 +403,6 @@ func (wm *WorktreeManager) CreateWorktreeWithBranch(ticketType, name, branchName
 	}
 
 	// Create the worktree with custom branch name
-	relativePath := filepath.Join(ticketType, name)
 	err = wm.runner.Run(repoRoot, "git", "worktree", "add", relativePath, "-b", branchName, baseBranch)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to create worktree")
@@ -273,8 +448,11 @@ func (wm *WorktreeManager) fetchAndPull(repoRoot, baseBranch string) error {
 		fmt.Println("Fetching latest changes from origin...")
 	}
 
+	ctx, cancel := networkContext(wm.NetworkTimeout)
+	defer cancel()
+
 	// git fetch origin
-	if err := wm.runner.Run(repoRoot, "git", "fetch", "origin"); err != nil {
+	if err := runContext(ctx, wm.runner, repoRoot, "git", "fetch", "origin"); err != nil {
 		return errors.Wrap(err, "git fetch failed")
 	}
 
@@ -283,7 +461,7 @@ func (wm *WorktreeManager) fetchAndPull(repoRoot, baseBranch string) error {
 	}
 
 	// git pull origin <baseBranch>
-	if err := wm.runner.Run(repoRoot, "git", "pull", "origin", baseBranch); err != nil {
+	if err := runContext(ctx, wm.runner, repoRoot, "git", "pull", "origin", baseBranch); err != nil {
 		return errors.Wrap(err, "git pull failed")
 	}
 
@@ -361,6 +539,59 @@ func (wm *WorktreeManager) ListWorktrees() ([]string, error) {
 	return worktrees, nil
 }
 
+// worktreeForBranch returns the path of the worktree that has branch checked
+// out, as reported by git worktree list
+func (wm *WorktreeManager) worktreeForBranch(repoRoot, branch string) (string, bool) {
+	output, err := wm.runner.Output(repoRoot, "git", "worktree", "list", "--porcelain")
+	if err != nil {
+		return "", false
+	}
+
+	var current string
+	for _, line := range strings.Split(string(output), "\n") {
+		switch {
+		case strings.HasPrefix(line, "worktree "):
+			current = strings.TrimPrefix(line, "worktree ")
+		case line == "branch refs/heads/"+branch:
+			return current, true
+		}
+	}
+
+	return "", false
+}
+
+// FetchPullRequest creates the local branch branchName from the head of pull
+// request number on origin. GitHub publishes every PR, including those from
+// forks, as refs/pull/<number>/head. An existing local branch is left as is
+// so work in progress on it isn't overwritten.
+func (wm *WorktreeManager) FetchPullRequest(number int, branchName string) error {
+	repoRoot, err := wm.GetRepoRoot()
+	if err != nil {
+		return err
+	}
+
+	if wm.branchExists(repoRoot, branchName) {
+		if wm.Verbose {
+			fmt.Printf("Branch %s already exists, not fetching PR #%d\n", branchName, number)
+		}
+		return nil
+	}
+
+	if wm.Verbose {
+		fmt.Printf("Fetching PR #%d into branch %s...\n", number, branchName)
+	}
+
+	ctx, cancel := networkContext(wm.NetworkTimeout)
+	defer cancel()
+
+	refspec := fmt.Sprintf("pull/%d/head:%s", number, branchName)
+	if err := runContext(ctx, wm.runner, repoRoot, "git", "fetch", "origin", refspec); err != nil {
+		return errors.Wrapf(err, "failed to fetch PR #%d", number)
+	}
+
+	return nil
+}
+
 // RemoveWorktree removes a worktree
 func (wm *WorktreeManager) RemoveWorktree(ticketType, ticket string) error {
 	repoRoot, err := wm.GetRepoRoot()
@@ -1,8 +1,15 @@
 package git
 
 import (
+	"context"
 	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -12,8 +19,12 @@ type MockCommandRunner struct {
 	RunFunc func(dir string, name string, args ...string) error
 	// OutputFunc is called for Output() - returns output and error
 	OutputFunc func(dir string, name string, args ...string) ([]byte, error)
+	// StreamFunc is called for Stream(); when nil, Stream falls back to RunFunc
+	StreamFunc func(stdout, stderr io.Writer, dir string, name string, args ...string) error
 	// Calls records all calls made
 	Calls []MockCall
+
+	mu sync.Mutex // Guards Calls when the runner is shared by goroutines
 }
 
 // MockCall represents a single call to the mock
@@ -25,7 +36,22 @@ type MockCall struct {
 }
 
 func (m *MockCommandRunner) Run(dir string, name string, args ...string) error {
+	m.mu.Lock()
 	m.Calls = append(m.Calls, MockCall{Method: "Run", Dir: dir, Name: name, Args: args})
+	m.mu.Unlock()
+	if m.RunFunc != nil {
+		return m.RunFunc(dir, name, args...)
+	}
+	return nil
+}
+
+func (m *MockCommandRunner) Stream(ctx context.Context, stdout, stderr io.Writer, dir string, name string, args ...string) error {
+	m.mu.Lock()
+	m.Calls = append(m.Calls, MockCall{Method: "Stream", Dir: dir, Name: name, Args: args})
+	m.mu.Unlock()
+	if m.StreamFunc != nil {
+		return m.StreamFunc(stdout, stderr, dir, name, args...)
+	}
 	if m.RunFunc != nil {
 		return m.RunFunc(dir, name, args...)
 	}
@@ -33,7 +59,9 @@ func (m *MockCommandRunner) Run(dir string, name string, args ...string) error {
 }
 
 func (m *MockCommandRunner) Output(dir string, name string, args ...string) ([]byte, error) {
+	m.mu.Lock()
 	m.Calls = append(m.Calls, MockCall{Method: "Output", Dir: dir, Name: name, Args: args})
+	m.mu.Unlock()
 	if m.OutputFunc != nil {
 		return m.OutputFunc(dir, name, args...)
 	}
@@ -944,6 +972,98 @@ func TestRealCommandRunner_Interface(t *testing.T) {
 	var _ CommandRunner = &RealCommandRunner{}
 }
 
+func TestRealCommandRunner_ErrorIncludesStderr(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not found in PATH")
+	}
+
+	runner := &RealCommandRunner{}
+	script := "echo 'fatal: Authentication failed for https://example.com/' >&2; exit 128"
+
+	runErr := runner.Run("", "sh", "-c", script)
+	_, outputErr := runner.Output("", "sh", "-c", script)
+
+	for name, err := range map[string]error{"Run": runErr, "Output": outputErr} {
+		if err == nil {
+			t.Fatalf("%s() expected error", name)
+		}
+		if !strings.Contains(err.Error(), "exit status 128: fatal: Authentication failed") {
+			t.Errorf("%s() error = %q, want exit status followed by stderr", name, err.Error())
+		}
+
+		var cmdErr *CommandError
+		if !errors.As(err, &cmdErr) {
+			t.Errorf("%s() error should be a *CommandError", name)
+		}
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Errorf("%s() error should unwrap to *exec.ExitError", name)
+		}
+	}
+
+	if err := runner.Run("", "sh", "-c", "echo ok >&2"); err != nil {
+		t.Errorf("Run() of a successful command error = %v", err)
+	}
+}
+
+func TestStderrDetail(t *testing.T) {
+	long := strings.Repeat("x", maxStderrDetail+100)
+
+	tests := []struct {
+		name   string
+		stderr string
+		want   string
+	}{
+		{"empty", "", ""},
+		{"whitespace", "  \n\n", ""},
+		{"single line", "fatal: repository not found\n", "fatal: repository not found"},
+		{"multiple lines", "Cloning into 'repo'...\nfatal: Authentication failed\n", "Cloning into 'repo'...; fatal: Authentication failed"},
+		{"progress redraws", "Receiving objects:  50% (5/10)\rReceiving objects: 100% (10/10)\r\nfatal: early EOF\n", "Receiving objects: 100% (10/10); fatal: early EOF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stderrDetail(tt.stderr); got != tt.want {
+				t.Errorf("stderrDetail() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("truncated keeps tail", func(t *testing.T) {
+		got := stderrDetail(long + "\nfatal: the end")
+		if !strings.HasPrefix(got, "...") || !strings.HasSuffix(got, "; fatal: the end") {
+			t.Errorf("stderrDetail() = %q, want ... prefix and the final line", got)
+		}
+		if len(got) != len("...")+maxStderrDetail {
+			t.Errorf("len(stderrDetail()) = %d, want %d", len(got), len("...")+maxStderrDetail)
+		}
+	})
+}
+
+func TestCreateWorktree_ErrorIncludesStderr(t *testing.T) {
+	repoRoot := t.TempDir()
+	mock := reuseMock(repoRoot, "worktree "+repoRoot+"\nbare\n", "main")
+	run := mock.RunFunc
+	mock.RunFunc = func(dir string, name string, args ...string) error {
+		if len(args) > 1 && args[0] == "worktree" && args[1] == "add" {
+			return &CommandError{Err: errors.New("exit status 128"), Stderr: "fatal: invalid reference: main\n"}
+		}
+		return run(dir, name, args...)
+	}
+	wm := NewWorktreeManagerWithRunner("main", false, mock)
+
+	_, err := wm.CreateWorktree("fraas", "FRAAS-123")
+	if err == nil {
+		t.Fatal("CreateWorktree() expected error")
+	}
+	if !strings.HasPrefix(err.Error(), "failed to create worktree") {
+		t.Errorf("Error = %q, want 'failed to create worktree' prefix", err.Error())
+	}
+	if !strings.Contains(err.Error(), "fatal: invalid reference: main") {
+		t.Errorf("Error = %q, want git's stderr", err.Error())
+	}
+}
+
 // =============================================================================
 // Path Traversal Prevention Tests (Security-Critical)
 // =============================================================================
@@ -1208,3 +1328,174 @@ func TestPathTraversalEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// reuseMock returns a mock runner for a repo at repoRoot whose worktree list
+// and existing branches are given
+func reuseMock(repoRoot, worktreeList string, branches ...string) *MockCommandRunner {
+	return &MockCommandRunner{
+		OutputFunc: func(dir string, name string, args ...string) ([]byte, error) {
+			if len(args) > 1 && args[0] == "rev-parse" && args[1] == "--git-common-dir" {
+				return []byte(repoRoot + "\n"), nil
+			}
+			if len(args) > 1 && args[0] == "worktree" && args[1] == "list" {
+				return []byte(worktreeList), nil
+			}
+			if len(args) > 0 && args[0] == "symbolic-ref" {
+				return []byte("refs/remotes/origin/main\n"), nil
+			}
+			return []byte{}, nil
+		},
+		RunFunc: func(dir string, name string, args ...string) error {
+			if len(args) > 0 && args[0] == "show-ref" {
+				for _, b := range branches {
+					if args[len(args)-1] == "refs/heads/"+b {
+						return nil
+					}
+				}
+				return errors.New("not found")
+			}
+			return nil
+		},
+	}
+}
+
+// worktreeAddCall returns the args of the git worktree add call, if any
+func worktreeAddCall(mock *MockCommandRunner) []string {
+	for _, call := range mock.Calls {
+		if call.Method == "Run" && len(call.Args) > 1 && call.Args[0] == "worktree" && call.Args[1] == "add" {
+			return call.Args
+		}
+	}
+	return nil
+}
+
+func TestCreateWorktreeWithBranch_ReusesCheckedOutBranch(t *testing.T) {
+	repoRoot := t.TempDir()
+	elsewhere := filepath.Join(t.TempDir(), "FRAAS-123")
+	if err := os.MkdirAll(elsewhere, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	list := "worktree " + repoRoot + "\nbare\n\nworktree " + elsewhere + "\nHEAD abc123\nbranch refs/heads/FRAAS-123\n"
+	mock := reuseMock(repoRoot, list, "FRAAS-123")
+	wm := NewWorktreeManagerWithRunner("main", false, mock)
+
+	path, err := wm.CreateWorktree("fraas", "FRAAS-123")
+	if err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+	if path != elsewhere {
+		t.Errorf("CreateWorktree() = %q, want existing worktree %q", path, elsewhere)
+	}
+	if args := worktreeAddCall(mock); args != nil {
+		t.Errorf("git worktree add should not be called, got %v", args)
+	}
+}
+
+func TestCreateWorktreeWithBranch_AttachesExistingBranch(t *testing.T) {
+	repoRoot := t.TempDir()
+	mock := reuseMock(repoRoot, "worktree "+repoRoot+"\nbare\n", "FRAAS-123")
+	wm := NewWorktreeManagerWithRunner("main", false, mock)
+
+	path, err := wm.CreateWorktree("fraas", "FRAAS-123")
+	if err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+	if want := filepath.Join(repoRoot, "fraas", "FRAAS-123"); path != want {
+		t.Errorf("CreateWorktree() = %q, want %q", path, want)
+	}
+
+	want := []string{"worktree", "add", filepath.Join("fraas", "FRAAS-123"), "FRAAS-123"}
+	if args := worktreeAddCall(mock); strings.Join(args, " ") != strings.Join(want, " ") {
+		t.Errorf("git worktree add args = %v, want %v", args, want)
+	}
+}
+
+func TestCreateWorktreeWithBranch_PrunesStaleWorktree(t *testing.T) {
+	repoRoot := t.TempDir()
+	stale := filepath.Join(repoRoot, "fraas", "FRAAS-123")
+	list := "worktree " + repoRoot + "\nbare\n\nworktree " + stale + "\nHEAD abc123\nbranch refs/heads/FRAAS-123\nprunable gitdir file points to non-existent location\n"
+	mock := reuseMock(repoRoot, list, "FRAAS-123")
+	wm := NewWorktreeManagerWithRunner("main", false, mock)
+
+	if _, err := wm.CreateWorktree("fraas", "FRAAS-123"); err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+
+	pruned := false
+	for _, call := range mock.Calls {
+		if call.Method == "Run" && strings.Join(call.Args, " ") == "worktree prune" {
+			pruned = true
+		}
+	}
+	if !pruned {
+		t.Error("stale worktree registration should be pruned")
+	}
+	if args := worktreeAddCall(mock); args == nil || args[len(args)-1] != "FRAAS-123" || slices.Contains(args, "-b") {
+		t.Errorf("git worktree add should attach the existing branch, got %v", args)
+	}
+}
+
+func TestCreateWorktreeWithBranch_NewBranch(t *testing.T) {
+	repoRoot := t.TempDir()
+	mock := reuseMock(repoRoot, "worktree "+repoRoot+"\nbare\n", "main")
+	wm := NewWorktreeManagerWithRunner("main", false, mock)
+
+	if _, err := wm.CreateWorktree("fraas", "FRAAS-123"); err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+
+	want := []string{"worktree", "add", filepath.Join("fraas", "FRAAS-123"), "-b", "FRAAS-123", "main"}
+	if args := worktreeAddCall(mock); strings.Join(args, " ") != strings.Join(want, " ") {
+		t.Errorf("git worktree add args = %v, want %v", args, want)
+	}
+}
+
+func TestFetchPullRequest(t *testing.T) {
+	tests := []struct {
+		name         string
+		branchExists bool
+		wantFetch    bool
+	}{
+		{name: "new branch is fetched", wantFetch: true},
+		{name: "existing branch is kept", branchExists: true, wantFetch: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &MockCommandRunner{
+				OutputFunc: func(dir string, name string, args ...string) ([]byte, error) {
+					if len(args) > 1 && args[0] == "rev-parse" && args[1] == "--git-common-dir" {
+						return []byte("/repo\n"), nil
+					}
+					return []byte{}, nil
+				},
+				RunFunc: func(dir string, name string, args ...string) error {
+					if len(args) > 0 && args[0] == "show-ref" && !tt.branchExists {
+						return errors.New("not found")
+					}
+					return nil
+				},
+			}
+			wm := NewWorktreeManagerWithRunner("", false, mock)
+
+			if err := wm.FetchPullRequest(42, "feature/login"); err != nil {
+				t.Fatalf("FetchPullRequest() error = %v", err)
+			}
+
+			fetched := false
+			for _, call := range mock.Calls {
+				if len(call.Args) > 0 && call.Args[0] == "fetch" {
+					fetched = true
+					want := []string{"fetch", "origin", "pull/42/head:feature/login"}
+					if strings.Join(call.Args, " ") != strings.Join(want, " ") {
+						t.Errorf("fetch args = %v, want %v", call.Args, want)
+					}
+				}
+			}
+			if fetched != tt.wantFetch {
+				t.Errorf("fetched = %v, want %v", fetched, tt.wantFetch)
+			}
+		})
+	}
+}
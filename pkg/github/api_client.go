@@ -126,11 +126,21 @@ func (c *APIClient) GetPR(ctx context.Context, number int) (*PRInfo, error) {
 		return nil, err
 	}
 
-	c.logDebug("getting PR", "number", number)
+	return c.getPR(ctx, "GetPR", owner, repo, number)
+}
+
+// GetPullRequest retrieves pull request information by number from owner/repo.
+func (c *APIClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PRInfo, error) {
+	return c.getPR(ctx, "GetPullRequest", owner, repo, number)
+}
+
+// getPR fetches a pull request along with its approval and check status.
+func (c *APIClient) getPR(ctx context.Context, operation, owner, repo string, number int) (*PRInfo, error) {
+	c.logDebug("getting PR", "owner", owner, "repo", repo, "number", number)
 
 	pr, resp, err := c.client.PullRequests.Get(ctx, owner, repo, number)
 	if err != nil {
-		return nil, toGitHubError("GetPR", resp, err)
+		return nil, toGitHubError(operation, resp, err)
 	}
 
 	info := prInfoFromGitHub(pr)
@@ -321,6 +331,11 @@ func prInfoFromGitHub(pr *gh.PullRequest) *PRInfo {
 		UpdatedAt: pr.GetUpdatedAt().Time,
 	}
 
+	// The REST API reports merged PRs as closed; match the gh CLI's state
+	if pr.GetMerged() || !pr.GetMergedAt().IsZero() {
+		info.State = "merged"
+	}
+
 	if pr.Head != nil {
 		info.HeadBranch = pr.GetHead().GetRef()
 	}
@@ -342,6 +357,8 @@ func prInfoFromGitHub(pr *gh.PullRequest) *PRInfo {
 	// Map mergeable state
 	info.MergeableState = strings.ToUpper(pr.GetMergeableState())
 
+	info.LinkedIssues = parseLinkedIssues(info.Body)
+
 	return info
 }
 
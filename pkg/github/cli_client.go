@@ -115,22 +115,35 @@ func (c *CLIClient) CreatePR(ctx context.Context, opts CreatePROptions) (*PRInfo
 
 // GetPR retrieves pull request information by number.
 func (c *CLIClient) GetPR(ctx context.Context, number int) (*PRInfo, error) {
+	c.logDebug("getting PR", "number", number)
+
+	return c.viewPR(ctx, "GetPR", number)
+}
+
+// GetPullRequest retrieves pull request information by number from owner/repo.
+func (c *CLIClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PRInfo, error) {
+	c.logDebug("getting PR", "owner", owner, "repo", repo, "number", number)
+
+	return c.viewPR(ctx, "GetPullRequest", number, "--repo", owner+"/"+repo)
+}
+
+// viewPR runs gh pr view for number with any extra arguments.
+func (c *CLIClient) viewPR(ctx context.Context, operation string, number int, extraArgs ...string) (*PRInfo, error) {
 	fields := prJSONFields()
 	args := []string{
 		"pr", "view", strconv.Itoa(number),
 		"--json", strings.Join(fields, ","),
 	}
-
-	c.logDebug("getting PR", "number", number)
+	args = append(args, extraArgs...)
 
 	output, err := c.runGH(ctx, args...)
 	if err != nil {
-		return nil, rigerrors.NewGitHubErrorWithCause("GetPR", fmt.Sprintf("failed to get PR #%d", number), err)
+		return nil, rigerrors.NewGitHubErrorWithCause(operation, fmt.Sprintf("failed to get PR #%d", number), err)
 	}
 
 	var resp ghPRResponse
 	if err := json.Unmarshal([]byte(output), &resp); err != nil {
-		return nil, rigerrors.NewGitHubErrorWithCause("GetPR", "failed to parse PR response", err)
+		return nil, rigerrors.NewGitHubErrorWithCause(operation, "failed to parse PR response", err)
 	}
 
 	return resp.toPRInfo(), nil
@@ -23,6 +23,10 @@ type Client interface {
 	// GetPR retrieves pull request information by number.
 	GetPR(ctx context.Context, number int) (*PRInfo, error)
 
+	// GetPullRequest retrieves pull request information by number from the
+	// given repository rather than the current one.
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*PRInfo, error)
+
 	// ListPRs lists pull requests with the given options.
 	ListPRs(ctx context.Context, opts ListPRsOptions) ([]PRInfo, error)
 
@@ -52,6 +52,29 @@ func TestPRInfoIsClean(t *testing.T) {
 	}
 }
 
+func TestPRInfoIsMerged(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+		want  bool
+	}{
+		{"gh CLI merged", "MERGED", true},
+		{"API merged", "merged", true},
+		{"open", "OPEN", false},
+		{"closed", "closed", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &PRInfo{State: tt.state}
+			if got := pr.IsMerged(); got != tt.want {
+				t.Errorf("PRInfo.IsMerged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGHPRResponseToPRInfo(t *testing.T) {
 	now := time.Now()
 	resp := &ghPRResponse{
@@ -212,6 +235,35 @@ func TestExtractPRNumber(t *testing.T) {
 	}
 }
 
+func TestParseLinkedIssues(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []int
+	}{
+		{name: "empty body", body: "", want: nil},
+		{name: "no keywords", body: "Related to #12", want: nil},
+		{name: "fixes", body: "Fixes #12", want: []int{12}},
+		{name: "case and colon", body: "closes: #3\nRESOLVED #4", want: []int{3, 4}},
+		{name: "duplicates keep first order", body: "Fix #9, closes #2 and fixes #9", want: []int{9, 2}},
+		{name: "keyword inside a word", body: "prefixes #5", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseLinkedIssues(tt.body)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseLinkedIssues(%q) = %v, want %v", tt.body, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parseLinkedIssues(%q) = %v, want %v", tt.body, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
 func TestIsRetryableGHError(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -5,7 +5,12 @@
 // The primary implementation uses the gh CLI tool for maximum compatibility.
 package github
 
-import "time"
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // AuthMethod represents the authentication method for GitHub.
 type AuthMethod string
@@ -35,6 +40,7 @@ type PRInfo struct {
 	Reviewers      []string  `json:"-"`                // Populated from reviewRequests
 	Approved       bool      `json:"-"`                // Computed from reviews
 	ChecksPassing  bool      `json:"-"`                // Computed from statusCheckRollup
+	LinkedIssues   []int     `json:"-"`                // Issues the body closes, e.g. "Fixes #12"
 	CreatedAt      time.Time `json:"createdAt"`
 	UpdatedAt      time.Time `json:"updatedAt"`
 }
@@ -44,6 +50,11 @@ func (pr *PRInfo) IsMergeable() bool {
 	return pr.Mergeable == "MERGEABLE"
 }
 
+// IsMerged returns true if the PR has been merged.
+func (pr *PRInfo) IsMerged() bool {
+	return strings.EqualFold(pr.State, "merged")
+}
+
 // IsClean returns true if the PR is in a clean state (checks pass, reviews approved).
 func (pr *PRInfo) IsClean() bool {
 	return pr.MergeableState == "CLEAN"
@@ -121,6 +132,7 @@ func (r *ghPRResponse) toPRInfo() *PRInfo {
 		MergeableState: r.MergeStateStatus,
 		CreatedAt:      r.CreatedAt,
 		UpdatedAt:      r.UpdatedAt,
+		LinkedIssues:   parseLinkedIssues(r.Body),
 	}
 
 	// Extract reviewers
@@ -152,6 +164,26 @@ func (r *ghPRResponse) toPRInfo() *PRInfo {
 	return pr
 }
 
+// closingKeywordRegex matches GitHub's issue-closing keywords followed by a
+// same-repository reference, e.g. "Fixes #12" or "closes: #7".
+var closingKeywordRegex = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+#(\d+)\b`)
+
+// parseLinkedIssues returns the issue numbers a PR body closes, in order of
+// first mention and without duplicates.
+func parseLinkedIssues(body string) []int {
+	var issues []int
+	seen := make(map[int]bool)
+	for _, match := range closingKeywordRegex.FindAllStringSubmatch(body, -1) {
+		number, err := strconv.Atoi(match[1])
+		if err != nil || seen[number] {
+			continue
+		}
+		seen[number] = true
+		issues = append(issues, number)
+	}
+	return issues
+}
+
 // ghRepoResponse represents the JSON response from gh repo view.
 type ghRepoResponse struct {
 	Name  string `json:"name"`
@@ -0,0 +1,76 @@
+package history
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// bashTimestampPattern matches the "#<epoch>" lines bash writes before each
+// command when HISTTIMEFORMAT is set
+var bashTimestampPattern = regexp.MustCompile(`^#(\d+)$`)
+
+// parseBashHistory reads a bash history file. When the file contains
+// HISTTIMEFORMAT timestamp lines, every line up to the next timestamp belongs
+// to the same command (so multi-line commands survive); otherwise each line is
+// its own command with a zero timestamp. Bash records no directory, session,
+// duration, or exit status.
+func parseBashHistory(path string) ([]Command, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open bash history")
+	}
+	defer f.Close()
+
+	var commands []Command
+	var current *Command
+
+	flush := func() {
+		if current != nil && strings.TrimSpace(current.Command) != "" {
+			current.ID = int64(len(commands) + 1)
+			commands = append(commands, *current)
+		}
+		current = nil
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if match := bashTimestampPattern.FindStringSubmatch(line); match != nil {
+			flush()
+			current = &Command{}
+			if seconds, parseErr := strconv.ParseInt(match[1], 10, 64); parseErr == nil {
+				current.Timestamp = time.Unix(seconds, 0)
+			}
+			continue
+		}
+
+		switch {
+		case current != nil && !current.Timestamp.IsZero():
+			// Lines following a timestamp belong to the same command
+			if current.Command == "" {
+				current.Command = line
+			} else {
+				current.Command += "\n" + line
+			}
+		default:
+			flush()
+			current = &Command{Command: line}
+			flush()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read bash history")
+	}
+	flush()
+
+	return commands, nil
+}
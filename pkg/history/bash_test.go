@@ -0,0 +1,156 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseBashHistory(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		want       []string
+		wantStamps []int64
+	}{
+		{
+			name:       "plain history",
+			content:    "git status\n\nls -la\nmake test\n",
+			want:       []string{"git status", "ls -la", "make test"},
+			wantStamps: []int64{0, 0, 0},
+		},
+		{
+			name:       "HISTTIMEFORMAT timestamps",
+			content:    "#1700000000\ngit status\n#1700000060\nmake test\n",
+			want:       []string{"git status", "make test"},
+			wantStamps: []int64{1700000000, 1700000060},
+		},
+		{
+			name:       "multi-line command after timestamp",
+			content:    "#1700000000\nfor f in *; do\n  echo $f\ndone\n#1700000060\nls\n",
+			want:       []string{"for f in *; do\n  echo $f\ndone", "ls"},
+			wantStamps: []int64{1700000000, 1700000060},
+		},
+		{
+			name:       "CRLF line endings",
+			content:    "#1700000000\r\ngit status\r\n",
+			want:       []string{"git status"},
+			wantStamps: []int64{1700000000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".bash_history")
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("Failed to write bash history: %v", err)
+			}
+
+			commands, err := parseBashHistory(path)
+			if err != nil {
+				t.Fatalf("parseBashHistory() error = %v", err)
+			}
+
+			if len(commands) != len(tt.want) {
+				t.Fatalf("parseBashHistory() returned %d commands, want %d", len(commands), len(tt.want))
+			}
+			for i, cmd := range commands {
+				if cmd.Command != tt.want[i] {
+					t.Errorf("commands[%d].Command = %q, want %q", i, cmd.Command, tt.want[i])
+				}
+				if cmd.ID != int64(i+1) {
+					t.Errorf("commands[%d].ID = %d, want %d", i, cmd.ID, i+1)
+				}
+				if tt.wantStamps[i] == 0 {
+					if !cmd.Timestamp.IsZero() {
+						t.Errorf("commands[%d].Timestamp = %v, want zero", i, cmd.Timestamp)
+					}
+				} else if !cmd.Timestamp.Equal(time.Unix(tt.wantStamps[i], 0)) {
+					t.Errorf("commands[%d].Timestamp = %v, want %v", i, cmd.Timestamp, time.Unix(tt.wantStamps[i], 0))
+				}
+			}
+		})
+	}
+}
+
+func TestDetectFileSchema_Bash(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	byName := filepath.Join(tmpDir, ".bash_history")
+	if err := os.WriteFile(byName, []byte("ls\n"), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if got := NewDatabaseManager(byName, false).detectFileSchema(); got != SchemaBash {
+		t.Errorf("detectFileSchema() by name = %q, want %q", got, SchemaBash)
+	}
+
+	byContent := filepath.Join(tmpDir, "history")
+	if err := os.WriteFile(byContent, []byte("#1700000000\nls\n"), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if got := NewDatabaseManager(byContent, false).detectFileSchema(); got != SchemaBash {
+		t.Errorf("detectFileSchema() by content = %q, want %q", got, SchemaBash)
+	}
+}
+
+func TestQueryCommands_Bash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".bash_history")
+	content := "#1700000000\ngit status\n#1700000060\ngit push\n#1700000120\nmake test\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write bash history: %v", err)
+	}
+
+	dm := NewDatabaseManager(path, false)
+	if !dm.IsAvailable() {
+		t.Fatal("IsAvailable() should return true for bash history")
+	}
+
+	failed := 1
+	tests := []struct {
+		name    string
+		options QueryOptions
+		want    []string
+	}{
+		{
+			name:    "pattern and limit",
+			options: QueryOptions{Pattern: "git", Limit: 1},
+			want:    []string{"git status"},
+		},
+		{
+			name:    "failed-only returns nothing",
+			options: QueryOptions{ExitCode: &failed},
+			want:    nil,
+		},
+		{
+			name:    "min duration returns nothing",
+			options: QueryOptions{MinDuration: time.Second},
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, err := dm.QueryCommands(tt.options)
+			if err != nil {
+				t.Fatalf("QueryCommands() error = %v", err)
+			}
+			if len(commands) != len(tt.want) {
+				t.Fatalf("QueryCommands() returned %d commands, want %d", len(commands), len(tt.want))
+			}
+			for i, cmd := range commands {
+				if cmd.Command != tt.want[i] {
+					t.Errorf("commands[%d] = %q, want %q", i, cmd.Command, tt.want[i])
+				}
+			}
+		})
+	}
+
+	info, err := dm.GetDatabaseInfo()
+	if err != nil {
+		t.Fatalf("GetDatabaseInfo() error = %v", err)
+	}
+	if info["schema"] != "bash" {
+		t.Errorf("schema = %v, want bash", info["schema"])
+	}
+}
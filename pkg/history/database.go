@@ -2,13 +2,14 @@ package history
 
 import (
 	"database/sql"
-	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/cockroachdb/errors"
 	_ "modernc.org/sqlite"
+
+	rlog "thoreinstein.com/rig/pkg/log"
 )
 
 // DatabaseManager handles SQLite history database operations
@@ -29,16 +30,21 @@ func NewDatabaseManager(databasePath string, verbose bool) *DatabaseManager {
 func (dm *DatabaseManager) IsAvailable() bool {
 	if _, err := os.Stat(dm.DatabasePath); os.IsNotExist(err) {
 		if dm.Verbose {
-			fmt.Printf("History database not found at: %s\n", dm.DatabasePath)
+			rlog.Debug("history database not found", "path", dm.DatabasePath)
 		}
 		return false
 	}
 
+	// Plain-text histories (fish, bash) don't need SQLite
+	if dm.detectFileSchema() != SchemaUnknown {
+		return true
+	}
+
 	// Try to open and query the database
 	db, err := sql.Open("sqlite", dm.DatabasePath)
 	if err != nil {
 		if dm.Verbose {
-			fmt.Printf("Failed to open history database: %v\n", err)
+			rlog.Debug("failed to open history database", "error", err)
 		}
 		return false
 	}
@@ -49,7 +55,7 @@ func (dm *DatabaseManager) IsAvailable() bool {
 	err = db.QueryRow("SELECT name FROM sqlite_master WHERE type='table' AND name IN ('commands', 'history')").Scan(&tableName)
 	if err != nil {
 		if dm.Verbose {
-			fmt.Printf("History database doesn't contain expected tables: %v\n", err)
+			rlog.Debug("history database doesn't contain expected tables", "error", err)
 		}
 		return false
 	}
@@ -63,6 +69,13 @@ func (dm *DatabaseManager) QueryCommands(options QueryOptions) ([]Command, error
 		return nil, errors.New("history database not available")
 	}
 
+	if schema := dm.detectFileSchema(); schema != SchemaUnknown {
+		if dm.Verbose {
+			rlog.Debug("reading history file", "schema", schema, "path", dm.DatabasePath)
+		}
+		return dm.queryTextHistory(schema, options)
+	}
+
 	db, err := sql.Open("sqlite", dm.DatabasePath)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to open database")
@@ -75,11 +88,17 @@ func (dm *DatabaseManager) QueryCommands(options QueryOptions) ([]Command, error
 		return nil, errors.Wrap(err, "failed to detect database schema")
 	}
 
-	query, args := dm.buildQuery(schema, options)
+	// Regex matching happens after the rows are scanned, so the limit can
+	// only be applied once the candidates have been filtered
+	sqlOptions := options
+	if options.Regex != nil {
+		sqlOptions.Limit = 0
+	}
+
+	query, args := dm.buildQuery(schema, sqlOptions)
 
 	if dm.Verbose {
-		fmt.Printf("Executing query: %s\n", query)
-		fmt.Printf("With args: %v\n", args)
+		rlog.Debug("executing history query", "query", query, "args", args)
 	}
 
 	rows, err := db.Query(query, args...)
@@ -94,7 +113,13 @@ func (dm *DatabaseManager) QueryCommands(options QueryOptions) ([]Command, error
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to scan command")
 		}
+		if options.Regex != nil && !options.Regex.MatchString(command.Command) {
+			continue
+		}
 		commands = append(commands, command)
+		if options.Regex != nil && options.Limit > 0 && len(commands) >= options.Limit {
+			break
+		}
 	}
 
 	if err = rows.Err(); err != nil {
@@ -110,6 +135,8 @@ type DatabaseSchema string
 const (
 	SchemaZshHistdb DatabaseSchema = "zsh-histdb"
 	SchemaAtuin     DatabaseSchema = "atuin"
+	SchemaFish      DatabaseSchema = "fish"
+	SchemaBash      DatabaseSchema = "bash"
 	SchemaUnknown   DatabaseSchema = "unknown"
 )
 
@@ -353,7 +380,7 @@ func (dm *DatabaseManager) scanCommand(rows *sql.Rows, schema DatabaseSchema) (C
 		timestamp, err = time.Parse(time.RFC3339, timestampStr)
 		if err != nil {
 			if dm.Verbose {
-				fmt.Printf("Warning: Could not parse timestamp: %s\n", timestampStr)
+				rlog.Debug("could not parse history timestamp", "timestamp", timestampStr)
 			}
 			timestamp = time.Now()
 		}
@@ -386,6 +413,18 @@ func (dm *DatabaseManager) GetDatabaseInfo() (map[string]interface{}, error) {
 	info["size"] = fileInfo.Size()
 	info["modified"] = fileInfo.ModTime()
 
+	// Plain-text histories are counted by parsing the file
+	if schema := dm.detectFileSchema(); schema != SchemaUnknown {
+		info["schema"] = string(schema)
+		commands, err := dm.queryTextHistory(schema, QueryOptions{})
+		if err != nil {
+			info["error"] = err.Error()
+		} else {
+			info["command_count"] = int64(len(commands))
+		}
+		return info, nil
+	}
+
 	// Open database and get more info
 	db, err := sql.Open("sqlite", dm.DatabasePath)
 	if err != nil {
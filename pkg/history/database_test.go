@@ -3,6 +3,7 @@ package history
 import (
 	"database/sql"
 	"path/filepath"
+	"regexp"
 	"testing"
 	"time"
 
@@ -615,3 +616,55 @@ func containsStringHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestQueryCommands_RegexAppliesBeforeLimit(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE history (
+			id INTEGER PRIMARY KEY,
+			command TEXT,
+			timestamp INTEGER,
+			duration INTEGER,
+			exit INTEGER,
+			cwd TEXT,
+			session TEXT,
+			hostname TEXT
+		);
+		INSERT INTO history (command, timestamp, duration, exit, cwd, session, hostname)
+		VALUES ('ls -la', 1700000000000000000, 50, 0, '/home/user', 'session1', 'localhost');
+		INSERT INTO history (command, timestamp, duration, exit, cwd, session, hostname)
+		VALUES ('git status', 1700000100000000000, 50, 0, '/home/user', 'session1', 'localhost');
+		INSERT INTO history (command, timestamp, duration, exit, cwd, session, hostname)
+		VALUES ('git push origin main', 1700000200000000000, 50, 0, '/home/user', 'session1', 'localhost');
+		INSERT INTO history (command, timestamp, duration, exit, cwd, session, hostname)
+		VALUES ('echo git pull', 1700000300000000000, 50, 0, '/home/user', 'session1', 'localhost');
+		INSERT INTO history (command, timestamp, duration, exit, cwd, session, hostname)
+		VALUES ('git pull --rebase', 1700000400000000000, 50, 0, '/home/user', 'session1', 'localhost');
+	`)
+	if err != nil {
+		t.Fatalf("Failed to setup test data: %v", err)
+	}
+	db.Close()
+
+	dm := NewDatabaseManager(dbPath, false)
+
+	commands, err := dm.QueryCommands(QueryOptions{
+		Regex: regexp.MustCompile(`^git (push|pull)`),
+		Limit: 2,
+	})
+	if err != nil {
+		t.Fatalf("QueryCommands() error: %v", err)
+	}
+
+	if len(commands) != 2 {
+		t.Fatalf("QueryCommands() returned %d commands, want 2", len(commands))
+	}
+	if commands[0].Command != "git push origin main" || commands[1].Command != "git pull --rebase" {
+		t.Errorf("QueryCommands() = [%q, %q], want [git push origin main, git pull --rebase]", commands[0].Command, commands[1].Command)
+	}
+}
@@ -0,0 +1,84 @@
+package history
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// parseFishHistory reads a fish_history file. Fish stores a YAML-like list of
+// entries with "- cmd:" and "when:" keys; there is no directory, session,
+// duration, or exit status, so those fields are left empty.
+func parseFishHistory(path string) ([]Command, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open fish history")
+	}
+	defer f.Close()
+
+	var commands []Command
+	var current *Command
+
+	flush := func() {
+		if current != nil && current.Command != "" {
+			current.ID = int64(len(commands) + 1)
+			commands = append(commands, *current)
+		}
+		current = nil
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "- cmd:"):
+			flush()
+			current = &Command{
+				Command: unescapeFishCommand(strings.TrimSpace(strings.TrimPrefix(line, "- cmd:"))),
+			}
+		case current != nil && strings.HasPrefix(strings.TrimSpace(line), "when:"):
+			value := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "when:"))
+			if seconds, parseErr := strconv.ParseInt(value, 10, 64); parseErr == nil {
+				current.Timestamp = time.Unix(seconds, 0)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read fish history")
+	}
+	flush()
+
+	return commands, nil
+}
+
+// unescapeFishCommand reverses fish's escaping of backslashes and newlines
+func unescapeFishCommand(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			switch s[i+1] {
+			case 'n':
+				sb.WriteByte('\n')
+				i++
+				continue
+			case '\\':
+				sb.WriteByte('\\')
+				i++
+				continue
+			}
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
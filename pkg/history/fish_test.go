@@ -0,0 +1,197 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testFishHistory = `- cmd: git status
+  when: 1700000000
+- cmd: cd ~/src/rig
+  when: 1700000060
+  paths:
+    - ~/src/rig
+- cmd: echo "line one\nline two" \\ done
+  when: 1700000120
+- cmd: make test PROJ-123
+  when: 1700000180
+`
+
+func writeFishHistory(t *testing.T, name string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(testFishHistory), 0600); err != nil {
+		t.Fatalf("Failed to write fish history: %v", err)
+	}
+	return path
+}
+
+func TestParseFishHistory(t *testing.T) {
+	path := writeFishHistory(t, "fish_history")
+
+	commands, err := parseFishHistory(path)
+	if err != nil {
+		t.Fatalf("parseFishHistory() error = %v", err)
+	}
+
+	if len(commands) != 4 {
+		t.Fatalf("parseFishHistory() returned %d commands, want 4", len(commands))
+	}
+
+	if commands[0].Command != "git status" {
+		t.Errorf("commands[0].Command = %q, want %q", commands[0].Command, "git status")
+	}
+	if !commands[0].Timestamp.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("commands[0].Timestamp = %v, want %v", commands[0].Timestamp, time.Unix(1700000000, 0))
+	}
+	if commands[0].ID != 1 || commands[3].ID != 4 {
+		t.Errorf("IDs = %d..%d, want 1..4", commands[0].ID, commands[3].ID)
+	}
+
+	want := "echo \"line one\nline two\" \\ done"
+	if commands[2].Command != want {
+		t.Errorf("commands[2].Command = %q, want %q", commands[2].Command, want)
+	}
+
+	for _, cmd := range commands {
+		if cmd.Directory != "" || cmd.Session != "" {
+			t.Errorf("command %q should have empty directory and session", cmd.Command)
+		}
+	}
+}
+
+func TestDetectFileSchema(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		want     DatabaseSchema
+	}{
+		{
+			name:     "fish history by name",
+			filename: "fish_history",
+			content:  "",
+			want:     SchemaFish,
+		},
+		{
+			name:     "fish history by content",
+			filename: "history.txt",
+			content:  "\n- cmd: ls\n  when: 1700000000\n",
+			want:     SchemaFish,
+		},
+		{
+			name:     "sqlite database",
+			filename: "fish_history.db",
+			content:  sqliteHeader + "rest",
+			want:     SchemaUnknown,
+		},
+		{
+			name:     "unrecognized text",
+			filename: "notes.txt",
+			content:  "hello world\n",
+			want:     SchemaUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, tt.filename)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("Failed to write file: %v", err)
+			}
+
+			dm := NewDatabaseManager(path, false)
+			if got := dm.detectFileSchema(); got != tt.want {
+				t.Errorf("detectFileSchema() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryCommands_Fish(t *testing.T) {
+	path := writeFishHistory(t, "fish_history")
+	dm := NewDatabaseManager(path, false)
+
+	if !dm.IsAvailable() {
+		t.Fatal("IsAvailable() should return true for fish history")
+	}
+
+	since := time.Unix(1700000060, 0)
+	exitCode := 0
+
+	tests := []struct {
+		name    string
+		options QueryOptions
+		want    []string
+	}{
+		{
+			name:    "all commands",
+			options: QueryOptions{},
+			want:    []string{"git status", "cd ~/src/rig", "echo \"line one\nline two\" \\ done", "make test PROJ-123"},
+		},
+		{
+			name:    "pattern is case-insensitive",
+			options: QueryOptions{Pattern: "GIT"},
+			want:    []string{"git status"},
+		},
+		{
+			name:    "since and limit",
+			options: QueryOptions{Since: &since, Limit: 2},
+			want:    []string{"cd ~/src/rig", "echo \"line one\nline two\" \\ done"},
+		},
+		{
+			name:    "ticket matches command",
+			options: QueryOptions{Ticket: "proj-123"},
+			want:    []string{"make test PROJ-123"},
+		},
+		{
+			name:    "directory filter never matches",
+			options: QueryOptions{Directory: "/src"},
+			want:    nil,
+		},
+		{
+			name:    "exit code filter matches unrecorded status",
+			options: QueryOptions{ExitCode: &exitCode, Pattern: "status"},
+			want:    []string{"git status"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, err := dm.QueryCommands(tt.options)
+			if err != nil {
+				t.Fatalf("QueryCommands() error = %v", err)
+			}
+
+			if len(commands) != len(tt.want) {
+				t.Fatalf("QueryCommands() returned %d commands, want %d", len(commands), len(tt.want))
+			}
+			for i, cmd := range commands {
+				if cmd.Command != tt.want[i] {
+					t.Errorf("commands[%d] = %q, want %q", i, cmd.Command, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetDatabaseInfo_Fish(t *testing.T) {
+	path := writeFishHistory(t, "fish_history")
+	dm := NewDatabaseManager(path, false)
+
+	info, err := dm.GetDatabaseInfo()
+	if err != nil {
+		t.Fatalf("GetDatabaseInfo() error = %v", err)
+	}
+
+	if info["schema"] != "fish" {
+		t.Errorf("schema = %v, want fish", info["schema"])
+	}
+	if info["command_count"] != int64(4) {
+		t.Errorf("command_count = %v, want 4", info["command_count"])
+	}
+}
@@ -0,0 +1,97 @@
+package history
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// unknownSession labels commands recorded without a session
+const unknownSession = "(no session)"
+
+// SessionActivity summarizes the commands run in one history session
+type SessionActivity struct {
+	Session  string    `json:"session"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Commands int       `json:"commands"`
+	Failed   int       `json:"failed"`
+}
+
+// SummarizeSessions groups commands by session and returns one entry per
+// session, ordered chronologically by the session's first command. End is
+// the finish time of the session's last command.
+func SummarizeSessions(commands []Command) []SessionActivity {
+	bySession := make(map[string]*SessionActivity)
+	var order []string
+
+	for _, cmd := range commands {
+		name := cmd.Session
+		if name == "" {
+			name = unknownSession
+		}
+
+		activity, ok := bySession[name]
+		if !ok {
+			activity = &SessionActivity{Session: name, Start: cmd.Timestamp, End: cmd.Timestamp}
+			bySession[name] = activity
+			order = append(order, name)
+		}
+
+		if cmd.Timestamp.Before(activity.Start) {
+			activity.Start = cmd.Timestamp
+		}
+		if end := cmd.Timestamp.Add(time.Duration(cmd.Duration) * time.Millisecond); end.After(activity.End) {
+			activity.End = end
+		}
+		activity.Commands++
+		if cmd.ExitCode != 0 {
+			activity.Failed++
+		}
+	}
+
+	sessions := make([]SessionActivity, 0, len(order))
+	for _, name := range order {
+		sessions = append(sessions, *bySession[name])
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].Start.Before(sessions[j].Start)
+	})
+
+	return sessions
+}
+
+// FormatSessionTimeline renders session activity as markdown, one heading per
+// day with a line per session that started on it
+func FormatSessionTimeline(sessions []SessionActivity) string {
+	var b strings.Builder
+
+	b.WriteString("## Session Timeline\n\n")
+
+	currentDay := ""
+	for _, s := range sessions {
+		day := s.Start.Format("2006-01-02")
+		if day != currentDay {
+			if currentDay != "" {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "### %s\n\n", day)
+			currentDay = day
+		}
+
+		commands := fmt.Sprintf("%d commands", s.Commands)
+		if s.Commands == 1 {
+			commands = "1 command"
+		}
+		if s.Failed > 0 {
+			commands += fmt.Sprintf(", %d failed", s.Failed)
+		}
+
+		fmt.Fprintf(&b, "- **%s–%s** `%s` (%s, %s)\n",
+			s.Start.Format("15:04"), s.End.Format("15:04"), s.Session, commands,
+			formatDuration(s.End.Sub(s.Start).Milliseconds()))
+	}
+
+	return b.String()
+}
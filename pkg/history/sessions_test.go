@@ -0,0 +1,67 @@
+package history
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSummarizeSessions(t *testing.T) {
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2025, 1, day, hour, min, 0, 0, time.UTC)
+	}
+
+	commands := []Command{
+		{Command: "git status", Timestamp: at(1, 9, 0), Duration: 100, Session: "rig-proj-1"},
+		{Command: "make test", Timestamp: at(1, 8, 30), Duration: 60000, ExitCode: 2, Session: "rig-proj-2"},
+		{Command: "make build", Timestamp: at(1, 9, 45), Duration: 120000, Session: "rig-proj-1"},
+		{Command: "ls", Timestamp: at(2, 10, 0), Duration: 0},
+	}
+
+	got := SummarizeSessions(commands)
+
+	want := []SessionActivity{
+		{Session: "rig-proj-2", Start: at(1, 8, 30), End: at(1, 8, 31), Commands: 1, Failed: 1},
+		{Session: "rig-proj-1", Start: at(1, 9, 0), End: at(1, 9, 47), Commands: 2},
+		{Session: unknownSession, Start: at(2, 10, 0), End: at(2, 10, 0), Commands: 1},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("SummarizeSessions() returned %d sessions, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("session %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSummarizeSessions_Empty(t *testing.T) {
+	if got := SummarizeSessions(nil); len(got) != 0 {
+		t.Errorf("SummarizeSessions(nil) = %+v, want empty", got)
+	}
+}
+
+func TestFormatSessionTimeline(t *testing.T) {
+	sessions := []SessionActivity{
+		{Session: "rig-proj-1", Start: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC), End: time.Date(2025, 1, 1, 9, 47, 0, 0, time.UTC), Commands: 2, Failed: 1},
+		{Session: "rig-proj-2", Start: time.Date(2025, 1, 1, 14, 0, 0, 0, time.UTC), End: time.Date(2025, 1, 1, 14, 0, 5, 0, time.UTC), Commands: 1},
+		{Session: "rig-proj-1", Start: time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC), End: time.Date(2025, 1, 2, 10, 30, 0, 0, time.UTC), Commands: 12},
+	}
+
+	output := FormatSessionTimeline(sessions)
+
+	want := []string{
+		"## Session Timeline\n",
+		"### 2025-01-01\n\n- **09:00–09:47** `rig-proj-1` (2 commands, 1 failed, 47m0s)\n- **14:00–14:00** `rig-proj-2` (1 command, 5.0s)\n",
+		"### 2025-01-02\n\n- **10:00–10:30** `rig-proj-1` (12 commands, 30m0s)\n",
+	}
+	for _, w := range want {
+		if !strings.Contains(output, w) {
+			t.Errorf("output missing %q\ngot:\n%s", w, output)
+		}
+	}
+	if strings.Index(output, "2025-01-01") > strings.Index(output, "2025-01-02") {
+		t.Error("days should be in chronological order")
+	}
+}
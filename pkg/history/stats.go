@@ -0,0 +1,188 @@
+package history
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+
+	rlog "thoreinstein.com/rig/pkg/log"
+)
+
+// busiestLimit caps the directory and session lists in Stats
+const busiestLimit = 10
+
+// CountEntry is a value paired with how many commands matched it
+type CountEntry struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// Stats summarizes the commands matching a set of query options
+type Stats struct {
+	TotalCommands  int64        `json:"total_commands"`
+	FailedCommands int64        `json:"failed_commands"`
+	FailureRate    float64      `json:"failure_rate"`
+	TopCommands    []CountEntry `json:"top_commands"`
+	TopDirectories []CountEntry `json:"top_directories"`
+	TopSessions    []CountEntry `json:"top_sessions"`
+}
+
+// GetStats aggregates the commands matching options. TopCommands holds at most
+// topN entries; directories and sessions are capped at busiestLimit. The
+// Limit field of options is ignored so the totals cover every matching row.
+func (dm *DatabaseManager) GetStats(options QueryOptions, topN int) (*Stats, error) {
+	if !dm.IsAvailable() {
+		return nil, errors.New("history database not available")
+	}
+
+	options.Limit = 0
+
+	if schema := dm.detectFileSchema(); schema != SchemaUnknown {
+		commands, err := dm.queryTextHistory(schema, options)
+		if err != nil {
+			return nil, err
+		}
+		return aggregateCommands(commands, topN), nil
+	}
+
+	db, err := sql.Open("sqlite", dm.DatabasePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open database")
+	}
+	defer db.Close()
+
+	schema, err := dm.detectSchema(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to detect database schema")
+	}
+
+	// Reuse the query builders so stats honor exactly the same filters,
+	// naming the columns through a CTE so they can be aggregated
+	query, args := dm.buildQuery(schema, options)
+	filtered := "WITH filtered(id, command, start_time, duration, exit_code, directory, session, host) AS (" + query + ") "
+
+	stats := &Stats{}
+
+	err = db.QueryRow(
+		filtered+"SELECT COUNT(*), COALESCE(SUM(CASE WHEN exit_code != 0 THEN 1 ELSE 0 END), 0) FROM filtered",
+		args...,
+	).Scan(&stats.TotalCommands, &stats.FailedCommands)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to count commands")
+	}
+
+	if stats.TotalCommands > 0 {
+		stats.FailureRate = float64(stats.FailedCommands) / float64(stats.TotalCommands)
+	}
+
+	stats.TopCommands, err = dm.queryTopCounts(db, filtered, args, "command", topN)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.TopDirectories, err = dm.queryTopCounts(db, filtered, args, "directory", busiestLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.TopSessions, err = dm.queryTopCounts(db, filtered, args, "session", busiestLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// queryTopCounts groups the filtered rows by column and returns the most
+// frequent non-empty values. column is always a hardcoded CTE column name.
+func (dm *DatabaseManager) queryTopCounts(db *sql.DB, filtered string, args []interface{}, column string, limit int) ([]CountEntry, error) {
+	query := filtered + fmt.Sprintf(
+		"SELECT %[1]s, COUNT(*) AS n FROM filtered WHERE %[1]s != '' GROUP BY %[1]s ORDER BY n DESC, %[1]s ASC",
+		column,
+	)
+
+	queryArgs := append([]interface{}{}, args...)
+	if limit > 0 {
+		query += " LIMIT ?"
+		queryArgs = append(queryArgs, limit)
+	}
+
+	if dm.Verbose {
+		rlog.Debug("executing history query", "query", query, "args", queryArgs)
+	}
+
+	rows, err := db.Query(query, queryArgs...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to aggregate by %s", column)
+	}
+	defer rows.Close()
+
+	var entries []CountEntry
+	for rows.Next() {
+		var entry CountEntry
+		if err := rows.Scan(&entry.Name, &entry.Count); err != nil {
+			return nil, errors.Wrapf(err, "failed to scan %s count", column)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error during row iteration")
+	}
+
+	return entries, nil
+}
+
+// aggregateCommands computes Stats in memory for plain-text history sources
+func aggregateCommands(commands []Command, topN int) *Stats {
+	stats := &Stats{TotalCommands: int64(len(commands))}
+
+	byCommand := make(map[string]int64)
+	byDirectory := make(map[string]int64)
+	bySession := make(map[string]int64)
+
+	for _, cmd := range commands {
+		if cmd.ExitCode != 0 {
+			stats.FailedCommands++
+		}
+		byCommand[cmd.Command]++
+		if cmd.Directory != "" {
+			byDirectory[cmd.Directory]++
+		}
+		if cmd.Session != "" {
+			bySession[cmd.Session]++
+		}
+	}
+
+	if stats.TotalCommands > 0 {
+		stats.FailureRate = float64(stats.FailedCommands) / float64(stats.TotalCommands)
+	}
+
+	stats.TopCommands = topCounts(byCommand, topN)
+	stats.TopDirectories = topCounts(byDirectory, busiestLimit)
+	stats.TopSessions = topCounts(bySession, busiestLimit)
+
+	return stats
+}
+
+// topCounts sorts counts by frequency (ties broken by name) and truncates to limit
+func topCounts(counts map[string]int64, limit int) []CountEntry {
+	var entries []CountEntry
+	for name, count := range counts {
+		entries = append(entries, CountEntry{Name: name, Count: count})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return entries[i].Name < entries[j].Name
+	})
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
@@ -0,0 +1,167 @@
+package history
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestGetStats_ZshHistdb(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE commands (
+			id INTEGER PRIMARY KEY,
+			argv TEXT,
+			start_time INTEGER,
+			duration INTEGER,
+			exit_status INTEGER,
+			place_id INTEGER,
+			session_id INTEGER,
+			hostname TEXT
+		);
+		CREATE TABLE places (
+			id INTEGER PRIMARY KEY,
+			dir TEXT
+		);
+		CREATE TABLE sessions (
+			id INTEGER PRIMARY KEY,
+			session TEXT
+		);
+		INSERT INTO places (id, dir) VALUES (1, '/home/user/project');
+		INSERT INTO places (id, dir) VALUES (2, '/home/user/other');
+		INSERT INTO sessions (id, session) VALUES (1, 'FRAAS-123');
+		INSERT INTO sessions (id, session) VALUES (2, 'other');
+		INSERT INTO commands (argv, start_time, duration, exit_status, place_id, session_id, hostname)
+		VALUES ('git status', 1700000000, 1, 0, 1, 1, 'localhost');
+		INSERT INTO commands (argv, start_time, duration, exit_status, place_id, session_id, hostname)
+		VALUES ('git status', 1700000100, 1, 0, 1, 1, 'localhost');
+		INSERT INTO commands (argv, start_time, duration, exit_status, place_id, session_id, hostname)
+		VALUES ('make build', 1700000200, 5, 1, 1, 1, 'localhost');
+		INSERT INTO commands (argv, start_time, duration, exit_status, place_id, session_id, hostname)
+		VALUES ('docker ps', 1700000300, 1, 0, 2, 2, 'localhost');
+	`)
+	if err != nil {
+		t.Fatalf("Failed to setup test data: %v", err)
+	}
+	db.Close()
+
+	dm := NewDatabaseManager(dbPath, false)
+
+	stats, err := dm.GetStats(QueryOptions{Limit: 1}, 20)
+	if err != nil {
+		t.Fatalf("GetStats() error: %v", err)
+	}
+
+	if stats.TotalCommands != 4 {
+		t.Errorf("TotalCommands = %d, want 4 (Limit should be ignored)", stats.TotalCommands)
+	}
+	if stats.FailedCommands != 1 {
+		t.Errorf("FailedCommands = %d, want 1", stats.FailedCommands)
+	}
+	if stats.FailureRate != 0.25 {
+		t.Errorf("FailureRate = %v, want 0.25", stats.FailureRate)
+	}
+
+	wantCommands := []CountEntry{{"git status", 2}, {"docker ps", 1}, {"make build", 1}}
+	if !reflect.DeepEqual(stats.TopCommands, wantCommands) {
+		t.Errorf("TopCommands = %v, want %v", stats.TopCommands, wantCommands)
+	}
+
+	wantDirs := []CountEntry{{"/home/user/project", 3}, {"/home/user/other", 1}}
+	if !reflect.DeepEqual(stats.TopDirectories, wantDirs) {
+		t.Errorf("TopDirectories = %v, want %v", stats.TopDirectories, wantDirs)
+	}
+
+	wantSessions := []CountEntry{{"FRAAS-123", 3}, {"other", 1}}
+	if !reflect.DeepEqual(stats.TopSessions, wantSessions) {
+		t.Errorf("TopSessions = %v, want %v", stats.TopSessions, wantSessions)
+	}
+
+	// Filters are shared with QueryCommands
+	since := time.Unix(1700000150, 0)
+	stats, err = dm.GetStats(QueryOptions{Since: &since, Directory: "/home/user/project"}, 1)
+	if err != nil {
+		t.Fatalf("GetStats() with filters error: %v", err)
+	}
+	if stats.TotalCommands != 1 || stats.FailedCommands != 1 {
+		t.Errorf("filtered totals = %d/%d, want 1/1", stats.TotalCommands, stats.FailedCommands)
+	}
+	if len(stats.TopCommands) != 1 || stats.TopCommands[0].Name != "make build" {
+		t.Errorf("filtered TopCommands = %v, want [make build]", stats.TopCommands)
+	}
+}
+
+func TestGetStats_Atuin(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE history (
+			id INTEGER PRIMARY KEY,
+			command TEXT,
+			timestamp INTEGER,
+			duration INTEGER,
+			exit INTEGER,
+			cwd TEXT,
+			session TEXT,
+			hostname TEXT
+		);
+		INSERT INTO history (command, timestamp, duration, exit, cwd, session, hostname)
+		VALUES ('ls -la', 1700000000000000000, 50, 0, '/home/user', 'session1', 'localhost');
+		INSERT INTO history (command, timestamp, duration, exit, cwd, session, hostname)
+		VALUES ('ls -la', 1700000100000000000, 100, 2, '/home/user', 'session1', 'localhost');
+	`)
+	if err != nil {
+		t.Fatalf("Failed to setup test data: %v", err)
+	}
+	db.Close()
+
+	stats, err := NewDatabaseManager(dbPath, false).GetStats(QueryOptions{}, 20)
+	if err != nil {
+		t.Fatalf("GetStats() error: %v", err)
+	}
+
+	if stats.TotalCommands != 2 || stats.FailedCommands != 1 {
+		t.Errorf("totals = %d/%d, want 2/1", stats.TotalCommands, stats.FailedCommands)
+	}
+	wantCommands := []CountEntry{{"ls -la", 2}}
+	if !reflect.DeepEqual(stats.TopCommands, wantCommands) {
+		t.Errorf("TopCommands = %v, want %v", stats.TopCommands, wantCommands)
+	}
+}
+
+func TestGetStats_TextHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".bash_history")
+	if err := os.WriteFile(path, []byte("ls\ngit status\nls\n"), 0600); err != nil {
+		t.Fatalf("Failed to write bash history: %v", err)
+	}
+
+	stats, err := NewDatabaseManager(path, false).GetStats(QueryOptions{}, 1)
+	if err != nil {
+		t.Fatalf("GetStats() error: %v", err)
+	}
+
+	if stats.TotalCommands != 3 || stats.FailedCommands != 0 || stats.FailureRate != 0 {
+		t.Errorf("totals = %d/%d/%v, want 3/0/0", stats.TotalCommands, stats.FailedCommands, stats.FailureRate)
+	}
+	wantCommands := []CountEntry{{"ls", 2}}
+	if !reflect.DeepEqual(stats.TopCommands, wantCommands) {
+		t.Errorf("TopCommands = %v, want %v", stats.TopCommands, wantCommands)
+	}
+	if len(stats.TopDirectories) != 0 || len(stats.TopSessions) != 0 {
+		t.Errorf("bash history should have no directories or sessions, got %v / %v", stats.TopDirectories, stats.TopSessions)
+	}
+}
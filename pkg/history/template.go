@@ -5,43 +5,54 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/cockroachdb/errors"
 )
 
-// FormatTimeline generates a markdown timeline from commands
-func FormatTimeline(commands []Command, ticket string) string {
-	var timeline strings.Builder
+// Timeline is ticket activity assembled from history commands, ready to be
+// rendered by a TimelineFormatter
+type Timeline struct {
+	Ticket        string
+	Generated     time.Time
+	TotalCommands int
+	SuccessCount  int
+	TotalDuration int64 // milliseconds
+	Days          []TimelineDay
+}
+
+// TimelineDay holds the commands run on a single day, in query order
+type TimelineDay struct {
+	Date     string // YYYY-MM-DD
+	Commands []Command
+}
+
+// SuccessRate returns the percentage of commands that exited with status 0
+func (t *Timeline) SuccessRate() float64 {
+	if t.TotalCommands == 0 {
+		return 0
+	}
+	return float64(t.SuccessCount) / float64(t.TotalCommands) * 100.0
+}
 
-	// Calculate summary stats
-	totalCommands := len(commands)
-	var successCount int
-	var totalDuration int64
+// BuildTimeline groups commands by day and computes summary statistics
+func BuildTimeline(commands []Command, ticket string) *Timeline {
+	timeline := &Timeline{
+		Ticket:        ticket,
+		Generated:     time.Now(),
+		TotalCommands: len(commands),
+	}
 
 	dayGroups := make(map[string][]Command)
 	for _, cmd := range commands {
 		if cmd.ExitCode == 0 {
-			successCount++
+			timeline.SuccessCount++
 		}
-		totalDuration += cmd.Duration
+		timeline.TotalDuration += cmd.Duration
 
 		day := cmd.Timestamp.Format("2006-01-02")
 		dayGroups[day] = append(dayGroups[day], cmd)
 	}
 
-	successRate := 0.0
-	if totalCommands > 0 {
-		successRate = float64(successCount) / float64(totalCommands) * 100.0
-	}
-
-	// Header and Summary
-	timeline.WriteString(fmt.Sprintf("## Command Timeline - %s\n\n", ticket))
-	timeline.WriteString(fmt.Sprintf("Generated: %s\n\n", time.Now().Format("2006-01-02 15:04:05")))
-
-	timeline.WriteString("### Summary\n")
-	timeline.WriteString(fmt.Sprintf("- **Total Commands:** %d\n", totalCommands))
-	timeline.WriteString(fmt.Sprintf("- **Success Rate:** %.1f%%\n", successRate))
-	timeline.WriteString(fmt.Sprintf("- **Total Duration:** %s\n\n", formatDuration(totalDuration)))
-
-	// Sort days
 	days := make([]string, 0, len(dayGroups))
 	for day := range dayGroups {
 		days = append(days, day)
@@ -49,10 +60,57 @@ func FormatTimeline(commands []Command, ticket string) string {
 	sort.Strings(days)
 
 	for _, day := range days {
-		dayCommands := dayGroups[day]
-		timeline.WriteString(fmt.Sprintf("### %s\n\n", day))
+		timeline.Days = append(timeline.Days, TimelineDay{Date: day, Commands: dayGroups[day]})
+	}
+
+	return timeline
+}
+
+// TimelineFormatter renders a Timeline in a particular output format
+type TimelineFormatter interface {
+	Format(t *Timeline) string
+}
+
+// TimelineFormats lists the names accepted by NewTimelineFormatter
+var TimelineFormats = []string{"text", "mermaid"}
+
+// NewTimelineFormatter returns the formatter registered under name
+func NewTimelineFormatter(name string) (TimelineFormatter, error) {
+	switch name {
+	case "", "text":
+		return MarkdownFormatter{}, nil
+	case "mermaid":
+		return MermaidFormatter{}, nil
+	default:
+		return nil, errors.Newf("unknown timeline format %q: must be one of: %s", name, strings.Join(TimelineFormats, ", "))
+	}
+}
+
+// FormatTimeline generates a markdown timeline from commands
+func FormatTimeline(commands []Command, ticket string) string {
+	return MarkdownFormatter{}.Format(BuildTimeline(commands, ticket))
+}
+
+// MarkdownFormatter renders a timeline as a markdown list of commands per day
+type MarkdownFormatter struct{}
+
+// Format implements TimelineFormatter
+func (MarkdownFormatter) Format(t *Timeline) string {
+	var timeline strings.Builder
+
+	// Header and Summary
+	timeline.WriteString(fmt.Sprintf("## Command Timeline - %s\n\n", t.Ticket))
+	timeline.WriteString(fmt.Sprintf("Generated: %s\n\n", t.Generated.Format("2006-01-02 15:04:05")))
 
-		for _, cmd := range dayCommands {
+	timeline.WriteString("### Summary\n")
+	timeline.WriteString(fmt.Sprintf("- **Total Commands:** %d\n", t.TotalCommands))
+	timeline.WriteString(fmt.Sprintf("- **Success Rate:** %.1f%%\n", t.SuccessRate()))
+	timeline.WriteString(fmt.Sprintf("- **Total Duration:** %s\n\n", formatDuration(t.TotalDuration)))
+
+	for _, day := range t.Days {
+		timeline.WriteString(fmt.Sprintf("### %s\n\n", day.Date))
+
+		for _, cmd := range day.Commands {
 			// Format timestamp
 			timeStr := cmd.Timestamp.Format("15:04:05")
 
@@ -96,6 +154,103 @@ func FormatTimeline(commands []Command, ticket string) string {
 	return timeline.String()
 }
 
+// activityGap is the idle time that splits a day's commands into separate
+// blocks of activity in the Mermaid gantt chart
+const activityGap = 30 * time.Minute
+
+// minActivityBlock is the shortest bar drawn, so single quick commands remain visible
+const minActivityBlock = time.Minute
+
+// MermaidFormatter renders a timeline as a Mermaid gantt chart with one
+// section per day and one bar per block of continuous activity
+type MermaidFormatter struct{}
+
+// Format implements TimelineFormatter
+func (MermaidFormatter) Format(t *Timeline) string {
+	var b strings.Builder
+
+	// Same header as the markdown output so an existing timeline section in
+	// a note is replaced regardless of format
+	fmt.Fprintf(&b, "## Command Timeline - %s\n\n", t.Ticket)
+	b.WriteString("```mermaid\n")
+	b.WriteString("gantt\n")
+	fmt.Fprintf(&b, "    title Command Timeline - %s\n", t.Ticket)
+	b.WriteString("    dateFormat YYYY-MM-DD HH:mm\n")
+	b.WriteString("    axisFormat %H:%M\n")
+
+	for _, day := range t.Days {
+		fmt.Fprintf(&b, "    section %s\n", day.Date)
+		for _, block := range activityBlocks(day.Commands) {
+			status := "done"
+			if block.failed > 0 {
+				status = "crit"
+			}
+
+			label := fmt.Sprintf("%d commands", block.commands)
+			if block.commands == 1 {
+				label = "1 command"
+			}
+			if block.failed > 0 {
+				label += fmt.Sprintf(", %d failed", block.failed)
+			}
+
+			fmt.Fprintf(&b, "    %s :%s, %s, %s\n", label, status,
+				block.start.Format("2006-01-02 15:04"), block.end.Format("2006-01-02 15:04"))
+		}
+	}
+
+	b.WriteString("```\n")
+	return b.String()
+}
+
+// activityBlock is a run of commands without an idle gap of activityGap
+type activityBlock struct {
+	start    time.Time
+	end      time.Time
+	commands int
+	failed   int
+}
+
+// activityBlocks splits commands into blocks of continuous activity
+func activityBlocks(commands []Command) []activityBlock {
+	sorted := make([]Command, len(commands))
+	copy(sorted, commands)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	var blocks []activityBlock
+	for _, cmd := range sorted {
+		end := cmd.Timestamp.Add(time.Duration(cmd.Duration) * time.Millisecond)
+
+		if n := len(blocks); n > 0 && cmd.Timestamp.Sub(blocks[n-1].end) < activityGap {
+			last := &blocks[n-1]
+			if end.After(last.end) {
+				last.end = end
+			}
+			last.commands++
+			if cmd.ExitCode != 0 {
+				last.failed++
+			}
+			continue
+		}
+
+		block := activityBlock{start: cmd.Timestamp, end: end, commands: 1}
+		if cmd.ExitCode != 0 {
+			block.failed = 1
+		}
+		blocks = append(blocks, block)
+	}
+
+	for i := range blocks {
+		if blocks[i].end.Sub(blocks[i].start) < minActivityBlock {
+			blocks[i].end = blocks[i].start.Add(minActivityBlock)
+		}
+	}
+
+	return blocks
+}
+
 func formatDuration(ms int64) string {
 	if ms < 1000 {
 		return fmt.Sprintf("%dms", ms)
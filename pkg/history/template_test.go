@@ -49,3 +49,91 @@ func TestFormatTimeline(t *testing.T) {
 		t.Error("Output missing duration")
 	}
 }
+
+func TestBuildTimeline(t *testing.T) {
+	commands := []Command{
+		{Command: "make build", Timestamp: time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC), Duration: 2000, ExitCode: 0},
+		{Command: "git status", Timestamp: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC), Duration: 100, ExitCode: 0},
+		{Command: "make test", Timestamp: time.Date(2025, 1, 1, 10, 5, 0, 0, time.UTC), Duration: 5000, ExitCode: 1},
+	}
+
+	timeline := BuildTimeline(commands, "PROJ-123")
+
+	if timeline.TotalCommands != 3 {
+		t.Errorf("TotalCommands = %d, want 3", timeline.TotalCommands)
+	}
+	if timeline.SuccessCount != 2 {
+		t.Errorf("SuccessCount = %d, want 2", timeline.SuccessCount)
+	}
+	if timeline.TotalDuration != 7100 {
+		t.Errorf("TotalDuration = %d, want 7100", timeline.TotalDuration)
+	}
+	if len(timeline.Days) != 2 {
+		t.Fatalf("len(Days) = %d, want 2", len(timeline.Days))
+	}
+	if timeline.Days[0].Date != "2025-01-01" || timeline.Days[1].Date != "2025-01-02" {
+		t.Errorf("Days = %s, %s; want chronological order", timeline.Days[0].Date, timeline.Days[1].Date)
+	}
+	if len(timeline.Days[0].Commands) != 2 {
+		t.Errorf("len(Days[0].Commands) = %d, want 2", len(timeline.Days[0].Commands))
+	}
+}
+
+func TestNewTimelineFormatter(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    TimelineFormatter
+		wantErr bool
+	}{
+		{"", MarkdownFormatter{}, false},
+		{"text", MarkdownFormatter{}, false},
+		{"mermaid", MermaidFormatter{}, false},
+		{"html", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTimelineFormatter(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTimelineFormatter(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NewTimelineFormatter(%q) = %T, want %T", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMermaidFormatter(t *testing.T) {
+	commands := []Command{
+		{Command: "git status", Timestamp: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC), Duration: 100, ExitCode: 0},
+		{Command: "make test", Timestamp: time.Date(2025, 1, 1, 10, 5, 0, 0, time.UTC), Duration: 5000, ExitCode: 1},
+		// More than activityGap later: starts a new block
+		{Command: "git push", Timestamp: time.Date(2025, 1, 1, 14, 0, 0, 0, time.UTC), Duration: 1000, ExitCode: 0},
+		{Command: "make lint", Timestamp: time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC), Duration: 90000, ExitCode: 0},
+	}
+
+	output := MermaidFormatter{}.Format(BuildTimeline(commands, "PROJ-123"))
+
+	want := []string{
+		"## Command Timeline - PROJ-123\n",
+		"```mermaid\ngantt\n",
+		"    dateFormat YYYY-MM-DD HH:mm\n",
+		"    section 2025-01-01\n",
+		"    2 commands, 1 failed :crit, 2025-01-01 10:00, 2025-01-01 10:05\n",
+		"    1 command :done, 2025-01-01 14:00, 2025-01-01 14:01\n",
+		"    section 2025-01-02\n",
+		"    1 command :done, 2025-01-02 09:00, 2025-01-02 09:01\n",
+	}
+	for _, w := range want {
+		if !strings.Contains(output, w) {
+			t.Errorf("output missing %q\ngot:\n%s", w, output)
+		}
+	}
+	if !strings.HasSuffix(output, "```\n") {
+		t.Errorf("output should end with closing fence, got:\n%s", output)
+	}
+	if strings.Contains(output, "git status") {
+		t.Error("mermaid output should not include raw commands")
+	}
+}
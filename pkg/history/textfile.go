@@ -0,0 +1,155 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// sqliteHeader is the magic string at the start of every SQLite database file
+const sqliteHeader = "SQLite format 3\x00"
+
+// detectFileSchema inspects the history file to see whether it is a plain-text
+// shell history rather than a SQLite database. It returns SchemaUnknown for
+// SQLite files (and anything unrecognized) so the SQL detection can take over.
+func (dm *DatabaseManager) detectFileSchema() DatabaseSchema {
+	f, err := os.Open(dm.DatabasePath)
+	if err != nil {
+		return SchemaUnknown
+	}
+	defer f.Close()
+
+	head := make([]byte, 4096)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return SchemaUnknown
+	}
+	head = head[:n]
+
+	if bytes.HasPrefix(head, []byte(sqliteHeader)) {
+		return SchemaUnknown
+	}
+
+	name := filepath.Base(dm.DatabasePath)
+	if name == "fish_history" {
+		return SchemaFish
+	}
+	if strings.HasSuffix(name, "bash_history") {
+		return SchemaBash
+	}
+
+	// Fall back to sniffing the first non-empty line
+	scanner := bufio.NewScanner(bytes.NewReader(head))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "- cmd:") {
+			return SchemaFish
+		}
+		if bashTimestampPattern.MatchString(line) {
+			return SchemaBash
+		}
+		break
+	}
+
+	return SchemaUnknown
+}
+
+// queryTextHistory loads a plain-text history file and applies the query options
+func (dm *DatabaseManager) queryTextHistory(schema DatabaseSchema, options QueryOptions) ([]Command, error) {
+	var commands []Command
+	var err error
+
+	switch schema {
+	case SchemaFish:
+		commands, err = parseFishHistory(dm.DatabasePath)
+	case SchemaBash:
+		commands, err = parseBashHistory(dm.DatabasePath)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return filterCommands(commands, options), nil
+}
+
+// filterCommands applies query options in memory for history sources that
+// aren't backed by SQL. Filters on fields the source doesn't record (such as
+// directory for fish and bash) never match, mirroring an SQL comparison
+// against an empty column. Duration and exit status are recorded as zero, so
+// those filters degrade to "no duration" and "succeeded" instead of erroring.
+func filterCommands(commands []Command, options QueryOptions) []Command {
+	var result []Command
+
+	for _, cmd := range commands {
+		if options.Since != nil && cmd.Timestamp.Before(*options.Since) {
+			continue
+		}
+		if options.Until != nil && cmd.Timestamp.After(*options.Until) {
+			continue
+		}
+		if options.Directory != "" && !strings.HasPrefix(cmd.Directory, options.Directory) {
+			continue
+		}
+		if options.Session != "" && !containsFold(cmd.Session, options.Session) {
+			continue
+		}
+		if options.SessionID != "" && cmd.Session != options.SessionID {
+			continue
+		}
+		if options.ExitCode != nil && cmd.ExitCode != *options.ExitCode {
+			continue
+		}
+		if options.MinDuration > 0 && cmd.Duration < options.MinDuration.Milliseconds() {
+			continue
+		}
+		if options.Pattern != "" && !containsFold(cmd.Command, options.Pattern) {
+			continue
+		}
+		if options.Regex != nil && !options.Regex.MatchString(cmd.Command) {
+			continue
+		}
+		if !matchesTicketOrProject(cmd, options) {
+			continue
+		}
+
+		result = append(result, cmd)
+		if options.Limit > 0 && len(result) >= options.Limit {
+			break
+		}
+	}
+
+	return result
+}
+
+// matchesTicketOrProject applies the OR'd ticket/project path filter
+func matchesTicketOrProject(cmd Command, options QueryOptions) bool {
+	ticket := strings.TrimSpace(options.Ticket)
+	if ticket == "" && len(options.ProjectPaths) == 0 {
+		return true
+	}
+
+	if ticket != "" && (containsFold(cmd.Session, ticket) || containsFold(cmd.Command, ticket)) {
+		return true
+	}
+
+	for _, path := range options.ProjectPaths {
+		if cmd.Directory != "" && strings.HasPrefix(cmd.Directory, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsFold reports whether substr is within s, ignoring case like SQL LIKE
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
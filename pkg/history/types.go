@@ -1,17 +1,20 @@
 package history
 
-import "time"
+import (
+	"regexp"
+	"time"
+)
 
 // Command represents a command from the history database
 type Command struct {
-	ID        int64
-	Command   string
-	Timestamp time.Time
-	Duration  int64 // milliseconds
-	ExitCode  int
-	Directory string
-	Session   string
-	Host      string
+	ID        int64     `json:"-"`
+	Command   string    `json:"command"`
+	Timestamp time.Time `json:"start_time"`
+	Duration  int64     `json:"duration_ms"` // milliseconds
+	ExitCode  int       `json:"exit_status"`
+	Directory string    `json:"directory"`
+	Session   string    `json:"session"`
+	Host      string    `json:"host,omitempty"`
 }
 
 // QueryOptions defines filtering options for history queries
@@ -27,4 +30,5 @@ type QueryOptions struct {
 	MinDuration  time.Duration // Minimum duration filter
 	Limit        int
 	Pattern      string
+	Regex        *regexp.Regexp // Matched against the command in Go; Limit applies after matching
 }
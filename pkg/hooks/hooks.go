@@ -0,0 +1,105 @@
+// Package hooks runs the user's shell commands around rig operations.
+//
+// Hooks are configured under [hooks] as plain shell commands, for example
+// post_work = "code $RIG_WORKTREE". They are a lightweight way to customize
+// rig without writing a plugin.
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"thoreinstein.com/rig/pkg/config"
+)
+
+// Env describes the operation a hook runs for. Each field is exported to the
+// hook as an environment variable.
+type Env struct {
+	Ticket   string // RIG_TICKET
+	Worktree string // RIG_WORKTREE
+	GitRoot  string // RIG_GIT_ROOT
+}
+
+// Runner runs hook commands
+type Runner struct {
+	Timeout     time.Duration // Per-hook deadline (0 means none)
+	FailOnError bool          // Return hook failures instead of warning
+	Stdout      io.Writer
+	Stderr      io.Writer
+}
+
+// NewRunner creates a Runner from the hooks configuration. Hook output goes
+// to the terminal.
+func NewRunner(cfg *config.HooksConfig) *Runner {
+	return &Runner{
+		Timeout:     cfg.Timeout,
+		FailOnError: cfg.FailOnError,
+		Stdout:      os.Stdout,
+		Stderr:      os.Stderr,
+	}
+}
+
+// Run runs command as the hook called name. An empty command does nothing.
+// The hook runs in the worktree when it exists, otherwise in the git root.
+// A failed or timed out hook is returned as an error when FailOnError is set
+// and reported as a warning on Stderr otherwise.
+func (r *Runner) Run(ctx context.Context, name, command string, env Env) error {
+	if command == "" {
+		return nil
+	}
+
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = hookDir(env)
+	cmd.Env = append(os.Environ(),
+		"RIG_HOOK="+name,
+		"RIG_TICKET="+env.Ticket,
+		"RIG_WORKTREE="+env.Worktree,
+		"RIG_GIT_ROOT="+env.GitRoot,
+	)
+	cmd.Stdout = r.Stdout
+	cmd.Stderr = r.Stderr
+	// Don't wait forever on output from processes the hook left running
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		err = errors.Newf("timed out after %s", r.Timeout)
+	}
+	if err == nil {
+		return nil
+	}
+
+	err = errors.Wrapf(err, "hook %s failed", name)
+	if r.FailOnError {
+		return err
+	}
+	if r.Stderr != nil {
+		fmt.Fprintf(r.Stderr, "Warning: %v\n", err)
+	}
+	return nil
+}
+
+// hookDir picks the working directory for a hook
+func hookDir(env Env) string {
+	for _, dir := range []string{env.Worktree, env.GitRoot} {
+		if dir == "" {
+			continue
+		}
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun_Env(t *testing.T) {
+	worktree := t.TempDir()
+	var stdout bytes.Buffer
+	r := &Runner{Stdout: &stdout, Stderr: &stdout}
+
+	env := Env{Ticket: "proj-123", Worktree: worktree, GitRoot: filepath.Dir(worktree)}
+	err := r.Run(context.Background(), "post_work", `echo "$RIG_HOOK $RIG_TICKET $RIG_WORKTREE $RIG_GIT_ROOT"; pwd`, env)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	want := "post_work proj-123 " + worktree + " " + filepath.Dir(worktree)
+	if len(lines) != 2 || lines[0] != want {
+		t.Fatalf("hook output = %q, want first line %q", stdout.String(), want)
+	}
+	if got, _ := filepath.EvalSymlinks(lines[1]); got != mustEvalSymlinks(t, worktree) {
+		t.Errorf("hook ran in %q, want the worktree %q", lines[1], worktree)
+	}
+}
+
+func TestRun_Empty(t *testing.T) {
+	r := &Runner{FailOnError: true}
+	if err := r.Run(context.Background(), "pre_work", "", Env{}); err != nil {
+		t.Errorf("Run() with no command error = %v", err)
+	}
+}
+
+func TestRun_Failure(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     string
+		timeout     time.Duration
+		failOnError bool
+		wantErr     string
+		wantWarning string
+	}{
+		{
+			name:        "non-zero exit warns",
+			command:     "exit 3",
+			wantWarning: "Warning: hook pre_work failed: exit status 3",
+		},
+		{
+			name:        "non-zero exit fails when configured",
+			command:     "exit 3",
+			failOnError: true,
+			wantErr:     "hook pre_work failed: exit status 3",
+		},
+		{
+			name:        "timeout",
+			command:     "sleep 5",
+			timeout:     50 * time.Millisecond,
+			failOnError: true,
+			wantErr:     "timed out after 50ms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			r := &Runner{Timeout: tt.timeout, FailOnError: tt.failOnError, Stderr: &stderr}
+
+			err := r.Run(context.Background(), "pre_work", tt.command, Env{})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Run() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() error = %v, want nil", err)
+			}
+			if !strings.Contains(stderr.String(), tt.wantWarning) {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.wantWarning)
+			}
+		})
+	}
+}
+
+func mustEvalSymlinks(t *testing.T, path string) string {
+	t.Helper()
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resolved
+}
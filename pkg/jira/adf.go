@@ -0,0 +1,235 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// extractADFMarkdown converts an ADF document to Markdown, preserving lists,
+// code blocks, headings, and inline formatting such as links.
+// Use extractADFText when plain text is preferred.
+func extractADFMarkdown(doc *jiraADFDocument) string {
+	if doc == nil {
+		return ""
+	}
+
+	return renderADFBlocks(doc.Content, "")
+}
+
+// renderADFBlocks renders a sequence of block nodes separated by blank lines.
+// Blocks that render as whitespace only are skipped.
+// indent is prepended to every line so nested lists line up under their parent.
+func renderADFBlocks(nodes []jiraADFContent, indent string) string {
+	var blocks []string
+	for i := range nodes {
+		block := renderADFBlock(&nodes[i], indent)
+		if strings.TrimSpace(block) != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// renderADFBlock renders a single ADF block node as Markdown.
+func renderADFBlock(node *jiraADFContent, indent string) string {
+	switch node.Type {
+	case "paragraph":
+		return indentLines(renderADFInline(node.Content), indent)
+	case "heading":
+		level := adfIntAttr(node.Attrs, "level", 1)
+		if level < 1 || level > 6 {
+			level = 1
+		}
+		return indent + strings.Repeat("#", level) + " " + renderADFInline(node.Content)
+	case "bulletList":
+		return renderADFList(node, indent, false)
+	case "orderedList":
+		return renderADFList(node, indent, true)
+	case "codeBlock":
+		language, _ := node.Attrs["language"].(string)
+		var code strings.Builder
+		for _, child := range node.Content {
+			code.WriteString(child.Text)
+		}
+		return indentLines("```"+language+"\n"+code.String()+"\n```", indent)
+	case "table":
+		return indentLines(renderADFTable(node), indent)
+	case "blockquote":
+		inner := renderADFBlocks(node.Content, "")
+		return indentLines(prefixLines(inner, "> "), indent)
+	case "rule":
+		return indent + "---"
+	case "text", "hardBreak", "mention", "emoji", "inlineCard":
+		return indentLines(renderADFInline([]jiraADFContent{*node}), indent)
+	default:
+		return renderADFBlocks(node.Content, indent)
+	}
+}
+
+// renderADFList renders bullet and ordered lists, including nested lists.
+func renderADFList(node *jiraADFContent, indent string, ordered bool) string {
+	start := 1
+	if ordered {
+		start = adfIntAttr(node.Attrs, "order", 1)
+	}
+
+	var lines []string
+	for i, item := range node.Content {
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", start+i)
+		}
+		childIndent := indent + strings.Repeat(" ", len(marker))
+
+		var parts []string
+		for j := range item.Content {
+			if rendered := renderADFBlock(&item.Content[j], childIndent); rendered != "" {
+				parts = append(parts, rendered)
+			}
+		}
+
+		// Swap the first line's indentation for the list marker
+		body := strings.Join(parts, "\n")
+		if body == "" {
+			lines = append(lines, indent+strings.TrimSpace(marker))
+			continue
+		}
+		lines = append(lines, indent+marker+strings.TrimPrefix(body, childIndent))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderADFTable renders a table as a Markdown pipe table.
+// The first row becomes the header; Markdown has no headerless tables.
+func renderADFTable(node *jiraADFContent) string {
+	var rows [][]string
+	columns := 0
+	for _, row := range node.Content {
+		var cells []string
+		for j := range row.Content {
+			cells = append(cells, renderADFTableCell(&row.Content[j]))
+		}
+		if len(cells) == 0 {
+			continue
+		}
+		columns = max(columns, len(cells))
+		rows = append(rows, cells)
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+
+	var lines []string
+	for i, cells := range rows {
+		// Pad short rows so every line has the same number of columns
+		for len(cells) < columns {
+			cells = append(cells, "")
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderADFTableCell renders a table cell on a single line.
+// Line breaks become <br> and pipes are escaped so nested content, including
+// nested tables, cannot break the surrounding table.
+func renderADFTableCell(cell *jiraADFContent) string {
+	text := strings.TrimSpace(renderADFBlocks(cell.Content, ""))
+	text = strings.ReplaceAll(text, "|", "\\|")
+	text = strings.ReplaceAll(text, "\n\n", "<br>")
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
+// renderADFInline renders inline nodes (text with marks, breaks, mentions).
+func renderADFInline(nodes []jiraADFContent) string {
+	var sb strings.Builder
+	for _, node := range nodes {
+		switch node.Type {
+		case "text":
+			sb.WriteString(applyADFMarks(node.Text, node.Marks))
+		case "hardBreak":
+			sb.WriteString("\n")
+		case "mention":
+			if text, ok := node.Attrs["text"].(string); ok {
+				sb.WriteString(text)
+			}
+		case "emoji":
+			if text, ok := node.Attrs["text"].(string); ok {
+				sb.WriteString(text)
+			} else if shortName, ok := node.Attrs["shortName"].(string); ok {
+				sb.WriteString(shortName)
+			}
+		case "inlineCard":
+			if url, ok := node.Attrs["url"].(string); ok {
+				sb.WriteString("<" + url + ">")
+			}
+		default:
+			sb.WriteString(renderADFInline(node.Content))
+		}
+	}
+	return sb.String()
+}
+
+// applyADFMarks wraps text in the Markdown syntax for each ADF mark.
+func applyADFMarks(text string, marks []jiraADFMark) string {
+	if text == "" {
+		return ""
+	}
+
+	var href string
+	for _, mark := range marks {
+		switch mark.Type {
+		case "code":
+			text = "`" + text + "`"
+		case "strong":
+			text = "**" + text + "**"
+		case "em":
+			text = "*" + text + "*"
+		case "strike":
+			text = "~~" + text + "~~"
+		case "link":
+			href, _ = mark.Attrs["href"].(string)
+		}
+	}
+
+	// Apply the link last so it wraps any other formatting
+	if href != "" {
+		text = "[" + text + "](" + href + ")"
+	}
+
+	return text
+}
+
+// adfIntAttr reads an integer attribute, which JSON decodes as float64.
+func adfIntAttr(attrs map[string]interface{}, key string, fallback int) int {
+	switch v := attrs[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return fallback
+	}
+}
+
+// indentLines prepends indent to every line of text.
+func indentLines(text, indent string) string {
+	if indent == "" || text == "" {
+		return text
+	}
+	return prefixLines(text, indent)
+}
+
+// prefixLines prepends prefix to every line of text.
+func prefixLines(text, prefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,314 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExtractADFMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		adf  string
+		want string
+	}{
+		{
+			name: "paragraphs",
+			adf: `{"type":"doc","content":[
+				{"type":"paragraph","content":[{"type":"text","text":"First"}]},
+				{"type":"paragraph","content":[{"type":"text","text":"Second"}]}
+			]}`,
+			want: "First\n\nSecond",
+		},
+		{
+			name: "whitespace-only document",
+			adf: `{"type":"doc","content":[
+				{"type":"paragraph","content":[]},
+				{"type":"paragraph","content":[{"type":"hardBreak"},{"type":"text","text":"  "}]},
+				{"type":"paragraph","content":[{"type":"text","text":"\t"}]}
+			]}`,
+			want: "",
+		},
+		{
+			name: "empty paragraphs between text dropped",
+			adf: `{"type":"doc","content":[
+				{"type":"paragraph","content":[{"type":"text","text":"First"}]},
+				{"type":"paragraph","content":[{"type":"hardBreak"}]},
+				{"type":"paragraph","content":[{"type":"text","text":"Second"}]}
+			]}`,
+			want: "First\n\nSecond",
+		},
+		{
+			name: "heading",
+			adf: `{"type":"doc","content":[
+				{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Steps"}]}
+			]}`,
+			want: "## Steps",
+		},
+		{
+			name: "bullet list",
+			adf: `{"type":"doc","content":[
+				{"type":"bulletList","content":[
+					{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"one"}]}]},
+					{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"two"}]}]}
+				]}
+			]}`,
+			want: "- one\n- two",
+		},
+		{
+			name: "ordered list",
+			adf: `{"type":"doc","content":[
+				{"type":"orderedList","content":[
+					{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"first"}]}]},
+					{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"second"}]}]}
+				]}
+			]}`,
+			want: "1. first\n2. second",
+		},
+		{
+			name: "nested list",
+			adf: `{"type":"doc","content":[
+				{"type":"bulletList","content":[
+					{"type":"listItem","content":[
+						{"type":"paragraph","content":[{"type":"text","text":"parent"}]},
+						{"type":"bulletList","content":[
+							{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"child"}]}]}
+						]}
+					]}
+				]}
+			]}`,
+			want: "- parent\n  - child",
+		},
+		{
+			name: "code block",
+			adf: `{"type":"doc","content":[
+				{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"fmt.Println(\"hi\")"}]}
+			]}`,
+			want: "```go\nfmt.Println(\"hi\")\n```",
+		},
+		{
+			name: "link mark",
+			adf: `{"type":"doc","content":[
+				{"type":"paragraph","content":[
+					{"type":"text","text":"See "},
+					{"type":"text","text":"the runbook","marks":[{"type":"link","attrs":{"href":"https://example.com/runbook"}}]}
+				]}
+			]}`,
+			want: "See [the runbook](https://example.com/runbook)",
+		},
+		{
+			name: "inline formatting",
+			adf: `{"type":"doc","content":[
+				{"type":"paragraph","content":[
+					{"type":"text","text":"bold","marks":[{"type":"strong"}]},
+					{"type":"text","text":" and "},
+					{"type":"text","text":"code","marks":[{"type":"code"}]}
+				]}
+			]}`,
+			want: "**bold** and `code`",
+		},
+		{
+			name: "blockquote",
+			adf: `{"type":"doc","content":[
+				{"type":"blockquote","content":[{"type":"paragraph","content":[{"type":"text","text":"quoted"}]}]}
+			]}`,
+			want: "> quoted",
+		},
+		{
+			name: "table",
+			adf: `{"type":"doc","content":[
+				{"type":"table","content":[
+					{"type":"tableRow","content":[
+						{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"Step"}]}]},
+						{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"Command"}]}]}
+					]},
+					{"type":"tableRow","content":[
+						{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"1"}]}]},
+						{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"make build","marks":[{"type":"code"}]}]}]}
+					]},
+					{"type":"tableRow","content":[
+						{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"2"}]}]},
+						{"type":"tableCell","content":[]}
+					]}
+				]}
+			]}`,
+			want: "| Step | Command |\n| --- | --- |\n| 1 | `make build` |\n| 2 |  |",
+		},
+		{
+			name: "nested table",
+			adf: `{"type":"doc","content":[
+				{"type":"table","content":[
+					{"type":"tableRow","content":[
+						{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"Host"}]}]},
+						{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"Ports"}]}]}
+					]},
+					{"type":"tableRow","content":[
+						{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"web"}]}]},
+						{"type":"tableCell","content":[
+							{"type":"table","content":[
+								{"type":"tableRow","content":[
+									{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"80"}]}]},
+									{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"443"}]}]}
+								]}
+							]}
+						]}
+					]}
+				]}
+			]}`,
+			want: "| Host | Ports |\n| --- | --- |\n| web | \\| 80 \\| 443 \\|<br>\\| --- \\| --- \\| |",
+		},
+		{
+			name: "unknown node recurses into children",
+			adf: `{"type":"doc","content":[
+				{"type":"panel","attrs":{"panelType":"info"},"content":[
+					{"type":"paragraph","content":[{"type":"text","text":"inside panel"}]}
+				]}
+			]}`,
+			want: "inside panel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc jiraADFDocument
+			if err := json.Unmarshal([]byte(tt.adf), &doc); err != nil {
+				t.Fatalf("failed to parse test ADF: %v", err)
+			}
+
+			got := extractADFMarkdown(&doc)
+			if got != tt.want {
+				t.Errorf("extractADFMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractADFText_Blocks(t *testing.T) {
+	tests := []struct {
+		name string
+		adf  string
+		want string
+	}{
+		{
+			name: "code block",
+			adf: `{"type":"doc","content":[
+				{"type":"paragraph","content":[{"type":"text","text":"Run:"}]},
+				{"type":"codeBlock","attrs":{"language":"sh"},"content":[{"type":"text","text":"make build\nmake test"}]}
+			]}`,
+			want: "Run:\n```sh\nmake build\nmake test\n```",
+		},
+		{
+			name: "table",
+			adf: `{"type":"doc","content":[
+				{"type":"table","content":[
+					{"type":"tableRow","content":[
+						{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"Step"}]}]},
+						{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"Command"}]}]}
+					]},
+					{"type":"tableRow","content":[
+						{"type":"tableCell","content":[]},
+						{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"make build"}]}]}
+					]}
+				]}
+			]}`,
+			want: "Step\tCommand\n\tmake build",
+		},
+		{
+			name: "nested table",
+			adf: `{"type":"doc","content":[
+				{"type":"table","content":[
+					{"type":"tableRow","content":[
+						{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"web"}]}]},
+						{"type":"tableCell","content":[
+							{"type":"table","content":[
+								{"type":"tableRow","content":[
+									{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"80"}]}]}
+								]},
+								{"type":"tableRow","content":[
+									{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"443"}]}]}
+								]}
+							]}
+						]}
+					]}
+				]}
+			]}`,
+			want: "web\t80 443",
+		},
+		{
+			name: "link mark",
+			adf: `{"type":"doc","content":[
+				{"type":"paragraph","content":[
+					{"type":"text","text":"See "},
+					{"type":"text","text":"the dashboard","marks":[{"type":"strong"},{"type":"link","attrs":{"href":"https://example.com/d/1"}}]},
+					{"type":"text","text":" for details"}
+				]}
+			]}`,
+			want: "See [the dashboard](https://example.com/d/1) for details",
+		},
+		{
+			name: "link mark without href",
+			adf: `{"type":"doc","content":[
+				{"type":"paragraph","content":[{"type":"text","text":"dangling","marks":[{"type":"link","attrs":{}}]}]}
+			]}`,
+			want: "dangling",
+		},
+		{
+			name: "unknown node recurses into children",
+			adf: `{"type":"doc","content":[
+				{"type":"panel","content":[{"type":"paragraph","content":[{"type":"text","text":"inside panel"}]}]}
+			]}`,
+			want: "inside panel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc jiraADFDocument
+			if err := json.Unmarshal([]byte(tt.adf), &doc); err != nil {
+				t.Fatalf("failed to parse test ADF: %v", err)
+			}
+
+			got := extractADFText(&doc)
+			if got != tt.want {
+				t.Errorf("extractADFText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractADFMarkdown_NilDocument(t *testing.T) {
+	if got := extractADFMarkdown(nil); got != "" {
+		t.Errorf("extractADFMarkdown(nil) = %q, want empty", got)
+	}
+}
+
+func TestParseResponse_DescriptionFormat(t *testing.T) {
+	body := []byte(`{"fields":{"summary":"s","description":{"type":"doc","content":[
+		{"type":"bulletList","content":[
+			{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"one"}]}]},
+			{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"two"}]}]}
+		]}
+	]}}}`)
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "", want: "one\ntwo"},
+		{format: "text", want: "one\ntwo"},
+		{format: "markdown", want: "- one\n- two"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			client := &APIClient{descriptionFormat: tt.format}
+
+			info, err := client.parseResponse(body)
+			if err != nil {
+				t.Fatalf("parseResponse() error = %v", err)
+			}
+			if info.Description != tt.want {
+				t.Errorf("Description = %q, want %q", info.Description, tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,11 @@
 package jira
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
-	"math"
-	"math/rand/v2"
 	"net/http"
 	"os"
 	"strconv"
@@ -14,36 +13,45 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/zalando/go-keyring"
 
 	"thoreinstein.com/rig/pkg/config"
+	rlog "thoreinstein.com/rig/pkg/log"
+	"thoreinstein.com/rig/pkg/retry"
 )
 
-// Rate limit retry configuration
-const (
-	maxRetries   = 3
-	baseDelay    = time.Second
-	maxDelay     = 30 * time.Second
-	jitterFactor = 0.4 // jitterFactor = 0.4 produces a multiplier range of [0.8, 1.2], which is ±20% variation around 1.0
-)
+// defaultTimeout bounds each request when neither jira.timeout nor
+// network.timeout is configured
+const defaultTimeout = 30 * time.Second
 
-// Compile-time interface check
-var _ JiraClient = (*APIClient)(nil)
+// Compile-time interface checks
+var (
+	_ JiraClient    = (*APIClient)(nil)
+	_ ContextClient = (*APIClient)(nil)
+	_ Assigner      = (*APIClient)(nil)
+)
 
 // APIClient implements JiraClient using Jira Cloud REST API v3
 type APIClient struct {
-	baseURL      string
-	email        string
-	token        string
-	customFields map[string]string
-	httpClient   *http.Client
-	verbose      bool
+	baseURL           string
+	email             string
+	token             string
+	customFields      map[string]string
+	epicLinkField     string
+	descriptionFormat string
+	httpClient        *http.Client
 }
 
 // NewAPIClient creates a new API-based Jira client.
-// Token lookup precedence: JIRA_TOKEN env var > config token.
-func NewAPIClient(cfg *config.JiraConfig, verbose bool) (*APIClient, error) {
+// Token lookup precedence: JIRA_TOKEN env var > keychain > config token.
+// Diagnostics are logged at debug level; the verbose argument is kept for
+// symmetry with NewCLIClient and is otherwise unused.
+func NewAPIClient(cfg *config.JiraConfig, _ bool) (*APIClient, error) {
 	// Token from env var takes precedence
 	token := os.Getenv("JIRA_TOKEN")
+	if token == "" && cfg.TokenKeychainService != "" {
+		token = keychainToken(cfg.TokenKeychainService, cfg.Email)
+	}
 	if token == "" {
 		token = cfg.Token
 	}
@@ -58,116 +66,126 @@ func NewAPIClient(cfg *config.JiraConfig, verbose bool) (*APIClient, error) {
 		return nil, errors.New("jira token is required (set JIRA_TOKEN env var or config)")
 	}
 
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
 	return &APIClient{
-		baseURL:      strings.TrimSuffix(cfg.BaseURL, "/"),
-		email:        cfg.Email,
-		token:        token,
-		customFields: cfg.CustomFields,
-		httpClient:   &http.Client{Timeout: 30 * time.Second},
-		verbose:      verbose,
+		baseURL:           strings.TrimSuffix(cfg.BaseURL, "/"),
+		email:             cfg.Email,
+		token:             token,
+		customFields:      cfg.CustomFields,
+		epicLinkField:     cfg.EpicLinkField,
+		descriptionFormat: cfg.DescriptionFormat,
+		httpClient:        &http.Client{Timeout: timeout},
 	}, nil
 }
 
+// keychainToken reads the Jira token from the OS keychain (macOS Keychain,
+// Secret Service on Linux, Credential Manager on Windows). The item is looked
+// up by service name with the Jira email as the account. Any failure returns
+// an empty token so lookup falls through to the config value.
+func keychainToken(service, account string) string {
+	token, err := keyring.Get(service, account)
+	if err != nil {
+		if !errors.Is(err, keyring.ErrNotFound) {
+			rlog.Debug("could not read Jira token from keychain", "service", service, "error", err)
+		}
+		return ""
+	}
+	return token
+}
+
 // IsAvailable checks if the API client is configured and ready to use.
 func (c *APIClient) IsAvailable() bool {
 	return c.baseURL != "" && c.email != "" && c.token != ""
 }
 
-// calculateBackoff computes the delay for a retry attempt using exponential backoff with jitter.
-// Formula: delay = min(initial * 2^attempt, max) * (0.8 + 0.4*rand())
-func calculateBackoff(base, max time.Duration, attempt int) time.Duration {
-	// Calculate exponential delay: base * 2^attempt
-	expDelay := float64(base) * math.Pow(2, float64(attempt))
+// maxUnsafeRetries caps retries of non-idempotent requests (e.g., POST) when the
+// server response does not guarantee the request went unprocessed.
+const maxUnsafeRetries = 1
 
-	// Cap at max delay
-	if expDelay > float64(max) {
-		expDelay = float64(max)
+// isRetryableStatus reports whether an HTTP status is worth retrying.
+// 429 signals rate limiting; 502/503/504 are transient gateway or maintenance errors.
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
 	}
-
-	// Apply jitter: multiply by (0.8 + 0.4*rand()) which gives range [0.8, 1.2]
-	// This is ±20% variation around the base value
-	jitter := 0.8 + jitterFactor*rand.Float64()
-	delay := time.Duration(expDelay * jitter)
-
-	return delay
 }
 
-// parseRetryAfter extracts the delay from a Retry-After header.
-// Returns the duration if present and valid, otherwise returns 0.
-func parseRetryAfter(header string) time.Duration {
-	if header == "" {
-		return 0
-	}
-
-	// Try parsing as seconds (integer)
-	if seconds, err := strconv.Atoi(header); err == nil {
-		return time.Duration(seconds) * time.Second
+// isSafeToRetry reports whether a retryable status guarantees the server did not
+// act on the request. Rate limiting (429) and service unavailable (503) are
+// rejected before reaching the handler, so even non-idempotent requests may retry.
+func isSafeToRetry(method string, statusCode int) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
 	}
-
-	// Try parsing as HTTP date (RFC1123)
-	if t, err := time.Parse(time.RFC1123, header); err == nil {
-		delay := time.Until(t)
-		if delay > 0 {
-			return delay
-		}
-	}
-
-	return 0
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
 }
 
-// doRequestWithRetry executes an HTTP request with retry logic for rate limiting.
+// doRequestWithRetry executes an HTTP request with retry logic for rate limiting
+// and transient server errors (502, 503, 504).
 // It implements exponential backoff with jitter and respects Retry-After headers.
+// Cancelling the request's context stops the wait between attempts.
+// Non-idempotent requests are retried at most maxUnsafeRetries times on 502/504.
 func (c *APIClient) doRequestWithRetry(req *http.Request) (*http.Response, error) {
-	var lastErr error
+	policy := retry.DefaultPolicy()
+	policy.OnRetry = func(attempt int, delay time.Duration, err error) {
+		rlog.Debug("retrying Jira request", "error", err,
+			"delay", delay.Round(time.Millisecond), "attempt", attempt+1, "max_attempts", policy.MaxRetries)
+	}
 
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		// Clone the request for retry (body has already been read)
-		// Since we're doing GET requests, we don't need to worry about body cloning
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to execute request")
+	unsafeRetries := 0
+	resp, err := retry.RetryableHTTP(c.httpClient, req, policy, func(resp *http.Response) bool {
+		if !isRetryableStatus(resp.StatusCode) {
+			return false
 		}
-
-		// If not rate limited, return the response
-		if resp.StatusCode != http.StatusTooManyRequests {
-			return resp, nil
+		if isSafeToRetry(req.Method, resp.StatusCode) {
+			return true
 		}
-
-		// Close the response body before retry
-		resp.Body.Close()
-
-		// Check if we've exhausted retries
-		if attempt == maxRetries {
-			lastErr = errors.Newf("rate limited after %d retries", maxRetries)
-			break
-		}
-
-		// Calculate delay, preferring Retry-After header if present
-		delay := parseRetryAfter(resp.Header.Get("Retry-After"))
-		if delay == 0 {
-			delay = calculateBackoff(baseDelay, maxDelay, attempt)
+		if unsafeRetries >= maxUnsafeRetries {
+			return false
 		}
-
-		if c.verbose {
-			fmt.Printf("Rate limited (HTTP 429), retrying in %v (attempt %d/%d)...\n",
-				delay.Round(time.Millisecond), attempt+1, maxRetries)
+		unsafeRetries++
+		return true
+	})
+	if err != nil {
+		if ctxErr := req.Context().Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+			return nil, errors.Wrap(err, "jira request cancelled")
 		}
+		return nil, errors.Wrap(err, "failed to execute request")
+	}
 
-		time.Sleep(delay)
+	// 429 is always safe to retry, so it only comes back once retries are exhausted
+	if resp.StatusCode == http.StatusTooManyRequests {
+		resp.Body.Close()
+		return nil, errors.Newf("rate limited after %d retries", policy.MaxRetries)
 	}
 
-	return nil, lastErr
+	// Anything else, including a server error that outlasted the retries, is
+	// reported by the caller from the response
+	return resp, nil
 }
 
 // FetchTicketDetails retrieves ticket information from Jira using the REST API v3.
 func (c *APIClient) FetchTicketDetails(ticket string) (*TicketInfo, error) {
+	return c.FetchTicketDetailsContext(context.Background(), ticket)
+}
+
+// FetchTicketDetailsContext is like FetchTicketDetails but binds the request to ctx.
+func (c *APIClient) FetchTicketDetailsContext(ctx context.Context, ticket string) (*TicketInfo, error) {
 	if !c.IsAvailable() {
 		return nil, errors.New("jira API client is not configured")
 	}
 
 	url := fmt.Sprintf("%s/rest/api/3/issue/%s", c.baseURL, ticket)
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create request")
 	}
@@ -177,9 +195,7 @@ func (c *APIClient) FetchTicketDetails(ticket string) (*TicketInfo, error) {
 	req.Header.Set("Authorization", "Basic "+auth)
 	req.Header.Set("Accept", "application/json")
 
-	if c.verbose {
-		fmt.Printf("Fetching Jira ticket: %s\n", url)
-	}
+	rlog.Debug("fetching Jira ticket", "url", url)
 
 	resp, err := c.doRequestWithRetry(req)
 	if err != nil {
@@ -233,6 +249,24 @@ type jiraIssueResponse struct {
 		Status      *jiraNameField   `json:"status"`
 		Priority    *jiraNameField   `json:"priority"`
 		Description *jiraADFDocument `json:"description"`
+		Labels      []string         `json:"labels"`
+		Components  []jiraNameField  `json:"components"`
+		Parent      *jiraParentField `json:"parent"`
+		Assignee    *jiraUserField   `json:"assignee"`
+		Reporter    *jiraUserField   `json:"reporter"`
+	} `json:"fields"`
+}
+
+// jiraUserField represents a Jira user reference such as the assignee.
+type jiraUserField struct {
+	DisplayName string `json:"displayName"`
+}
+
+// jiraParentField represents the parent issue reference on a Jira issue.
+type jiraParentField struct {
+	Key    string `json:"key"`
+	Fields struct {
+		IssueType *jiraNameField `json:"issuetype"`
 	} `json:"fields"`
 }
 
@@ -245,14 +279,23 @@ type jiraNameField struct {
 // ADF is a nested JSON structure used by Jira Cloud API v3 for rich text fields.
 type jiraADFDocument struct {
 	Type    string           `json:"type"`
+	Version int              `json:"version,omitempty"`
 	Content []jiraADFContent `json:"content"`
 }
 
 // jiraADFContent represents a content node in an ADF document.
 type jiraADFContent struct {
-	Type    string           `json:"type"`
-	Text    string           `json:"text,omitempty"`
-	Content []jiraADFContent `json:"content,omitempty"`
+	Type    string                 `json:"type"`
+	Text    string                 `json:"text,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Marks   []jiraADFMark          `json:"marks,omitempty"`
+	Content []jiraADFContent       `json:"content,omitempty"`
+}
+
+// jiraADFMark represents formatting applied to an ADF text node (e.g., strong, link).
+type jiraADFMark struct {
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
 }
 
 // parseResponse parses the Jira API response and extracts ticket information.
@@ -276,7 +319,35 @@ func (c *APIClient) parseResponse(body []byte) (*TicketInfo, error) {
 		info.Priority = resp.Fields.Priority.Name
 	}
 	if resp.Fields.Description != nil {
-		info.Description = extractADFText(resp.Fields.Description)
+		if c.descriptionFormat == "markdown" {
+			info.Description = extractADFMarkdown(resp.Fields.Description)
+		} else {
+			info.Description = extractADFText(resp.Fields.Description)
+		}
+	}
+	if len(resp.Fields.Labels) > 0 {
+		info.Labels = resp.Fields.Labels
+	}
+	for _, component := range resp.Fields.Components {
+		if component.Name != "" {
+			info.Components = append(info.Components, component.Name)
+		}
+	}
+	if resp.Fields.Assignee != nil {
+		info.Assignee = resp.Fields.Assignee.DisplayName
+	}
+	if resp.Fields.Reporter != nil {
+		info.Reporter = resp.Fields.Reporter.DisplayName
+	}
+	if parent := resp.Fields.Parent; parent != nil && parent.Key != "" {
+		info.ParentKey = parent.Key
+		// Next-gen projects model the epic as the parent issue
+		if parent.Fields.IssueType != nil && strings.EqualFold(parent.Fields.IssueType.Name, "Epic") {
+			info.EpicKey = parent.Key
+		}
+	}
+	if info.EpicKey == "" && c.epicLinkField != "" {
+		info.EpicKey = c.extractEpicLink(body)
 	}
 
 	// Extract custom fields if configured
@@ -284,9 +355,7 @@ func (c *APIClient) parseResponse(body []byte) (*TicketInfo, error) {
 		info.CustomFields = c.extractCustomFields(body)
 	}
 
-	if c.verbose {
-		fmt.Printf("Fetched Jira details for ticket: %s\n", info.Summary)
-	}
+	rlog.Debug("fetched Jira details", "summary", info.Summary)
 
 	return info, nil
 }
@@ -318,6 +387,23 @@ func (c *APIClient) extractCustomFields(body []byte) map[string]string {
 	return result
 }
 
+// extractEpicLink reads the classic-project epic link custom field from the raw response.
+func (c *APIClient) extractEpicLink(body []byte) string {
+	var raw struct {
+		Fields map[string]json.RawMessage `json:"fields"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return ""
+	}
+
+	rawValue, ok := raw.Fields[c.epicLinkField]
+	if !ok || len(rawValue) == 0 || string(rawValue) == "null" {
+		return ""
+	}
+
+	return extractCustomFieldValue(rawValue)
+}
+
 // extractCustomFieldValue converts a raw JSON custom field value to a string.
 // Handles different value types: string, number, object with value/name, array.
 func extractCustomFieldValue(raw json.RawMessage) string {
@@ -381,6 +467,8 @@ func extractCustomFieldValue(raw json.RawMessage) string {
 
 // extractADFText extracts plain text from an Atlassian Document Format document.
 // ADF is a tree structure where text is found in leaf nodes of type "text".
+// Blocks holding only whitespace, such as the empty paragraph Jira stores for
+// a cleared description, are dropped, so an empty document yields "".
 func extractADFText(doc *jiraADFDocument) string {
 	if doc == nil {
 		return ""
@@ -389,11 +477,11 @@ func extractADFText(doc *jiraADFDocument) string {
 	var parts []string
 	for _, content := range doc.Content {
 		text := extractADFContentText(&content)
-		if text != "" {
+		if strings.TrimSpace(text) != "" {
 			parts = append(parts, text)
 		}
 	}
-	return strings.Join(parts, "\n")
+	return strings.TrimSpace(strings.Join(parts, "\n"))
 }
 
 // extractADFContentText recursively extracts text from an ADF content node.
@@ -402,11 +490,52 @@ func extractADFContentText(content *jiraADFContent) string {
 		return ""
 	}
 
-	// If this is a text node, return its text
+	// If this is a text node, return its text, keeping the target of any link
 	if content.Type == "text" {
+		for _, mark := range content.Marks {
+			if mark.Type != "link" {
+				continue
+			}
+			if href, _ := mark.Attrs["href"].(string); href != "" && content.Text != "" {
+				return "[" + content.Text + "](" + href + ")"
+			}
+		}
 		return content.Text
 	}
 
+	// Hard breaks are line breaks within a block
+	if content.Type == "hardBreak" {
+		return "\n"
+	}
+
+	switch content.Type {
+	case "codeBlock":
+		language, _ := content.Attrs["language"].(string)
+		var code strings.Builder
+		for _, child := range content.Content {
+			code.WriteString(child.Text)
+		}
+		return "```" + language + "\n" + code.String() + "\n```"
+	case "table":
+		var rows []string
+		for _, row := range content.Content {
+			if text := extractADFContentText(&row); text != "" {
+				rows = append(rows, text)
+			}
+		}
+		return strings.Join(rows, "\n")
+	case "tableRow":
+		// Empty cells are kept so columns stay aligned
+		var cells []string
+		for _, cell := range content.Content {
+			cells = append(cells, extractADFTableCellText(&cell))
+		}
+		if strings.TrimSpace(strings.Join(cells, "")) == "" {
+			return ""
+		}
+		return strings.Join(cells, "\t")
+	}
+
 	// Otherwise, recursively extract text from children
 	var parts []string
 	for _, child := range content.Content {
@@ -427,6 +556,55 @@ func extractADFContentText(content *jiraADFContent) string {
 	}
 }
 
+// extractADFTableCellText extracts the text of a table cell on a single line.
+// Blocks within the cell, including nested tables, are joined with spaces.
+func extractADFTableCellText(cell *jiraADFContent) string {
+	var parts []string
+	for _, child := range cell.Content {
+		text := strings.TrimSpace(extractADFContentText(&child))
+		if text != "" {
+			parts = append(parts, strings.ReplaceAll(text, "\n", " "))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// buildADFDocument wraps plain text in a minimal ADF document.
+// It is the inverse of extractADFText: blank-line separated blocks become
+// paragraphs, and single newlines within a block become hard breaks.
+func buildADFDocument(text string) jiraADFDocument {
+	doc := jiraADFDocument{
+		Type:    "doc",
+		Version: 1,
+		Content: []jiraADFContent{},
+	}
+
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	for _, block := range strings.Split(text, "\n\n") {
+		block = strings.Trim(block, "\n")
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+
+		var nodes []jiraADFContent
+		for i, line := range strings.Split(block, "\n") {
+			if i > 0 {
+				nodes = append(nodes, jiraADFContent{Type: "hardBreak"})
+			}
+			if line != "" {
+				nodes = append(nodes, jiraADFContent{Type: "text", Text: line})
+			}
+		}
+
+		doc.Content = append(doc.Content, jiraADFContent{
+			Type:    "paragraph",
+			Content: nodes,
+		})
+	}
+
+	return doc
+}
+
 // jiraTransitionsResponse represents the response from the transitions endpoint.
 type jiraTransitionsResponse struct {
 	Transitions []jiraTransition `json:"transitions"`
@@ -448,13 +626,18 @@ type jiraTransition struct {
 // GetTransitions returns the available workflow transitions for a ticket.
 // GET /rest/api/3/issue/{issueKey}/transitions
 func (c *APIClient) GetTransitions(ticket string) ([]Transition, error) {
+	return c.GetTransitionsContext(context.Background(), ticket)
+}
+
+// GetTransitionsContext is like GetTransitions but binds the request to ctx.
+func (c *APIClient) GetTransitionsContext(ctx context.Context, ticket string) ([]Transition, error) {
 	if !c.IsAvailable() {
 		return nil, errors.New("jira API client is not configured")
 	}
 
 	url := fmt.Sprintf("%s/rest/api/3/issue/%s/transitions", c.baseURL, ticket)
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create request")
 	}
@@ -463,9 +646,7 @@ func (c *APIClient) GetTransitions(ticket string) ([]Transition, error) {
 	req.Header.Set("Authorization", "Basic "+auth)
 	req.Header.Set("Accept", "application/json")
 
-	if c.verbose {
-		fmt.Printf("Fetching transitions for ticket: %s\n", ticket)
-	}
+	rlog.Debug("fetching Jira transitions", "ticket", ticket)
 
 	resp, err := c.doRequestWithRetry(req)
 	if err != nil {
@@ -499,9 +680,7 @@ func (c *APIClient) GetTransitions(ticket string) ([]Transition, error) {
 		}
 	}
 
-	if c.verbose {
-		fmt.Printf("Found %d available transitions for %s\n", len(transitions), ticket)
-	}
+	rlog.Debug("found Jira transitions", "ticket", ticket, "count", len(transitions))
 
 	return transitions, nil
 }
@@ -520,6 +699,11 @@ type jiraTransitionID struct {
 // POST /rest/api/3/issue/{issueKey}/transitions
 // Body: {"transition": {"id": "31"}}
 func (c *APIClient) TransitionTicket(ticket string, transitionID string) error {
+	return c.TransitionTicketContext(context.Background(), ticket, transitionID)
+}
+
+// TransitionTicketContext is like TransitionTicket but binds the request to ctx.
+func (c *APIClient) TransitionTicketContext(ctx context.Context, ticket string, transitionID string) error {
 	if !c.IsAvailable() {
 		return errors.New("jira API client is not configured")
 	}
@@ -534,7 +718,7 @@ func (c *APIClient) TransitionTicket(ticket string, transitionID string) error {
 		return errors.Wrap(err, "failed to marshal request body")
 	}
 
-	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(string(bodyBytes)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(string(bodyBytes)))
 	if err != nil {
 		return errors.Wrap(err, "failed to create request")
 	}
@@ -544,9 +728,7 @@ func (c *APIClient) TransitionTicket(ticket string, transitionID string) error {
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	if c.verbose {
-		fmt.Printf("Transitioning ticket %s with transition ID %s\n", ticket, transitionID)
-	}
+	rlog.Debug("transitioning Jira ticket", "ticket", ticket, "transition_id", transitionID)
 
 	resp, err := c.doRequestWithRetry(req)
 	if err != nil {
@@ -556,9 +738,7 @@ func (c *APIClient) TransitionTicket(ticket string, transitionID string) error {
 
 	// 204 No Content is the success response for transitions
 	if resp.StatusCode == http.StatusNoContent {
-		if c.verbose {
-			fmt.Printf("Successfully transitioned ticket %s\n", ticket)
-		}
+		rlog.Debug("transitioned Jira ticket", "ticket", ticket)
 		return nil
 	}
 
@@ -602,7 +782,12 @@ func (c *APIClient) handleTransitionError(statusCode int, body []byte, ticket, t
 // TransitionTicketByName finds a transition by status name and executes it.
 // It performs a case-insensitive match on the transition name or target status name.
 func (c *APIClient) TransitionTicketByName(ticket string, statusName string) error {
-	transitions, err := c.GetTransitions(ticket)
+	return c.TransitionTicketByNameContext(context.Background(), ticket, statusName)
+}
+
+// TransitionTicketByNameContext is like TransitionTicketByName but binds the request to ctx.
+func (c *APIClient) TransitionTicketByNameContext(ctx context.Context, ticket string, statusName string) error {
+	transitions, err := c.GetTransitionsContext(ctx, ticket)
 	if err != nil {
 		return errors.Wrap(err, "failed to get available transitions")
 	}
@@ -628,10 +813,262 @@ func (c *APIClient) TransitionTicketByName(ticket string, statusName string) err
 			statusName, ticket, strings.Join(available, ", "))
 	}
 
-	if c.verbose {
-		fmt.Printf("Found transition %q (ID: %s) -> %s for ticket %s\n",
-			matchedTransition.Name, matchedTransition.ID, matchedTransition.To.Name, ticket)
+	rlog.Debug("found Jira transition", "ticket", ticket, "name", matchedTransition.Name,
+		"id", matchedTransition.ID, "to", matchedTransition.To.Name)
+
+	return c.TransitionTicketContext(ctx, ticket, matchedTransition.ID)
+}
+
+// jiraWorklogRequest represents the request body for adding a worklog entry.
+type jiraWorklogRequest struct {
+	TimeSpent string           `json:"timeSpent"`
+	Started   string           `json:"started"`
+	Comment   *jiraADFDocument `json:"comment,omitempty"`
+}
+
+// jiraWorklogTimeFormat is the timestamp layout Jira expects for worklog start times.
+const jiraWorklogTimeFormat = "2006-01-02T15:04:05.000-0700"
+
+// AddWorklog logs time spent against a ticket.
+// POST /rest/api/3/issue/{issueKey}/worklog
+// timeSpent uses Jira duration notation (e.g., "1h 30m"). The comment is optional.
+func (c *APIClient) AddWorklog(ticket string, timeSpent string, comment string, started time.Time) error {
+	return c.AddWorklogContext(context.Background(), ticket, timeSpent, comment, started)
+}
+
+// AddWorklogContext is like AddWorklog but binds the request to ctx.
+func (c *APIClient) AddWorklogContext(ctx context.Context, ticket string, timeSpent string, comment string, started time.Time) error {
+	if !c.IsAvailable() {
+		return errors.New("jira API client is not configured")
+	}
+	if strings.TrimSpace(timeSpent) == "" {
+		return errors.New("time spent is required")
+	}
+
+	url := fmt.Sprintf("%s/rest/api/3/issue/%s/worklog", c.baseURL, ticket)
+
+	reqBody := jiraWorklogRequest{
+		TimeSpent: timeSpent,
+		Started:   started.Format(jiraWorklogTimeFormat),
+	}
+	if comment != "" {
+		doc := buildADFDocument(comment)
+		reqBody.Comment = &doc
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request body")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+
+	auth := base64.StdEncoding.EncodeToString([]byte(c.email + ":" + c.token))
+	req.Header.Set("Authorization", "Basic "+auth)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	rlog.Debug("logging Jira work", "ticket", ticket, "time_spent", timeSpent)
+
+	resp, err := c.doRequestWithRetry(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// 201 Created is the success response for worklogs
+	if resp.StatusCode == http.StatusCreated {
+		rlog.Debug("logged Jira work", "ticket", ticket)
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response body")
+	}
+
+	return c.handleHTTPError(resp.StatusCode, body, ticket)
+}
+
+// jiraCommentRequest represents the request body for adding a comment.
+type jiraCommentRequest struct {
+	Body jiraADFDocument `json:"body"`
+}
+
+// AddComment posts a plain text comment to a ticket.
+// POST /rest/api/3/issue/{issueKey}/comment
+func (c *APIClient) AddComment(ticket string, body string) error {
+	return c.AddCommentContext(context.Background(), ticket, body)
+}
+
+// AddCommentContext is like AddComment but binds the request to ctx.
+func (c *APIClient) AddCommentContext(ctx context.Context, ticket string, body string) error {
+	if !c.IsAvailable() {
+		return errors.New("jira API client is not configured")
+	}
+	if strings.TrimSpace(body) == "" {
+		return errors.New("comment body is required")
+	}
+
+	url := fmt.Sprintf("%s/rest/api/3/issue/%s/comment", c.baseURL, ticket)
+
+	bodyBytes, err := json.Marshal(jiraCommentRequest{Body: buildADFDocument(body)})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request body")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+
+	auth := base64.StdEncoding.EncodeToString([]byte(c.email + ":" + c.token))
+	req.Header.Set("Authorization", "Basic "+auth)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	rlog.Debug("adding Jira comment", "ticket", ticket)
+
+	resp, err := c.doRequestWithRetry(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// 201 Created is the success response for comments
+	if resp.StatusCode == http.StatusCreated {
+		rlog.Debug("added Jira comment", "ticket", ticket)
+		return nil
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response body")
+	}
+
+	return c.handleHTTPError(resp.StatusCode, respBody, ticket)
+}
+
+// jiraUserResponse represents the parts of GET /rest/api/3/myself used by rig.
+type jiraUserResponse struct {
+	AccountID   string `json:"accountId"`
+	DisplayName string `json:"displayName"`
+}
+
+// GetCurrentUser returns the account ID of the authenticated user.
+// GET /rest/api/3/myself
+func (c *APIClient) GetCurrentUser() (string, error) {
+	return c.GetCurrentUserContext(context.Background())
+}
+
+// GetCurrentUserContext is like GetCurrentUser but binds the request to ctx.
+func (c *APIClient) GetCurrentUserContext(ctx context.Context) (string, error) {
+	if !c.IsAvailable() {
+		return "", errors.New("jira API client is not configured")
+	}
+
+	url := fmt.Sprintf("%s/rest/api/3/myself", c.baseURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create request")
+	}
+
+	auth := base64.StdEncoding.EncodeToString([]byte(c.email + ":" + c.token))
+	req.Header.Set("Authorization", "Basic "+auth)
+	req.Header.Set("Accept", "application/json")
+
+	rlog.Debug("fetching current Jira user", "url", url)
+
+	resp, err := c.doRequestWithRetry(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read response body")
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return "", c.handleHTTPError(resp.StatusCode, body, "")
+	default:
+		return "", errors.Newf("failed to look up current jira user (HTTP %d)", resp.StatusCode)
+	}
+
+	var user jiraUserResponse
+	if err := json.Unmarshal(body, &user); err != nil {
+		return "", errors.Wrap(err, "failed to parse jira user response")
+	}
+	if user.AccountID == "" {
+		return "", errors.New("jira user response has no accountId")
+	}
+
+	return user.AccountID, nil
+}
+
+// jiraAssigneeRequest represents the request body for assigning a ticket.
+type jiraAssigneeRequest struct {
+	AccountID string `json:"accountId"`
+}
+
+// AssignTicket assigns a ticket to the user with the given account ID.
+// PUT /rest/api/3/issue/{issueKey}/assignee
+// Body: {"accountId": "5b10ac8d82e05b22cc7d4ef5"}
+func (c *APIClient) AssignTicket(ticket string, accountID string) error {
+	return c.AssignTicketContext(context.Background(), ticket, accountID)
+}
+
+// AssignTicketContext is like AssignTicket but binds the request to ctx.
+func (c *APIClient) AssignTicketContext(ctx context.Context, ticket string, accountID string) error {
+	if !c.IsAvailable() {
+		return errors.New("jira API client is not configured")
+	}
+	if accountID == "" {
+		return errors.New("account ID is required")
+	}
+
+	url := fmt.Sprintf("%s/rest/api/3/issue/%s/assignee", c.baseURL, ticket)
+
+	bodyBytes, err := json.Marshal(jiraAssigneeRequest{AccountID: accountID})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request body")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, strings.NewReader(string(bodyBytes)))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+
+	auth := base64.StdEncoding.EncodeToString([]byte(c.email + ":" + c.token))
+	req.Header.Set("Authorization", "Basic "+auth)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	rlog.Debug("assigning Jira ticket", "ticket", ticket, "account_id", accountID)
+
+	resp, err := c.doRequestWithRetry(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// 204 No Content is the success response for assignment
+	if resp.StatusCode == http.StatusNoContent {
+		rlog.Debug("assigned Jira ticket", "ticket", ticket)
+		return nil
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response body")
 	}
 
-	return c.TransitionTicket(ticket, matchedTransition.ID)
+	return c.handleHTTPError(resp.StatusCode, respBody, ticket)
 }
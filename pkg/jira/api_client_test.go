@@ -13,13 +13,18 @@ package jira
 // following Go's idiomatic approach for HTTP client testing.
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/errors"
+	"github.com/zalando/go-keyring"
+
 	"thoreinstein.com/rig/pkg/config"
 )
 
@@ -49,6 +54,38 @@ func TestNewAPIClient(t *testing.T) {
 	}
 }
 
+func TestNewAPIClient_Timeout(t *testing.T) {
+	t.Setenv("JIRA_TOKEN", "")
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    time.Duration
+	}{
+		{"default", 0, defaultTimeout},
+		{"configured", 5 * time.Second, 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.JiraConfig{
+				BaseURL: "https://example.atlassian.net",
+				Email:   "test@example.com",
+				Token:   "test-token",
+				Timeout: tt.timeout,
+			}
+
+			client, err := NewAPIClient(cfg, false)
+			if err != nil {
+				t.Fatalf("NewAPIClient() error = %v, want nil", err)
+			}
+			if client.httpClient.Timeout != tt.want {
+				t.Errorf("httpClient.Timeout = %s, want %s", client.httpClient.Timeout, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewAPIClient_TrimsTrailingSlash(t *testing.T) {
 	// Clear env var to ensure test uses config token
 	t.Setenv("JIRA_TOKEN", "")
@@ -111,6 +148,67 @@ func TestNewAPIClient_EnvVarFallback(t *testing.T) {
 	}
 }
 
+func TestNewAPIClient_KeychainToken(t *testing.T) {
+	keyring.MockInit()
+	if err := keyring.Set("rig-jira", "test@example.com", "keychain-token"); err != nil {
+		t.Fatalf("keyring.Set() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		envToken  string
+		service   string
+		cfgToken  string
+		wantToken string
+	}{
+		{
+			name:      "keychain wins over config",
+			service:   "rig-jira",
+			cfgToken:  "config-token",
+			wantToken: "keychain-token",
+		},
+		{
+			name:      "env var wins over keychain",
+			envToken:  "env-token",
+			service:   "rig-jira",
+			cfgToken:  "config-token",
+			wantToken: "env-token",
+		},
+		{
+			name:      "missing keychain entry falls through to config",
+			service:   "rig-jira-missing",
+			cfgToken:  "config-token",
+			wantToken: "config-token",
+		},
+		{
+			name:      "no keychain service configured",
+			cfgToken:  "config-token",
+			wantToken: "config-token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JIRA_TOKEN", tt.envToken)
+
+			cfg := &config.JiraConfig{
+				BaseURL:              "https://example.atlassian.net",
+				Email:                "test@example.com",
+				Token:                tt.cfgToken,
+				TokenKeychainService: tt.service,
+			}
+
+			client, err := NewAPIClient(cfg, false)
+			if err != nil {
+				t.Fatalf("NewAPIClient() error = %v, want nil", err)
+			}
+			if client.token != tt.wantToken {
+				t.Errorf("token = %q, want %q", client.token, tt.wantToken)
+			}
+		})
+	}
+}
+
 func TestNewAPIClient_MissingFields(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -576,6 +674,98 @@ func TestExtractADFText_EmptyDocument(t *testing.T) {
 	}
 }
 
+func TestExtractADFText_WhitespaceOnlyDocument(t *testing.T) {
+	doc := &jiraADFDocument{
+		Type: "doc",
+		Content: []jiraADFContent{
+			{Type: "paragraph"},
+			{
+				Type: "paragraph",
+				Content: []jiraADFContent{
+					{Type: "hardBreak"},
+					{Type: "text", Text: "   "},
+				},
+			},
+		},
+	}
+
+	result := extractADFText(doc)
+	if result != "" {
+		t.Errorf("extractADFText() = %q, want empty", result)
+	}
+}
+
+func TestBuildADFDocument(t *testing.T) {
+	tests := []struct {
+		name           string
+		text           string
+		wantParagraphs int
+	}{
+		{
+			name:           "single line",
+			text:           "Deployed to staging",
+			wantParagraphs: 1,
+		},
+		{
+			name:           "multiple paragraphs",
+			text:           "First paragraph\n\nSecond paragraph",
+			wantParagraphs: 2,
+		},
+		{
+			name:           "line breaks within paragraph",
+			text:           "line one\nline two",
+			wantParagraphs: 1,
+		},
+		{
+			name:           "windows line endings",
+			text:           "First\r\n\r\nSecond",
+			wantParagraphs: 2,
+		},
+		{
+			name:           "empty",
+			text:           "",
+			wantParagraphs: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := buildADFDocument(tt.text)
+
+			if doc.Type != "doc" {
+				t.Errorf("Type = %q, want %q", doc.Type, "doc")
+			}
+			if doc.Version != 1 {
+				t.Errorf("Version = %d, want 1", doc.Version)
+			}
+			if len(doc.Content) != tt.wantParagraphs {
+				t.Fatalf("len(Content) = %d, want %d", len(doc.Content), tt.wantParagraphs)
+			}
+			for _, p := range doc.Content {
+				if p.Type != "paragraph" {
+					t.Errorf("node type = %q, want %q", p.Type, "paragraph")
+				}
+			}
+		})
+	}
+}
+
+func TestBuildADFDocument_RoundTrip(t *testing.T) {
+	tests := []string{
+		"Simple comment",
+		"First paragraph\nSecond paragraph",
+		"line one\nline two\nline three",
+	}
+
+	for _, text := range tests {
+		doc := buildADFDocument(text)
+		got := extractADFText(&doc)
+		if got != text {
+			t.Errorf("extractADFText(buildADFDocument(%q)) = %q", text, got)
+		}
+	}
+}
+
 func TestNewJiraClient_APIMode(t *testing.T) {
 	cfg := &config.JiraConfig{
 		Mode:    "api",
@@ -665,127 +855,6 @@ func containsHelper(s, substr string) bool {
 	return false
 }
 
-func TestCalculateBackoff(t *testing.T) {
-	tests := []struct {
-		name    string
-		base    time.Duration
-		max     time.Duration
-		attempt int
-		wantMin time.Duration
-		wantMax time.Duration
-	}{
-		{
-			name:    "attempt 0",
-			base:    time.Second,
-			max:     30 * time.Second,
-			attempt: 0,
-			wantMin: 800 * time.Millisecond,  // 1s * 2^0 * 0.8
-			wantMax: 1200 * time.Millisecond, // 1s * 2^0 * 1.2
-		},
-		{
-			name:    "attempt 1",
-			base:    time.Second,
-			max:     30 * time.Second,
-			attempt: 1,
-			wantMin: 1600 * time.Millisecond, // 1s * 2^1 * 0.8
-			wantMax: 2400 * time.Millisecond, // 1s * 2^1 * 1.2
-		},
-		{
-			name:    "attempt 2",
-			base:    time.Second,
-			max:     30 * time.Second,
-			attempt: 2,
-			wantMin: 3200 * time.Millisecond, // 1s * 2^2 * 0.8
-			wantMax: 4800 * time.Millisecond, // 1s * 2^2 * 1.2
-		},
-		{
-			name:    "capped at max",
-			base:    time.Second,
-			max:     2 * time.Second,
-			attempt: 5,
-			wantMin: 1600 * time.Millisecond, // max (2s) * 0.8
-			wantMax: 2400 * time.Millisecond, // max (2s) * 1.2
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Run multiple times to account for random jitter
-			for range 100 {
-				got := calculateBackoff(tt.base, tt.max, tt.attempt)
-				if got < tt.wantMin || got > tt.wantMax {
-					t.Errorf("calculateBackoff() = %v, want between %v and %v",
-						got, tt.wantMin, tt.wantMax)
-				}
-			}
-		})
-	}
-}
-
-func TestParseRetryAfter(t *testing.T) {
-	tests := []struct {
-		name   string
-		header string
-		want   time.Duration
-	}{
-		{
-			name:   "empty string",
-			header: "",
-			want:   0,
-		},
-		{
-			name:   "integer seconds",
-			header: "5",
-			want:   5 * time.Second,
-		},
-		{
-			name:   "zero seconds",
-			header: "0",
-			want:   0,
-		},
-		{
-			name:   "invalid string",
-			header: "invalid",
-			want:   0,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := parseRetryAfter(tt.header)
-			if got != tt.want {
-				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.header, got, tt.want)
-			}
-		})
-	}
-}
-
-func TestParseRetryAfter_RFC1123Date(t *testing.T) {
-	// Create a time 5 seconds in the future
-	futureTime := time.Now().Add(5 * time.Second)
-	header := futureTime.Format(time.RFC1123)
-
-	got := parseRetryAfter(header)
-
-	// Should return approximately 5 seconds (allow margin for test execution time)
-	if got < 4*time.Second || got > 6*time.Second {
-		t.Errorf("parseRetryAfter(%q) = %v, want approximately 5s", header, got)
-	}
-}
-
-func TestParseRetryAfter_RFC1123DateInPast(t *testing.T) {
-	// Create a time in the past
-	pastTime := time.Now().Add(-5 * time.Second)
-	header := pastTime.Format(time.RFC1123)
-
-	got := parseRetryAfter(header)
-
-	// Past dates should return 0
-	if got != 0 {
-		t.Errorf("parseRetryAfter(%q) = %v, want 0 for past date", header, got)
-	}
-}
-
 func TestAPIClient_FetchTicketDetails_RateLimitRetrySuccess(t *testing.T) {
 	requestCount := 0
 
@@ -874,6 +943,75 @@ func TestAPIClient_FetchTicketDetails_RateLimitExhausted(t *testing.T) {
 	}
 }
 
+func TestAPIClient_FetchTicketDetailsContext_CancelsRetryWait(t *testing.T) {
+	requestCount := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	cfg := &config.JiraConfig{
+		BaseURL: server.URL,
+		Email:   "test@example.com",
+		Token:   "test-token",
+	}
+
+	client, err := NewAPIClient(cfg, false)
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v, want nil", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = client.FetchTicketDetailsContext(ctx, "TEST-123")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("FetchTicketDetailsContext() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("FetchTicketDetailsContext() took %s, should stop waiting at the deadline", elapsed)
+	}
+	if requestCount != 1 {
+		t.Errorf("Request count = %d, want 1 (no retry after cancellation)", requestCount)
+	}
+}
+
+func TestAPIClient_TransitionTicketByNameContext_Cancelled(t *testing.T) {
+	requestCount := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &config.JiraConfig{
+		BaseURL: server.URL,
+		Email:   "test@example.com",
+		Token:   "test-token",
+	}
+
+	client, err := NewAPIClient(cfg, false)
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v, want nil", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = client.TransitionTicketByNameContext(ctx, "TEST-123", "Done")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("TransitionTicketByNameContext() error = %v, want context.Canceled", err)
+	}
+	if requestCount != 0 {
+		t.Errorf("Request count = %d, want 0 for a cancelled context", requestCount)
+	}
+}
+
 func TestAPIClient_FetchTicketDetails_RespectsRetryAfterHeader(t *testing.T) {
 	requestCount := 0
 	var requestTimes []time.Time
@@ -1387,6 +1525,66 @@ func TestAPIClient_FetchTicketDetails_MinimalFields(t *testing.T) {
 	if info.Description != "" {
 		t.Errorf("Description = %q, want empty", info.Description)
 	}
+	if info.Labels != nil {
+		t.Errorf("Labels = %v, want nil", info.Labels)
+	}
+	if info.Components != nil {
+		t.Errorf("Components = %v, want nil", info.Components)
+	}
+}
+
+func TestAPIClient_FetchTicketDetails_LabelsAndComponents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"fields": map[string]interface{}{
+				"summary": "Labelled ticket",
+				"labels":  []string{"backend", "oncall"},
+				"components": []map[string]interface{}{
+					{"id": "10000", "name": "API"},
+					{"id": "10001", "name": "Database"},
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	cfg := &config.JiraConfig{
+		BaseURL: server.URL,
+		Email:   "test@example.com",
+		Token:   "test-token",
+	}
+
+	client, err := NewAPIClient(cfg, false)
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v, want nil", err)
+	}
+
+	info, err := client.FetchTicketDetails("TEST-123")
+	if err != nil {
+		t.Fatalf("FetchTicketDetails() error = %v, want nil", err)
+	}
+
+	wantLabels := []string{"backend", "oncall"}
+	if len(info.Labels) != len(wantLabels) {
+		t.Fatalf("Labels = %v, want %v", info.Labels, wantLabels)
+	}
+	for i, label := range wantLabels {
+		if info.Labels[i] != label {
+			t.Errorf("Labels[%d] = %q, want %q", i, info.Labels[i], label)
+		}
+	}
+
+	wantComponents := []string{"API", "Database"}
+	if len(info.Components) != len(wantComponents) {
+		t.Fatalf("Components = %v, want %v", info.Components, wantComponents)
+	}
+	for i, component := range wantComponents {
+		if info.Components[i] != component {
+			t.Errorf("Components[%d] = %q, want %q", i, info.Components[i], component)
+		}
+	}
 }
 
 func TestAPIClient_GetTransitions_Success(t *testing.T) {
@@ -1766,3 +1964,659 @@ func TestAPIClient_TransitionTicketByName_NotFound(t *testing.T) {
 		t.Errorf("error = %q, should contain available transitions", err.Error())
 	}
 }
+
+func TestAPIClient_AddWorklog_Success(t *testing.T) {
+	started := time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/rest/api/3/issue/TEST-123/worklog" {
+			t.Errorf("Expected path /rest/api/3/issue/TEST-123/worklog, got %s", r.URL.Path)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected Content-Type 'application/json', got %s", r.Header.Get("Content-Type"))
+		}
+
+		var reqBody map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if reqBody["timeSpent"] != "1h 30m" {
+			t.Errorf("timeSpent = %v, want '1h 30m'", reqBody["timeSpent"])
+		}
+		if reqBody["started"] != "2025-01-15T09:30:00.000+0000" {
+			t.Errorf("started = %v, want '2025-01-15T09:30:00.000+0000'", reqBody["started"])
+		}
+
+		comment, ok := reqBody["comment"].(map[string]interface{})
+		if !ok {
+			t.Fatal("Request body missing 'comment' field")
+		}
+		if comment["type"] != "doc" {
+			t.Errorf("comment.type = %v, want 'doc'", comment["type"])
+		}
+		if comment["version"] != float64(1) {
+			t.Errorf("comment.version = %v, want 1", comment["version"])
+		}
+
+		// 201 Created indicates success
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"10001"}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.JiraConfig{
+		BaseURL: server.URL,
+		Email:   "test@example.com",
+		Token:   "test-token",
+	}
+
+	client, err := NewAPIClient(cfg, false)
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v, want nil", err)
+	}
+
+	err = client.AddWorklog("TEST-123", "1h 30m", "Investigated flaky deploy", started)
+	if err != nil {
+		t.Fatalf("AddWorklog() error = %v, want nil", err)
+	}
+}
+
+func TestAPIClient_AddWorklog_NoComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if _, ok := reqBody["comment"]; ok {
+			t.Error("Request body should omit 'comment' when empty")
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	cfg := &config.JiraConfig{
+		BaseURL: server.URL,
+		Email:   "test@example.com",
+		Token:   "test-token",
+	}
+
+	client, err := NewAPIClient(cfg, false)
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v, want nil", err)
+	}
+
+	err = client.AddWorklog("TEST-123", "45m", "", time.Now())
+	if err != nil {
+		t.Fatalf("AddWorklog() error = %v, want nil", err)
+	}
+}
+
+func TestAPIClient_AddWorklog_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantErr    string
+	}{
+		{
+			name:       "bad request",
+			statusCode: http.StatusBadRequest,
+			body:       `{"errorMessages":["Worklog must not be null."]}`,
+			wantErr:    "Worklog must not be null.",
+		},
+		{
+			name:       "forbidden",
+			statusCode: http.StatusForbidden,
+			body:       `{}`,
+			wantErr:    "access denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			cfg := &config.JiraConfig{
+				BaseURL: server.URL,
+				Email:   "test@example.com",
+				Token:   "test-token",
+			}
+
+			client, err := NewAPIClient(cfg, false)
+			if err != nil {
+				t.Fatalf("NewAPIClient() error = %v, want nil", err)
+			}
+
+			err = client.AddWorklog("TEST-123", "1h", "", time.Now())
+			if err == nil {
+				t.Fatal("AddWorklog() should return error")
+			}
+			if !contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %q, should contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAPIClient_AddWorklog_EmptyTimeSpent(t *testing.T) {
+	client := &APIClient{
+		baseURL: "https://example.atlassian.net",
+		email:   "test@example.com",
+		token:   "test-token",
+	}
+
+	err := client.AddWorklog("TEST-123", "  ", "", time.Now())
+	if err == nil {
+		t.Fatal("AddWorklog() should return error for empty time spent")
+	}
+}
+
+func TestAPIClient_AddComment_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/rest/api/3/issue/TEST-123/comment" {
+			t.Errorf("Expected path /rest/api/3/issue/TEST-123/comment, got %s", r.URL.Path)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected Content-Type 'application/json', got %s", r.Header.Get("Content-Type"))
+		}
+
+		var reqBody struct {
+			Body jiraADFDocument `json:"body"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if reqBody.Body.Type != "doc" {
+			t.Errorf("body.type = %q, want 'doc'", reqBody.Body.Type)
+		}
+		if got := extractADFText(&reqBody.Body); got != "Started work on this" {
+			t.Errorf("body text = %q, want 'Started work on this'", got)
+		}
+
+		// 201 Created indicates success
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"10000"}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.JiraConfig{
+		BaseURL: server.URL,
+		Email:   "test@example.com",
+		Token:   "test-token",
+	}
+
+	client, err := NewAPIClient(cfg, false)
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v, want nil", err)
+	}
+
+	err = client.AddComment("TEST-123", "Started work on this")
+	if err != nil {
+		t.Fatalf("AddComment() error = %v, want nil", err)
+	}
+}
+
+func TestAPIClient_AddComment_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.JiraConfig{
+		BaseURL: server.URL,
+		Email:   "test@example.com",
+		Token:   "test-token",
+	}
+
+	client, err := NewAPIClient(cfg, false)
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v, want nil", err)
+	}
+
+	err = client.AddComment("TEST-999", "hello")
+	if err == nil {
+		t.Fatal("AddComment() should return error for 404")
+	}
+	if !contains(err.Error(), "not found") {
+		t.Errorf("error = %q, should contain 'not found'", err.Error())
+	}
+}
+
+func TestAPIClient_AddComment_EmptyBody(t *testing.T) {
+	client := &APIClient{
+		baseURL: "https://example.atlassian.net",
+		email:   "test@example.com",
+		token:   "test-token",
+	}
+
+	err := client.AddComment("TEST-123", "   ")
+	if err == nil {
+		t.Fatal("AddComment() should return error for empty body")
+	}
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       bool
+	}{
+		{http.StatusTooManyRequests, true},
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusGatewayTimeout, true},
+		{http.StatusOK, false},
+		{http.StatusBadRequest, false},
+		{http.StatusNotFound, false},
+		{http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		if got := isRetryableStatus(tt.statusCode); got != tt.want {
+			t.Errorf("isRetryableStatus(%d) = %v, want %v", tt.statusCode, got, tt.want)
+		}
+	}
+}
+
+func TestAPIClient_FetchTicketDetails_ServerErrorRetrySuccess(t *testing.T) {
+	requestCount := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+
+		// Return 504 for the first request, then succeed
+		if requestCount == 1 {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+
+		response := map[string]interface{}{
+			"fields": map[string]interface{}{
+				"summary": "Recovered after gateway timeout",
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	cfg := &config.JiraConfig{
+		BaseURL: server.URL,
+		Email:   "test@example.com",
+		Token:   "test-token",
+	}
+
+	client, err := NewAPIClient(cfg, false)
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v, want nil", err)
+	}
+
+	info, err := client.FetchTicketDetails("TEST-123")
+	if err != nil {
+		t.Fatalf("FetchTicketDetails() error = %v, want nil", err)
+	}
+	if info.Summary != "Recovered after gateway timeout" {
+		t.Errorf("Summary = %q, want %q", info.Summary, "Recovered after gateway timeout")
+	}
+	if requestCount != 2 {
+		t.Errorf("Request count = %d, want 2", requestCount)
+	}
+}
+
+func TestAPIClient_FetchTicketDetails_ClientErrorNotRetried(t *testing.T) {
+	requestCount := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	cfg := &config.JiraConfig{
+		BaseURL: server.URL,
+		Email:   "test@example.com",
+		Token:   "test-token",
+	}
+
+	client, err := NewAPIClient(cfg, false)
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v, want nil", err)
+	}
+
+	_, err = client.FetchTicketDetails("TEST-123")
+	if err == nil {
+		t.Fatal("FetchTicketDetails() should return error for 400")
+	}
+	if requestCount != 1 {
+		t.Errorf("Request count = %d, want 1 (4xx should not be retried)", requestCount)
+	}
+}
+
+func TestAPIClient_TransitionTicket_BadGatewayRetriedOnce(t *testing.T) {
+	requestCount := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	cfg := &config.JiraConfig{
+		BaseURL: server.URL,
+		Email:   "test@example.com",
+		Token:   "test-token",
+	}
+
+	client, err := NewAPIClient(cfg, false)
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v, want nil", err)
+	}
+
+	err = client.TransitionTicket("TEST-123", "21")
+	if err == nil {
+		t.Fatal("TransitionTicket() should return error after 502")
+	}
+	if !contains(err.Error(), "502") {
+		t.Errorf("error = %q, should mention HTTP 502", err.Error())
+	}
+
+	// POST is non-idempotent: 1 initial + at most 1 retry
+	if requestCount != 2 {
+		t.Errorf("Request count = %d, want 2", requestCount)
+	}
+}
+
+func TestAPIClient_TransitionTicket_ServiceUnavailableRetriesWithBody(t *testing.T) {
+	requestCount := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+
+		// The body must be replayed intact on retry
+		var reqBody map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Errorf("request %d: failed to decode request body: %v", requestCount, err)
+		}
+
+		if requestCount == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := &config.JiraConfig{
+		BaseURL: server.URL,
+		Email:   "test@example.com",
+		Token:   "test-token",
+	}
+
+	client, err := NewAPIClient(cfg, false)
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v, want nil", err)
+	}
+
+	if err := client.TransitionTicket("TEST-123", "21"); err != nil {
+		t.Fatalf("TransitionTicket() error = %v, want nil", err)
+	}
+	if requestCount != 2 {
+		t.Errorf("Request count = %d, want 2", requestCount)
+	}
+}
+
+func TestParseResponse_ParentAndEpic(t *testing.T) {
+	tests := []struct {
+		name          string
+		epicLinkField string
+		body          string
+		wantParent    string
+		wantEpic      string
+	}{
+		{
+			name:       "sub-task with parent",
+			body:       `{"fields":{"parent":{"key":"PROJ-10","fields":{"issuetype":{"name":"Story"}}}}}`,
+			wantParent: "PROJ-10",
+		},
+		{
+			name:       "next-gen epic parent",
+			body:       `{"fields":{"parent":{"key":"PROJ-1","fields":{"issuetype":{"name":"Epic"}}}}}`,
+			wantParent: "PROJ-1",
+			wantEpic:   "PROJ-1",
+		},
+		{
+			name:          "classic epic link field",
+			epicLinkField: "customfield_10014",
+			body:          `{"fields":{"customfield_10014":"PROJ-2"}}`,
+			wantEpic:      "PROJ-2",
+		},
+		{
+			name:          "null epic link field",
+			epicLinkField: "customfield_10014",
+			body:          `{"fields":{"customfield_10014":null}}`,
+		},
+		{
+			name: "no parent",
+			body: `{"fields":{"summary":"standalone"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &APIClient{epicLinkField: tt.epicLinkField}
+
+			info, err := client.parseResponse([]byte(tt.body))
+			if err != nil {
+				t.Fatalf("parseResponse() error = %v", err)
+			}
+			if info.ParentKey != tt.wantParent {
+				t.Errorf("ParentKey = %q, want %q", info.ParentKey, tt.wantParent)
+			}
+			if info.EpicKey != tt.wantEpic {
+				t.Errorf("EpicKey = %q, want %q", info.EpicKey, tt.wantEpic)
+			}
+		})
+	}
+}
+
+func TestParseResponse_AssigneeAndReporter(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		wantAssignee string
+		wantReporter string
+	}{
+		{
+			name:         "assigned ticket",
+			body:         `{"fields":{"assignee":{"accountId":"abc","displayName":"Jane Doe"},"reporter":{"displayName":"John Roe"}}}`,
+			wantAssignee: "Jane Doe",
+			wantReporter: "John Roe",
+		},
+		{
+			name:         "unassigned ticket",
+			body:         `{"fields":{"assignee":null,"reporter":{"displayName":"John Roe"}}}`,
+			wantReporter: "John Roe",
+		},
+		{
+			name: "fields absent",
+			body: `{"fields":{"summary":"standalone"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &APIClient{}
+
+			info, err := client.parseResponse([]byte(tt.body))
+			if err != nil {
+				t.Fatalf("parseResponse() error = %v", err)
+			}
+			if info.Assignee != tt.wantAssignee {
+				t.Errorf("Assignee = %q, want %q", info.Assignee, tt.wantAssignee)
+			}
+			if info.Reporter != tt.wantReporter {
+				t.Errorf("Reporter = %q, want %q", info.Reporter, tt.wantReporter)
+			}
+		})
+	}
+}
+
+func TestAPIClient_AssignTicket_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+		if r.URL.Path != "/rest/api/3/issue/TEST-123/assignee" {
+			t.Errorf("Expected path /rest/api/3/issue/TEST-123/assignee, got %s", r.URL.Path)
+		}
+
+		var reqBody map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if reqBody["accountId"] != "abc-123" {
+			t.Errorf("accountId = %v, want 'abc-123'", reqBody["accountId"])
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewAPIClient(&config.JiraConfig{BaseURL: server.URL, Email: "test@example.com", Token: "test-token"}, false)
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v, want nil", err)
+	}
+
+	if err := client.AssignTicket("TEST-123", "abc-123"); err != nil {
+		t.Fatalf("AssignTicket() error = %v, want nil", err)
+	}
+}
+
+func TestAPIClient_AssignTicket_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		accountID string
+		wantErr   string
+	}{
+		{name: "no permission", status: http.StatusForbidden, accountID: "abc-123", wantErr: "access denied"},
+		{name: "ticket not found", status: http.StatusNotFound, accountID: "abc-123", wantErr: "not found"},
+		{name: "missing account ID", accountID: "", wantErr: "account ID is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client, err := NewAPIClient(&config.JiraConfig{BaseURL: server.URL, Email: "test@example.com", Token: "test-token"}, false)
+			if err != nil {
+				t.Fatalf("NewAPIClient() error = %v, want nil", err)
+			}
+
+			err = client.AssignTicket("TEST-123", tt.accountID)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("AssignTicket() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAPIClient_GetCurrentUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    string
+		wantErr string
+	}{
+		{name: "success", status: http.StatusOK, body: `{"accountId":"abc-123","displayName":"Jane Doe"}`, want: "abc-123"},
+		{name: "unauthorized", status: http.StatusUnauthorized, wantErr: "authentication failed"},
+		{name: "missing account ID", status: http.StatusOK, body: `{"name":"jdoe"}`, wantErr: "no accountId"},
+		{name: "server error", status: http.StatusInternalServerError, wantErr: "HTTP 500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/rest/api/3/myself" {
+					t.Errorf("Expected path /rest/api/3/myself, got %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client, err := NewAPIClient(&config.JiraConfig{BaseURL: server.URL, Email: "test@example.com", Token: "test-token"}, false)
+			if err != nil {
+				t.Fatalf("NewAPIClient() error = %v, want nil", err)
+			}
+
+			got, err := client.GetCurrentUser()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("GetCurrentUser() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetCurrentUser() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetCurrentUser() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignToCurrentUser(t *testing.T) {
+	var assigned string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/myself":
+			_, _ = w.Write([]byte(`{"accountId":"abc-123"}`))
+		case "/rest/api/3/issue/TEST-123/assignee":
+			var reqBody jiraAssigneeRequest
+			_ = json.NewDecoder(r.Body).Decode(&reqBody)
+			assigned = reqBody.AccountID
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	apiClient, err := NewAPIClient(&config.JiraConfig{BaseURL: server.URL, Email: "test@example.com", Token: "test-token"}, false)
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v, want nil", err)
+	}
+
+	// Through the caching wrapper, as NewJiraClient returns it
+	client := NewCachingJiraClient(apiClient, time.Minute)
+	if err := AssignToCurrentUser(context.Background(), client, "TEST-123"); err != nil {
+		t.Fatalf("AssignToCurrentUser() error = %v", err)
+	}
+	if assigned != "abc-123" {
+		t.Errorf("assigned account = %q, want %q", assigned, "abc-123")
+	}
+
+	cliClient, _ := NewCLIClient("acli", false)
+	if err := AssignToCurrentUser(context.Background(), cliClient, "TEST-123"); err == nil {
+		t.Error("AssignToCurrentUser() with CLI client expected error")
+	}
+	if err := AssignToCurrentUser(context.Background(), NewCachingJiraClient(cliClient, time.Minute), "TEST-123"); err == nil {
+		t.Error("AssignToCurrentUser() with cached CLI client expected error")
+	}
+}
@@ -0,0 +1,151 @@
+package jira
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Compile-time checks that CachingJiraClient implements JiraClient and ContextClient.
+var (
+	_ JiraClient    = (*CachingJiraClient)(nil)
+	_ ContextClient = (*CachingJiraClient)(nil)
+	_ Assigner      = (*CachingJiraClient)(nil)
+)
+
+// cacheEntry holds a cached ticket and when it was fetched.
+type cacheEntry struct {
+	info      *TicketInfo
+	fetchedAt time.Time
+}
+
+// CachingJiraClient wraps a JiraClient and caches FetchTicketDetails results
+// for a short TTL. Transitions invalidate the cached entry for that ticket.
+type CachingJiraClient struct {
+	client  JiraClient
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	now     func() time.Time
+}
+
+// NewCachingJiraClient wraps client with a ticket details cache.
+func NewCachingJiraClient(client JiraClient, ttl time.Duration) *CachingJiraClient {
+	return &CachingJiraClient{
+		client:  client,
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+		now:     time.Now,
+	}
+}
+
+// IsAvailable delegates to the wrapped client.
+func (c *CachingJiraClient) IsAvailable() bool {
+	return c.client.IsAvailable()
+}
+
+// FetchTicketDetails returns cached ticket details when they are younger than
+// the TTL, otherwise fetches them from the wrapped client.
+func (c *CachingJiraClient) FetchTicketDetails(ticket string) (*TicketInfo, error) {
+	return c.FetchTicketDetailsContext(context.Background(), ticket)
+}
+
+// FetchTicketDetailsContext is like FetchTicketDetails but binds a fetch from
+// the wrapped client to ctx. Cached entries are returned regardless of ctx.
+func (c *CachingJiraClient) FetchTicketDetailsContext(ctx context.Context, ticket string) (*TicketInfo, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[ticket]
+	c.mu.Unlock()
+
+	if ok && c.now().Sub(entry.fetchedAt) < c.ttl {
+		return entry.info, nil
+	}
+
+	info, err := FetchTicketDetailsContext(ctx, c.client, ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[ticket] = cacheEntry{info: info, fetchedAt: c.now()}
+	c.mu.Unlock()
+
+	return info, nil
+}
+
+// GetTransitions delegates to the wrapped client without caching.
+func (c *CachingJiraClient) GetTransitions(ticket string) ([]Transition, error) {
+	return c.client.GetTransitions(ticket)
+}
+
+// TransitionTicket delegates to the wrapped client and invalidates the ticket
+// once the transition succeeds.
+func (c *CachingJiraClient) TransitionTicket(ticket string, transitionID string) error {
+	return c.invalidateOnSuccess(ticket, c.client.TransitionTicket(ticket, transitionID))
+}
+
+// TransitionTicketByName delegates to the wrapped client and invalidates the
+// ticket once the transition succeeds.
+func (c *CachingJiraClient) TransitionTicketByName(ticket string, statusName string) error {
+	return c.invalidateOnSuccess(ticket, c.client.TransitionTicketByName(ticket, statusName))
+}
+
+// GetTransitionsContext delegates to the wrapped client without caching.
+func (c *CachingJiraClient) GetTransitionsContext(ctx context.Context, ticket string) ([]Transition, error) {
+	return GetTransitionsContext(ctx, c.client, ticket)
+}
+
+// TransitionTicketContext delegates to the wrapped client and invalidates the
+// ticket once the transition succeeds.
+func (c *CachingJiraClient) TransitionTicketContext(ctx context.Context, ticket string, transitionID string) error {
+	return c.invalidateOnSuccess(ticket, TransitionTicketContext(ctx, c.client, ticket, transitionID))
+}
+
+// TransitionTicketByNameContext delegates to the wrapped client and
+// invalidates the ticket once the transition succeeds.
+func (c *CachingJiraClient) TransitionTicketByNameContext(ctx context.Context, ticket string, statusName string) error {
+	return c.invalidateOnSuccess(ticket, TransitionTicketByNameContext(ctx, c.client, ticket, statusName))
+}
+
+// GetCurrentUserContext delegates to the wrapped client when it implements Assigner.
+func (c *CachingJiraClient) GetCurrentUserContext(ctx context.Context) (string, error) {
+	assigner, ok := c.client.(Assigner)
+	if !ok {
+		return "", errors.New("assigning tickets requires jira API mode")
+	}
+	return assigner.GetCurrentUserContext(ctx)
+}
+
+// AssignTicketContext delegates to the wrapped client when it implements
+// Assigner, and invalidates the ticket once the assignment succeeds.
+func (c *CachingJiraClient) AssignTicketContext(ctx context.Context, ticket string, accountID string) error {
+	assigner, ok := c.client.(Assigner)
+	if !ok {
+		return errors.New("assigning tickets requires jira API mode")
+	}
+	return c.invalidateOnSuccess(ticket, assigner.AssignTicketContext(ctx, ticket, accountID))
+}
+
+// Invalidate removes a ticket from the cache.
+func (c *CachingJiraClient) Invalidate(ticket string) {
+	c.mu.Lock()
+	delete(c.entries, ticket)
+	c.mu.Unlock()
+}
+
+// invalidateOnSuccess invalidates ticket once a change to it has succeeded
+// and returns err. Invalidating only afterwards means a fetch racing the
+// change can't re-cache the old state, and a failed change keeps the entry.
+func (c *CachingJiraClient) invalidateOnSuccess(ticket string, err error) error {
+	if err == nil {
+		c.Invalidate(ticket)
+	}
+	return err
+}
+
+// Unwrap returns the underlying client.
+func (c *CachingJiraClient) Unwrap() JiraClient {
+	return c.client
+}
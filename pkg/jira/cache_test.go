@@ -0,0 +1,222 @@
+package jira
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"thoreinstein.com/rig/pkg/config"
+)
+
+// countingClient is a JiraClient that records how often each method is called.
+type countingClient struct {
+	fetches       int
+	transitions   int
+	fetchErr      error
+	transitionErr error
+	status        string
+	onTransition  func() // Runs while a transition is in flight
+}
+
+func (c *countingClient) IsAvailable() bool { return true }
+
+func (c *countingClient) FetchTicketDetails(ticket string) (*TicketInfo, error) {
+	c.fetches++
+	if c.fetchErr != nil {
+		return nil, c.fetchErr
+	}
+	return &TicketInfo{Summary: ticket, Status: c.status}, nil
+}
+
+func (c *countingClient) GetTransitions(ticket string) ([]Transition, error) {
+	return nil, nil
+}
+
+func (c *countingClient) TransitionTicket(ticket string, transitionID string) error {
+	c.transitions++
+	return nil
+}
+
+func (c *countingClient) TransitionTicketByName(ticket string, statusName string) error {
+	c.transitions++
+	if c.onTransition != nil {
+		c.onTransition()
+	}
+	if c.transitionErr != nil {
+		return c.transitionErr
+	}
+	c.status = statusName
+	return nil
+}
+
+func TestCachingJiraClient_CachesWithinTTL(t *testing.T) {
+	inner := &countingClient{}
+	client := NewCachingJiraClient(inner, time.Minute)
+
+	for range 3 {
+		info, err := client.FetchTicketDetails("PROJ-1")
+		if err != nil {
+			t.Fatalf("FetchTicketDetails() error = %v", err)
+		}
+		if info.Summary != "PROJ-1" {
+			t.Errorf("Summary = %q, want %q", info.Summary, "PROJ-1")
+		}
+	}
+
+	if inner.fetches != 1 {
+		t.Errorf("inner fetches = %d, want 1", inner.fetches)
+	}
+
+	// A different ticket is fetched separately
+	if _, err := client.FetchTicketDetails("PROJ-2"); err != nil {
+		t.Fatalf("FetchTicketDetails() error = %v", err)
+	}
+	if inner.fetches != 2 {
+		t.Errorf("inner fetches = %d, want 2", inner.fetches)
+	}
+}
+
+func TestCachingJiraClient_ExpiresAfterTTL(t *testing.T) {
+	inner := &countingClient{}
+	client := NewCachingJiraClient(inner, time.Minute)
+
+	now := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+
+	_, _ = client.FetchTicketDetails("PROJ-1")
+	now = now.Add(30 * time.Second)
+	_, _ = client.FetchTicketDetails("PROJ-1")
+	if inner.fetches != 1 {
+		t.Errorf("inner fetches = %d, want 1 before TTL expires", inner.fetches)
+	}
+
+	now = now.Add(31 * time.Second)
+	_, _ = client.FetchTicketDetails("PROJ-1")
+	if inner.fetches != 2 {
+		t.Errorf("inner fetches = %d, want 2 after TTL expires", inner.fetches)
+	}
+}
+
+func TestCachingJiraClient_TransitionInvalidates(t *testing.T) {
+	inner := &countingClient{}
+	client := NewCachingJiraClient(inner, time.Minute)
+
+	_, _ = client.FetchTicketDetails("PROJ-1")
+	if err := client.TransitionTicketByName("PROJ-1", "In Progress"); err != nil {
+		t.Fatalf("TransitionTicketByName() error = %v", err)
+	}
+	_, _ = client.FetchTicketDetails("PROJ-1")
+
+	if inner.fetches != 2 {
+		t.Errorf("inner fetches = %d, want 2 after transition", inner.fetches)
+	}
+	if inner.transitions != 1 {
+		t.Errorf("inner transitions = %d, want 1", inner.transitions)
+	}
+}
+
+func TestCachingJiraClient_FetchDuringTransition(t *testing.T) {
+	inner := &countingClient{status: "To Do"}
+	client := NewCachingJiraClient(inner, time.Minute)
+
+	// A fetch that lands while the transition is in flight sees the old status
+	inner.onTransition = func() { _, _ = client.FetchTicketDetails("PROJ-1") }
+
+	if err := client.TransitionTicketByName("PROJ-1", "In Progress"); err != nil {
+		t.Fatalf("TransitionTicketByName() error = %v", err)
+	}
+
+	info, err := client.FetchTicketDetails("PROJ-1")
+	if err != nil {
+		t.Fatalf("FetchTicketDetails() error = %v", err)
+	}
+	if info.Status != "In Progress" {
+		t.Errorf("Status = %q after transition, want the new status, not a stale cached one", info.Status)
+	}
+}
+
+func TestCachingJiraClient_FailedTransitionKeepsEntry(t *testing.T) {
+	inner := &countingClient{status: "To Do", transitionErr: errors.New("no such transition")}
+	client := NewCachingJiraClient(inner, time.Minute)
+
+	_, _ = client.FetchTicketDetails("PROJ-1")
+	if err := client.TransitionTicketByName("PROJ-1", "Done"); err == nil {
+		t.Fatal("TransitionTicketByName() should return error")
+	}
+	_, _ = client.FetchTicketDetails("PROJ-1")
+
+	if inner.fetches != 1 {
+		t.Errorf("inner fetches = %d, want 1 (a failed transition keeps the cached entry)", inner.fetches)
+	}
+}
+
+func TestCachingJiraClient_ErrorsNotCached(t *testing.T) {
+	inner := &countingClient{fetchErr: errors.New("boom")}
+	client := NewCachingJiraClient(inner, time.Minute)
+
+	_, err := client.FetchTicketDetails("PROJ-1")
+	if err == nil {
+		t.Fatal("FetchTicketDetails() should return error")
+	}
+
+	inner.fetchErr = nil
+	if _, err := client.FetchTicketDetails("PROJ-1"); err != nil {
+		t.Fatalf("FetchTicketDetails() error = %v, want nil", err)
+	}
+	if inner.fetches != 2 {
+		t.Errorf("inner fetches = %d, want 2", inner.fetches)
+	}
+}
+
+func TestNewJiraClient_WithCacheTTL(t *testing.T) {
+	cfg := &config.JiraConfig{
+		Mode:     "api",
+		BaseURL:  "https://example.atlassian.net",
+		Email:    "test@example.com",
+		Token:    "test-token",
+		CacheTTL: time.Minute,
+	}
+
+	client, err := NewJiraClient(cfg, false)
+	if err != nil {
+		t.Fatalf("NewJiraClient() error = %v, want nil", err)
+	}
+
+	cached, ok := client.(*CachingJiraClient)
+	if !ok {
+		t.Fatalf("NewJiraClient(cache_ttl>0) should return *CachingJiraClient, got %T", client)
+	}
+	if _, ok := cached.Unwrap().(*APIClient); !ok {
+		t.Errorf("Unwrap() should return *APIClient, got %T", cached.Unwrap())
+	}
+}
+
+func TestCachingJiraClient_FetchTicketDetailsContext(t *testing.T) {
+	inner := &countingClient{}
+	client := NewCachingJiraClient(inner, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// A cancelled context stops a fetch from the wrapped client
+	if _, err := client.FetchTicketDetailsContext(ctx, "PROJ-1"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("FetchTicketDetailsContext() error = %v, want context.Canceled", err)
+	}
+	if inner.fetches != 0 {
+		t.Errorf("inner fetches = %d, want 0", inner.fetches)
+	}
+
+	// Cached entries are served without touching the network
+	if _, err := client.FetchTicketDetails("PROJ-1"); err != nil {
+		t.Fatalf("FetchTicketDetails() error = %v", err)
+	}
+	info, err := client.FetchTicketDetailsContext(ctx, "PROJ-1")
+	if err != nil {
+		t.Fatalf("FetchTicketDetailsContext() on a cached ticket error = %v", err)
+	}
+	if info.Summary != "PROJ-1" {
+		t.Errorf("Summary = %q, want %q", info.Summary, "PROJ-1")
+	}
+}
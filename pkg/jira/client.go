@@ -1,7 +1,7 @@
 package jira
 
 import (
-	"fmt"
+	"context"
 	"os/exec"
 	"regexp"
 	"strings"
@@ -9,6 +9,7 @@ import (
 	"github.com/cockroachdb/errors"
 
 	"thoreinstein.com/rig/pkg/config"
+	rlog "thoreinstein.com/rig/pkg/log"
 )
 
 // validCliCommandPattern validates CLI command names to prevent injection.
@@ -22,6 +23,12 @@ type TicketInfo struct {
 	Status       string
 	Priority     string
 	Description  string
+	Labels       []string
+	Components   []string
+	Assignee     string            // Assignee display name, empty when unassigned
+	Reporter     string            // Reporter display name
+	ParentKey    string            // Parent issue key (sub-tasks and next-gen children)
+	EpicKey      string            // Epic issue key, from the parent or the epic link field
 	CustomFields map[string]string // Maps friendly field names to their values
 }
 
@@ -58,24 +65,133 @@ type JiraClient interface {
 	TransitionTicketByName(ticket string, statusName string) error
 }
 
+// ContextClient is implemented by JiraClients whose requests can be bound to a
+// context, so a deadline or cancellation stops them (including retry waits).
+// APIClient and CachingJiraClient implement it; CLIClient does not.
+type ContextClient interface {
+	FetchTicketDetailsContext(ctx context.Context, ticket string) (*TicketInfo, error)
+	GetTransitionsContext(ctx context.Context, ticket string) ([]Transition, error)
+	TransitionTicketContext(ctx context.Context, ticket string, transitionID string) error
+	TransitionTicketByNameContext(ctx context.Context, ticket string, statusName string) error
+}
+
+// FetchTicketDetailsContext fetches ticket details through client, bound to
+// ctx when the client implements ContextClient
+func FetchTicketDetailsContext(ctx context.Context, client JiraClient, ticket string) (*TicketInfo, error) {
+	if cc, ok := client.(ContextClient); ok {
+		return cc.FetchTicketDetailsContext(ctx, ticket)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return client.FetchTicketDetails(ticket)
+}
+
+// GetTransitionsContext lists transitions through client, bound to ctx when
+// the client implements ContextClient
+func GetTransitionsContext(ctx context.Context, client JiraClient, ticket string) ([]Transition, error) {
+	if cc, ok := client.(ContextClient); ok {
+		return cc.GetTransitionsContext(ctx, ticket)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return client.GetTransitions(ticket)
+}
+
+// TransitionTicketContext executes a transition through client, bound to ctx
+// when the client implements ContextClient
+func TransitionTicketContext(ctx context.Context, client JiraClient, ticket string, transitionID string) error {
+	if cc, ok := client.(ContextClient); ok {
+		return cc.TransitionTicketContext(ctx, ticket, transitionID)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return client.TransitionTicket(ticket, transitionID)
+}
+
+// TransitionTicketByNameContext transitions a ticket by status name through
+// client, bound to ctx when the client implements ContextClient
+func TransitionTicketByNameContext(ctx context.Context, client JiraClient, ticket string, statusName string) error {
+	if cc, ok := client.(ContextClient); ok {
+		return cc.TransitionTicketByNameContext(ctx, ticket, statusName)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return client.TransitionTicketByName(ticket, statusName)
+}
+
+// Assigner is implemented by JiraClients that can assign tickets. APIClient
+// and CachingJiraClient (when wrapping one) implement it; CLIClient does not.
+type Assigner interface {
+	GetCurrentUserContext(ctx context.Context) (string, error)
+	AssignTicketContext(ctx context.Context, ticket string, accountID string) error
+}
+
+// AssignToCurrentUser assigns ticket to the authenticated user through
+// client, which must implement Assigner
+func AssignToCurrentUser(ctx context.Context, client JiraClient, ticket string) error {
+	assigner, ok := client.(Assigner)
+	if !ok {
+		return errors.New("assigning tickets requires jira API mode")
+	}
+
+	accountID, err := assigner.GetCurrentUserContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to look up current jira user")
+	}
+	return assigner.AssignTicketContext(ctx, ticket, accountID)
+}
+
 // Compile-time check that CLIClient implements JiraClient.
 var _ JiraClient = (*CLIClient)(nil)
 
 // NewJiraClient creates a JiraClient based on the provided configuration.
 // Returns an APIClient when mode is "api", or a CLIClient when mode is "acli" or empty.
+// When cfg.CacheTTL is positive, the client is wrapped in a CachingJiraClient.
 func NewJiraClient(cfg *config.JiraConfig, verbose bool) (JiraClient, error) {
 	if cfg == nil {
 		return nil, errors.New("jira config is required")
 	}
 
+	var client JiraClient
+	var err error
+
 	switch cfg.Mode {
 	case "api":
-		return NewAPIClient(cfg, verbose)
+		client, err = NewAPIClient(cfg, verbose)
 	case "acli", "":
-		return NewCLIClient(cfg.CliCommand, verbose)
+		client, err = NewCLIClient(cfg.CliCommand, verbose)
 	default:
 		return nil, errors.Newf("unknown jira mode: %s", cfg.Mode)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.CacheTTL > 0 {
+		return NewCachingJiraClient(client, cfg.CacheTTL), nil
+	}
+
+	return client, nil
+}
+
+// NewJiraClientForTicket creates a JiraClient for the Jira instance that
+// handles ticket, selected from cfg.Profiles by the ticket's project key (see
+// config.JiraConfig.ForTicket). Without profiles it is equivalent to
+// NewJiraClient.
+func NewJiraClientForTicket(cfg *config.JiraConfig, ticket string, verbose bool) (JiraClient, error) {
+	if cfg == nil {
+		return nil, errors.New("jira config is required")
+	}
+
+	resolved := cfg.ForTicket(ticket)
+	if name := cfg.ProfileFor(ticket); name != "" {
+		rlog.Debug("using Jira profile", "profile", name, "ticket", ticket)
+	}
+	return NewJiraClient(resolved, verbose)
 }
 
 // CLIClient handles JIRA integration via CLI tool (e.g., ACLI)
@@ -112,29 +228,23 @@ func (c *CLIClient) IsAvailable() bool {
 // FetchTicketDetails fetches JIRA ticket details using the CLI
 func (c *CLIClient) FetchTicketDetails(ticket string) (*TicketInfo, error) {
 	if !c.IsAvailable() {
-		if c.Verbose {
-			fmt.Printf("JIRA CLI command '%s' not found, skipping JIRA details fetch\n", c.CliCommand)
-		}
+		rlog.Debug("Jira CLI command not found, skipping Jira details fetch", "command", c.CliCommand)
 		return nil, errors.New("JIRA CLI command not available")
 	}
 
 	// Execute the CLI command
-	//nolint:gosec // G204: ticket validated by parseTicket regex, CliCommand from config
+	//nolint:gosec // G204: ticket validated by ticket.Parse, CliCommand from config
 	cmd := exec.Command(c.CliCommand, "jira", "workitem", "view", ticket)
 	output, err := cmd.Output()
 	if err != nil {
-		if c.Verbose {
-			fmt.Printf("Failed to fetch JIRA details for %s: %v\n", ticket, err)
-		}
+		rlog.Debug("failed to fetch Jira details", "ticket", ticket, "error", err)
 		return nil, errors.Wrap(err, "failed to fetch JIRA details")
 	}
 
 	// Parse the output
 	jiraInfo := c.parseJiraOutput(string(output))
 
-	if c.Verbose {
-		fmt.Printf("Fetched JIRA details for %s: %s\n", ticket, jiraInfo.Summary)
-	}
+	rlog.Debug("fetched Jira details", "ticket", ticket, "summary", jiraInfo.Summary)
 
 	return jiraInfo, nil
 }
@@ -2,6 +2,8 @@ package jira
 
 import (
 	"testing"
+
+	"thoreinstein.com/rig/pkg/config"
 )
 
 func TestNewCLIClient(t *testing.T) {
@@ -395,3 +397,44 @@ func TestCLIClient_TransitionTicketByName_NotImplemented(t *testing.T) {
 		t.Errorf("error = %q, want 'TransitionTicketByName not implemented for CLI client'", err.Error())
 	}
 }
+
+func TestNewJiraClientForTicket(t *testing.T) {
+	cfg := &config.JiraConfig{
+		Mode:       "acli",
+		CliCommand: "acli",
+		Profiles: map[string]config.JiraProfile{
+			"cloud": {
+				Projects: []string{"FRAAS"},
+				Mode:     "api",
+				BaseURL:  "https://cloud.atlassian.net/",
+				Email:    "me@example.com",
+				Token:    "secret",
+			},
+		},
+	}
+	t.Setenv("JIRA_TOKEN", "")
+
+	client, err := NewJiraClientForTicket(cfg, "FRAAS-12", false)
+	if err != nil {
+		t.Fatalf("NewJiraClientForTicket() error = %v", err)
+	}
+	apiClient, ok := client.(*APIClient)
+	if !ok {
+		t.Fatalf("NewJiraClientForTicket(FRAAS-12) = %T, want *APIClient", client)
+	}
+	if apiClient.baseURL != "https://cloud.atlassian.net" {
+		t.Errorf("baseURL = %q, want the cloud profile's", apiClient.baseURL)
+	}
+
+	client, err = NewJiraClientForTicket(cfg, "OPS-3", false)
+	if err != nil {
+		t.Fatalf("NewJiraClientForTicket() error = %v", err)
+	}
+	if _, ok := client.(*CLIClient); !ok {
+		t.Errorf("NewJiraClientForTicket(OPS-3) = %T, want *CLIClient", client)
+	}
+
+	if _, err := NewJiraClientForTicket(nil, "OPS-3", false); err == nil {
+		t.Error("NewJiraClientForTicket(nil) expected error")
+	}
+}
@@ -0,0 +1,97 @@
+// Package log configures rig's diagnostic logging on top of log/slog.
+//
+// All diagnostics go to stderr so that stdout only carries command output,
+// which keeps JSON and CSV output machine-readable. Setup is called once from
+// the root command; packages then log through Debug, Info, Warn and Error or
+// pass Logger() to components that take a *slog.Logger.
+package log
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ValidLevels lists the accepted --log-level values
+var ValidLevels = []string{"debug", "info", "warn", "error"}
+
+// ValidFormats lists the accepted --log-format values
+var ValidFormats = []string{"text", "json"}
+
+// ParseLevel converts a level name to a slog.Level
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, errors.Newf("invalid log level %q: must be one of: %s", level, strings.Join(ValidLevels, ", "))
+	}
+}
+
+// New returns a logger writing to w at level in format ("text" or "json")
+func New(w io.Writer, level, format string) (*slog.Logger, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "text", "":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	default:
+		return nil, errors.Newf("invalid log format %q: must be one of: %s", format, strings.Join(ValidFormats, ", "))
+	}
+}
+
+// Setup installs a stderr logger at level in format as the slog default
+func Setup(level, format string) error {
+	logger, err := New(os.Stderr, level, format)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+	return nil
+}
+
+// Logger returns the configured default logger
+func Logger() *slog.Logger {
+	return slog.Default()
+}
+
+// Enabled reports whether messages at level are written
+func Enabled(level slog.Level) bool {
+	return slog.Default().Enabled(context.Background(), level)
+}
+
+// Debug logs a diagnostic message, shown with --verbose or --log-level debug
+func Debug(msg string, args ...any) {
+	slog.Default().Debug(msg, args...)
+}
+
+// Info logs an informational message
+func Info(msg string, args ...any) {
+	slog.Default().Info(msg, args...)
+}
+
+// Warn logs a recoverable problem
+func Warn(msg string, args ...any) {
+	slog.Default().Warn(msg, args...)
+}
+
+// Error logs a failure
+func Error(msg string, args ...any) {
+	slog.Default().Error(msg, args...)
+}
@@ -0,0 +1,105 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    slog.Level
+		wantErr bool
+	}{
+		{"debug", slog.LevelDebug, false},
+		{"INFO", slog.LevelInfo, false},
+		{"", slog.LevelInfo, false},
+		{"warn", slog.LevelWarn, false},
+		{"warning", slog.LevelWarn, false},
+		{"error", slog.LevelError, false},
+		{"trace", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseLevel(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLevel(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseLevel(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("text filters by level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := New(&buf, "warn", "text")
+		if err != nil {
+			t.Fatal(err)
+		}
+		logger.Info("hidden")
+		logger.Warn("shown", "ticket", "proj-123")
+
+		out := buf.String()
+		if strings.Contains(out, "hidden") {
+			t.Errorf("info message should be filtered at warn level: %q", out)
+		}
+		if !strings.Contains(out, "msg=shown") || !strings.Contains(out, "ticket=proj-123") {
+			t.Errorf("warn message missing from text output: %q", out)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := New(&buf, "debug", "json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		logger.Debug("fetching", "ticket", "proj-123")
+
+		var entry map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("output is not JSON: %v: %q", err, buf.String())
+		}
+		if entry["msg"] != "fetching" || entry["ticket"] != "proj-123" || entry["level"] != "DEBUG" {
+			t.Errorf("unexpected JSON entry: %v", entry)
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		if _, err := New(&bytes.Buffer{}, "info", "xml"); err == nil {
+			t.Error("New() should reject an unknown format")
+		}
+	})
+
+	t.Run("invalid level", func(t *testing.T) {
+		if _, err := New(&bytes.Buffer{}, "loud", "text"); err == nil {
+			t.Error("New() should reject an unknown level")
+		}
+	})
+}
+
+func TestSetup(t *testing.T) {
+	prev := slog.Default()
+	defer slog.SetDefault(prev)
+
+	if err := Setup("error", "text"); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	if Enabled(slog.LevelWarn) {
+		t.Error("warn should be disabled at error level")
+	}
+	if !Enabled(slog.LevelError) {
+		t.Error("error should be enabled at error level")
+	}
+
+	if err := Setup("info", "yaml"); err == nil {
+		t.Error("Setup() should reject an unknown format")
+	}
+}
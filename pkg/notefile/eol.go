@@ -0,0 +1,39 @@
+package notefile
+
+import "strings"
+
+// utf8BOM is the byte order mark some Windows editors prepend to UTF-8 files
+const utf8BOM = "\ufeff"
+
+// TextStyle records the encoding details of a note that line-based editing
+// strips, so they can be put back when the note is written
+type TextStyle struct {
+	bom  bool // Content started with a UTF-8 BOM
+	crlf bool // Content used CRLF line endings
+}
+
+// NormalizeText strips a leading BOM and converts CRLF line endings to LF so
+// notes can be edited line by line regardless of where they were saved
+func NormalizeText(content string) (string, TextStyle) {
+	var style TextStyle
+	if strings.HasPrefix(content, utf8BOM) {
+		style.bom = true
+		content = strings.TrimPrefix(content, utf8BOM)
+	}
+	if strings.Contains(content, "\r\n") {
+		style.crlf = true
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+	}
+	return content, style
+}
+
+// Restore converts normalized content back to the style it was read in
+func (s TextStyle) Restore(content string) string {
+	if s.crlf {
+		content = strings.ReplaceAll(content, "\n", "\r\n")
+	}
+	if s.bom {
+		content = utf8BOM + content
+	}
+	return content
+}
@@ -0,0 +1,32 @@
+package notefile
+
+import "testing"
+
+func TestNormalizeText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "lf", content: "# Note\n\nbody\n", want: "# Note\n\nbody\n"},
+		{name: "crlf", content: "# Note\r\n\r\nbody\r\n", want: "# Note\n\nbody\n"},
+		{name: "bom", content: utf8BOM + "# Note\n", want: "# Note\n"},
+		{name: "crlf and bom", content: utf8BOM + "# Note\r\nbody\r\n", want: "# Note\nbody\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, style := NormalizeText(tt.content)
+			if got != tt.want {
+				t.Errorf("NormalizeText(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+			if restored := style.Restore(got); restored != tt.content {
+				t.Errorf("Restore() = %q, want original %q", restored, tt.content)
+			}
+		})
+	}
+}
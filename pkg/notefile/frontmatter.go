@@ -0,0 +1,51 @@
+package notefile
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// frontMatterKeyOrder lists well-known front matter keys in the order they are written.
+// Any other keys follow in alphabetical order.
+var frontMatterKeyOrder = []string{"ticket", "type", "status", "summary", "created"}
+
+// BuildFrontMatter renders fields as a YAML front matter block.
+// Empty values are omitted and values are quoted when YAML requires it.
+func BuildFrontMatter(fields map[string]string) string {
+	var extra []string
+	for key := range fields {
+		if !slices.Contains(frontMatterKeyOrder, key) {
+			extra = append(extra, key)
+		}
+	}
+	slices.Sort(extra)
+
+	var fm strings.Builder
+	fm.WriteString("---\n")
+	for _, key := range append(slices.Clone(frontMatterKeyOrder), extra...) {
+		if value := fields[key]; value != "" {
+			fmt.Fprintf(&fm, "%s: %s\n", key, yamlScalar(value))
+		}
+	}
+	fm.WriteString("---\n\n")
+	return fm.String()
+}
+
+// yamlScalar quotes a value when it would otherwise be misread as YAML syntax
+func yamlScalar(value string) string {
+	if strings.ContainsAny(value, ":#{}[],&*!|>'\"%@`\n") ||
+		strings.TrimSpace(value) != value ||
+		strings.HasPrefix(value, "-") || strings.HasPrefix(value, "?") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// HasFrontMatter reports whether content already begins with a front matter
+// block, ignoring a leading UTF-8 BOM
+func HasFrontMatter(content string) bool {
+	content = strings.TrimPrefix(content, utf8BOM)
+	return strings.HasPrefix(content, "---\n") || strings.HasPrefix(content, "---\r\n")
+}
@@ -0,0 +1,84 @@
+package notefile
+
+import "testing"
+
+func TestBuildFrontMatter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		fields map[string]string
+		want   string
+	}{
+		{
+			name: "known keys in order",
+			fields: map[string]string{
+				"created": "2025-01-01",
+				"status":  "In Progress",
+				"type":    "Bug",
+				"ticket":  "PROJ-123",
+			},
+			want: "---\nticket: PROJ-123\ntype: Bug\nstatus: In Progress\ncreated: 2025-01-01\n---\n\n",
+		},
+		{
+			name: "empty values omitted",
+			fields: map[string]string{
+				"ticket": "PROJ-1",
+				"type":   "",
+			},
+			want: "---\nticket: PROJ-1\n---\n\n",
+		},
+		{
+			name: "extra keys sorted after known keys",
+			fields: map[string]string{
+				"ticket":   "PROJ-1",
+				"team":     "platform",
+				"assignee": "jdoe",
+			},
+			want: "---\nticket: PROJ-1\nassignee: jdoe\nteam: platform\n---\n\n",
+		},
+		{
+			name: "special characters quoted",
+			fields: map[string]string{
+				"summary": "Fix: login fails",
+			},
+			want: "---\nsummary: \"Fix: login fails\"\n---\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := BuildFrontMatter(tt.fields); got != tt.want {
+				t.Errorf("BuildFrontMatter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasFrontMatter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{name: "lf", content: "---\nticket: PROJ-1\n---\n", want: true},
+		{name: "crlf", content: "---\r\nticket: PROJ-1\r\n---\r\n", want: true},
+		{name: "bom", content: utf8BOM + "---\nticket: PROJ-1\n---\n", want: true},
+		{name: "heading", content: "# PROJ-1\n", want: false},
+		{name: "rule later in note", content: "# PROJ-1\n\n---\n", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := HasFrontMatter(tt.content); got != tt.want {
+				t.Errorf("HasFrontMatter(%q) = %v, want %v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Package notefile provides the note file handling shared by rig's note
+// managers: an advisory lock serializing read-modify-write cycles between rig
+// processes, atomic writes that never leave a truncated note behind, YAML
+// front matter for new notes, BOM and line ending preservation for
+// line-based edits, and validation of names used as note paths.
+package notefile
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// lockTimeout bounds how long a note update waits for another rig process
+// holding the same note's lock
+const lockTimeout = 10 * time.Second
+
+// Lock retries back off from lockRetryDelay, doubling up to lockMaxRetryDelay
+const (
+	lockRetryDelay    = 10 * time.Millisecond
+	lockMaxRetryDelay = 100 * time.Millisecond
+)
+
+// Lock takes an exclusive advisory lock guarding a read-modify-write of
+// the note at path and returns the function that releases it. The lock is a
+// hidden sidecar file rather than the note itself, because WriteAtomic
+// replaces the note's inode on every write.
+func Lock(path string) (func(), error) {
+	lockPath := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".lock")
+	return acquireLock(lockPath, lockTimeout)
+}
+
+// retryLock calls try until it reports the lock was taken, returns an error,
+// or timeout elapses, backing off between attempts
+func retryLock(path string, timeout time.Duration, try func() (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	delay := lockRetryDelay
+	for {
+		locked, err := try()
+		if err != nil {
+			return errors.Wrapf(err, "failed to lock %s", path)
+		}
+		if locked {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Newf("timed out after %s waiting for lock %s", timeout, path)
+		}
+		time.Sleep(delay)
+		delay = min(delay*2, lockMaxRetryDelay)
+	}
+}
@@ -0,0 +1,33 @@
+//go:build !unix
+
+package notefile
+
+import (
+	"os"
+	"time"
+)
+
+// acquireLock creates the file at path exclusively, retrying while another
+// process holds it. Unlike flock, a lock file left behind by a crashed process
+// is not released automatically; the timeout error names the file so it can
+// be removed by hand.
+func acquireLock(path string, timeout time.Duration) (func(), error) {
+	err := retryLock(path, timeout, func() (bool, error) {
+		f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			f.Close()
+			return true, nil
+		}
+		if os.IsExist(err) {
+			return false, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		_ = os.Remove(path)
+	}, nil
+}
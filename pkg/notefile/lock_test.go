@@ -0,0 +1,68 @@
+package notefile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAcquireLock_Exclusive(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".note.md.lock")
+
+	release, err := acquireLock(path, time.Second)
+	if err != nil {
+		t.Fatalf("acquireLock() error = %v", err)
+	}
+
+	// A second holder must wait and time out while the lock is held
+	if _, err := acquireLock(path, 50*time.Millisecond); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("second acquireLock() error = %v, want timeout", err)
+	}
+
+	// Releasing lets a waiting holder in
+	acquired := make(chan error, 1)
+	go func() {
+		release2, err := acquireLock(path, 5*time.Second)
+		if err == nil {
+			release2()
+		}
+		acquired <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	release()
+
+	select {
+	case err := <-acquired:
+		if err != nil {
+			t.Errorf("acquireLock() after release error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("acquireLock() did not succeed after release")
+	}
+}
+
+func TestLock_UsesHiddenSidecar(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	notePath := filepath.Join(dir, "2025-01-15.md")
+
+	release, err := Lock(notePath)
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	defer release()
+
+	// The note itself is never created or locked directly
+	if _, err := acquireLock(filepath.Join(dir, ".2025-01-15.md.lock"), 20*time.Millisecond); err == nil {
+		t.Error("Lock() should hold the .2025-01-15.md.lock sidecar")
+	}
+	if _, err := os.Stat(notePath); err == nil {
+		t.Error("Lock() should not create the note")
+	}
+}
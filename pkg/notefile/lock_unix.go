@@ -0,0 +1,41 @@
+//go:build unix
+
+package notefile
+
+import (
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// acquireLock takes an exclusive flock on the file at path, creating it if
+// needed. The kernel releases the lock if the process dies, so a crash never
+// leaves a note locked.
+func acquireLock(path string, timeout time.Duration) (func(), error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open lock file")
+	}
+
+	err = retryLock(path, timeout, func() (bool, error) {
+		err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			return true, nil
+		}
+		if errors.Is(err, syscall.EWOULDBLOCK) || errors.Is(err, syscall.EINTR) {
+			return false, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
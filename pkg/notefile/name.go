@@ -0,0 +1,17 @@
+package notefile
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ValidateName rejects empty names, "." and "..", and names containing a
+// path separator or NUL byte. Ticket IDs and types become file and directory
+// names, so anything that could escape the notes directory is refused.
+func ValidateName(kind, value string) error {
+	if value == "" || value == "." || value == ".." || strings.ContainsAny(value, "/\\\x00") {
+		return errors.Newf("invalid %s %q: must not be empty or contain path separators or '..'", kind, value)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package notefile
+
+import "testing"
+
+func TestValidateName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "PROJ-123"},
+		{value: "winter-cleanup"},
+		{value: "..a"},
+		{value: "", wantErr: true},
+		{value: ".", wantErr: true},
+		{value: "..", wantErr: true},
+		{value: "../etc/passwd", wantErr: true},
+		{value: `..\evil`, wantErr: true},
+		{value: "/tmp/evil", wantErr: true},
+		{value: "PROJ-1\x00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			if err := ValidateName("ticket", tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateName(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
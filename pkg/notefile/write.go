@@ -0,0 +1,51 @@
+package notefile
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/cockroachdb/errors"
+)
+
+// WriteAtomic writes data to path by writing a temporary file in the same
+// directory and renaming it over path. A crash or full disk mid-write leaves
+// either the old note or the new one, never a truncated file that a vault
+// sync would pick up. The temporary file is hidden (dot-prefixed) and removed
+// on failure.
+func WriteAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary file")
+	}
+	tmpPath := tmp.Name()
+
+	renamed := false
+	defer func() {
+		if !renamed {
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to write temporary file")
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to sync temporary file")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to close temporary file")
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return errors.Wrap(err, "failed to set file permissions")
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.Wrap(err, "failed to replace file")
+	}
+	renamed = true
+
+	return nil
+}
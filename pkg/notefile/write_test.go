@@ -0,0 +1,103 @@
+package notefile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteAtomic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		existing string // Content already at the path; empty means no file
+		data     string
+	}{
+		{name: "new file", data: "# Note\n"},
+		{name: "replaces existing", existing: "# Old\n\nlonger previous content\n", data: "# New\n"},
+		{name: "empty data", existing: "# Old\n", data: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, "PROJ-1.md")
+			if tt.existing != "" {
+				if err := os.WriteFile(path, []byte(tt.existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := WriteAtomic(path, []byte(tt.data), 0600); err != nil {
+				t.Fatalf("WriteAtomic() error = %v", err)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.data {
+				t.Errorf("content = %q, want %q", got, tt.data)
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if perm := info.Mode().Perm(); perm != 0600 {
+				t.Errorf("permissions = %o, want 600", perm)
+			}
+
+			assertOnlyFile(t, dir, "PROJ-1.md")
+		})
+	}
+}
+
+func TestWriteAtomic_FailureLeavesTargetIntact(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	// Renaming a file over a non-empty directory fails, exercising cleanup
+	target := filepath.Join(dir, "note.md")
+	if err := os.MkdirAll(filepath.Join(target, "child"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteAtomic(target, []byte("data"), 0600); err == nil {
+		t.Fatal("WriteAtomic() expected error when target is a directory")
+	}
+
+	if info, err := os.Stat(target); err != nil || !info.IsDir() {
+		t.Errorf("target should be left untouched, stat = %v, %v", info, err)
+	}
+	assertOnlyFile(t, dir, "note.md")
+}
+
+func TestWriteAtomic_MissingDirectory(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "missing", "note.md")
+	if err := WriteAtomic(path, []byte("data"), 0600); err == nil {
+		t.Error("WriteAtomic() expected error for a missing directory")
+	}
+}
+
+// assertOnlyFile fails if dir holds anything but name, such as a leftover
+// temporary file
+func assertOnlyFile(t *testing.T, dir, name string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.Name() != name {
+			t.Errorf("unexpected file left in %s: %s", dir, e.Name())
+		}
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
+
+	"thoreinstein.com/rig/pkg/notefile"
 )
 
 //go:embed templates/*.tmpl
@@ -22,12 +24,49 @@ type NoteResult struct {
 	Created bool // true if newly created, false if already existed
 }
 
+// DefaultLogTimeFormat is the timestamp layout used for daily note log entries.
+const DefaultLogTimeFormat = "15:04"
+
+// namedLogTimeFormats maps friendly format names to Go time layouts.
+var namedLogTimeFormats = map[string]string{
+	"24h":         "15:04",
+	"24h-seconds": "15:04:05",
+	"12h":         "3:04 PM",
+	"12h-seconds": "3:04:05 PM",
+}
+
+// ResolveLogTimeFormat converts a named format (e.g., "12h") to a Go time layout.
+// Any other non-empty value is treated as a Go layout; empty uses DefaultLogTimeFormat.
+func ResolveLogTimeFormat(format string) string {
+	if format == "" {
+		return DefaultLogTimeFormat
+	}
+	if layout, ok := namedLogTimeFormats[strings.ToLower(format)]; ok {
+		return layout
+	}
+	return format
+}
+
+// DefaultSubdir is the subdirectory for ticket types missing from Subdirs
+const DefaultSubdir = "Tickets"
+
+// DefaultArchiveDir is the directory under BasePath for archived ticket notes
+const DefaultArchiveDir = "archive"
+
+// maxArchiveSuffix bounds the search for a free archive filename
+const maxArchiveSuffix = 1000
+
 // Manager handles markdown note operations
 type Manager struct {
-	BasePath    string // Root path for notes
-	DailyDir    string // Relative path for daily notes
-	TemplateDir string // Optional user template directory
-	Verbose     bool
+	BasePath      string            // Root path for notes
+	DailyDir      string            // Relative path for daily notes
+	TemplateDir   string            // Optional user template directory
+	LogTimeFormat string            // Timestamp format for daily log entries (see ResolveLogTimeFormat)
+	Subdirs       map[string]string // Ticket type to subdirectory (empty keeps one directory per type)
+	DefaultSubdir string            // Subdirectory for types missing from Subdirs (see DefaultSubdir)
+	ArchiveDir    string            // Directory for archived ticket notes (see DefaultArchiveDir)
+	FrontMatter   bool              // Prepend YAML front matter to new ticket notes
+	Verbose       bool
 }
 
 // TicketData holds data for template rendering
@@ -36,6 +75,7 @@ type TicketData struct {
 	TicketType   string // e.g., "proj"
 	Date         string // e.g., "2025-01-15"
 	Time         string // e.g., "14:30"
+	IssueType    string // e.g., "Bug", from JIRA or beads (if available)
 	Summary      string // From JIRA (if available)
 	Status       string // From JIRA (if available)
 	Description  string // From JIRA (if available)
@@ -47,16 +87,52 @@ type TicketData struct {
 // NewManager creates a new note Manager
 func NewManager(basePath, dailyDir, templateDir string, verbose bool) *Manager {
 	return &Manager{
-		BasePath:    basePath,
-		DailyDir:    dailyDir,
-		TemplateDir: templateDir,
-		Verbose:     verbose,
+		BasePath:      basePath,
+		DailyDir:      dailyDir,
+		TemplateDir:   templateDir,
+		LogTimeFormat: DefaultLogTimeFormat,
+		DefaultSubdir: DefaultSubdir,
+		ArchiveDir:    DefaultArchiveDir,
+		Verbose:       verbose,
+	}
+}
+
+// GetNotePath returns the path for a ticket note. The ticket type and ID
+// must each be a single path component; anything that could escape the notes
+// directory is rejected.
+func (m *Manager) GetNotePath(ticketType, ticket string) (string, error) {
+	if err := validateTicket(ticketType, ticket); err != nil {
+		return "", err
 	}
+	return filepath.Join(m.BasePath, m.Subdir(ticketType), ticket+".md"), nil
 }
 
-// GetNotePath returns the path for a ticket note
-func (m *Manager) GetNotePath(ticketType, ticket string) string {
-	return filepath.Join(m.BasePath, ticketType, ticket+".md")
+// validateTicket checks that ticketType and ticket are safe to use as path
+// components
+func validateTicket(ticketType, ticket string) error {
+	if err := notefile.ValidateName("ticket type", ticketType); err != nil {
+		return err
+	}
+	return notefile.ValidateName("ticket", ticket)
+}
+
+// Subdir returns the directory under BasePath holding notes for ticketType.
+// Without a Subdirs mapping each type gets a directory named after it.
+// Otherwise types are looked up case-insensitively, and unmapped types use
+// DefaultSubdir, falling back to the package DefaultSubdir when that is empty.
+func (m *Manager) Subdir(ticketType string) string {
+	if len(m.Subdirs) == 0 {
+		return ticketType
+	}
+	for key, subdir := range m.Subdirs {
+		if strings.EqualFold(key, ticketType) {
+			return subdir
+		}
+	}
+	if m.DefaultSubdir != "" {
+		return m.DefaultSubdir
+	}
+	return DefaultSubdir
 }
 
 // GetDailyNotePath returns the path for today's daily note
@@ -69,7 +145,10 @@ func (m *Manager) GetDailyNotePath() string {
 // Returns NoteResult with Created=true if a new note was created,
 // or Created=false if the note already existed.
 func (m *Manager) CreateTicketNote(data TicketData) (NoteResult, error) {
-	notePath := m.GetNotePath(data.TicketType, data.Ticket)
+	notePath, err := m.GetNotePath(data.TicketType, data.Ticket)
+	if err != nil {
+		return NoteResult{}, err
+	}
 	noteDir := filepath.Dir(notePath)
 
 	if m.Verbose {
@@ -86,6 +165,13 @@ func (m *Manager) CreateTicketNote(data TicketData) (NoteResult, error) {
 		return NoteResult{}, errors.Wrap(err, "failed to create note directory")
 	}
 
+	// Hold the note's lock so a concurrent rig process can't create it too
+	unlock, err := notefile.Lock(notePath)
+	if err != nil {
+		return NoteResult{}, errors.Wrap(err, "failed to lock note")
+	}
+	defer unlock()
+
 	// Check if note already exists
 	if _, err := os.Stat(notePath); err == nil {
 		if m.Verbose {
@@ -114,8 +200,13 @@ func (m *Manager) CreateTicketNote(data TicketData) (NoteResult, error) {
 		return NoteResult{}, errors.Wrap(err, "failed to render template")
 	}
 
+	// Prepend front matter unless the template already provides it
+	if m.FrontMatter && !notefile.HasFrontMatter(content) {
+		content = notefile.BuildFrontMatter(frontMatterFields(data)) + content
+	}
+
 	// Write the note with restricted permissions (may contain command history)
-	if err := os.WriteFile(notePath, []byte(content), 0600); err != nil {
+	if err := notefile.WriteAtomic(notePath, []byte(content), 0600); err != nil {
 		return NoteResult{}, errors.Wrap(err, "failed to write note")
 	}
 
@@ -126,26 +217,119 @@ func (m *Manager) CreateTicketNote(data TicketData) (NoteResult, error) {
 	return NoteResult{Path: notePath, Created: true}, nil
 }
 
+// GetArchivePath returns the path a ticket note is archived to. The note
+// keeps its subdirectory under the archive directory.
+func (m *Manager) GetArchivePath(ticketType, ticket string) (string, error) {
+	if err := validateTicket(ticketType, ticket); err != nil {
+		return "", err
+	}
+	archiveDir := m.ArchiveDir
+	if archiveDir == "" {
+		archiveDir = DefaultArchiveDir
+	}
+	return filepath.Join(m.BasePath, archiveDir, m.Subdir(ticketType), ticket+".md"), nil
+}
+
+// ArchiveNote moves a ticket note into the archive directory and returns the
+// new path. Archiving an already archived note is a no-op. If a different
+// note already occupies the archive path, a numeric suffix is appended
+// instead of overwriting it.
+func (m *Manager) ArchiveNote(ticket, ticketType string) (string, error) {
+	srcPath, err := m.GetNotePath(ticketType, ticket)
+	if err != nil {
+		return "", err
+	}
+	dstPath, err := m.GetArchivePath(ticketType, ticket)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(srcPath); os.IsNotExist(err) {
+		// Already archived: nothing left to move
+		if _, statErr := os.Stat(dstPath); statErr == nil {
+			return dstPath, nil
+		}
+		return "", errors.Newf("note not found at %s", srcPath)
+	} else if err != nil {
+		return "", errors.Wrap(err, "failed to check note")
+	}
+
+	archiveDir := filepath.Dir(dstPath)
+	if err := os.MkdirAll(archiveDir, 0700); err != nil {
+		return "", errors.Wrap(err, "failed to create archive directory")
+	}
+
+	// Never clobber an existing archived note
+	if _, err := os.Stat(dstPath); err == nil {
+		found := false
+		for i := 1; i <= maxArchiveSuffix; i++ {
+			candidate := filepath.Join(archiveDir, fmt.Sprintf("%s-%d.md", ticket, i))
+			if _, statErr := os.Stat(candidate); os.IsNotExist(statErr) {
+				dstPath = candidate
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", errors.Newf("no free archive filename for %s in %s", ticket, archiveDir)
+		}
+	}
+
+	if err := os.Rename(srcPath, dstPath); err != nil {
+		return "", errors.Wrap(err, "failed to move note to archive")
+	}
+
+	if m.Verbose {
+		fmt.Printf("Archived note to %s\n", dstPath)
+	}
+
+	return dstPath, nil
+}
+
+// frontMatterFields collects the front matter values for a new ticket note
+func frontMatterFields(data TicketData) map[string]string {
+	return map[string]string{
+		"ticket":  data.Ticket,
+		"type":    data.IssueType,
+		"status":  data.Status,
+		"summary": data.Summary,
+		"created": data.Date,
+	}
+}
+
 // UpdateDailyNote adds an entry to the daily note, creating it if necessary
 func (m *Manager) UpdateDailyNote(ticket, ticketType string) error {
 	today := time.Now().Format("2006-01-02")
-	currentTime := time.Now().Format("15:04")
+	currentTime := time.Now().Format(ResolveLogTimeFormat(m.LogTimeFormat))
 	dailyNotePath := m.GetDailyNotePath()
 
+	notePath, err := m.GetNotePath(ticketType, ticket)
+	if err != nil {
+		return err
+	}
+
 	if m.Verbose {
 		fmt.Printf("Updating daily note at: %s\n", dailyNotePath)
 	}
 
+	// Create the daily directory if needed (0700 for user-only access)
+	dailyDir := filepath.Dir(dailyNotePath)
+	if err := os.MkdirAll(dailyDir, 0700); err != nil {
+		return errors.Wrap(err, "failed to create daily notes directory")
+	}
+
+	// Hold the note's lock across the read-modify-write so entries appended
+	// by concurrent rig processes aren't lost
+	unlock, err := notefile.Lock(dailyNotePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock daily note")
+	}
+	defer unlock()
+
 	var content string
 
 	// Check if daily note exists, create if not
 	if _, statErr := os.Stat(dailyNotePath); os.IsNotExist(statErr) {
-		// Create the daily directory if needed (0700 for user-only access)
-		dailyDir := filepath.Dir(dailyNotePath)
-		if err := os.MkdirAll(dailyDir, 0700); err != nil {
-			return errors.Wrap(err, "failed to create daily notes directory")
-		}
-
 		// Render daily template
 		data := TicketData{Date: today}
 		rendered, err := m.renderTemplate("daily.md.tmpl", data)
@@ -168,9 +352,13 @@ func (m *Manager) UpdateDailyNote(ticket, ticketType string) error {
 
 	// Calculate relative path from daily note to ticket note
 	// Daily note: {base}/daily/2025-01-15.md
-	// Ticket note: {base}/proj/proj-123.md
-	// Relative path: ../proj/proj-123.md
-	relativePath := filepath.Join("..", ticketType, ticket+".md")
+	// Ticket note: {base}/{subdir}/proj-123.md
+	// Relative path: ../{subdir}/proj-123.md
+	relativePath, err := filepath.Rel(dailyDir, notePath)
+	if err != nil {
+		relativePath = filepath.Join("..", m.Subdir(ticketType), ticket+".md")
+	}
+	relativePath = filepath.ToSlash(relativePath)
 
 	// Create log entry with relative markdown link
 	logEntry := fmt.Sprintf("- [%s] [%s](%s)", currentTime, ticket, relativePath)
@@ -179,7 +367,7 @@ func (m *Manager) UpdateDailyNote(ticket, ticketType string) error {
 	updatedContent := m.insertLogEntry(content, logEntry)
 
 	// Write back to file with restricted permissions
-	if err := os.WriteFile(dailyNotePath, []byte(updatedContent), 0600); err != nil {
+	if err := notefile.WriteAtomic(dailyNotePath, []byte(updatedContent), 0600); err != nil {
 		return errors.Wrap(err, "failed to update daily note")
 	}
 
@@ -232,8 +420,15 @@ func (m *Manager) renderTemplate(name string, data TicketData) (string, error) {
 	return buf.String(), nil
 }
 
-// insertLogEntry inserts a log entry into the note content
+// insertLogEntry inserts a log entry into the note content.
+// Notes saved with a UTF-8 BOM or CRLF line endings keep them.
 func (m *Manager) insertLogEntry(content, logEntry string) string {
+	content, style := notefile.NormalizeText(content)
+	return style.Restore(insertLogEntryLF(content, logEntry))
+}
+
+// insertLogEntryLF inserts a log entry into LF-terminated note content
+func insertLogEntryLF(content, logEntry string) string {
 	lines := strings.Split(content, "\n")
 
 	// Look for ## Log section
@@ -1,6 +1,7 @@
 package notes
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -22,6 +23,9 @@ func TestNewManager(t *testing.T) {
 	if !m.Verbose {
 		t.Error("Verbose = false, want true")
 	}
+	if m.LogTimeFormat != DefaultLogTimeFormat {
+		t.Errorf("LogTimeFormat = %q, want %q", m.LogTimeFormat, DefaultLogTimeFormat)
+	}
 }
 
 func TestGetNotePath(t *testing.T) {
@@ -39,7 +43,10 @@ func TestGetNotePath(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.ticket, func(t *testing.T) {
-			got := m.GetNotePath(tt.ticketType, tt.ticket)
+			got, err := m.GetNotePath(tt.ticketType, tt.ticket)
+			if err != nil {
+				t.Fatalf("GetNotePath(%q, %q) error = %v", tt.ticketType, tt.ticket, err)
+			}
 			if got != tt.want {
 				t.Errorf("GetNotePath(%q, %q) = %q, want %q", tt.ticketType, tt.ticket, got, tt.want)
 			}
@@ -47,6 +54,117 @@ func TestGetNotePath(t *testing.T) {
 	}
 }
 
+func TestGetNotePath_Subdirs(t *testing.T) {
+	m := NewManager("/notes", "daily", "", false)
+	m.Subdirs = map[string]string{
+		"incident": "Incidents",
+		"hack":     "Hacks",
+		"FRAAS":    "Tickets",
+	}
+
+	tests := []struct {
+		name          string
+		defaultSubdir string
+		ticketType    string
+		ticket        string
+		want          string
+	}{
+		{"mapped type", "", "incident", "incident-1", "/notes/Incidents/incident-1.md"},
+		{"case-insensitive key", "", "fraas", "fraas-42", "/notes/Tickets/fraas-42.md"},
+		{"unmapped type uses package default", "", "proj", "proj-123", "/notes/Tickets/proj-123.md"},
+		{"unmapped type uses configured default", "Work", "proj", "proj-123", "/notes/Work/proj-123.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m.DefaultSubdir = tt.defaultSubdir
+			got, err := m.GetNotePath(tt.ticketType, tt.ticket)
+			if err != nil {
+				t.Fatalf("GetNotePath(%q, %q) error = %v", tt.ticketType, tt.ticket, err)
+			}
+			if got != tt.want {
+				t.Errorf("GetNotePath(%q, %q) = %q, want %q", tt.ticketType, tt.ticket, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateTicketNote_Subdir(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	m := NewManager(tmpDir, "daily", "", false)
+	m.Subdirs = map[string]string{"incident": "Incidents"}
+
+	result, err := m.CreateTicketNote(TicketData{Ticket: "incident-7", TicketType: "incident"})
+	if err != nil {
+		t.Fatalf("CreateTicketNote() error = %v", err)
+	}
+
+	want := filepath.Join(tmpDir, "Incidents", "incident-7.md")
+	if result.Path != want {
+		t.Errorf("Path = %q, want %q", result.Path, want)
+	}
+	if _, err := os.Stat(want); err != nil {
+		t.Errorf("note should exist at %s: %v", want, err)
+	}
+}
+
+func TestManager_RejectsPathTraversal(t *testing.T) {
+	tests := []struct {
+		name       string
+		ticketType string
+		ticket     string
+	}{
+		{"traversal in ticket", "proj", "../../../etc/passwd"},
+		{"traversal in type", "../../outside", "proj-1"},
+		{"dotdot type", "..", "proj-1"},
+		{"absolute ticket", "proj", "/tmp/evil"},
+		{"backslash ticket", "proj", `..\..\evil`},
+		{"nested ticket", "proj", "a/b"},
+		{"empty ticket", "proj", ""},
+		{"empty type", "", "proj-1"},
+		{"nul byte", "proj", "proj-1\x00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			base := filepath.Join(root, "notes")
+			if err := os.MkdirAll(base, 0700); err != nil {
+				t.Fatal(err)
+			}
+			m := NewManager(base, "daily", "", false)
+
+			if _, err := m.GetNotePath(tt.ticketType, tt.ticket); err == nil || !strings.Contains(err.Error(), "invalid ticket") {
+				t.Errorf("GetNotePath(%q, %q) error = %v, want invalid ticket error", tt.ticketType, tt.ticket, err)
+			}
+			if _, err := m.CreateTicketNote(TicketData{Ticket: tt.ticket, TicketType: tt.ticketType}); err == nil || !strings.Contains(err.Error(), "invalid ticket") {
+				t.Errorf("CreateTicketNote(%q, %q) error = %v, want invalid ticket error", tt.ticketType, tt.ticket, err)
+			}
+			if err := m.UpdateDailyNote(tt.ticket, tt.ticketType); err == nil || !strings.Contains(err.Error(), "invalid ticket") {
+				t.Errorf("UpdateDailyNote(%q, %q) error = %v, want invalid ticket error", tt.ticket, tt.ticketType, err)
+			}
+			if _, err := m.ArchiveNote(tt.ticket, tt.ticketType); err == nil || !strings.Contains(err.Error(), "invalid ticket") {
+				t.Errorf("ArchiveNote(%q, %q) error = %v, want invalid ticket error", tt.ticket, tt.ticketType, err)
+			}
+
+			// Nothing may be written anywhere, inside or outside the notes directory
+			err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if path != root && path != base {
+					t.Errorf("unexpected file created: %s", path)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
 func TestGetDailyNotePath(t *testing.T) {
 	m := NewManager("/notes", "daily", "", false)
 
@@ -153,6 +271,75 @@ func TestCreateTicketNote_AlreadyExists(t *testing.T) {
 	}
 }
 
+func TestCreateTicketNote_FrontMatter(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	m := NewManager(tmpDir, "daily", "", false)
+	m.FrontMatter = true
+
+	data := TicketData{
+		Ticket:     "proj-123",
+		TicketType: "proj",
+		Date:       "2025-01-01",
+		IssueType:  "Bug",
+		Status:     "In Progress",
+		Summary:    "Fix: login loop",
+	}
+
+	result, err := m.CreateTicketNote(data)
+	if err != nil {
+		t.Fatalf("CreateTicketNote() error = %v, want nil", err)
+	}
+
+	content, err := os.ReadFile(result.Path)
+	if err != nil {
+		t.Fatalf("Failed to read note: %v", err)
+	}
+
+	want := "---\nticket: proj-123\ntype: Bug\nstatus: In Progress\nsummary: \"Fix: login loop\"\ncreated: 2025-01-01\n---\n\n# proj-123"
+	if !strings.HasPrefix(string(content), want) {
+		t.Errorf("note should start with front matter:\n%s", content)
+	}
+
+	// Creating the note again leaves it, and its front matter, alone
+	if _, err := m.CreateTicketNote(data); err != nil {
+		t.Fatalf("CreateTicketNote() error = %v, want nil", err)
+	}
+	again, err := os.ReadFile(result.Path)
+	if err != nil {
+		t.Fatalf("Failed to read note: %v", err)
+	}
+	if strings.Count(string(again), "ticket: proj-123") != 1 {
+		t.Errorf("front matter duplicated:\n%s", again)
+	}
+}
+
+func TestCreateTicketNote_FrontMatterFromTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+	templateDir := t.TempDir()
+
+	userTemplate := "---\nticket: {{.Ticket}}\n---\n\n# {{.Ticket}}\n"
+	if err := os.WriteFile(filepath.Join(templateDir, "ticket.md.tmpl"), []byte(userTemplate), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	m := NewManager(tmpDir, "daily", templateDir, false)
+	m.FrontMatter = true
+
+	result, err := m.CreateTicketNote(TicketData{Ticket: "proj-123", TicketType: "proj", Status: "Open"})
+	if err != nil {
+		t.Fatalf("CreateTicketNote() error = %v, want nil", err)
+	}
+
+	content, err := os.ReadFile(result.Path)
+	if err != nil {
+		t.Fatalf("Failed to read note: %v", err)
+	}
+	if strings.Count(string(content), "---\n") != 2 || strings.Contains(string(content), "status:") {
+		t.Errorf("template front matter should be kept as is:\n%s", content)
+	}
+}
+
 func TestCreateTicketNote_HackTemplate(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -222,6 +409,70 @@ func TestUpdateDailyNote_CreateNew(t *testing.T) {
 	}
 }
 
+func TestUpdateDailyNote_SubdirLink(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	m := NewManager(tmpDir, "daily", "", false)
+	m.Subdirs = map[string]string{"incident": "Incidents"}
+
+	if err := m.UpdateDailyNote("INC-1", "incident"); err != nil {
+		t.Fatalf("UpdateDailyNote() error = %v, want nil", err)
+	}
+
+	content, err := os.ReadFile(m.GetDailyNotePath())
+	if err != nil {
+		t.Fatalf("Failed to read daily note: %v", err)
+	}
+
+	if !strings.Contains(string(content), "[INC-1](../Incidents/INC-1.md)") {
+		t.Errorf("Daily note should link into mapped subdir, got: %s", string(content))
+	}
+}
+
+func TestResolveLogTimeFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"", "15:04"},
+		{"24h", "15:04"},
+		{"12h", "3:04 PM"},
+		{"12H", "3:04 PM"},
+		{"24h-seconds", "15:04:05"},
+		{"12h-seconds", "3:04:05 PM"},
+		{"15:04:05 MST", "15:04:05 MST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := ResolveLogTimeFormat(tt.format); got != tt.want {
+				t.Errorf("ResolveLogTimeFormat(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateDailyNote_LogTimeFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	m := NewManager(tmpDir, "daily", "", false)
+	m.LogTimeFormat = "12h"
+
+	if err := m.UpdateDailyNote("proj-123", "proj"); err != nil {
+		t.Fatalf("UpdateDailyNote() error = %v, want nil", err)
+	}
+
+	content, err := os.ReadFile(m.GetDailyNotePath())
+	if err != nil {
+		t.Fatalf("Failed to read daily note: %v", err)
+	}
+
+	// 12-hour timestamps always carry an AM/PM suffix
+	if !strings.Contains(string(content), "AM] [proj-123]") && !strings.Contains(string(content), "PM] [proj-123]") {
+		t.Errorf("Daily note should use 12h timestamp, got: %s", string(content))
+	}
+}
+
 func TestUpdateDailyNote_AppendToExisting(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -290,6 +541,33 @@ func TestInsertLogEntry_NoLogSection(t *testing.T) {
 	}
 }
 
+func TestInsertLogEntry_CRLFAndBOM(t *testing.T) {
+	m := NewManager("/notes", "daily", "", false)
+
+	lf := "# 2025-01-15\n\n## Log\n\n- [09:00] earlier\n\n## Other\n\nText\n"
+	entry := "- [14:30] [proj-123](../proj/proj-123.md)"
+	wantLF := m.insertLogEntry(lf, entry)
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"crlf", strings.ReplaceAll(lf, "\n", "\r\n"), strings.ReplaceAll(wantLF, "\n", "\r\n")},
+		{"bom", "\ufeff" + lf, "\ufeff" + wantLF},
+		{"crlf and bom", "\ufeff" + strings.ReplaceAll(lf, "\n", "\r\n"), "\ufeff" + strings.ReplaceAll(wantLF, "\n", "\r\n")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := m.insertLogEntry(tt.content, entry)
+			if got != tt.want {
+				t.Errorf("insertLogEntry() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRenderTemplate_EmbeddedTemplate(t *testing.T) {
 	m := NewManager("/notes", "daily", "", false)
 
@@ -351,3 +629,88 @@ func TestRenderTemplate_InvalidTemplate(t *testing.T) {
 		t.Fatal("renderTemplate() expected error for nonexistent template, got nil")
 	}
 }
+
+func TestArchiveNote(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	m := NewManager(tmpDir, "daily", "", false)
+	m.Subdirs = map[string]string{"proj": "Work"}
+
+	writeNote := func(content string) {
+		t.Helper()
+		notePath := filepath.Join(tmpDir, "Work", "proj-123.md")
+		if err := os.MkdirAll(filepath.Dir(notePath), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(notePath, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeNote("first")
+	archived, err := m.ArchiveNote("proj-123", "proj")
+	if err != nil {
+		t.Fatalf("ArchiveNote() error = %v", err)
+	}
+	want := filepath.Join(tmpDir, "archive", "Work", "proj-123.md")
+	if archived != want {
+		t.Errorf("ArchiveNote() = %q, want %q", archived, want)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "Work", "proj-123.md")); !os.IsNotExist(err) {
+		t.Error("note should no longer be in its ticket directory")
+	}
+
+	// Archiving again is a no-op
+	again, err := m.ArchiveNote("proj-123", "proj")
+	if err != nil || again != want {
+		t.Errorf("ArchiveNote() again = %q, %v; want %q, nil", again, err, want)
+	}
+
+	// A new note with the same name gets a suffix rather than clobbering
+	writeNote("second")
+	suffixed, err := m.ArchiveNote("proj-123", "proj")
+	if err != nil {
+		t.Fatalf("ArchiveNote() error = %v", err)
+	}
+	if suffixed != filepath.Join(tmpDir, "archive", "Work", "proj-123-1.md") {
+		t.Errorf("ArchiveNote() = %q, want a -1 suffix", suffixed)
+	}
+	if content, _ := os.ReadFile(want); string(content) != "first" {
+		t.Errorf("first archived note = %q, want it untouched", content)
+	}
+
+	if _, err := m.ArchiveNote("proj-999", "proj"); err == nil {
+		t.Error("ArchiveNote() should fail for a missing note")
+	}
+}
+
+func TestUpdateDailyNote_Concurrent(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	m := NewManager(tmpDir, "daily", "", false)
+
+	const writers = 8
+	errs := make(chan error, writers)
+	for i := range writers {
+		go func() {
+			errs <- m.UpdateDailyNote(fmt.Sprintf("proj-%d", i), "proj")
+		}()
+	}
+	for range writers {
+		if err := <-errs; err != nil {
+			t.Fatalf("UpdateDailyNote() error = %v", err)
+		}
+	}
+
+	content, err := os.ReadFile(m.GetDailyNotePath())
+	if err != nil {
+		t.Fatalf("Failed to read daily note: %v", err)
+	}
+
+	// Every entry survives; none was lost to an interleaved read-modify-write
+	for i := range writers {
+		if entry := fmt.Sprintf("[proj-%d]", i); !strings.Contains(string(content), entry) {
+			t.Errorf("daily note missing %s:\n%s", entry, content)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package obsidian
+
+import (
+	"io"
+	"io/fs"
+	"os"
+
+	"thoreinstein.com/rig/pkg/notefile"
+)
+
+// FileSystem is the filesystem a NoteManager reads and writes the vault
+// through. OSFileSystem is used in production; tests can substitute an
+// in-memory implementation.
+type FileSystem interface {
+	Stat(name string) (fs.FileInfo, error)
+	ReadFile(name string) ([]byte, error)
+	// Open opens name for streaming reads
+	Open(name string) (io.ReadCloser, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	MkdirAll(path string, perm fs.FileMode) error
+	// WriteFile replaces name with data; a partial write must never be visible
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	// Lock takes an exclusive lock guarding a read-modify-write of name and
+	// returns the function that releases it
+	Lock(name string) (func(), error)
+}
+
+// OSFileSystem is the FileSystem backed by the operating system. Writes are
+// atomic (notefile.WriteAtomic) and locks are advisory file locks (notefile.Lock).
+type OSFileSystem struct{}
+
+// Stat implements FileSystem
+func (OSFileSystem) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// ReadFile implements FileSystem
+func (OSFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+// Open implements FileSystem
+func (OSFileSystem) Open(name string) (io.ReadCloser, error) {
+	return os.Open(name)
+}
+
+// ReadDir implements FileSystem
+func (OSFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+// MkdirAll implements FileSystem
+func (OSFileSystem) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+// WriteFile implements FileSystem
+func (OSFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return notefile.WriteAtomic(name, data, perm)
+}
+
+// Lock implements FileSystem
+func (OSFileSystem) Lock(name string) (func(), error) {
+	return notefile.Lock(name)
+}
@@ -0,0 +1,250 @@
+package obsidian
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+var _ FileSystem = OSFileSystem{}
+
+// memFS is an in-memory FileSystem backed by fstest.MapFS. Absolute paths
+// are stored without their leading slash.
+type memFS struct {
+	mu    sync.Mutex
+	files fstest.MapFS
+	lock  sync.Mutex
+}
+
+func newMemFS(files map[string]string) *memFS {
+	m := &memFS{files: fstest.MapFS{}}
+	for name, data := range files {
+		m.files[m.key(name)] = &fstest.MapFile{Data: []byte(data), Mode: 0600}
+	}
+	return m
+}
+
+func (m *memFS) key(name string) string {
+	return strings.TrimPrefix(path.Clean(filepath.ToSlash(name)), "/")
+}
+
+func (m *memFS) Stat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return fs.Stat(m.files, m.key(name))
+}
+
+func (m *memFS) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return fs.ReadFile(m.files, m.key(name))
+}
+
+func (m *memFS) Open(name string) (io.ReadCloser, error) {
+	data, err := m.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return fs.ReadDir(m.files, m.key(name))
+}
+
+func (m *memFS) MkdirAll(name string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for dir := m.key(name); dir != "." && dir != ""; dir = path.Dir(dir) {
+		if _, ok := m.files[dir]; !ok {
+			m.files[dir] = &fstest.MapFile{Mode: fs.ModeDir | perm}
+		}
+	}
+	return nil
+}
+
+func (m *memFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if dir := path.Dir(m.key(name)); dir != "." {
+		if _, err := fs.Stat(m.files, dir); err != nil {
+			return &fs.PathError{Op: "write", Path: name, Err: fs.ErrNotExist}
+		}
+	}
+	m.files[m.key(name)] = &fstest.MapFile{Data: append([]byte(nil), data...), Mode: perm}
+	return nil
+}
+
+func (m *memFS) Lock(name string) (func(), error) {
+	m.lock.Lock()
+	return m.lock.Unlock, nil
+}
+
+func (m *memFS) content(t *testing.T, name string) string {
+	t.Helper()
+	data, err := m.ReadFile(name)
+	if err != nil {
+		t.Fatalf("reading %s from memory FS: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestNoteManager_MemFS_CreateTicketNoteFromTemplate(t *testing.T) {
+	t.Parallel()
+
+	fsys := newMemFS(map[string]string{
+		"/vault/templates/bug.md":  "# {{ticket}} ({{type}})\n\n## Summary\n\n{{summary}}\n\n## Log\n",
+		"/vault/templates/Jira.md": "# generic\n",
+	})
+	nm := NewNoteManagerWithFS("/vault", "templates", "Areas", "Daily", false, fsys)
+
+	notePath, err := nm.CreateTicketNote("proj", "PROJ-1", &JiraInfo{Type: "Bug", Summary: "Crash on save", Status: "Open"})
+	if err != nil {
+		t.Fatalf("CreateTicketNote() error = %v", err)
+	}
+	if want := filepath.Join("/vault", "Areas", "proj", "PROJ-1.md"); notePath != want {
+		t.Errorf("CreateTicketNote() path = %q, want %q", notePath, want)
+	}
+
+	content := fsys.content(t, notePath)
+	for _, want := range []string{"# PROJ-1 (Bug)", "Crash on save", "**Status:** Open"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("note should contain %q, got:\n%s", want, content)
+		}
+	}
+
+	// A second call leaves the existing note alone
+	if err := fsys.WriteFile(notePath, []byte("edited"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nm.CreateTicketNote("proj", "PROJ-1", &JiraInfo{Type: "Bug"}); err != nil {
+		t.Fatalf("CreateTicketNote() on existing note error = %v", err)
+	}
+	if content := fsys.content(t, notePath); content != "edited" {
+		t.Errorf("existing note was overwritten: %q", content)
+	}
+}
+
+func TestNoteManager_MemFS_ResolveJiraTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		files     map[string]string
+		issueType string
+		want      string
+	}{
+		{
+			name:      "type template matched case-insensitively",
+			files:     map[string]string{"/vault/templates/STORY.md": "", "/vault/templates/Jira.md": ""},
+			issueType: "story",
+			want:      "/vault/templates/STORY.md",
+		},
+		{
+			name:      "falls back to Jira.md",
+			files:     map[string]string{"/vault/templates/Jira.md": ""},
+			issueType: "Epic",
+			want:      "/vault/templates/Jira.md",
+		},
+		{
+			name:      "no templates directory",
+			files:     map[string]string{"/vault/Areas/x.md": ""},
+			issueType: "Bug",
+			want:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			nm := NewNoteManagerWithFS("/vault", "templates", "Areas", "Daily", false, newMemFS(tt.files))
+			if got := nm.resolveJiraTemplate(tt.issueType); got != filepath.FromSlash(tt.want) {
+				t.Errorf("resolveJiraTemplate(%q) = %q, want %q", tt.issueType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoteManager_MemFS_VaultExists(t *testing.T) {
+	t.Parallel()
+
+	fsys := newMemFS(map[string]string{"/vault/Daily/2025-01-01.md": "x"})
+
+	if !NewNoteManagerWithFS("/vault", "", "", "", false, fsys).vaultExists() {
+		t.Error("vaultExists() = false for a vault holding files")
+	}
+	if NewNoteManagerWithFS("/missing", "", "", "", false, fsys).vaultExists() {
+		t.Error("vaultExists() = true for a missing vault")
+	}
+
+	if _, err := NewNoteManagerWithFS("/missing", "", "Areas", "", false, fsys).CreateTicketNote("proj", "PROJ-1", nil); err == nil {
+		t.Error("CreateTicketNote() expected error for missing vault")
+	}
+}
+
+func TestNoteManager_MemFS_UpdateDailyNote(t *testing.T) {
+	t.Parallel()
+
+	fsys := newMemFS(map[string]string{"/vault/.keep": ""})
+	nm := NewNoteManagerWithFS("/vault", "templates", "Areas", "Daily", false, fsys)
+
+	for _, ticket := range []string{"PROJ-1", "PROJ-2"} {
+		if err := nm.UpdateDailyNote(ticket); err != nil {
+			t.Fatalf("UpdateDailyNote(%s) error = %v", ticket, err)
+		}
+	}
+
+	content := fsys.content(t, filepath.Join("/vault", "Daily", getTodayDate()+".md"))
+	for _, want := range []string{"[[PROJ-1]]", "[[PROJ-2]]"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("daily note should contain %s, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestNoteManager_MemFS_SearchNotes(t *testing.T) {
+	t.Parallel()
+
+	fsys := newMemFS(map[string]string{
+		"/vault/Areas/proj/PROJ-1.md":           "# PROJ-1\n\nConnection pool exhausted\n",
+		"/vault/Areas/proj/PROJ-2.md":           "# PROJ-2\n\nnothing here\nraise the connection POOL size\n",
+		"/vault/Areas/proj/notes.txt":           "connection pool in a text file\n",
+		"/vault/Areas/.obsidian/cache.md":       "connection pool in hidden dir\n",
+		"/vault/Areas/templates/Jira.md":        "connection pool template\n",
+		"/vault/Areas/incident/INC-1/report.md": "no match\n",
+	})
+	nm := NewNoteManagerWithFS("/vault", filepath.Join("Areas", "templates"), "Areas", "Daily", false, fsys)
+
+	matches, err := nm.SearchNotes("connection pool")
+	if err != nil {
+		t.Fatalf("SearchNotes() error = %v", err)
+	}
+
+	want := []NoteMatch{
+		{Path: filepath.Join("/vault", "Areas", "proj", "PROJ-1.md"), Line: 3, Text: "Connection pool exhausted"},
+		{Path: filepath.Join("/vault", "Areas", "proj", "PROJ-2.md"), Line: 4, Text: "raise the connection POOL size"},
+	}
+	if len(matches) != len(want) {
+		t.Fatalf("SearchNotes() = %+v, want %+v", matches, want)
+	}
+	for i := range want {
+		if matches[i] != want[i] {
+			t.Errorf("match %d = %+v, want %+v", i, matches[i], want[i])
+		}
+	}
+
+	// A vault without the notes subdirectory has nothing to search
+	empty := NewNoteManagerWithFS("/vault", "", "Missing", "", false, fsys)
+	if matches, err := empty.SearchNotes("pool"); err != nil || matches != nil {
+		t.Errorf("SearchNotes() on missing subdirectory = %v, %v; want nil, nil", matches, err)
+	}
+}
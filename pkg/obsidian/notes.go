@@ -2,23 +2,31 @@ package obsidian
 
 import (
 	"fmt"
-	"os"
+	"io/fs"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 	"unicode"
 
 	"github.com/cockroachdb/errors"
+
+	"thoreinstein.com/rig/pkg/notefile"
+	"thoreinstein.com/rig/pkg/notes"
 )
 
 // NoteManager handles Obsidian note operations
 type NoteManager struct {
-	VaultPath    string
-	TemplatesDir string
-	AreasDir     string
-	DailyDir     string
-	VaultSubdir  string // Configurable subdirectory (e.g., "Jira", "Incidents", "Hacks")
-	Verbose      bool
+	VaultPath     string
+	TemplatesDir  string
+	AreasDir      string
+	DailyDir      string
+	WeeklyDir     string // Directory for weekly rollup notes (defaults to "weekly")
+	VaultSubdir   string // Configurable subdirectory (e.g., "Jira", "Incidents", "Hacks")
+	FrontMatter   bool   // Prepend YAML front matter to new ticket notes
+	LogTimeFormat string // Log entry time format, named or a Go layout (see notes.ResolveLogTimeFormat)
+	Verbose       bool
+	fsys          FileSystem
 }
 
 // NewNoteManager creates a new NoteManager
@@ -28,23 +36,51 @@ func NewNoteManager(vaultPath, templatesDir, areasDir, dailyDir string, verbose
 		TemplatesDir: templatesDir,
 		AreasDir:     areasDir,
 		DailyDir:     dailyDir,
+		WeeklyDir:    "weekly",
 		VaultSubdir:  "", // Will use default logic if not set
 		Verbose:      verbose,
+		fsys:         OSFileSystem{},
 	}
 }
 
+// NewNoteManagerWithFS creates a NoteManager that accesses the vault through
+// fsys instead of the OS filesystem (for testing)
+func NewNoteManagerWithFS(vaultPath, templatesDir, areasDir, dailyDir string, verbose bool, fsys FileSystem) *NoteManager {
+	nm := NewNoteManager(vaultPath, templatesDir, areasDir, dailyDir, verbose)
+	nm.fsys = fsys
+	return nm
+}
+
 // SetVaultSubdir sets the vault subdirectory for note creation
 func (nm *NoteManager) SetVaultSubdir(subdir string) {
 	nm.VaultSubdir = subdir
 }
 
+// SetWeeklyDir sets the directory for weekly notes
+func (nm *NoteManager) SetWeeklyDir(dir string) {
+	nm.WeeklyDir = dir
+}
+
+// ticketNotePath returns the path of a ticket note within the vault. The
+// ticket type and ID become a directory and file name, so both must be a
+// single path component; anything that could escape the vault is rejected.
+func (nm *NoteManager) ticketNotePath(ticketType, ticket string) (string, error) {
+	if err := notefile.ValidateName("ticket type", ticketType); err != nil {
+		return "", err
+	}
+	if err := notefile.ValidateName("ticket", ticket); err != nil {
+		return "", err
+	}
+	return filepath.Join(nm.VaultPath, nm.AreasDir, nm.VaultSubdir, ticketType, ticket+".md"), nil
+}
+
 // CreateTicketNote creates or updates a ticket note in Obsidian
 func (nm *NoteManager) CreateTicketNote(ticketType, ticket string, jiraInfo *JiraInfo) (string, error) {
-	// Use configured vault subdirectory (should be set via SetVaultSubdir)
-	vaultSubdir := nm.VaultSubdir
-
-	// Create full note path
-	notePath := filepath.Join(nm.VaultPath, nm.AreasDir, vaultSubdir, ticketType, ticket+".md")
+	// Create full note path (uses the subdirectory set via SetVaultSubdir)
+	notePath, err := nm.ticketNotePath(ticketType, ticket)
+	if err != nil {
+		return "", err
+	}
 	noteDir := filepath.Dir(notePath)
 
 	if nm.Verbose {
@@ -57,12 +93,12 @@ func (nm *NoteManager) CreateTicketNote(ticketType, ticket string, jiraInfo *Jir
 	}
 
 	// Create directory if it doesn't exist (restricted permissions for user data)
-	if err := os.MkdirAll(noteDir, 0700); err != nil {
+	if err := nm.fsys.MkdirAll(noteDir, 0700); err != nil {
 		return "", errors.Wrap(err, "failed to create note directory")
 	}
 
 	// Check if note already exists
-	if _, err := os.Stat(notePath); err == nil {
+	if _, err := nm.fsys.Stat(notePath); err == nil {
 		if nm.Verbose {
 			fmt.Printf("Note already exists at %s\n", notePath)
 		}
@@ -71,7 +107,6 @@ func (nm *NoteManager) CreateTicketNote(ticketType, ticket string, jiraInfo *Jir
 
 	// Create the note content
 	var content string
-	var err error
 
 	if ticketType != "incident" && jiraInfo != nil {
 		content, err = nm.createJiraNote(ticket, jiraInfo)
@@ -83,8 +118,13 @@ func (nm *NoteManager) CreateTicketNote(ticketType, ticket string, jiraInfo *Jir
 		return "", errors.Wrap(err, "failed to create note content")
 	}
 
+	// Prepend front matter unless the template already provides it
+	if nm.FrontMatter && !notefile.HasFrontMatter(content) {
+		content = notefile.BuildFrontMatter(nm.frontMatterFields(ticket, jiraInfo)) + content
+	}
+
 	// Write the note with restricted permissions (may contain command history)
-	if err := os.WriteFile(notePath, []byte(content), 0600); err != nil {
+	if err := nm.fsys.WriteFile(notePath, []byte(content), 0600); err != nil {
 		return "", errors.Wrap(err, "failed to write note")
 	}
 
@@ -100,18 +140,68 @@ type JiraInfo struct {
 	Type        string
 	Summary     string
 	Status      string
+	Priority    string
 	Description string
+	Labels      []string
+	Components  []string
+}
+
+// frontMatterFields collects the front matter values for a new ticket note.
+func (nm *NoteManager) frontMatterFields(ticket string, jiraInfo *JiraInfo) map[string]string {
+	fields := map[string]string{
+		"ticket":  ticket,
+		"created": time.Now().Format("2006-01-02"),
+	}
+
+	if jiraInfo != nil {
+		fields["type"] = jiraInfo.Type
+		fields["status"] = jiraInfo.Status
+		fields["summary"] = jiraInfo.Summary
+	}
+
+	return fields
+}
+
+// resolveJiraTemplate finds the template for a Jira issue type.
+// It looks for "<Type>.md" (case-insensitive) in the templates directory,
+// falling back to "Jira.md". Returns an empty string if neither exists.
+func (nm *NoteManager) resolveJiraTemplate(issueType string) string {
+	templatesDir := filepath.Join(nm.VaultPath, nm.TemplatesDir)
+
+	entries, err := nm.fsys.ReadDir(templatesDir)
+	if err != nil {
+		return ""
+	}
+
+	candidates := []string{"Jira.md"}
+	if issueType != "" {
+		candidates = append([]string{issueType + ".md"}, candidates...)
+	}
+
+	for _, candidate := range candidates {
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.EqualFold(entry.Name(), candidate) {
+				return filepath.Join(templatesDir, entry.Name())
+			}
+		}
+	}
+
+	return ""
 }
 
 // createJiraNote creates a note with JIRA template and information
 func (nm *NoteManager) createJiraNote(ticket string, jiraInfo *JiraInfo) (string, error) {
-	templatePath := filepath.Join(nm.VaultPath, nm.TemplatesDir, "Jira.md")
+	templatePath := nm.resolveJiraTemplate(jiraInfo.Type)
 
 	var content string
 
 	// Try to use template if it exists
-	if _, err := os.Stat(templatePath); err == nil {
-		templateBytes, err := os.ReadFile(templatePath)
+	if templatePath != "" {
+		if nm.Verbose {
+			fmt.Printf("Using template: %s\n", templatePath)
+		}
+
+		templateBytes, err := nm.fsys.ReadFile(templatePath)
 		if err != nil {
 			return "", errors.Wrap(err, "failed to read template")
 		}
@@ -122,12 +212,21 @@ func (nm *NoteManager) createJiraNote(ticket string, jiraInfo *JiraInfo) (string
 			content = strings.ReplaceAll(content, "<Insert ticket title or short summary here>", jiraInfo.Summary)
 		}
 
-		// Replace date placeholder
+		// Replace Templater date placeholder for backward compatibility
 		today := time.Now().Format("2006-01-02")
 		content = strings.ReplaceAll(content, "<% tp.date.now(\"YYYY-MM-DD\") %>", today)
 
+		content = renderTemplate(content, map[string]string{
+			"ticket":   ticket,
+			"type":     jiraInfo.Type,
+			"status":   jiraInfo.Status,
+			"priority": jiraInfo.Priority,
+			"summary":  jiraInfo.Summary,
+			"date":     today,
+		})
+
 		// Add JIRA details section after ## Summary
-		if jiraInfo.Type != "" || jiraInfo.Status != "" || jiraInfo.Description != "" {
+		if jiraInfo.Type != "" || jiraInfo.Status != "" || strings.TrimSpace(jiraInfo.Description) != "" {
 			jiraSection := nm.buildJiraSection(jiraInfo)
 			content = nm.insertAfterSummary(content, jiraSection)
 		}
@@ -139,27 +238,47 @@ func (nm *NoteManager) createJiraNote(ticket string, jiraInfo *JiraInfo) (string
 	return content, nil
 }
 
-// createBasicNote creates a basic note without template
-func (nm *NoteManager) createBasicNote(ticket, ticketType string) (string, error) {
-	today := time.Now().Format("2006-01-02")
-
-	content := fmt.Sprintf(`# %s
+// basicNoteTemplate is the built-in note layout used when no Jira details are available
+const basicNoteTemplate = `# {{ticket}}
 
 ## Summary
 
-Work on %s ticket: %s
+Work on {{type}} ticket: {{ticket}}
 
 ## Notes
 
-- Created: %s
+- Created: {{date}}
 
 ## Log
 
-`, ticket, titleCase(ticketType), ticket, today)
+`
+
+// createBasicNote creates a basic note without template
+func (nm *NoteManager) createBasicNote(ticket, ticketType string) (string, error) {
+	content := renderTemplate(basicNoteTemplate, map[string]string{
+		"ticket": ticket,
+		"type":   titleCase(ticketType),
+		"date":   time.Now().Format("2006-01-02"),
+	})
 
 	return content, nil
 }
 
+// templateVarPattern matches {{name}} tokens, allowing surrounding whitespace
+var templateVarPattern = regexp.MustCompile(`\{\{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`)
+
+// renderTemplate substitutes {{name}} tokens with values from vars.
+// Tokens without a matching variable are left untouched so they remain visible.
+func renderTemplate(content string, vars map[string]string) string {
+	return templateVarPattern.ReplaceAllStringFunc(content, func(token string) string {
+		name := templateVarPattern.FindStringSubmatch(token)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return token
+	})
+}
+
 // titleCase capitalizes the first letter of a string (replacement for deprecated strings.Title)
 func titleCase(s string) string {
 	if s == "" {
@@ -184,7 +303,15 @@ func (nm *NoteManager) buildJiraSection(jiraInfo *JiraInfo) string {
 		section.WriteString(fmt.Sprintf("**Status:** %s\n", jiraInfo.Status))
 	}
 
-	if jiraInfo.Description != "" {
+	if len(jiraInfo.Labels) > 0 {
+		section.WriteString(fmt.Sprintf("**Labels:** %s\n", strings.Join(jiraInfo.Labels, ", ")))
+	}
+
+	if len(jiraInfo.Components) > 0 {
+		section.WriteString(fmt.Sprintf("**Components:** %s\n", strings.Join(jiraInfo.Components, ", ")))
+	}
+
+	if strings.TrimSpace(jiraInfo.Description) != "" {
 		section.WriteString(fmt.Sprintf("\n**Description:**\n%s\n", jiraInfo.Description))
 	}
 
@@ -193,8 +320,11 @@ func (nm *NoteManager) buildJiraSection(jiraInfo *JiraInfo) string {
 	return section.String()
 }
 
-// insertAfterSummary inserts content after the ## Summary section
+// insertAfterSummary inserts content after the ## Summary section, or at the
+// end of the note when it has no Summary so the insertion is never dropped.
+// The note's BOM and line endings are preserved.
 func (nm *NoteManager) insertAfterSummary(content, insertion string) string {
+	content, style := notefile.NormalizeText(content)
 	lines := strings.Split(content, "\n")
 	result := make([]string, 0, len(lines)+1)
 	summaryFound := false
@@ -221,13 +351,13 @@ func (nm *NoteManager) insertAfterSummary(content, insertion string) string {
 		}
 	}
 
-	// If we never found a place to insert, append at the end
-	if summaryFound && !insertionDone {
+	// If there is no Summary or we never found a place to insert, append at the end
+	if !insertionDone {
 		result = append(result, "")
 		result = append(result, strings.Split(insertion, "\n")...)
 	}
 
-	return strings.Join(result, "\n")
+	return style.Restore(strings.Join(result, "\n"))
 }
 
 // createDefaultJiraNote creates a default JIRA note structure
@@ -258,23 +388,31 @@ func (nm *NoteManager) createDefaultJiraNote(ticket string, jiraInfo *JiraInfo)
 // UpdateDailyNote adds an entry to the daily note, creating it if necessary
 func (nm *NoteManager) UpdateDailyNote(ticket string) error {
 	today := time.Now().Format("2006-01-02")
-	currentTime := time.Now().Format("15:04")
+	currentTime := time.Now().Format(notes.ResolveLogTimeFormat(nm.LogTimeFormat))
 	dailyNotePath := filepath.Join(nm.VaultPath, nm.DailyDir, today+".md")
 
 	if nm.Verbose {
 		fmt.Printf("Updating daily note at: %s\n", dailyNotePath)
 	}
 
+	// Create the daily directory if needed
+	dailyDir := filepath.Dir(dailyNotePath)
+	if err := nm.fsys.MkdirAll(dailyDir, 0755); err != nil {
+		return errors.Wrap(err, "failed to create daily notes directory")
+	}
+
+	// Serialize with other rig processes updating the same note so that no
+	// log entry is lost between read and write
+	unlock, err := nm.fsys.Lock(dailyNotePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock daily note")
+	}
+	defer unlock()
+
 	var content []byte
 
 	// Check if daily note exists, create if not
-	if _, statErr := os.Stat(dailyNotePath); os.IsNotExist(statErr) {
-		// Create the daily directory if needed
-		dailyDir := filepath.Dir(dailyNotePath)
-		if err := os.MkdirAll(dailyDir, 0755); err != nil {
-			return errors.Wrap(err, "failed to create daily notes directory")
-		}
-
+	if _, statErr := nm.fsys.Stat(dailyNotePath); errors.Is(statErr, fs.ErrNotExist) {
 		// Create default daily note
 		content = []byte(nm.createDefaultDailyNote(today))
 		if nm.Verbose {
@@ -282,8 +420,7 @@ func (nm *NoteManager) UpdateDailyNote(ticket string) error {
 		}
 	} else {
 		// Read existing content
-		var err error
-		content, err = os.ReadFile(dailyNotePath)
+		content, err = nm.fsys.ReadFile(dailyNotePath)
 		if err != nil {
 			return errors.Wrap(err, "failed to read daily note")
 		}
@@ -296,7 +433,7 @@ func (nm *NoteManager) UpdateDailyNote(ticket string) error {
 	updatedContent := nm.insertLogEntry(string(content), logEntry)
 
 	// Write back to file with restricted permissions
-	if err := os.WriteFile(dailyNotePath, []byte(updatedContent), 0600); err != nil {
+	if err := nm.fsys.WriteFile(dailyNotePath, []byte(updatedContent), 0600); err != nil {
 		return errors.Wrap(err, "failed to update daily note")
 	}
 
@@ -307,14 +444,81 @@ func (nm *NoteManager) UpdateDailyNote(ticket string) error {
 	return nil
 }
 
+// isoWeek returns the ISO 8601 week identifier for t (e.g., "2025-W03")
+func isoWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// UpdateWeeklyNote adds an entry to the current ISO week's note, creating it if necessary
+func (nm *NoteManager) UpdateWeeklyNote(ticket string) error {
+	now := time.Now()
+	week := isoWeek(now)
+	weeklyNotePath := filepath.Join(nm.VaultPath, nm.WeeklyDir, week+".md")
+
+	if nm.Verbose {
+		fmt.Printf("Updating weekly note at: %s\n", weeklyNotePath)
+	}
+
+	weeklyDir := filepath.Dir(weeklyNotePath)
+	if err := nm.fsys.MkdirAll(weeklyDir, 0700); err != nil {
+		return errors.Wrap(err, "failed to create weekly notes directory")
+	}
+
+	unlock, err := nm.fsys.Lock(weeklyNotePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock weekly note")
+	}
+	defer unlock()
+
+	var content []byte
+
+	// Check if weekly note exists, create if not
+	if _, statErr := nm.fsys.Stat(weeklyNotePath); errors.Is(statErr, fs.ErrNotExist) {
+		content = []byte(nm.createDefaultWeeklyNote(week))
+		if nm.Verbose {
+			fmt.Printf("Creating new weekly note for %s\n", week)
+		}
+	} else {
+		content, err = nm.fsys.ReadFile(weeklyNotePath)
+		if err != nil {
+			return errors.Wrap(err, "failed to read weekly note")
+		}
+	}
+
+	// Include the day so entries stay meaningful across the week
+	logEntry := fmt.Sprintf("- [%s] [[%s]]", now.Format("Mon "+notes.ResolveLogTimeFormat(nm.LogTimeFormat)), ticket)
+
+	updatedContent := nm.insertLogEntry(string(content), logEntry)
+
+	if err := nm.fsys.WriteFile(weeklyNotePath, []byte(updatedContent), 0600); err != nil {
+		return errors.Wrap(err, "failed to update weekly note")
+	}
+
+	if nm.Verbose {
+		fmt.Printf("Added log entry to weekly note: %s\n", logEntry)
+	}
+
+	return nil
+}
+
 // insertLogEntry inserts a log entry into the daily note
 func (nm *NoteManager) insertLogEntry(content, logEntry string) string {
+	return nm.insertSectionEntry(content, "Log", logEntry)
+}
+
+// insertSectionEntry appends entry to the end of the named "## " section,
+// creating the section at the end of the note if it doesn't exist.
+// The note's BOM and line endings are preserved.
+func (nm *NoteManager) insertSectionEntry(content, section, entry string) string {
+	content, style := notefile.NormalizeText(content)
 	lines := strings.Split(content, "\n")
+	heading := "## " + section
 
-	// Look for ## Log section
+	// Look for the section heading
 	for i, line := range lines {
-		if strings.HasPrefix(line, "## Log") {
-			// Find the end of the log section
+		if strings.HasPrefix(line, heading) {
+			// Find the end of the section
 			insertIndex := len(lines) // Default to end of file
 
 			for j := i + 1; j < len(lines); j++ {
@@ -324,18 +528,75 @@ func (nm *NoteManager) insertLogEntry(content, logEntry string) string {
 				}
 			}
 
-			// Insert the log entry
+			// Insert the entry
 			newLines := make([]string, 0, len(lines)+1)
 			newLines = append(newLines, lines[:insertIndex]...)
-			newLines = append(newLines, logEntry)
+			newLines = append(newLines, entry)
 			newLines = append(newLines, lines[insertIndex:]...)
 
-			return strings.Join(newLines, "\n")
+			return style.Restore(strings.Join(newLines, "\n"))
 		}
 	}
 
-	// If no ## Log section found, add it at the end
-	return content + "\n\n## Log\n" + logEntry
+	// If the section wasn't found, add it at the end
+	return style.Restore(content + "\n\n" + heading + "\n" + entry)
+}
+
+// sectionContains reports whether the named "## " section contains text
+func sectionContains(content, section, text string) bool {
+	heading := "## " + section
+	inSection := false
+
+	content, _ = notefile.NormalizeText(content)
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "## ") {
+			inSection = strings.HasPrefix(line, heading)
+			continue
+		}
+		if inSection && strings.Contains(line, text) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddBacklink adds a [[target]] link to the ticket note's ## References section,
+// creating the section if needed. Existing links to the same target are not duplicated.
+func (nm *NoteManager) AddBacklink(ticket, ticketType, target string) error {
+	if strings.TrimSpace(target) == "" {
+		return errors.New("backlink target is required")
+	}
+
+	notePath, err := nm.ticketNotePath(ticketType, ticket)
+	if err != nil {
+		return err
+	}
+
+	content, err := nm.fsys.ReadFile(notePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to read ticket note")
+	}
+
+	link := "[[" + target + "]]"
+	if sectionContains(string(content), "References", link) {
+		if nm.Verbose {
+			fmt.Printf("Backlink %s already present in %s\n", link, notePath)
+		}
+		return nil
+	}
+
+	updatedContent := nm.insertSectionEntry(string(content), "References", "- "+link)
+
+	if err := nm.fsys.WriteFile(notePath, []byte(updatedContent), 0600); err != nil {
+		return errors.Wrap(err, "failed to update ticket note")
+	}
+
+	if nm.Verbose {
+		fmt.Printf("Added backlink %s to %s\n", link, notePath)
+	}
+
+	return nil
 }
 
 // createDefaultDailyNote creates a basic daily note structure
@@ -350,8 +611,20 @@ func (nm *NoteManager) createDefaultDailyNote(date string) string {
 `, date)
 }
 
+// createDefaultWeeklyNote creates a basic weekly note structure
+func (nm *NoteManager) createDefaultWeeklyNote(week string) string {
+	return fmt.Sprintf(`# %s
+
+## Notes
+
+
+## Log
+
+`, week)
+}
+
 // vaultExists checks if the Obsidian vault exists
 func (nm *NoteManager) vaultExists() bool {
-	_, err := os.Stat(nm.VaultPath)
-	return !os.IsNotExist(err)
+	_, err := nm.fsys.Stat(nm.VaultPath)
+	return !errors.Is(err, fs.ErrNotExist)
 }
@@ -1,11 +1,15 @@
 package obsidian
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"thoreinstein.com/rig/pkg/notefile"
 )
 
 func TestNewNoteManager(t *testing.T) {
@@ -69,6 +73,9 @@ func TestNewNoteManager(t *testing.T) {
 			if nm.VaultSubdir != "" {
 				t.Errorf("VaultSubdir should be empty after construction, got %q", nm.VaultSubdir)
 			}
+			if nm.WeeklyDir != "weekly" {
+				t.Errorf("WeeklyDir = %q, want %q", nm.WeeklyDir, "weekly")
+			}
 		})
 	}
 }
@@ -221,6 +228,61 @@ func TestCreateTicketNote_MissingVault(t *testing.T) {
 	}
 }
 
+func TestCreateTicketNote_PathTraversal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		ticketType string
+		ticket     string
+	}{
+		{"dotdot ticket", "jira", ".."},
+		{"traversal in ticket", "jira", "../../../etc/passwd"},
+		{"traversal in type", "../../outside", "PROJ-1"},
+		{"dotdot type", "..", "PROJ-1"},
+		{"absolute ticket", "jira", "/tmp/evil"},
+		{"backslash ticket", "jira", `..\..\evil`},
+		{"nested ticket", "jira", "a/b"},
+		{"empty ticket", "jira", ""},
+		{"empty type", "", "PROJ-1"},
+		{"nul byte", "jira", "PROJ-1\x00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			vault := filepath.Join(root, "vault")
+			if err := os.MkdirAll(vault, 0755); err != nil {
+				t.Fatal(err)
+			}
+			nm := NewNoteManager(vault, "templates", "Areas", "Daily", false)
+
+			if _, err := nm.CreateTicketNote(tt.ticketType, tt.ticket, nil); err == nil || !strings.Contains(err.Error(), "invalid ticket") {
+				t.Errorf("CreateTicketNote(%q, %q) error = %v, want invalid ticket error", tt.ticketType, tt.ticket, err)
+			}
+			if err := nm.AddBacklink(tt.ticket, tt.ticketType, "target"); err == nil || !strings.Contains(err.Error(), "invalid ticket") {
+				t.Errorf("AddBacklink(%q, %q) error = %v, want invalid ticket error", tt.ticket, tt.ticketType, err)
+			}
+
+			// Nothing may be written anywhere, inside or outside the vault
+			err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if path != root && path != vault {
+					t.Errorf("unexpected file created: %s", path)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
 func TestCreateTicketNote_WithJiraInfo(t *testing.T) {
 	t.Parallel()
 
@@ -452,6 +514,30 @@ func TestBuildJiraSection(t *testing.T) {
 	if !strings.Contains(section, "Fix the widget") {
 		t.Error("buildJiraSection() missing Description")
 	}
+	if strings.Contains(section, "**Labels:**") {
+		t.Error("buildJiraSection() should omit empty Labels")
+	}
+}
+
+func TestBuildJiraSection_LabelsAndComponents(t *testing.T) {
+	t.Parallel()
+
+	nm := NewNoteManager("/vault", "templates", "areas", "daily", false)
+
+	jiraInfo := &JiraInfo{
+		Type:       "Story",
+		Labels:     []string{"frontend", "a11y"},
+		Components: []string{"Web"},
+	}
+
+	section := nm.buildJiraSection(jiraInfo)
+
+	if !strings.Contains(section, "**Labels:** frontend, a11y") {
+		t.Errorf("buildJiraSection() missing Labels, got %q", section)
+	}
+	if !strings.Contains(section, "**Components:** Web") {
+		t.Errorf("buildJiraSection() missing Components, got %q", section)
+	}
 }
 
 func TestInsertAfterSummary(t *testing.T) {
@@ -517,12 +603,11 @@ This is the summary at the end.`,
 ## Notes
 
 Some notes without summary.`,
-			insertion: "## JIRA Details\n\nShould not be inserted.",
+			insertion: "## JIRA Details\n\nAppended at the end.",
 			validate: func(t *testing.T, result string) {
-				// When no summary section, insertion may not happen in expected location
-				// Just verify the original content is preserved
-				if !strings.Contains(result, "## Notes") {
-					t.Error("Original Notes section should be preserved")
+				want := "# Ticket\n\n## Notes\n\nSome notes without summary.\n\n## JIRA Details\n\nAppended at the end."
+				if result != want {
+					t.Errorf("insertAfterSummary() = %q, want %q", result, want)
 				}
 			},
 		},
@@ -573,6 +658,73 @@ References content.`,
 	}
 }
 
+func TestInsertAfterSummary_CRLFAndBOM(t *testing.T) {
+	t.Parallel()
+
+	nm := NewNoteManager("/vault", "templates", "areas", "daily", false)
+
+	lf := "# Ticket\n\n## Summary\n\nText\n\n## Notes\n"
+	insertion := "## JIRA Details\n\n**Status:** Open\n"
+	wantLF := nm.insertAfterSummary(lf, insertion)
+
+	got := nm.insertAfterSummary("\ufeff"+strings.ReplaceAll(lf, "\n", "\r\n"), insertion)
+	want := "\ufeff" + strings.ReplaceAll(wantLF, "\n", "\r\n")
+	if got != want {
+		t.Errorf("insertAfterSummary() = %q, want %q", got, want)
+	}
+	if strings.Index(got, "## JIRA Details") > strings.Index(got, "## Notes") {
+		t.Error("JIRA section should be inserted before ## Notes")
+	}
+}
+
+func TestInsertLogEntry_CRLFAndBOM(t *testing.T) {
+	t.Parallel()
+
+	nm := NewNoteManager("/vault", "templates", "areas", "daily", false)
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "crlf log section",
+			content: "# Daily\r\n\r\n## Log\r\n- [09:00] earlier\r\n## Tasks\r\n- task\r\n",
+			want:    "# Daily\r\n\r\n## Log\r\n- [09:00] earlier\r\n- [14:30] [[NEW]]\r\n## Tasks\r\n- task\r\n",
+		},
+		{
+			name:    "bom before log heading",
+			content: "\ufeff" + "## Log\n- [09:00] earlier",
+			want:    "\ufeff" + "## Log\n- [09:00] earlier\n- [14:30] [[NEW]]",
+		},
+		{
+			name:    "crlf without log section",
+			content: "# Daily\r\n",
+			want:    "# Daily\r\n\r\n\r\n## Log\r\n- [14:30] [[NEW]]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := nm.insertLogEntry(tt.content, "- [14:30] [[NEW]]")
+			if got != tt.want {
+				t.Errorf("insertLogEntry() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSectionContains_CRLF(t *testing.T) {
+	t.Parallel()
+
+	content := "## References\r\n- [[PROJ-1]]\r\n## Log\r\n"
+	if !sectionContains(content, "References", "[[PROJ-1]]") {
+		t.Error("sectionContains() should find the link in a CRLF note")
+	}
+}
+
 func TestInsertLogEntry(t *testing.T) {
 	t.Parallel()
 
@@ -900,6 +1052,44 @@ func TestUpdateDailyNote_MultipleUpdates(t *testing.T) {
 	}
 }
 
+func TestUpdateDailyNote_ConcurrentUpdates(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	// Separate managers stand in for separate rig processes
+	const updates = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, updates)
+	for i := range updates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nm := NewNoteManager(tmpDir, "templates", "Areas", "Daily", false)
+			errs <- nm.UpdateDailyNote(fmt.Sprintf("TICKET-%03d", i))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("UpdateDailyNote() error: %v", err)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "Daily", getTodayDate()+".md"))
+	if err != nil {
+		t.Fatalf("Failed to read daily note: %v", err)
+	}
+
+	for i := range updates {
+		if link := fmt.Sprintf("[[TICKET-%03d]]", i); !strings.Contains(string(content), link) {
+			t.Errorf("Daily note lost concurrent entry %s", link)
+		}
+	}
+}
+
 func TestCreateJiraNote_WithTemplate(t *testing.T) {
 	t.Parallel()
 
@@ -1133,6 +1323,109 @@ func TestCreateDefaultDailyNote(t *testing.T) {
 	}
 }
 
+func TestISOWeek(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		date time.Time
+		want string
+	}{
+		{time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC), "2025-W03"},
+		{time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), "2025-W01"},
+		// Dec 29 2025 belongs to the first ISO week of 2026
+		{time.Date(2025, 12, 29, 12, 0, 0, 0, time.UTC), "2026-W01"},
+		// Jan 1 2021 belongs to the last ISO week of 2020
+		{time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC), "2020-W53"},
+	}
+
+	for _, tt := range tests {
+		if got := isoWeek(tt.date); got != tt.want {
+			t.Errorf("isoWeek(%s) = %q, want %q", tt.date.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+func TestCreateDefaultWeeklyNote(t *testing.T) {
+	t.Parallel()
+
+	nm := NewNoteManager("/vault", "templates", "areas", "daily", false)
+
+	content := nm.createDefaultWeeklyNote("2025-W03")
+
+	if !strings.Contains(content, "# 2025-W03") {
+		t.Error("createDefaultWeeklyNote() missing week header")
+	}
+	if !strings.Contains(content, "## Notes") {
+		t.Error("createDefaultWeeklyNote() missing Notes section")
+	}
+	if !strings.Contains(content, "## Log") {
+		t.Error("createDefaultWeeklyNote() missing Log section")
+	}
+}
+
+func TestUpdateWeeklyNote_CreatesAndAppends(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	nm := NewNoteManager(tmpDir, "templates", "Areas", "Daily", false)
+	nm.SetWeeklyDir("Weekly")
+
+	for _, ticket := range []string{"PROJ-1", "PROJ-2"} {
+		if err := nm.UpdateWeeklyNote(ticket); err != nil {
+			t.Fatalf("UpdateWeeklyNote(%s) error: %v", ticket, err)
+		}
+	}
+
+	weeklyNotePath := filepath.Join(tmpDir, "Weekly", isoWeek(time.Now())+".md")
+	content, err := os.ReadFile(weeklyNotePath)
+	if err != nil {
+		t.Fatalf("Failed to read weekly note: %v", err)
+	}
+
+	if !strings.Contains(string(content), "## Log") {
+		t.Error("Weekly note should contain Log section")
+	}
+	for _, ticket := range []string{"PROJ-1", "PROJ-2"} {
+		if !strings.Contains(string(content), "[["+ticket+"]]") {
+			t.Errorf("Weekly note should contain ticket link: %s", ticket)
+		}
+	}
+}
+
+func TestUpdateDailyAndWeeklyNote_LogTimeFormat(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	nm := NewNoteManager(tmpDir, "templates", "Areas", "Daily", false)
+	nm.SetWeeklyDir("Weekly")
+	nm.LogTimeFormat = "2006" // year-only layout keeps the expected entry stable
+
+	if err := nm.UpdateDailyNote("PROJ-1"); err != nil {
+		t.Fatalf("UpdateDailyNote() error: %v", err)
+	}
+	if err := nm.UpdateWeeklyNote("PROJ-1"); err != nil {
+		t.Fatalf("UpdateWeeklyNote() error: %v", err)
+	}
+
+	now := time.Now()
+	tests := []struct {
+		path  string
+		entry string
+	}{
+		{filepath.Join(tmpDir, "Daily", getTodayDate()+".md"), "- [" + now.Format("2006") + "] [[PROJ-1]]"},
+		{filepath.Join(tmpDir, "Weekly", isoWeek(now)+".md"), "- [" + now.Format("Mon 2006") + "] [[PROJ-1]]"},
+	}
+	for _, tt := range tests {
+		content, err := os.ReadFile(tt.path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", tt.path, err)
+		}
+		if !strings.Contains(string(content), tt.entry) {
+			t.Errorf("%s should contain %q, got:\n%s", filepath.Base(tt.path), tt.entry, content)
+		}
+	}
+}
+
 func TestBuildJiraSection_PartialInfo(t *testing.T) {
 	t.Parallel()
 
@@ -1231,3 +1524,385 @@ func TestJiraInfo_ZeroValue(t *testing.T) {
 func getTodayDate() string {
 	return time.Now().Format("2006-01-02")
 }
+
+func TestCreateTicketNote_FrontMatter(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	nm := NewNoteManager(tmpDir, "templates", "Areas", "Daily", false)
+	nm.FrontMatter = true
+
+	jiraInfo := &JiraInfo{
+		Type:    "Bug",
+		Summary: "Fix critical issue",
+		Status:  "In Progress",
+	}
+
+	notePath, err := nm.CreateTicketNote("proj", "PROJ-123", jiraInfo)
+	if err != nil {
+		t.Fatalf("CreateTicketNote() error: %v", err)
+	}
+
+	content, err := os.ReadFile(notePath)
+	if err != nil {
+		t.Fatalf("Failed to read note: %v", err)
+	}
+
+	wantPrefix := "---\nticket: PROJ-123\ntype: Bug\nstatus: In Progress\nsummary: Fix critical issue\ncreated: " + getTodayDate() + "\n---\n\n"
+	if !strings.HasPrefix(string(content), wantPrefix) {
+		t.Errorf("note should start with front matter %q, got %q", wantPrefix, string(content))
+	}
+
+	// Creating again must not duplicate front matter
+	if _, err := nm.CreateTicketNote("proj", "PROJ-123", jiraInfo); err != nil {
+		t.Fatalf("CreateTicketNote() second call error: %v", err)
+	}
+	content, err = os.ReadFile(notePath)
+	if err != nil {
+		t.Fatalf("Failed to read note: %v", err)
+	}
+	if count := strings.Count(string(content), "ticket: PROJ-123"); count != 1 {
+		t.Errorf("front matter appears %d times, want 1", count)
+	}
+}
+
+func TestCreateTicketNote_FrontMatterTemplateAlreadyHasIt(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	templatesDir := filepath.Join(tmpDir, "templates")
+	if err := os.MkdirAll(templatesDir, 0755); err != nil {
+		t.Fatalf("Failed to create templates dir: %v", err)
+	}
+	template := "---\ntags: [jira]\n---\n\n# Ticket\n\n## Summary\n\n<Insert ticket title or short summary here>\n"
+	if err := os.WriteFile(filepath.Join(templatesDir, "Jira.md"), []byte(template), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	nm := NewNoteManager(tmpDir, "templates", "Areas", "Daily", false)
+	nm.FrontMatter = true
+
+	notePath, err := nm.CreateTicketNote("proj", "PROJ-5", &JiraInfo{Summary: "Templated"})
+	if err != nil {
+		t.Fatalf("CreateTicketNote() error: %v", err)
+	}
+
+	content, err := os.ReadFile(notePath)
+	if err != nil {
+		t.Fatalf("Failed to read note: %v", err)
+	}
+	if count := strings.Count(string(content), "---\n"); count != 2 {
+		t.Errorf("expected a single front matter block, found %d delimiters in %q", count, string(content))
+	}
+}
+
+func TestCreateTicketNote_FrontMatterDisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	nm := NewNoteManager(tmpDir, "templates", "Areas", "Daily", false)
+
+	notePath, err := nm.CreateTicketNote("proj", "PROJ-9", nil)
+	if err != nil {
+		t.Fatalf("CreateTicketNote() error: %v", err)
+	}
+
+	content, err := os.ReadFile(notePath)
+	if err != nil {
+		t.Fatalf("Failed to read note: %v", err)
+	}
+	if notefile.HasFrontMatter(string(content)) {
+		t.Error("note should not have front matter when disabled")
+	}
+}
+
+func TestResolveJiraTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		templates []string
+		issueType string
+		want      string
+	}{
+		{
+			name:      "type-specific template",
+			templates: []string{"Jira.md", "Incident.md", "Bug.md"},
+			issueType: "Incident",
+			want:      "Incident.md",
+		},
+		{
+			name:      "case-insensitive match",
+			templates: []string{"Jira.md", "Bug.md"},
+			issueType: "bug",
+			want:      "Bug.md",
+		},
+		{
+			name:      "falls back to Jira.md",
+			templates: []string{"Jira.md", "Bug.md"},
+			issueType: "Story",
+			want:      "Jira.md",
+		},
+		{
+			name:      "empty type uses Jira.md",
+			templates: []string{"Jira.md"},
+			issueType: "",
+			want:      "Jira.md",
+		},
+		{
+			name:      "no templates",
+			templates: nil,
+			issueType: "Bug",
+			want:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+			templatesDir := filepath.Join(tmpDir, "templates")
+			if err := os.MkdirAll(templatesDir, 0755); err != nil {
+				t.Fatalf("Failed to create templates dir: %v", err)
+			}
+			for _, name := range tt.templates {
+				if err := os.WriteFile(filepath.Join(templatesDir, name), []byte("# "+name), 0644); err != nil {
+					t.Fatalf("Failed to write template: %v", err)
+				}
+			}
+
+			nm := NewNoteManager(tmpDir, "templates", "Areas", "Daily", false)
+
+			got := nm.resolveJiraTemplate(tt.issueType)
+			want := ""
+			if tt.want != "" {
+				want = filepath.Join(templatesDir, tt.want)
+			}
+			if got != want {
+				t.Errorf("resolveJiraTemplate(%q) = %q, want %q", tt.issueType, got, want)
+			}
+		})
+	}
+}
+
+func TestCreateJiraNote_PerTypeTemplate(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	templatesDir := filepath.Join(tmpDir, "templates")
+	if err := os.MkdirAll(templatesDir, 0755); err != nil {
+		t.Fatalf("Failed to create templates dir: %v", err)
+	}
+
+	incidentTemplate := `# <Insert ticket title or short summary here>
+
+Opened: <% tp.date.now("YYYY-MM-DD") %>
+
+## Summary
+
+## Timeline
+
+## Root Cause
+`
+	if err := os.WriteFile(filepath.Join(templatesDir, "Incident.md"), []byte(incidentTemplate), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(templatesDir, "Jira.md"), []byte("# generic\n"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	nm := NewNoteManager(tmpDir, "templates", "Areas", "Daily", false)
+
+	content, err := nm.createJiraNote("OPS-1", &JiraInfo{Type: "incident", Summary: "API outage"})
+	if err != nil {
+		t.Fatalf("createJiraNote() error: %v", err)
+	}
+
+	if !strings.Contains(content, "# API outage") {
+		t.Error("createJiraNote() did not replace summary placeholder in per-type template")
+	}
+	if !strings.Contains(content, "## Root Cause") {
+		t.Error("createJiraNote() should use the Incident template")
+	}
+	if strings.Contains(content, "tp.date.now") {
+		t.Error("createJiraNote() did not replace date placeholder in per-type template")
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]string{
+		"ticket":  "PROJ-123",
+		"type":    "Bug",
+		"summary": "Fix login",
+		"status":  "",
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "known tokens replaced",
+			content: "# {{ticket}}: {{summary}} ({{type}})",
+			want:    "# PROJ-123: Fix login (Bug)",
+		},
+		{
+			name:    "whitespace inside braces",
+			content: "{{ ticket }}",
+			want:    "PROJ-123",
+		},
+		{
+			name:    "empty value replaced with empty string",
+			content: "Status: {{status}}",
+			want:    "Status: ",
+		},
+		{
+			name:    "unknown tokens left untouched",
+			content: "Owner: {{owner}}",
+			want:    "Owner: {{owner}}",
+		},
+		{
+			name:    "templater syntax untouched",
+			content: `<% tp.date.now("YYYY-MM-DD") %>`,
+			want:    `<% tp.date.now("YYYY-MM-DD") %>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := renderTemplate(tt.content, vars); got != tt.want {
+				t.Errorf("renderTemplate(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateJiraNote_TemplateVariables(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	templatesDir := filepath.Join(tmpDir, "templates")
+	if err := os.MkdirAll(templatesDir, 0755); err != nil {
+		t.Fatalf("Failed to create templates dir: %v", err)
+	}
+
+	template := `# {{ticket}} {{summary}}
+
+Type: {{type}} | Status: {{status}} | Priority: {{priority}}
+Created: {{date}}
+Owner: {{owner}}
+
+## Summary
+`
+	if err := os.WriteFile(filepath.Join(templatesDir, "Jira.md"), []byte(template), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	nm := NewNoteManager(tmpDir, "templates", "Areas", "Daily", false)
+
+	content, err := nm.createJiraNote("PROJ-7", &JiraInfo{
+		Type:     "Story",
+		Summary:  "Add export",
+		Status:   "Open",
+		Priority: "High",
+	})
+	if err != nil {
+		t.Fatalf("createJiraNote() error: %v", err)
+	}
+
+	wants := []string{
+		"# PROJ-7 Add export",
+		"Type: Story | Status: Open | Priority: High",
+		"Created: " + getTodayDate(),
+		"Owner: {{owner}}",
+	}
+	for _, want := range wants {
+		if !strings.Contains(content, want) {
+			t.Errorf("createJiraNote() should contain %q, got %q", want, content)
+		}
+	}
+}
+
+func TestAddBacklink(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	nm := NewNoteManager(tmpDir, "templates", "Areas", "Daily", false)
+
+	notePath, err := nm.CreateTicketNote("proj", "PROJ-1", nil)
+	if err != nil {
+		t.Fatalf("CreateTicketNote() error: %v", err)
+	}
+
+	for _, target := range []string{"2025-01-01", "2025-01-02", "2025-01-01"} {
+		if err := nm.AddBacklink("PROJ-1", "proj", target); err != nil {
+			t.Fatalf("AddBacklink(%s) error: %v", target, err)
+		}
+	}
+
+	content, err := os.ReadFile(notePath)
+	if err != nil {
+		t.Fatalf("Failed to read note: %v", err)
+	}
+	contentStr := string(content)
+
+	if strings.Count(contentStr, "## References") != 1 {
+		t.Errorf("note should contain exactly one References section, got %q", contentStr)
+	}
+	if strings.Count(contentStr, "- [[2025-01-01]]") != 1 {
+		t.Errorf("duplicate backlink should not be added, got %q", contentStr)
+	}
+	if !strings.Contains(contentStr, "- [[2025-01-02]]") {
+		t.Errorf("note should contain second backlink, got %q", contentStr)
+	}
+}
+
+func TestAddBacklink_ExistingReferencesSection(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	nm := NewNoteManager(tmpDir, "templates", "Areas", "Daily", false)
+
+	notePath := filepath.Join(tmpDir, "Areas", "proj", "PROJ-2.md")
+	if err := os.MkdirAll(filepath.Dir(notePath), 0755); err != nil {
+		t.Fatalf("Failed to create note dir: %v", err)
+	}
+	initial := "# PROJ-2\n\n## References\n\n- [[PROJ-1]]\n\n## Log\n\n- entry mentioning [[2025-01-03]]\n"
+	if err := os.WriteFile(notePath, []byte(initial), 0644); err != nil {
+		t.Fatalf("Failed to write note: %v", err)
+	}
+
+	// A link elsewhere in the note doesn't count as an existing backlink
+	if err := nm.AddBacklink("PROJ-2", "proj", "2025-01-03"); err != nil {
+		t.Fatalf("AddBacklink() error: %v", err)
+	}
+
+	content, err := os.ReadFile(notePath)
+	if err != nil {
+		t.Fatalf("Failed to read note: %v", err)
+	}
+
+	refIdx := strings.Index(string(content), "## References")
+	logIdx := strings.Index(string(content), "## Log")
+	linkIdx := strings.Index(string(content), "- [[2025-01-03]]")
+	if linkIdx < refIdx || linkIdx > logIdx {
+		t.Errorf("backlink should be inserted inside the References section, got %q", string(content))
+	}
+}
+
+func TestAddBacklink_MissingNote(t *testing.T) {
+	t.Parallel()
+
+	nm := NewNoteManager(t.TempDir(), "templates", "Areas", "Daily", false)
+
+	if err := nm.AddBacklink("PROJ-404", "proj", "2025-01-01"); err == nil {
+		t.Error("AddBacklink() should return error when note does not exist")
+	}
+}
@@ -0,0 +1,122 @@
+package obsidian
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// maxSearchLineSize bounds the length of a single line read while searching.
+const maxSearchLineSize = 1024 * 1024
+
+// NoteMatch is a single line in a note that matched a search query
+type NoteMatch struct {
+	Path string // Absolute path to the note
+	Line int    // 1-based line number
+	Text string // Content of the matching line
+}
+
+// SearchNotes scans markdown notes under the vault's areas/subdirectory for
+// lines containing query (case-insensitive). The templates directory and
+// hidden directories (e.g., .obsidian, .git) are skipped. Files are streamed
+// line by line so large vaults are never loaded into memory at once.
+func (nm *NoteManager) SearchNotes(query string) ([]NoteMatch, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, errors.New("search query is required")
+	}
+
+	if !nm.vaultExists() {
+		return nil, errors.Newf("vault path not found at %s", nm.VaultPath)
+	}
+
+	root := filepath.Join(nm.VaultPath, nm.AreasDir, nm.VaultSubdir)
+	templatesPath := ""
+	if nm.TemplatesDir != "" {
+		templatesPath = filepath.Join(nm.VaultPath, nm.TemplatesDir)
+	}
+
+	// Nothing to search if the notes subdirectory hasn't been created yet
+	if _, err := nm.fsys.Stat(root); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	matches, err := nm.searchDir(root, templatesPath, strings.ToLower(query))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to search notes")
+	}
+
+	if nm.Verbose {
+		fmt.Printf("Found %d matches for %q under %s\n", len(matches), query, root)
+	}
+
+	return matches, nil
+}
+
+// searchDir searches the markdown notes in dir and its subdirectories in
+// lexical order, skipping hidden directories and the templates directory
+func (nm *NoteManager) searchDir(dir, templatesPath, needle string) ([]NoteMatch, error) {
+	entries, err := nm.fsys.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []NoteMatch
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		if entry.IsDir() {
+			if strings.HasPrefix(entry.Name(), ".") || path == templatesPath {
+				continue
+			}
+			dirMatches, err := nm.searchDir(path, templatesPath, needle)
+			if err != nil {
+				return nil, err
+			}
+			matches = append(matches, dirMatches...)
+			continue
+		}
+
+		if !strings.EqualFold(filepath.Ext(path), ".md") {
+			continue
+		}
+
+		fileMatches, err := nm.searchFile(path, needle)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, fileMatches...)
+	}
+
+	return matches, nil
+}
+
+// searchFile returns the lines in path containing needle (already lowercased)
+func (nm *NoteManager) searchFile(path, needle string) ([]NoteMatch, error) {
+	f, err := nm.fsys.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s", path)
+	}
+	defer f.Close()
+
+	var matches []NoteMatch
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSearchLineSize)
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if strings.Contains(strings.ToLower(line), needle) {
+			matches = append(matches, NoteMatch{Path: path, Line: lineNum, Text: line})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+
+	return matches, nil
+}
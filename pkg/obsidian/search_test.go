@@ -0,0 +1,111 @@
+package obsidian
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSearchFixture(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestSearchNotes(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	writeSearchFixture(t, tmpDir, map[string]string{
+		"Areas/Jira/proj/PROJ-1.md":    "# PROJ-1\n\nDeploy the Widget service\n",
+		"Areas/Jira/proj/PROJ-2.md":    "# PROJ-2\n\nnothing here\nwidget config drift\n",
+		"Areas/Jira/proj/notes.txt":    "widget in a non-markdown file\n",
+		"Areas/Jira/.obsidian/a.md":    "widget in hidden dir\n",
+		"Areas/Other/OTHER-1.md":       "widget outside the subdir\n",
+		"templates/Jira.md":            "widget in template\n",
+		"Areas/Jira/templates/Copy.md": "widget in nested templates is searched\n",
+	})
+
+	nm := NewNoteManager(tmpDir, "templates", "Areas", "Daily", false)
+	nm.SetVaultSubdir("Jira")
+
+	matches, err := nm.SearchNotes("WIDGET")
+	if err != nil {
+		t.Fatalf("SearchNotes() error: %v", err)
+	}
+
+	got := make(map[string]int)
+	for _, m := range matches {
+		rel, _ := filepath.Rel(tmpDir, m.Path)
+		got[rel] = m.Line
+		if !strings.Contains(strings.ToLower(m.Text), "widget") {
+			t.Errorf("match text %q does not contain query", m.Text)
+		}
+	}
+
+	want := map[string]int{
+		"Areas/Jira/proj/PROJ-1.md":    3,
+		"Areas/Jira/proj/PROJ-2.md":    4,
+		"Areas/Jira/templates/Copy.md": 1,
+	}
+	if len(got) != len(want) {
+		t.Errorf("SearchNotes() matched %v, want %v", got, want)
+	}
+	for path, line := range want {
+		if got[path] != line {
+			t.Errorf("match in %s at line %d, want line %d", path, got[path], line)
+		}
+	}
+}
+
+func TestSearchNotes_SkipsTemplatesDir(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	writeSearchFixture(t, tmpDir, map[string]string{
+		"templates/Jira.md": "needle\n",
+		"notes/PROJ-1.md":   "needle\n",
+	})
+
+	nm := NewNoteManager(tmpDir, "templates", "", "Daily", false)
+
+	matches, err := nm.SearchNotes("needle")
+	if err != nil {
+		t.Fatalf("SearchNotes() error: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("SearchNotes() returned %d matches, want 1: %+v", len(matches), matches)
+	}
+	if !strings.HasSuffix(matches[0].Path, filepath.Join("notes", "PROJ-1.md")) {
+		t.Errorf("match path = %q, want notes/PROJ-1.md", matches[0].Path)
+	}
+}
+
+func TestSearchNotes_EmptyQuery(t *testing.T) {
+	t.Parallel()
+
+	nm := NewNoteManager(t.TempDir(), "templates", "Areas", "Daily", false)
+
+	if _, err := nm.SearchNotes("  "); err == nil {
+		t.Error("SearchNotes() should return error for empty query")
+	}
+}
+
+func TestSearchNotes_MissingVault(t *testing.T) {
+	t.Parallel()
+
+	nm := NewNoteManager("/nonexistent/vault/path", "templates", "Areas", "Daily", false)
+
+	if _, err := nm.SearchNotes("anything"); err == nil {
+		t.Error("SearchNotes() should return error for missing vault")
+	}
+}
@@ -0,0 +1,211 @@
+// Package retry provides exponential backoff with jitter for operations that
+// fail transiently, and an HTTP helper that honors Retry-After.
+//
+// Do retries any function whose error is marked with Retryable. RetryableHTTP
+// builds on it to resend requests whose response a caller-supplied predicate
+// deems transient, such as 429 or 503.
+package retry
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Defaults used by DefaultPolicy
+const (
+	DefaultMaxRetries = 3
+	DefaultBaseDelay  = time.Second
+	DefaultMaxDelay   = 30 * time.Second
+	DefaultJitter     = 0.4 // Produces a multiplier range of [0.8, 1.2], ±20% around the delay
+)
+
+// Policy controls how many times an operation is retried and how long to
+// wait between attempts
+type Policy struct {
+	MaxRetries    int           // Retries after the first attempt
+	BaseDelay     time.Duration // Delay before the first retry, doubled for each one after
+	MaxDelay      time.Duration // Cap on the exponential delay
+	Jitter        float64       // Spread applied to each delay (0 disables)
+	MaxRetryAfter time.Duration // Cap on a server-requested delay (0 means none)
+
+	// OnRetry, when set, is called before each wait with the zero-based
+	// attempt that failed, the delay about to be waited, and its error
+	OnRetry func(attempt int, delay time.Duration, err error)
+}
+
+// DefaultPolicy returns a Policy with three retries starting at one second
+// and capped at thirty
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxRetries: DefaultMaxRetries,
+		BaseDelay:  DefaultBaseDelay,
+		MaxDelay:   DefaultMaxDelay,
+		Jitter:     DefaultJitter,
+	}
+}
+
+// Backoff computes the delay before retry attempt (zero-based):
+// min(base * 2^attempt, max) * (1 - jitter/2 + jitter*rand()).
+func Backoff(base, max time.Duration, attempt int, jitter float64) time.Duration {
+	expDelay := float64(base) * math.Pow(2, float64(attempt))
+	if expDelay > float64(max) {
+		expDelay = float64(max)
+	}
+
+	multiplier := 1.0 - jitter/2 + jitter*rand.Float64()
+	return time.Duration(expDelay * multiplier)
+}
+
+// ParseRetryAfter returns the delay requested by a Retry-After header given
+// in seconds or as an HTTP date, or 0 when it is absent, invalid or past.
+func ParseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	// RFC1123 first, which accepts any zone name, then the other HTTP formats
+	t, err := time.Parse(time.RFC1123, header)
+	if err != nil {
+		t, err = http.ParseTime(header)
+	}
+	if err == nil {
+		if delay := time.Until(t); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
+
+// retryableError marks an error as worth retrying, optionally after a
+// specific delay
+type retryableError struct {
+	err   error
+	after time.Duration
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// Retryable marks err so that Do retries it. A nil err stays nil.
+func Retryable(err error) error {
+	return RetryableAfter(err, 0)
+}
+
+// RetryableAfter marks err so that Do retries it after delay rather than the
+// policy's backoff, e.g. when a server asked for it. A nil err stays nil.
+func RetryableAfter(err error, delay time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &retryableError{err: err, after: delay}
+}
+
+// Do calls fn until it returns nil, returns an error not marked with
+// Retryable, or p.MaxRetries retries have failed. fn receives the zero-based
+// attempt number. The last error is returned without the Retryable marker.
+// Cancelling ctx stops the wait between attempts and returns ctx's error.
+func Do(ctx context.Context, p Policy, fn func(attempt int) error) error {
+	for attempt := 0; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		err := fn(attempt)
+		var re *retryableError
+		if !errors.As(err, &re) {
+			return err
+		}
+		if attempt >= p.MaxRetries {
+			return re.err
+		}
+
+		delay := re.after
+		if p.MaxRetryAfter > 0 && delay > p.MaxRetryAfter {
+			delay = p.MaxRetryAfter
+		}
+		if delay <= 0 {
+			delay = Backoff(p.BaseDelay, p.MaxDelay, attempt, p.Jitter)
+		}
+
+		if p.OnRetry != nil {
+			p.OnRetry(attempt, delay, re.err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// StatusError reports an HTTP response that RetryableHTTP retried. It is
+// passed to Policy.OnRetry.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return "HTTP " + strconv.Itoa(e.StatusCode) + ": " + http.StatusText(e.StatusCode)
+}
+
+// RetryableHTTP sends req with client, resending it while shouldRetry
+// reports the response as transient. The wait honors a Retry-After header
+// (capped at p.MaxRetryAfter) and otherwise uses p's backoff. The request
+// body is rewound with GetBody between attempts, and req's context bounds
+// the waits.
+//
+// When retries run out the final response is returned unread, so the caller
+// can report it. Transport errors are returned without retrying.
+func RetryableHTTP(client *http.Client, req *http.Request, p Policy, shouldRetry func(resp *http.Response) bool) (*http.Response, error) {
+	var resp *http.Response
+
+	err := Do(req.Context(), p, func(attempt int) error {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return errors.Wrap(err, "failed to reset request body for retry")
+			}
+			req.Body = body
+		}
+
+		var err error
+		resp, err = client.Do(req)
+		if err != nil {
+			return err
+		}
+		if !shouldRetry(resp) {
+			return nil
+		}
+
+		delay := ParseRetryAfter(resp.Header.Get("Retry-After"))
+		if attempt < p.MaxRetries {
+			// Another attempt follows; the caller only sees the last response
+			resp.Body.Close()
+		}
+		return RetryableAfter(&StatusError{StatusCode: resp.StatusCode}, delay)
+	})
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		// Retries exhausted: hand back the final, still-open response
+		return resp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,345 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    time.Duration
+		max     time.Duration
+		attempt int
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{
+			name:    "attempt 0",
+			base:    time.Second,
+			max:     30 * time.Second,
+			attempt: 0,
+			wantMin: 800 * time.Millisecond,  // 1s * 2^0 * 0.8
+			wantMax: 1200 * time.Millisecond, // 1s * 2^0 * 1.2
+		},
+		{
+			name:    "attempt 1",
+			base:    time.Second,
+			max:     30 * time.Second,
+			attempt: 1,
+			wantMin: 1600 * time.Millisecond, // 1s * 2^1 * 0.8
+			wantMax: 2400 * time.Millisecond, // 1s * 2^1 * 1.2
+		},
+		{
+			name:    "attempt 2",
+			base:    time.Second,
+			max:     30 * time.Second,
+			attempt: 2,
+			wantMin: 3200 * time.Millisecond, // 1s * 2^2 * 0.8
+			wantMax: 4800 * time.Millisecond, // 1s * 2^2 * 1.2
+		},
+		{
+			name:    "capped at max",
+			base:    time.Second,
+			max:     2 * time.Second,
+			attempt: 5,
+			wantMin: 1600 * time.Millisecond, // max (2s) * 0.8
+			wantMax: 2400 * time.Millisecond, // max (2s) * 1.2
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Run multiple times to account for random jitter
+			for range 100 {
+				got := Backoff(tt.base, tt.max, tt.attempt, DefaultJitter)
+				if got < tt.wantMin || got > tt.wantMax {
+					t.Errorf("Backoff() = %v, want between %v and %v",
+						got, tt.wantMin, tt.wantMax)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{
+			name:   "empty string",
+			header: "",
+			want:   0,
+		},
+		{
+			name:   "integer seconds",
+			header: "5",
+			want:   5 * time.Second,
+		},
+		{
+			name:   "zero seconds",
+			header: "0",
+			want:   0,
+		},
+		{
+			name:   "invalid string",
+			header: "invalid",
+			want:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseRetryAfter(tt.header)
+			if got != tt.want {
+				t.Errorf("ParseRetryAfter(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter_RFC1123Date(t *testing.T) {
+	// Create a time 5 seconds in the future
+	futureTime := time.Now().Add(5 * time.Second)
+	header := futureTime.Format(time.RFC1123)
+
+	got := ParseRetryAfter(header)
+
+	// Should return approximately 5 seconds (allow margin for test execution time)
+	if got < 4*time.Second || got > 6*time.Second {
+		t.Errorf("ParseRetryAfter(%q) = %v, want approximately 5s", header, got)
+	}
+}
+
+func TestParseRetryAfter_RFC1123DateInPast(t *testing.T) {
+	// Create a time in the past
+	pastTime := time.Now().Add(-5 * time.Second)
+	header := pastTime.Format(time.RFC1123)
+
+	got := ParseRetryAfter(header)
+
+	// Past dates should return 0
+	if got != 0 {
+		t.Errorf("ParseRetryAfter(%q) = %v, want 0 for past date", header, got)
+	}
+}
+
+func TestParseRetryAfter_HTTPDate(t *testing.T) {
+	header := time.Now().Add(5 * time.Second).UTC().Format(http.TimeFormat)
+
+	got := ParseRetryAfter(header)
+	if got < 3*time.Second || got > 6*time.Second {
+		t.Errorf("ParseRetryAfter(%q) = %v, want approximately 5s", header, got)
+	}
+}
+
+// fastPolicy retries quickly so tests don't wait on real backoff
+func fastPolicy() Policy {
+	return Policy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+}
+
+func TestDo(t *testing.T) {
+	errTransient := errors.New("transient")
+	errPermanent := errors.New("permanent")
+
+	tests := []struct {
+		name      string
+		errs      []error // returned by successive attempts; nil once exhausted
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "success first time", errs: nil, wantCalls: 1},
+		{name: "success after retries", errs: []error{Retryable(errTransient), Retryable(errTransient)}, wantCalls: 3},
+		{name: "permanent error not retried", errs: []error{errPermanent}, wantErr: errPermanent, wantCalls: 1},
+		{
+			name:      "gives up after max retries",
+			errs:      []error{Retryable(errTransient), Retryable(errTransient), Retryable(errTransient), Retryable(errTransient), Retryable(errTransient)},
+			wantErr:   errTransient,
+			wantCalls: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := Do(context.Background(), fastPolicy(), func(attempt int) error {
+				if attempt != calls {
+					t.Errorf("attempt = %d, want %d", attempt, calls)
+				}
+				calls++
+				if attempt < len(tt.errs) {
+					return tt.errs[attempt]
+				}
+				return nil
+			})
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("Do() error = %q, want the unmarked error %q", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestDo_RetryAfterAndOnRetry(t *testing.T) {
+	policy := fastPolicy()
+	policy.MaxRetryAfter = 20 * time.Millisecond
+
+	var delays []time.Duration
+	policy.OnRetry = func(attempt int, delay time.Duration, err error) {
+		delays = append(delays, delay)
+	}
+
+	err := Do(context.Background(), policy, func(attempt int) error {
+		switch attempt {
+		case 0:
+			return RetryableAfter(errors.New("slow down"), 10*time.Millisecond)
+		case 1:
+			return RetryableAfter(errors.New("slow down"), time.Hour)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	if len(delays) != 2 || delays[0] != 10*time.Millisecond || delays[1] != 20*time.Millisecond {
+		t.Errorf("delays = %v, want [10ms 20ms] (requested, then capped)", delays)
+	}
+}
+
+func TestDo_ContextCancelled(t *testing.T) {
+	policy := Policy{MaxRetries: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	err := Do(ctx, policy, func(int) error {
+		calls++
+		return Retryable(errors.New("transient"))
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() error = %v, want context.DeadlineExceeded", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	if err := Do(cancelled, policy, func(int) error { t.Error("fn called with cancelled context"); return nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("Do() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestRetryable_Nil(t *testing.T) {
+	if Retryable(nil) != nil || RetryableAfter(nil, time.Second) != nil {
+		t.Error("Retryable(nil) should be nil")
+	}
+}
+
+func TestRetryableHTTP(t *testing.T) {
+	retry5xx := func(resp *http.Response) bool { return resp.StatusCode >= 500 }
+
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantStatus   int
+		wantRequests int32
+	}{
+		{name: "no retry needed", statuses: []int{200}, wantStatus: 200, wantRequests: 1},
+		{name: "retries until success", statuses: []int{503, 502, 200}, wantStatus: 200, wantRequests: 3},
+		{name: "predicate rejects status", statuses: []int{404}, wantStatus: 404, wantRequests: 1},
+		{name: "returns final response when exhausted", statuses: []int{503, 503, 503, 503}, wantStatus: 503, wantRequests: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := requests.Add(1)
+				body := make([]byte, 16)
+				k, _ := r.Body.Read(body)
+				if string(body[:k]) != "payload" {
+					t.Errorf("request %d body = %q, want it resent", n, body[:k])
+				}
+				w.WriteHeader(tt.statuses[n-1])
+				_, _ = w.Write([]byte("final"))
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := RetryableHTTP(server.Client(), req, fastPolicy(), retry5xx)
+			if err != nil {
+				t.Fatalf("RetryableHTTP() error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+			buf := make([]byte, 16)
+			if n, _ := resp.Body.Read(buf); string(buf[:n]) != "final" {
+				t.Errorf("final response body = %q, want it unread", buf[:n])
+			}
+		})
+	}
+}
+
+func TestRetryableHTTP_HonorsRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	policy := fastPolicy()
+	var delay time.Duration
+	policy.OnRetry = func(_ int, d time.Duration, err error) {
+		delay = d
+		var statusErr *StatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusTooManyRequests {
+			t.Errorf("OnRetry error = %v, want a 429 StatusError", err)
+		}
+	}
+	policy.MaxRetryAfter = 10 * time.Millisecond
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := RetryableHTTP(server.Client(), req, policy, func(resp *http.Response) bool {
+		return resp.StatusCode == http.StatusTooManyRequests
+	})
+	if err != nil {
+		t.Fatalf("RetryableHTTP() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if delay != 10*time.Millisecond {
+		t.Errorf("delay = %v, want Retry-After of 1s capped to 10ms", delay)
+	}
+}
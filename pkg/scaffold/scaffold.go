@@ -0,0 +1,112 @@
+// Package scaffold populates a directory from a template tree.
+//
+// Templates are plain directories: every file and subdirectory under the
+// template root is copied into the destination, keeping relative paths and
+// file modes. Files that already exist in the destination are left untouched,
+// so scaffolding an existing worktree again never clobbers work in progress.
+package scaffold
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Result reports what Copy did
+type Result struct {
+	Created []string // Paths written, relative to the destination
+	Skipped []string // Paths left alone because they already existed
+}
+
+// Copy copies the template tree rooted at src into dst.
+// Symlinks and other non-regular files in the template are rejected rather
+// than followed, so a template can't write outside dst.
+func Copy(src, dst string) (Result, error) {
+	var result Result
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return result, errors.Wrapf(err, "template directory %s", src)
+	}
+	if !info.IsDir() {
+		return result, errors.Newf("template %s is not a directory", src)
+	}
+
+	err = filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, 0o755)
+		case d.Type().IsRegular():
+			created, err := copyFile(path, target)
+			if err != nil {
+				return err
+			}
+			if created {
+				result.Created = append(result.Created, rel)
+			} else {
+				result.Skipped = append(result.Skipped, rel)
+			}
+			return nil
+		default:
+			return errors.Newf("unsupported file type in template: %s", rel)
+		}
+	})
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to copy template %s", src)
+	}
+
+	return result, nil
+}
+
+// Names returns the configured template names in sorted order
+func Names(templates map[string]string) []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// copyFile copies src to dst unless dst already exists.
+// It reports whether dst was created.
+func copyFile(src, dst string) (bool, error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		return false, err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return false, err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if errors.Is(err, fs.ErrExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return false, err
+	}
+	return true, out.Close()
+}
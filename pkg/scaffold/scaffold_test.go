@@ -0,0 +1,117 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	src := t.TempDir()
+	writeFile(t, filepath.Join(src, "go.mod"), "module scratch\n")
+	writeFile(t, filepath.Join(src, "cmd", "main.go"), "package main\n")
+	if err := os.Chmod(filepath.Join(src, "cmd", "main.go"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := t.TempDir()
+	result, err := Copy(src, dst)
+	if err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+
+	wantCreated := []string{filepath.Join("cmd", "main.go"), "go.mod"}
+	if !reflect.DeepEqual(result.Created, wantCreated) {
+		t.Errorf("Created = %v, want %v", result.Created, wantCreated)
+	}
+	if len(result.Skipped) != 0 {
+		t.Errorf("Skipped = %v, want none", result.Skipped)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dst, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "module scratch\n" {
+		t.Errorf("go.mod = %q, want %q", data, "module scratch\n")
+	}
+
+	info, err := os.Stat(filepath.Join(dst, "cmd", "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("main.go mode = %v, want executable bit preserved", info.Mode())
+	}
+}
+
+func TestCopy_KeepsExistingFiles(t *testing.T) {
+	src := t.TempDir()
+	writeFile(t, filepath.Join(src, "README.md"), "template\n")
+	writeFile(t, filepath.Join(src, "notes.txt"), "new\n")
+
+	dst := t.TempDir()
+	writeFile(t, filepath.Join(dst, "README.md"), "my work\n")
+
+	result, err := Copy(src, dst)
+	if err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(result.Skipped, []string{"README.md"}) {
+		t.Errorf("Skipped = %v, want [README.md]", result.Skipped)
+	}
+	if !reflect.DeepEqual(result.Created, []string{"notes.txt"}) {
+		t.Errorf("Created = %v, want [notes.txt]", result.Created)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(dst, "README.md"))
+	if string(data) != "my work\n" {
+		t.Errorf("README.md = %q, existing file should not be overwritten", data)
+	}
+}
+
+func TestCopy_Errors(t *testing.T) {
+	t.Run("missing template", func(t *testing.T) {
+		if _, err := Copy(filepath.Join(t.TempDir(), "missing"), t.TempDir()); err == nil {
+			t.Error("Copy() should fail for a missing template directory")
+		}
+	})
+
+	t.Run("template is a file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		writeFile(t, file, "x")
+		if _, err := Copy(file, t.TempDir()); err == nil {
+			t.Error("Copy() should fail when the template is not a directory")
+		}
+	})
+
+	t.Run("symlink in template", func(t *testing.T) {
+		src := t.TempDir()
+		if err := os.Symlink("/etc/passwd", filepath.Join(src, "link")); err != nil {
+			t.Skipf("symlinks unsupported: %v", err)
+		}
+		if _, err := Copy(src, t.TempDir()); err == nil {
+			t.Error("Copy() should reject symlinks in the template")
+		}
+	})
+}
+
+func TestNames(t *testing.T) {
+	got := Names(map[string]string{"rust": "/r", "go": "/g"})
+	want := []string{"go", "rust"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,31 @@
+// Package ticket defines the canonical format of ticket identifiers.
+//
+// A ticket is TYPE-ID: a type of letters, exactly one dash, and an ID of
+// letters and digits. This covers Jira keys (proj-123) and beads IDs
+// (rig-abc123). Every command validates tickets through Parse so they all
+// accept the same input and report the same error.
+package ticket
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ErrInvalidFormat is returned for any ticket that does not match TYPE-ID.
+var ErrInvalidFormat = errors.New("invalid ticket format. Expected format: [project:]TYPE-ID (e.g., proj-123, rig:proj-123 or rig-abc)")
+
+// pattern matches TYPE-ID where ID can be digits or alphanumeric (e.g., proj-123, rig-abc, beads-42f)
+var pattern = regexp.MustCompile(`^([a-zA-Z]+)-([a-zA-Z0-9]+)$`)
+
+// Parse splits a TYPE-ID ticket into its type and number. The type is
+// lowercased, as it names directories and templates; the number keeps its
+// original case. Callers that need the ticket as typed should keep s.
+func Parse(s string) (ticketType, number string, err error) {
+	matches := pattern.FindStringSubmatch(s)
+	if len(matches) != 3 {
+		return "", "", ErrInvalidFormat
+	}
+	return strings.ToLower(matches[1]), matches[2], nil
+}
@@ -0,0 +1,51 @@
+package ticket
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantType   string
+		wantNumber string
+		wantErr    bool
+	}{
+		// Valid tickets
+		{input: "proj-123", wantType: "proj", wantNumber: "123"},
+		{input: "PROJ-123", wantType: "proj", wantNumber: "123"},
+		{input: "rig-abc123", wantType: "rig", wantNumber: "abc123"},
+		{input: "Beads-42F", wantType: "beads", wantNumber: "42F"},
+
+		// Invalid tickets
+		{input: "", wantErr: true},
+		{input: "proj", wantErr: true},
+		{input: "proj-", wantErr: true},
+		{input: "-123", wantErr: true},
+		{input: "proj-123-456", wantErr: true},
+		{input: "proj_1-123", wantErr: true},
+		{input: "proj-12.3", wantErr: true},
+		{input: "proj 123", wantErr: true},
+		{input: "rig:proj-123", wantErr: true},
+		{input: "../proj-123", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			ticketType, number, err := Parse(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidFormat) {
+					t.Errorf("Parse(%q) error = %v, want ErrInvalidFormat", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+			}
+			if ticketType != tt.wantType || number != tt.wantNumber {
+				t.Errorf("Parse(%q) = (%q, %q), want (%q, %q)", tt.input, ticketType, number, tt.wantType, tt.wantNumber)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 )
@@ -54,6 +55,14 @@ type WindowConfig struct {
 	Name       string
 	Command    string
 	WorkingDir string
+	Layout     string       // Optional layout applied after panes are split (e.g. "main-vertical")
+	Panes      []PaneConfig // Additional panes; Command runs in the window's first pane
+}
+
+// PaneConfig represents an additional pane within a window
+type PaneConfig struct {
+	Command    string
+	WorkingDir string
 }
 
 // NewSessionManager creates a new SessionManager
@@ -167,11 +176,43 @@ func (sm *SessionManager) GetSessionName(ticket string) string {
 	return ticket
 }
 
+// TicketFromSessionName strips the session prefix from a tmux session name,
+// reversing GetSessionName
+func (sm *SessionManager) TicketFromSessionName(sessionName string) string {
+	return strings.TrimPrefix(sessionName, sm.SessionPrefix)
+}
+
+// CurrentSessionName returns the name of the tmux session this process is
+// running in. It returns an error when not running inside tmux.
+func (sm *SessionManager) CurrentSessionName() (string, error) {
+	if os.Getenv("TMUX") == "" {
+		return "", errors.New("not running inside tmux")
+	}
+
+	output, err := sm.tmuxCmd("display-message", "-p", "#{session_name}").Output()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get current tmux session")
+	}
+
+	name := strings.TrimSpace(string(output))
+	if name == "" {
+		return "", errors.New("tmux returned an empty session name")
+	}
+
+	return name, nil
+}
+
 // getSessionName is a private helper method
 func (sm *SessionManager) getSessionName(ticket string) string {
 	return sm.GetSessionName(ticket)
 }
 
+// IsAvailable checks if the tmux binary is available in PATH
+func (sm *SessionManager) IsAvailable() bool {
+	_, err := exec.LookPath("tmux")
+	return err == nil
+}
+
 // SessionExists checks if a tmux session exists
 func (sm *SessionManager) SessionExists(sessionName string) bool {
 	cmd := sm.tmuxCmd("has-session", "-t", sessionName)
@@ -238,11 +279,63 @@ func (sm *SessionManager) createWindows(sessionName, worktreePath, notePath stri
 				return errors.Wrapf(err, "failed to send command to window %s", window.Name)
 			}
 		}
+
+		if len(window.Panes) > 0 || window.Layout != "" {
+			err := sm.createPanes(windowTarget, window, worktreePath, notePath)
+			if err != nil {
+				return errors.Wrapf(err, "failed to set up panes for window %s", window.Name)
+			}
+		}
 	}
 
 	return nil
 }
 
+// createPanes splits additional panes into a window, applies its layout,
+// and returns focus to the window's first pane
+func (sm *SessionManager) createPanes(windowTarget string, window WindowConfig, worktreePath, notePath string) error {
+	output, err := sm.tmuxCmd("display-message", "-p", "-t", windowTarget, "#{pane_id}").Output()
+	if err != nil {
+		return errors.Wrap(err, "failed to get first pane")
+	}
+	firstPane := strings.TrimSpace(string(output))
+
+	for i, pane := range window.Panes {
+		workingDir := sm.expandPath(pane.WorkingDir, worktreePath, notePath)
+		if workingDir == "" {
+			workingDir = worktreePath
+		}
+
+		output, err := sm.tmuxCmd("split-window", "-t", windowTarget, "-c", workingDir, "-P", "-F", "#{pane_id}").Output()
+		if err != nil {
+			return errors.Wrapf(err, "failed to split pane %d", i+1)
+		}
+		paneID := strings.TrimSpace(string(output))
+
+		if pane.Command != "" {
+			command := sm.expandPath(pane.Command, worktreePath, notePath)
+			if err := sm.sendCommand(paneID, command); err != nil {
+				return errors.Wrapf(err, "failed to send command to pane %d", i+1)
+			}
+		}
+	}
+
+	if window.Layout != "" {
+		cmd := sm.tmuxCmd("select-layout", "-t", windowTarget, window.Layout)
+
+		if sm.Verbose {
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+		}
+
+		if err := cmd.Run(); err != nil {
+			return errors.Wrapf(err, "failed to apply layout %q", window.Layout)
+		}
+	}
+
+	return sm.tmuxCmd("select-pane", "-t", firstPane).Run()
+}
+
 // expandPath expands template variables in paths and commands
 func (sm *SessionManager) expandPath(template, worktreePath, notePath string) string {
 	result := template
@@ -286,7 +379,7 @@ func (sm *SessionManager) createWindow(sessionName string, windowNum int, name,
 	return cmd.Run()
 }
 
-// sendCommand sends a command to a tmux window
+// sendCommand sends a command to a tmux window or pane
 // SECURITY: Commands come from user-controlled config files. While the config
 // is trusted (user creates it), we validate against an allowlist as defense-in-depth.
 func (sm *SessionManager) sendCommand(windowTarget, command string) error {
@@ -365,11 +458,11 @@ func (sm *SessionManager) selectWindow(sessionName string, windowNum int) error
 
 // AttachToSession attaches to or switches to a tmux session
 func (sm *SessionManager) AttachToSession(sessionName string) error {
-	// Check if we're already in a tmux session
-	if os.Getenv("TMUX") != "" {
-		// We're in tmux, switch to the session
-		cmd := sm.tmuxCmd("switch-client", "-t", sessionName)
+	inTmux := os.Getenv("TMUX") != ""
+	cmd := sm.tmuxCmd(AttachArgs(sessionName, inTmux)...)
 
+	if inTmux {
+		// We're in tmux, switch the current client to the session
 		if sm.Verbose {
 			fmt.Printf("Switching to session: %s\n", sessionName)
 			cmd.Stdout = os.Stdout
@@ -380,7 +473,6 @@ func (sm *SessionManager) AttachToSession(sessionName string) error {
 	}
 
 	// We're not in tmux, attach to the session
-	cmd := sm.tmuxCmd("attach-session", "-t", sessionName)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -392,30 +484,83 @@ func (sm *SessionManager) AttachToSession(sessionName string) error {
 	return cmd.Run()
 }
 
+// AttachArgs returns the tmux arguments used to bring sessionName to the
+// foreground. Inside tmux, attach-session would nest a client, so
+// switch-client is used instead.
+func AttachArgs(sessionName string, inTmux bool) []string {
+	if inTmux {
+		return []string{"switch-client", "-t", sessionName}
+	}
+	return []string{"attach-session", "-t", sessionName}
+}
+
 // attachToSession is a private helper method
 func (sm *SessionManager) attachToSession(sessionName string) error {
 	return sm.AttachToSession(sessionName)
 }
 
-// ListSessions returns a list of all tmux sessions
-func (sm *SessionManager) ListSessions() ([]string, error) {
-	cmd := sm.tmuxCmd("list-sessions", "-F", "#{session_name}")
+// SessionInfo describes a running tmux session
+type SessionInfo struct {
+	Name     string    `json:"name"`
+	Windows  int       `json:"windows"`
+	Attached bool      `json:"attached"`
+	Created  time.Time `json:"created"`
+}
+
+// sessionListFormat is the list-sessions format parsed by parseSessionList
+const sessionListFormat = "#{session_name}\t#{session_windows}\t#{session_attached}\t#{session_created}"
+
+// ListSessions returns all tmux sessions with their metadata
+func (sm *SessionManager) ListSessions() ([]SessionInfo, error) {
+	cmd := sm.tmuxCmd("list-sessions", "-F", sessionListFormat)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list sessions")
 	}
 
-	sessions := strings.Split(strings.TrimSpace(string(output)), "\n")
+	return parseSessionList(string(output)), nil
+}
 
-	// Filter out empty strings
-	var result []string
-	for _, session := range sessions {
-		if strings.TrimSpace(session) != "" {
-			result = append(result, session)
+// parseSessionList parses list-sessions output in sessionListFormat.
+// Fields that fail to parse are left at their zero value.
+func parseSessionList(output string) []SessionInfo {
+	var result []SessionInfo
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
 		}
+
+		fields := strings.Split(line, "\t")
+		info := SessionInfo{Name: fields[0]}
+		if len(fields) >= 4 {
+			info.Windows, _ = strconv.Atoi(fields[1])
+			attached, _ := strconv.Atoi(fields[2])
+			info.Attached = attached > 0
+			if created, err := strconv.ParseInt(fields[3], 10, 64); err == nil {
+				info.Created = time.Unix(created, 0)
+			}
+		}
+		result = append(result, info)
 	}
 
-	return result, nil
+	return result
+}
+
+// ManagedSessions returns the sessions whose names carry the configured
+// session prefix. With no prefix configured no session can be attributed to
+// rig, so none are returned.
+func (sm *SessionManager) ManagedSessions(sessions []SessionInfo) []SessionInfo {
+	if sm.SessionPrefix == "" {
+		return nil
+	}
+
+	var managed []SessionInfo
+	for _, session := range sessions {
+		if strings.HasPrefix(session.Name, sm.SessionPrefix) {
+			managed = append(managed, session)
+		}
+	}
+	return managed
 }
 
 // KillSession kills a tmux session
@@ -436,3 +581,66 @@ func (sm *SessionManager) KillSession(ticket string) error {
 
 	return cmd.Run()
 }
+
+// RenameSession renames the session for oldTicket to the session name for
+// newTicket and updates the session's RIG_TICKET environment variable.
+// It fails if the old session does not exist or the new name is taken.
+func (sm *SessionManager) RenameSession(oldTicket, newTicket string) error {
+	oldName := sm.getSessionName(oldTicket)
+	newName := sm.getSessionName(newTicket)
+
+	if !sm.sessionExists(oldName) {
+		return errors.Newf("session does not exist: %s", oldName)
+	}
+	if sm.sessionExists(newName) {
+		return errors.Newf("session already exists: %s", newName)
+	}
+
+	cmd := sm.tmuxCmd("rename-session", "-t", oldName, newName)
+
+	if sm.Verbose {
+		fmt.Printf("Renaming session: %s -> %s\n", oldName, newName)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	}
+
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "failed to rename session %s", oldName)
+	}
+
+	if err := sm.tmuxCmd("set-environment", "-t", newName, "RIG_TICKET", newTicket).Run(); err != nil {
+		return errors.Wrap(err, "failed to update RIG_TICKET")
+	}
+
+	return nil
+}
+
+// SendKeys types command into the active pane of the session for ticket,
+// followed by Enter when enter is set. The command is sent literally so
+// words such as "Enter" or "C-c" are not interpreted as key names.
+//
+// Unlike commands from config, the command is given explicitly by the user
+// on the command line and is not checked against AllowedCommandPatterns.
+func (sm *SessionManager) SendKeys(ticket, command string, enter bool) error {
+	sessionName := sm.getSessionName(ticket)
+
+	if !sm.sessionExists(sessionName) {
+		return errors.Newf("session does not exist: %s", sessionName)
+	}
+
+	if sm.Verbose {
+		fmt.Printf("Sending to session %s: %s\n", sessionName, command)
+	}
+
+	if err := sm.tmuxCmd("send-keys", "-t", sessionName, "-l", command).Run(); err != nil {
+		return errors.Wrapf(err, "failed to send keys to session %s", sessionName)
+	}
+
+	if enter {
+		if err := sm.tmuxCmd("send-keys", "-t", sessionName, "Enter").Run(); err != nil {
+			return errors.Wrapf(err, "failed to send Enter to session %s", sessionName)
+		}
+	}
+
+	return nil
+}
@@ -1,11 +1,13 @@
 package tmux
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestMain(m *testing.M) {
@@ -73,6 +75,65 @@ func TestGetSessionName(t *testing.T) {
 	}
 }
 
+func TestTicketFromSessionName(t *testing.T) {
+	tests := []struct {
+		name        string
+		prefix      string
+		sessionName string
+		want        string
+	}{
+		{"with prefix", "rig-", "rig-PROJ-123", "PROJ-123"},
+		{"without prefix", "", "PROJ-123", "PROJ-123"},
+		{"prefix not present", "rig-", "scratch", "scratch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := NewSessionManager(tt.prefix, nil, false)
+			if got := sm.TicketFromSessionName(tt.sessionName); got != tt.want {
+				t.Errorf("TicketFromSessionName(%q) = %q, want %q", tt.sessionName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrentSessionName_NotInTmux(t *testing.T) {
+	t.Setenv("TMUX", "")
+
+	sm := NewSessionManager("", nil, false)
+	if _, err := sm.CurrentSessionName(); err == nil {
+		t.Error("CurrentSessionName() should fail outside tmux")
+	}
+}
+
+func TestAttachArgs(t *testing.T) {
+	tests := []struct {
+		name   string
+		inTmux bool
+		want   []string
+	}{
+		{
+			name:   "outside tmux attaches",
+			inTmux: false,
+			want:   []string{"attach-session", "-t", "rig-PROJ-1"},
+		},
+		{
+			name:   "inside tmux switches client",
+			inTmux: true,
+			want:   []string{"switch-client", "-t", "rig-PROJ-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AttachArgs("rig-PROJ-1", tt.inTmux)
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("AttachArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestExpandPath(t *testing.T) {
 	sm := NewSessionManager("", nil, false)
 
@@ -154,6 +215,24 @@ func TestSessionExists_NoTmux(t *testing.T) {
 	}
 }
 
+func TestIsAvailable(t *testing.T) {
+	sm := NewSessionManager("", nil, false)
+
+	t.Setenv("PATH", t.TempDir())
+	if sm.IsAvailable() {
+		t.Error("IsAvailable() should be false when tmux is not in PATH")
+	}
+
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "tmux"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir)
+	if !sm.IsAvailable() {
+		t.Error("IsAvailable() should be true when tmux is in PATH")
+	}
+}
+
 // Integration tests - these require tmux to be running
 func TestCreateAndKillSession_Integration(t *testing.T) {
 	// Skip if tmux is not available
@@ -220,6 +299,76 @@ func TestListSessions_Integration(t *testing.T) {
 	t.Logf("Found %d sessions", len(sessions))
 }
 
+func TestManagedSessions(t *testing.T) {
+	sessions := []SessionInfo{
+		{Name: "rig-PROJ-1"},
+		{Name: "scratch"},
+		{Name: "rig-PROJ-2"},
+		{Name: "my-rig-PROJ-3"},
+	}
+
+	tests := []struct {
+		name   string
+		prefix string
+		want   []string
+	}{
+		{
+			name:   "filters by prefix",
+			prefix: "rig-",
+			want:   []string{"rig-PROJ-1", "rig-PROJ-2"},
+		},
+		{
+			name:   "no matches",
+			prefix: "other-",
+			want:   nil,
+		},
+		{
+			name:   "empty prefix matches nothing",
+			prefix: "",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := NewSessionManager(tt.prefix, nil, false)
+
+			var got []string
+			for _, s := range sm.ManagedSessions(sessions) {
+				got = append(got, s.Name)
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("ManagedSessions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSessionList(t *testing.T) {
+	output := "rig-PROJ-1\t3\t1\t1760000000\nrig-PROJ-2\t1\t0\t1760003600\n\nlegacy\n"
+
+	sessions := parseSessionList(output)
+	if len(sessions) != 3 {
+		t.Fatalf("parseSessionList() returned %d sessions, want 3: %+v", len(sessions), sessions)
+	}
+
+	want := []SessionInfo{
+		{Name: "rig-PROJ-1", Windows: 3, Attached: true, Created: time.Unix(1760000000, 0)},
+		{Name: "rig-PROJ-2", Windows: 1, Attached: false, Created: time.Unix(1760003600, 0)},
+		{Name: "legacy"},
+	}
+	for i := range want {
+		if sessions[i] != want[i] {
+			t.Errorf("sessions[%d] = %+v, want %+v", i, sessions[i], want[i])
+		}
+	}
+
+	if got := parseSessionList(""); len(got) != 0 {
+		t.Errorf("parseSessionList(\"\") = %+v, want empty", got)
+	}
+}
+
 func TestKillSession_NonExistent(t *testing.T) {
 	// Skip if tmux is not available
 	if _, err := exec.LookPath("tmux"); err != nil {
@@ -234,6 +383,107 @@ func TestKillSession_NonExistent(t *testing.T) {
 	}
 }
 
+func TestRenameSession_Integration(t *testing.T) {
+	// Skip if tmux is not available
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not found in PATH, skipping integration test")
+	}
+
+	tmpDir := t.TempDir()
+	sm := NewTestSessionManager("test-", nil)
+
+	oldName := sm.GetSessionName("rename-old")
+	newName := sm.GetSessionName("rename-new")
+	takenName := sm.GetSessionName("rename-taken")
+
+	for _, name := range []string{oldName, takenName} {
+		_ = exec.Command("tmux", "-L", TestSocketName, "kill-session", "-t", name).Run()
+		if err := exec.Command("tmux", "-L", TestSocketName, "new-session", "-d", "-s", name, "-c", tmpDir).Run(); err != nil {
+			t.Fatalf("Failed to create test session %s: %v", name, err)
+		}
+	}
+	defer func() {
+		for _, name := range []string{oldName, newName, takenName} {
+			_ = exec.Command("tmux", "-L", TestSocketName, "kill-session", "-t", name).Run()
+		}
+	}()
+
+	// Refuses to clobber an existing session
+	err := sm.RenameSession("rename-old", "rename-taken")
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("RenameSession() to taken name error = %v, want already exists", err)
+	}
+
+	if err := sm.RenameSession("rename-old", "rename-new"); err != nil {
+		t.Fatalf("RenameSession() error: %v", err)
+	}
+	if sm.SessionExists(oldName) {
+		t.Error("old session should not exist after rename")
+	}
+	if !sm.SessionExists(newName) {
+		t.Error("new session should exist after rename")
+	}
+
+	output, err := exec.Command("tmux", "-L", TestSocketName, "show-environment", "-t", newName, "RIG_TICKET").Output()
+	if err != nil {
+		t.Fatalf("show-environment failed: %v", err)
+	}
+	if got := strings.TrimSpace(string(output)); got != "RIG_TICKET=rename-new" {
+		t.Errorf("RIG_TICKET = %q, want %q", got, "RIG_TICKET=rename-new")
+	}
+
+	// Old session is gone now
+	err = sm.RenameSession("rename-old", "rename-other")
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("RenameSession() of missing session error = %v, want does not exist", err)
+	}
+}
+
+func TestSendKeys_Integration(t *testing.T) {
+	// Skip if tmux is not available
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not found in PATH, skipping integration test")
+	}
+
+	tmpDir := t.TempDir()
+	sm := NewTestSessionManager("test-", nil)
+	sessionName := sm.GetSessionName("send-test")
+
+	_ = exec.Command("tmux", "-L", TestSocketName, "kill-session", "-t", sessionName).Run()
+	if err := exec.Command("tmux", "-L", TestSocketName, "new-session", "-d", "-s", sessionName, "-c", tmpDir, "cat").Run(); err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+	defer func() {
+		_ = exec.Command("tmux", "-L", TestSocketName, "kill-session", "-t", sessionName).Run()
+	}()
+
+	// "Enter" must be typed literally, not interpreted as a key name
+	if err := sm.SendKeys("send-test", "echo Enter 'quoted words'", false); err != nil {
+		t.Fatalf("SendKeys() error: %v", err)
+	}
+
+	var pane string
+	for i := 0; i < 20; i++ {
+		output, err := exec.Command("tmux", "-L", TestSocketName, "capture-pane", "-p", "-t", sessionName).Output()
+		if err != nil {
+			t.Fatalf("capture-pane failed: %v", err)
+		}
+		pane = string(output)
+		if strings.Contains(pane, "echo Enter 'quoted words'") {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if !strings.Contains(pane, "echo Enter 'quoted words'") {
+		t.Errorf("pane does not contain sent text:\n%s", pane)
+	}
+
+	err := sm.SendKeys("send-missing", "ls", true)
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("SendKeys() to missing session error = %v, want does not exist", err)
+	}
+}
+
 func TestCreateSession_NonExistentWorktree(t *testing.T) {
 	// Skip if tmux is not available
 	if _, err := exec.LookPath("tmux"); err != nil {
@@ -540,6 +790,76 @@ func TestCreateSession_CreatesAllWindows(t *testing.T) {
 	}
 }
 
+// TestCreateWindows_PanesAndLayout verifies that configured panes are split and the layout applied
+func TestCreateWindows_PanesAndLayout(t *testing.T) {
+	// Skip if tmux is not available
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not found in PATH, skipping integration test")
+	}
+
+	tmpDir := t.TempDir()
+	subDir := filepath.Join(tmpDir, "sub")
+	if err := os.Mkdir(subDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	windows := []WindowConfig{
+		{
+			Name:       "code",
+			WorkingDir: "{worktree_path}",
+			Layout:     "even-horizontal",
+			Panes: []PaneConfig{
+				{WorkingDir: "{worktree_path}/sub"},
+				{},
+			},
+		},
+		{Name: "term", WorkingDir: "{worktree_path}"},
+	}
+
+	sm := NewTestSessionManager("test-", windows)
+	sessionName := sm.GetSessionName("pane-test")
+
+	_ = exec.Command("tmux", "-L", TestSocketName, "kill-session", "-t", sessionName).Run()
+	if err := exec.Command("tmux", "-L", TestSocketName, "new-session", "-d", "-s", sessionName, "-c", tmpDir).Run(); err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+	defer func() {
+		_ = exec.Command("tmux", "-L", TestSocketName, "kill-session", "-t", sessionName).Run()
+	}()
+
+	if err := sm.createWindows(sessionName, tmpDir, ""); err != nil {
+		t.Fatalf("createWindows failed: %v", err)
+	}
+
+	codeTarget := fmt.Sprintf("%s:%d", sessionName, sm.getBaseIndex())
+	output, err := exec.Command("tmux", "-L", TestSocketName, "list-panes", "-t", codeTarget,
+		"-F", "#{pane_active} #{pane_current_path}").Output()
+	if err != nil {
+		t.Fatalf("list-panes failed: %v", err)
+	}
+
+	panes := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(panes) != 3 {
+		t.Fatalf("Expected 3 panes in code window, got %d: %v", len(panes), panes)
+	}
+	if !strings.HasPrefix(panes[0], "1 ") {
+		t.Errorf("first pane should be active after setup, got %q", panes[0])
+	}
+	if !strings.HasSuffix(panes[1], "/sub") {
+		t.Errorf("second pane should start in sub directory, got %q", panes[1])
+	}
+
+	// Windows without panes keep a single pane
+	termTarget := fmt.Sprintf("%s:%d", sessionName, sm.getBaseIndex()+1)
+	output, err = exec.Command("tmux", "-L", TestSocketName, "list-panes", "-t", termTarget).Output()
+	if err != nil {
+		t.Fatalf("list-panes failed: %v", err)
+	}
+	if n := len(strings.Split(strings.TrimSpace(string(output)), "\n")); n != 1 {
+		t.Errorf("Expected 1 pane in term window, got %d", n)
+	}
+}
+
 // TestAllowedCommandPatterns_Coverage ensures all patterns in AllowedCommandPatterns are tested
 func TestAllowedCommandPatterns_Coverage(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/term"
+)
+
+// ColorMode controls when output is colorized
+type ColorMode string
+
+// Supported color modes
+const (
+	ColorAuto   ColorMode = "auto"
+	ColorAlways ColorMode = "always"
+	ColorNever  ColorMode = "never"
+)
+
+// ANSI SGR sequences used by Styler
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiCyan  = "\x1b[36m"
+)
+
+// ParseColorMode validates a --color value
+func ParseColorMode(s string) (ColorMode, error) {
+	switch mode := ColorMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case ColorAuto, ColorAlways, ColorNever:
+		return mode, nil
+	case "":
+		return ColorAuto, nil
+	default:
+		return "", errors.Newf("invalid color mode %q: must be one of: auto, always, never", s)
+	}
+}
+
+// ColorEnabled reports whether output written to w should be colorized. In
+// auto mode color is used only when w is a terminal, NO_COLOR is unset or
+// empty (https://no-color.org), and TERM is not "dumb".
+func ColorEnabled(mode ColorMode, w io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// Styler renders status glyphs and highlights, with or without color
+type Styler struct {
+	color bool
+}
+
+// NewStyler returns a Styler for output written to w under mode
+func NewStyler(mode ColorMode, w io.Writer) *Styler {
+	return &Styler{color: ColorEnabled(mode, w)}
+}
+
+// Color reports whether the Styler emits ANSI color codes
+func (s *Styler) Color() bool {
+	return s.color
+}
+
+func (s *Styler) wrap(code, text string) string {
+	if !s.color {
+		return text
+	}
+	return code + text + ansiReset
+}
+
+// Success renders text in green
+func (s *Styler) Success(text string) string {
+	return s.wrap(ansiGreen, text)
+}
+
+// Failure renders text in red
+func (s *Styler) Failure(text string) string {
+	return s.wrap(ansiRed, text)
+}
+
+// Highlight renders text in bold cyan
+func (s *Styler) Highlight(text string) string {
+	return s.wrap(ansiBold+ansiCyan, text)
+}
+
+// CheckMark returns a success check mark
+func (s *Styler) CheckMark() string {
+	return s.Success("✓")
+}
+
+// CrossMark returns a failure cross mark
+func (s *Styler) CrossMark() string {
+	return s.Failure("✗")
+}
+
+// Arrow returns the marker for the current item in a list
+func (s *Styler) Arrow() string {
+	return s.Highlight("→")
+}
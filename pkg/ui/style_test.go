@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestParseColorMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ColorMode
+		wantErr bool
+	}{
+		{"auto", ColorAuto, false},
+		{"", ColorAuto, false},
+		{"ALWAYS", ColorAlways, false},
+		{"never", ColorNever, false},
+		{"sometimes", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseColorMode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseColorMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseColorMode(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	var buf bytes.Buffer
+
+	t.Run("always overrides NO_COLOR", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		if !ColorEnabled(ColorAlways, &buf) {
+			t.Error("always should enable color")
+		}
+	})
+
+	t.Run("never", func(t *testing.T) {
+		if ColorEnabled(ColorNever, os.Stdout) {
+			t.Error("never should disable color")
+		}
+	})
+
+	t.Run("auto with non-terminal writer", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "")
+		if ColorEnabled(ColorAuto, &buf) {
+			t.Error("auto should disable color for a buffer")
+		}
+	})
+
+	t.Run("auto with pipe", func(t *testing.T) {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Close()
+		defer w.Close()
+		if ColorEnabled(ColorAuto, w) {
+			t.Error("auto should disable color for a pipe")
+		}
+	})
+
+	t.Run("auto with NO_COLOR", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		if ColorEnabled(ColorAuto, os.Stdout) {
+			t.Error("NO_COLOR should disable color in auto mode")
+		}
+	})
+}
+
+func TestStyler(t *testing.T) {
+	plain := NewStyler(ColorNever, nil)
+	if got := plain.CheckMark(); got != "✓" {
+		t.Errorf("plain CheckMark() = %q, want %q", got, "✓")
+	}
+	if got := plain.CrossMark(); got != "✗" {
+		t.Errorf("plain CrossMark() = %q, want %q", got, "✗")
+	}
+	if got := plain.Arrow(); got != "→" {
+		t.Errorf("plain Arrow() = %q, want %q", got, "→")
+	}
+
+	colored := NewStyler(ColorAlways, nil)
+	if !colored.Color() {
+		t.Fatal("ColorAlways styler should report color")
+	}
+	if got, want := colored.CheckMark(), "\x1b[32m✓\x1b[0m"; got != want {
+		t.Errorf("colored CheckMark() = %q, want %q", got, want)
+	}
+	if got, want := colored.CrossMark(), "\x1b[31m✗\x1b[0m"; got != want {
+		t.Errorf("colored CrossMark() = %q, want %q", got, want)
+	}
+	if got, want := colored.Arrow(), "\x1b[1m\x1b[36m→\x1b[0m"; got != want {
+		t.Errorf("colored Arrow() = %q, want %q", got, want)
+	}
+}
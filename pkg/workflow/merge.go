@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"os"
 	"time"
 
 	"thoreinstein.com/rig/pkg/ai"
@@ -12,6 +11,7 @@ import (
 	rigerrors "thoreinstein.com/rig/pkg/errors"
 	"thoreinstein.com/rig/pkg/github"
 	"thoreinstein.com/rig/pkg/jira"
+	rlog "thoreinstein.com/rig/pkg/log"
 )
 
 // Engine orchestrates the merge workflow.
@@ -36,12 +36,8 @@ type Engine struct {
 //   - projectPath: Path to the project directory for ticket routing
 //   - verbose: Enable verbose logging
 func NewEngine(gh github.Client, jiraClient jira.JiraClient, aiProvider ai.Provider, cfg *config.Config, projectPath string, verbose bool) *Engine {
-	var logger *slog.Logger
-	if verbose {
-		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
-	} else {
-		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}))
-	}
+	// Output level and format follow --log-level and --log-format
+	logger := rlog.Logger()
 
 	return &Engine{
 		github:      gh,
@@ -228,11 +224,9 @@ func (e *Engine) workflowToCheckpoint(wf *MergeWorkflow) *Checkpoint {
 	}
 }
 
-// log writes a message if verbose mode is enabled.
+// log writes a workflow progress message at debug level.
 func (e *Engine) log(format string, args ...any) {
-	if e.verbose {
-		fmt.Printf("[workflow] "+format+"\n", args...)
-	}
+	e.logger.Debug(fmt.Sprintf(format, args...), "component", "workflow")
 }
 
 // Preflight runs only the preflight checks and returns the result.
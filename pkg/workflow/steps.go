@@ -404,12 +404,13 @@ func (e *Engine) runCloseout(ctx context.Context, wf *MergeWorkflow, opts MergeO
 	return nil
 }
 
+// DoneStatuses are the common names of a Jira workflow's final status, tried
+// in order when closing out a ticket.
+var DoneStatuses = []string{"Done", "Closed", "Complete", "Resolved"}
+
 // transitionJiraToDone transitions a Jira ticket to "Done" status.
 func (e *Engine) transitionJiraToDone(ticket string) error {
-	// Try common "Done" status names
-	doneStatuses := []string{"Done", "Closed", "Complete", "Resolved"}
-
-	for _, status := range doneStatuses {
+	for _, status := range DoneStatuses {
 		err := e.jira.TransitionTicketByName(ticket, status)
 		if err == nil {
 			return nil
@@ -36,6 +36,10 @@ func (m *mockGitHubClient) GetPR(_ context.Context, _ int) (*github.PRInfo, erro
 	return m.pr, nil
 }
 
+func (m *mockGitHubClient) GetPullRequest(ctx context.Context, _, _ string, number int) (*github.PRInfo, error) {
+	return m.GetPR(ctx, number)
+}
+
 func (m *mockGitHubClient) ListPRs(_ context.Context, _ github.ListPRsOptions) ([]github.PRInfo, error) {
 	return nil, nil
 }